@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/terotoi/koticloud/server/api"
+	"github.com/terotoi/koticloud/server/fs"
+	"github.com/terotoi/koticloud/server/mx"
+)
+
+// apiGrantAdd requests giving a user or a group access to a node.
+func apiGrantAdd(path string, req api.GrantAddRequest, authToken, baseURL string) (*fs.Grant, error) {
+	id, err := apiNodeIDForPath(path, authToken, baseURL)
+	if err != nil {
+		return nil, err
+	}
+	req.NodeID = id
+
+	client := http.Client{}
+	res, err := PostJSON(&client, fmt.Sprintf("%s/grant/add", baseURL), authToken, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var g fs.Grant
+	if err := json.Unmarshal(res, &g); err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+// apiGrantList requests the grants given on a node.
+func apiGrantList(path, authToken, baseURL string) ([]*fs.GrantInfo, error) {
+	id, err := apiNodeIDForPath(path, authToken, baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	client := http.Client{}
+	res, err := RequestURL(&client, fmt.Sprintf("%s/grant/list/%d", baseURL, id),
+		"application/json", authToken, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var grants []*fs.GrantInfo
+	if err := json.Unmarshal(res, &grants); err != nil {
+		return nil, err
+	}
+	return grants, nil
+}
+
+// apiGrantRemove requests removal of a grant.
+func apiGrantRemove(id int, authToken, baseURL string) error {
+	client := http.Client{}
+	_, err := PostJSON(&client, fmt.Sprintf("%s/grant/remove", baseURL), authToken,
+		api.GrantRemoveRequest{ID: id})
+	return err
+}
+
+// apiSharedWithMe requests the nodes other users have shared with the user.
+func apiSharedWithMe(authToken, baseURL string) ([]*fs.SharedNode, error) {
+	client := http.Client{}
+	res, err := RequestURL(&client, fmt.Sprintf("%s/node/shared", baseURL),
+		"application/json", authToken, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []*fs.SharedNode
+	if err := json.Unmarshal(res, &nodes); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// apiGroup posts a group request to the given group endpoint.
+func apiGroup(endpoint string, req api.GroupRequest, authToken, baseURL string) error {
+	client := http.Client{}
+	_, err := PostJSON(&client, fmt.Sprintf("%s/group/%s", baseURL, endpoint), authToken, req)
+	return err
+}
+
+// apiGroupList requests all groups and their members.
+func apiGroupList(authToken, baseURL string) ([]*mx.Group, error) {
+	client := http.Client{}
+	res, err := RequestURL(&client, fmt.Sprintf("%s/group/list", baseURL),
+		"application/json", authToken, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []*mx.Group
+	if err := json.Unmarshal(res, &groups); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/terotoi/koticloud/server/api"
+	"github.com/terotoi/koticloud/server/jobs"
+)
+
+// apiStartJob requests an admin endpoint starting a job.
+func apiStartJob(path string, body interface{}, authToken, baseURL string) (*jobs.Job, error) {
+	client := http.Client{}
+
+	res, err := PostJSON(&client, fmt.Sprintf("%s/admin/%s", baseURL, path), authToken, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var job jobs.Job
+	if err := json.Unmarshal(res, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// apiJobList requests the latest jobs.
+func apiJobList(limit int, authToken, baseURL string) ([]*jobs.Job, error) {
+	client := http.Client{}
+
+	res, err := RequestURL(&client, fmt.Sprintf("%s/admin/jobs?limit=%d", baseURL, limit),
+		"application/json", authToken, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []*jobs.Job
+	if err := json.Unmarshal(res, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// apiJobShow requests a job with its log lines.
+func apiJobShow(id int, authToken, baseURL string) (*api.JobResponse, error) {
+	client := http.Client{}
+
+	res, err := RequestURL(&client, fmt.Sprintf("%s/admin/jobs/%d", baseURL, id),
+		"application/json", authToken, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp api.JobResponse
+	if err := json.Unmarshal(res, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// apiJobCancel requests a running job to stop. Returns false if the job was not running.
+func apiJobCancel(id int, authToken, baseURL string) (bool, error) {
+	client := http.Client{}
+
+	res, err := PostJSON(&client, fmt.Sprintf("%s/admin/jobs/%d/cancel", baseURL, id), authToken, nil)
+	if err != nil {
+		return false, err
+	}
+	return ParseBoolResponse(res)
+}
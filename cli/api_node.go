@@ -31,7 +31,7 @@ func apiNodeIDForPath(path, authToken, baseURL string) (int, error) {
 }
 
 // apiInfo requests information about one node from the server.
-func apiInfo(id int, authToken, baseURL string) (*fs.NodeWithProgress, error) {
+func apiInfo(id int, authToken, baseURL string) (*api.NodeInfoResponse, error) {
 	client := http.Client{}
 
 	body, err := RequestURL(&client, fmt.Sprintf("%s/node/info/%d", baseURL, id),
@@ -40,7 +40,7 @@ func apiInfo(id int, authToken, baseURL string) (*fs.NodeWithProgress, error) {
 		return nil, err
 	}
 
-	var rs fs.NodeWithProgress
+	var rs api.NodeInfoResponse
 	if err := json.Unmarshal(body, &rs); err != nil {
 		return nil, err
 	}
@@ -230,8 +230,8 @@ func apiRename(path, newName string, authToken, baseURL string) ([]models.Node,
 	return nodes, nil
 }
 
-// apiDelete requests deletion of a node.
-func apiDelete(path string, recursive bool, authToken, baseURL string) ([]models.Node, error) {
+// apiDelete requests deletion of a node. Unless permanent is true, the node is moved to the trash.
+func apiDelete(path string, recursive, permanent bool, authToken, baseURL string) ([]models.Node, error) {
 	id, err := apiNodeIDForPath(path, authToken, baseURL)
 	if err != nil {
 		return nil, err
@@ -240,6 +240,7 @@ func apiDelete(path string, recursive bool, authToken, baseURL string) ([]models
 	req := api.DeleteRequest{
 		ID:        id,
 		Recursive: recursive,
+		Permanent: permanent,
 	}
 
 	client := http.Client{}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/terotoi/koticloud/server/api"
+	"github.com/terotoi/koticloud/server/jobs"
+)
+
+// apiProcessFailed requests the nodes whose processing failed.
+func apiProcessFailed(authToken, baseURL string) ([]*jobs.NodeProcess, error) {
+	client := http.Client{}
+
+	res, err := RequestURL(&client, fmt.Sprintf("%s/admin/process/failed", baseURL),
+		"application/json", authToken, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var reqs []*jobs.NodeProcess
+	if err := json.Unmarshal(res, &reqs); err != nil {
+		return nil, err
+	}
+	return reqs, nil
+}
+
+// apiProcessRequeue requests processing failed nodes again, or all failed
+// nodes if ids is empty. Returns the number of requeued nodes.
+func apiProcessRequeue(ids []int, authToken, baseURL string) (int, error) {
+	client := http.Client{}
+
+	res, err := PostJSON(&client, fmt.Sprintf("%s/admin/process/requeue", baseURL), authToken,
+		&api.RequeueRequest{NodeIDs: ids})
+	if err != nil {
+		return 0, err
+	}
+
+	var n int
+	if err := json.Unmarshal(res, &n); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
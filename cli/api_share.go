@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/terotoi/koticloud/server/api"
+)
+
+// apiShareCreate requests a share link for a node.
+func apiShareCreate(path string, req api.ShareCreateRequest, authToken, baseURL string) (*api.ShareEntry, error) {
+	id, err := apiNodeIDForPath(path, authToken, baseURL)
+	if err != nil {
+		return nil, err
+	}
+	req.NodeID = id
+
+	client := http.Client{}
+	res, err := PostJSON(&client, fmt.Sprintf("%s/share/create", baseURL), authToken, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var entry api.ShareEntry
+	if err := json.Unmarshal(res, &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// apiShareList requests the share links created by the user.
+func apiShareList(authToken, baseURL string) ([]*api.ShareEntry, error) {
+	client := http.Client{}
+	res, err := RequestURL(&client, fmt.Sprintf("%s/share/list", baseURL),
+		"application/json", authToken, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*api.ShareEntry
+	if err := json.Unmarshal(res, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// apiShareRevoke requests deletion of a share link.
+func apiShareRevoke(slug, authToken, baseURL string) error {
+	client := http.Client{}
+	_, err := PostJSON(&client, fmt.Sprintf("%s/share/revoke", baseURL), authToken,
+		api.ShareRevokeRequest{Slug: slug})
+	return err
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/terotoi/koticloud/server/api"
+	"github.com/terotoi/koticloud/server/models"
+)
+
+// apiTrashList requests the contents of the user's trash.
+func apiTrashList(authToken, baseURL string) ([]*api.TrashEntry, error) {
+	client := http.Client{}
+
+	res, err := RequestURL(&client, fmt.Sprintf("%s/trash/list", baseURL),
+		"application/json", authToken, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*api.TrashEntry
+	if err := json.Unmarshal(res, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// apiRestore requests restoring a node from the trash.
+func apiRestore(id int, authToken, baseURL string) ([]models.Node, error) {
+	client := http.Client{}
+
+	req := api.RestoreRequest{ID: id}
+	res, err := PostJSON(&client, fmt.Sprintf("%s/trash/restore", baseURL), authToken, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []models.Node
+	if err := json.Unmarshal(res, &nodes); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// apiEmptyTrash requests permanent deletion of everything in the trash.
+func apiEmptyTrash(authToken, baseURL string) ([]models.Node, error) {
+	client := http.Client{}
+
+	res, err := PostJSON(&client, fmt.Sprintf("%s/trash/empty", baseURL), authToken, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []models.Node
+	if err := json.Unmarshal(res, &nodes); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
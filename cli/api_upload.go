@@ -1,74 +1,70 @@
 package main
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
-	"mime/multipart"
 	"net/http"
 	"os"
 	"path/filepath"
-	"strconv"
+	"time"
 
+	"github.com/terotoi/koticloud/server/api"
 	"github.com/terotoi/koticloud/server/models"
+	"github.com/terotoi/koticloud/server/uploads"
 )
 
-func newFileUploadRequest(url string, params map[string]string, paramName,
-	path, authToken string) (*http.Response, error) {
-	fh, err := os.Open(path)
+const uploadChunkSize = 8 << 20
+const uploadRetries = 5
+
+// apiUploadStatus queries the state of an upload session.
+func apiUploadStatus(client *http.Client, id, authToken, baseURL string) (*uploads.Session, error) {
+	res, err := RequestURL(client, fmt.Sprintf("%s/upload/%s", baseURL, id),
+		"application/json", authToken, nil, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	filename := filepath.Base(path)
-
-	pr, pw := io.Pipe()
-	m := multipart.NewWriter(pw)
-
-	go func() {
-		defer pw.Close()
-		defer m.Close()
+	var s uploads.Session
+	if err := json.Unmarshal(res, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
 
-		part, err := m.CreateFormFile(paramName, filename)
-		if err != nil {
-			fmt.Println(err.Error())
-			return
+// Returns the first range of the file not yet received by the server,
+// limited to uploadChunkSize. Returns false if all data has been received.
+func nextMissingRange(s *uploads.Session) (int64, int64, bool) {
+	var pos int64
+	for _, r := range s.Received {
+		if r.Start > pos {
+			break
 		}
-
-		_, err = io.Copy(part, fh)
-		if err != nil {
-			fmt.Printf("Upload: %s\n", err.Error())
-			return
+		if r.End > pos {
+			pos = r.End
 		}
+	}
 
-		for k, v := range params {
-			if err = m.WriteField(k, v); err != nil {
-				fmt.Printf("Upload: %s\n", err.Error())
-				return
-			}
-		}
-	}()
-
-	req, err := http.NewRequest("POST", url, pr)
-	if err != nil {
-		return nil, err
+	if pos >= s.Size {
+		return 0, 0, false
 	}
 
-	req.Header.Set("Content-Type", m.FormDataContentType())
-	if authToken != "" {
-		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", authToken))
+	end := s.Size
+	for _, r := range s.Received {
+		if r.Start > pos && r.Start < end {
+			end = r.Start
+		}
 	}
 
-	client := http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
+	if end-pos > uploadChunkSize {
+		end = pos + uploadChunkSize
 	}
-	return resp, nil
+	return pos, end, true
 }
 
-// apiUpload does a HTTP multipart upload of a file.
+// apiUpload uploads a file in chunks using an upload session.
+// If an earlier upload of the same file was interrupted, it is resumed.
+// Failed chunks are retried a few times before giving up.
 func apiUpload(path, targetPath, authToken, baseURL string) ([]*models.Node, error) {
 	targetDir := filepath.Dir(targetPath + "/")
 
@@ -77,30 +73,81 @@ func apiUpload(path, targetPath, authToken, baseURL string) ([]*models.Node, err
 		return nil, err
 	}
 
-	params := map[string]string{
-		"parentID": strconv.Itoa(parentID),
-		"filename": filepath.Base(path),
+	fh, err := os.Open(path)
+	if err != nil {
+		return nil, err
 	}
+	defer fh.Close()
 
-	res, err := newFileUploadRequest(fmt.Sprintf("%s/node/new", baseURL),
-		params, "file", path, authToken)
+	st, err := fh.Stat()
 	if err != nil {
 		return nil, err
 	}
 
-	bb := bytes.Buffer{}
-	_, err = bb.ReadFrom(res.Body)
+	client := http.Client{}
+	res, err := PostJSON(&client, fmt.Sprintf("%s/upload/create", baseURL), authToken,
+		api.UploadCreateRequest{
+			ParentID: parentID,
+			Filename: filepath.Base(path),
+			Size:     st.Size(),
+		})
 	if err != nil {
 		return nil, err
 	}
-	res.Body.Close()
 
-	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("server error: %d %s", res.StatusCode, bb.String())
+	var s *uploads.Session
+	if err := json.Unmarshal(res, &s); err != nil {
+		return nil, err
+	}
+
+	if received := s.ReceivedBytes(); received > 0 {
+		fmt.Printf("Resuming upload of %s (%d/%d bytes)\n", s.Filename, received, s.Size)
+	}
+
+	failures := 0
+	for {
+		start, end, ok := nextMissingRange(s)
+		if !ok {
+			break
+		}
+
+		url := fmt.Sprintf("%s/upload/%s/%d", baseURL, s.ID, start)
+		res, err := PutData(&client, url, authToken, io.NewSectionReader(fh, start, end-start), end-start)
+		if err == nil {
+			err = json.Unmarshal(res, &s)
+		}
+
+		if err != nil {
+			if herror, ok := err.(*HTTPError); ok && herror.StatusCode < 500 {
+				return nil, err
+			}
+
+			failures++
+			if failures > uploadRetries {
+				return nil, fmt.Errorf("upload of %s failed, run upload again to resume: %s",
+					s.Filename, err)
+			}
+
+			wait := time.Duration(failures*failures) * time.Second
+			fmt.Fprintf(os.Stderr, "upload: %s, retrying in %s\n", err, wait)
+			time.Sleep(wait)
+
+			// Parts of the failed chunk may have been stored.
+			if status, err := apiUploadStatus(&client, s.ID, authToken, baseURL); err == nil {
+				s = status
+			}
+			continue
+		}
+		failures = 0
+	}
+
+	res, err = PostJSON(&client, fmt.Sprintf("%s/upload/%s/finalize", baseURL, s.ID), authToken, nil)
+	if err != nil {
+		return nil, err
 	}
 
 	var nodes []*models.Node
-	if err := json.Unmarshal(bb.Bytes(), &nodes); err != nil {
+	if err := json.Unmarshal(res, &nodes); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/terotoi/koticloud/server/api"
+	"github.com/terotoi/koticloud/server/models"
+)
+
+// apiVersions requests the list of previous versions of a node.
+func apiVersions(path, authToken, baseURL string) (*api.VersionListResponse, error) {
+	id, err := apiNodeIDForPath(path, authToken, baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	client := http.Client{}
+	res, err := RequestURL(&client, fmt.Sprintf("%s/node/versions/%d", baseURL, id),
+		"application/json", authToken, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp api.VersionListResponse
+	if err := json.Unmarshal(res, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// apiGetVersion downloads the contents of a version into a file.
+func apiGetVersion(id int, filename, authToken, baseURL string) error {
+	fh, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+
+	client := http.Client{}
+	_, err = RequestURL(&client, fmt.Sprintf("%s/version/get/%d", baseURL, id),
+		"application/json", authToken, nil, fh)
+	return err
+}
+
+// apiDiffVersion requests a diff from a version to the current contents or to another version.
+// If toID is zero, the diff is made to the current contents.
+func apiDiffVersion(id, toID int, authToken, baseURL string) (string, error) {
+	url := fmt.Sprintf("%s/version/diff/%d", baseURL, id)
+	if toID != 0 {
+		url = fmt.Sprintf("%s?to=%d", url, toID)
+	}
+
+	client := http.Client{}
+	res, err := RequestURL(&client, url, "application/json", authToken, nil, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(res), nil
+}
+
+// apiRestoreVersion requests replacing the contents of a node with a version.
+func apiRestoreVersion(id int, authToken, baseURL string) ([]models.Node, error) {
+	client := http.Client{}
+
+	req := api.VersionRestoreRequest{ID: id}
+	res, err := PostJSON(&client, fmt.Sprintf("%s/version/restore", baseURL), authToken, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []models.Node
+	if err := json.Unmarshal(res, &nodes); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
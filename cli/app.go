@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/terotoi/koticloud/server/fs"
+	"github.com/terotoi/koticloud/server/jobs"
 	"github.com/terotoi/koticloud/server/models"
 )
 
@@ -51,6 +52,14 @@ func printNodeWithProgress(node *fs.NodeWithProgress) {
 
 		fmt.Printf("  progress: %.1f volume: %.1f\n", progress, volume)
 	}
+
+	if node.ProcState.Valid && node.ProcState.String != jobs.ProcDone {
+		fmt.Printf("  processing %s", node.ProcState.String)
+	}
+
+	if node.ChecksumFailed {
+		fmt.Printf("  checksum mismatch")
+	}
 	fmt.Println()
 }
 
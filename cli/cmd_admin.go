@@ -1,51 +1,134 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
-	"net/http"
+
+	"github.com/terotoi/koticloud/server/jobs"
 )
 
+func scanUsage() {
+	fmt.Println("Usage: scan [options]")
+	fmt.Println("Options:")
+	fmt.Println("  -u <user>   scan only the home directory of the user")
+	fmt.Println("  -p <path>   scan only the file or directory in the home directory of the user given with -u")
+	fmt.Println("  -new        scan only for new and modified files")
+	fmt.Println("  -deleted    scan only for deleted files")
+	fmt.Println("  -n          list the changes a scan would make without making them")
+}
+
 func (app *App) scanDeleted(cmd string, args []string) error {
-	client := http.Client{}
+	return app.scanAll(cmd, []string{"-deleted"})
+}
+
+func (app *App) scanAll(cmd string, args []string) error {
+	var opts jobs.ScanOptions
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-new":
+			opts.Mode = jobs.ScanModeNew
+		case "-deleted":
+			opts.Mode = jobs.ScanModeDeleted
+		case "-n":
+			opts.DryRun = true
+		case "-u", "-p":
+			if i+1 >= len(args) {
+				scanUsage()
+				return nil
+			}
 
-	_, err := PostJSON(&client, fmt.Sprintf("%s/admin/scan_deleted",
-		app.BaseURL), app.AuthToken, nil)
+			if args[i] == "-u" {
+				opts.User = args[i+1]
+			} else {
+				opts.Path = args[i+1]
+			}
+			i++
+		default:
+			scanUsage()
+			return nil
+		}
+	}
+
+	job, err := apiStartJob("scan_all", &opts, app.AuthToken, app.BaseURL)
 	if err != nil {
 		return err
+	} else if !opts.DryRun {
+		fmt.Printf("Scan started as job %d.\n", job.ID)
+		return nil
+	}
+
+	// A dry run only finds the changes, so its results are waited for.
+	if job, err = app.waitJob(job.ID); err != nil {
+		return err
+	} else if job.State != jobs.JobDone {
+		fmt.Printf("Scan %s: %s\n", job.State, job.Error.String)
+		return nil
+	}
+
+	var res jobs.ScanResult
+	if err := json.Unmarshal([]byte(job.Result.String), &res); err != nil {
+		return err
 	}
 
-	fmt.Println("Scaning deleted started.")
+	for _, c := range res.Changes {
+		fmt.Printf("%-6s  %s:%s\n", c.Op, c.User, c.Path)
+	}
+	fmt.Printf("%d new, %d changed and %d deleted files and directories.\n", res.New, res.Changed, res.Removed)
 	return nil
 }
 
-func (app *App) scanAll(cmd string, args []string) error {
-	client := http.Client{}
+func (app *App) generateThumbnails(cmd string, args []string) error {
+	onlyMissing := "false"
+	if len(args) > 0 && (args[0] == "-m" || args[0] == "--only-missing") {
+		onlyMissing = "true"
+	}
 
-	_, err := PostJSON(&client, fmt.Sprintf("%s/admin/scan_all",
-		app.BaseURL), app.AuthToken, nil)
+	job, err := apiStartJob("generate_thumbnails/"+onlyMissing, nil, app.AuthToken, app.BaseURL)
 	if err != nil {
 		return err
 	}
 
-	fmt.Println("Scan started.")
+	fmt.Printf("Thumbnail regeneration started as job %d.\n", job.ID)
 	return nil
 }
 
-func (app *App) generateThumbnails(cmd string, args []string) error {
+func (app *App) indexText(cmd string, args []string) error {
 	onlyMissing := "false"
 	if len(args) > 0 && (args[0] == "-m" || args[0] == "--only-missing") {
 		onlyMissing = "true"
 	}
 
-	client := http.Client{}
+	job, err := apiStartJob("index_text/"+onlyMissing, nil, app.AuthToken, app.BaseURL)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Text indexing started as job %d.\n", job.ID)
+	return nil
+}
+
+func (app *App) extractInfo(cmd string, args []string) error {
+	onlyMissing := "false"
+	if len(args) > 0 && (args[0] == "-m" || args[0] == "--only-missing") {
+		onlyMissing = "true"
+	}
 
-	_, err := PostJSON(&client, fmt.Sprintf("%s/admin/generate_thumbnails/"+onlyMissing,
-		app.BaseURL), app.AuthToken, nil)
+	job, err := apiStartJob("extract_info/"+onlyMissing, nil, app.AuthToken, app.BaseURL)
 	if err != nil {
 		return err
 	}
 
-	fmt.Println("Issued thumbnail regeneration request.")
+	fmt.Printf("Metadata extraction started as job %d.\n", job.ID)
+	return nil
+}
+
+func (app *App) scrub(cmd string, args []string) error {
+	job, err := apiStartJob("scrub", nil, app.AuthToken, app.BaseURL)
+	if err != nil {
+		return err
+	}
 
+	fmt.Printf("Scrub started as job %d.\n", job.ID)
 	return nil
 }
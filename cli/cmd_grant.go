@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/terotoi/koticloud/server/api"
+)
+
+func (app *App) grant(cmd string, args []string) error {
+	var req api.GrantAddRequest
+	var rest []string
+
+	for _, arg := range args {
+		if arg == "-w" {
+			req.Write = true
+		} else {
+			rest = append(rest, arg)
+		}
+	}
+
+	if len(rest) != 2 {
+		fmt.Println("Usage: grant [-w] <path> <user|@group>")
+		return nil
+	}
+
+	if strings.HasPrefix(rest[1], "@") {
+		req.Group = strings.TrimPrefix(rest[1], "@")
+	} else {
+		req.User = rest[1]
+	}
+
+	g, err := apiGrantAdd(app.resolvePath(rest[0]), req, app.AuthToken, app.BaseURL)
+	if err != nil {
+		return err
+	}
+
+	access := "read"
+	if g.Write {
+		access = "write"
+	}
+	fmt.Printf("Granted %s access to %s (grant %d).\n", access, rest[1], g.ID)
+	return nil
+}
+
+func (app *App) listGrants(cmd string, args []string) error {
+	if len(args) != 1 {
+		fmt.Println("Usage: grants <path>")
+		return nil
+	}
+
+	grants, err := apiGrantList(app.resolvePath(args[0]), app.AuthToken, app.BaseURL)
+	if err != nil {
+		return err
+	}
+
+	if len(grants) == 0 {
+		fmt.Printf("Not shared with anyone.\n")
+		return nil
+	}
+
+	fmt.Printf("ID        Access  Grantee\n")
+	fmt.Printf("=========================================\n")
+	for _, g := range grants {
+		access := "read"
+		if g.Write {
+			access = "write"
+		}
+
+		grantee := g.UserName.String
+		if g.GroupName.Valid {
+			grantee = "@" + g.GroupName.String
+		}
+		fmt.Printf("%-8d  %-6s  %s\n", g.ID, access, grantee)
+	}
+	return nil
+}
+
+func (app *App) ungrant(cmd string, args []string) error {
+	if len(args) != 1 {
+		fmt.Println("Usage: ungrant <grantid>")
+		return nil
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := apiGrantRemove(id, app.AuthToken, app.BaseURL); err != nil {
+		return err
+	}
+
+	fmt.Printf("Grant %d removed.\n", id)
+	return nil
+}
+
+func (app *App) sharedWithMe(cmd string, args []string) error {
+	nodes, err := apiSharedWithMe(app.AuthToken, app.BaseURL)
+	if err != nil {
+		return err
+	}
+
+	if len(nodes) == 0 {
+		fmt.Printf("Nothing has been shared with you.\n")
+		return nil
+	}
+
+	fmt.Printf("ID        Access  Owner           Name\n")
+	fmt.Printf("=========================================================\n")
+	for _, n := range nodes {
+		access := "read"
+		if n.Write {
+			access = "write"
+		}
+		fmt.Printf("%-8d  %-6s  %-14.14s  %s\n", n.ID, access, n.OwnerName.String, n.Name)
+	}
+	return nil
+}
+
+func groupUsage() {
+	fmt.Println("Usage: group create|delete <group>")
+	fmt.Println("       group add|remove <group> <username>")
+	fmt.Println("       group list")
+}
+
+func (app *App) group(cmd string, args []string) error {
+	if len(args) == 0 {
+		groupUsage()
+		return nil
+	}
+
+	switch args[0] {
+	case "list":
+		groups, err := apiGroupList(app.AuthToken, app.BaseURL)
+		if err != nil {
+			return err
+		}
+
+		for _, g := range groups {
+			fmt.Printf("%s: %s\n", g.Name, strings.Join(g.Members, ", "))
+		}
+	case "create", "delete":
+		if len(args) != 2 {
+			groupUsage()
+			return nil
+		}
+
+		if err := apiGroup(args[0], api.GroupRequest{Group: args[1]}, app.AuthToken, app.BaseURL); err != nil {
+			return err
+		}
+		fmt.Printf("Group %s %sd.\n", args[1], args[0])
+	case "add", "remove":
+		if len(args) != 3 {
+			groupUsage()
+			return nil
+		}
+
+		req := api.GroupRequest{Group: args[1], User: args[2]}
+		if err := apiGroup(args[0]+"_user", req, app.AuthToken, app.BaseURL); err != nil {
+			return err
+		}
+
+		if args[0] == "add" {
+			fmt.Printf("User %s added to group %s.\n", args[2], args[1])
+		} else {
+			fmt.Printf("User %s removed from group %s.\n", args[2], args[1])
+		}
+	default:
+		groupUsage()
+	}
+	return nil
+}
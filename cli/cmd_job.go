@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/terotoi/koticloud/server/jobs"
+)
+
+// Number of jobs listed by default.
+const defaultJobCount = 20
+
+// Interval of polling a job while waiting for it to finish.
+const jobPollInterval = time.Second
+
+func jobsUsage() {
+	fmt.Println("Usage: jobs list [-n <count>]")
+	fmt.Println("       jobs show|cancel <id>")
+}
+
+// Formats the progress of a job.
+func jobProgress(job *jobs.Job) string {
+	if job.Total > 0 {
+		return fmt.Sprintf("%d/%d", job.Done, job.Total)
+	}
+	return strconv.FormatInt(job.Done, 10)
+}
+
+func (app *App) jobs(cmd string, args []string) error {
+	if len(args) == 0 {
+		jobsUsage()
+		return nil
+	}
+
+	switch args[0] {
+	case "list":
+		count := defaultJobCount
+		if len(args) == 3 && args[1] == "-n" {
+			n, err := strconv.Atoi(args[2])
+			if err != nil {
+				return err
+			}
+			count = n
+		} else if len(args) != 1 {
+			jobsUsage()
+			return nil
+		}
+
+		list, err := apiJobList(count, app.AuthToken, app.BaseURL)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("ID     Kind          State      Progress       Started                         User\n")
+		fmt.Printf("===============================================================================================\n")
+		for _, j := range list {
+			fmt.Printf("%-5d  %-12s  %-9s  %-13s  %-30.30s  %s\n", j.ID, j.Kind, j.State, jobProgress(j),
+				j.CreatedOn.Local().Format(time.UnixDate), j.User.String)
+		}
+	case "show", "cancel":
+		if len(args) != 2 {
+			jobsUsage()
+			return nil
+		}
+
+		id, err := strconv.Atoi(args[1])
+		if err != nil {
+			return err
+		}
+
+		if args[0] == "cancel" {
+			ok, err := apiJobCancel(id, app.AuthToken, app.BaseURL)
+			if err != nil {
+				return err
+			} else if !ok {
+				fmt.Printf("Job %d is not running.\n", id)
+			} else {
+				fmt.Printf("Cancelling job %d.\n", id)
+			}
+			return nil
+		}
+
+		resp, err := apiJobShow(id, app.AuthToken, app.BaseURL)
+		if err != nil {
+			return err
+		}
+		printJob(resp.Job)
+
+		if len(resp.Log) > 0 {
+			fmt.Printf("Log:\n")
+		}
+		for _, l := range resp.Log {
+			fmt.Printf("  %s %s\n", l.LoggedOn.Local().Format("2006-01-02 15:04:05"), l.Line)
+		}
+	default:
+		jobsUsage()
+	}
+	return nil
+}
+
+// Prints the details of a job.
+func printJob(job *jobs.Job) {
+	fmt.Printf("Job:       %d\n", job.ID)
+	fmt.Printf("Kind:      %s\n", job.Kind)
+	if job.Args.Valid && job.Args.String != "null" {
+		fmt.Printf("Arguments: %s\n", job.Args.String)
+	}
+	fmt.Printf("User:      %s\n", job.User.String)
+	fmt.Printf("State:     %s\n", job.State)
+	fmt.Printf("Progress:  %s\n", jobProgress(job))
+	fmt.Printf("Started:   %s\n", job.CreatedOn.Local().Format(time.UnixDate))
+	if job.FinishedOn.Valid {
+		fmt.Printf("Finished:  %s\n", job.FinishedOn.Time.Local().Format(time.UnixDate))
+	}
+	if job.Error.Valid {
+		fmt.Printf("Error:     %s\n", job.Error.String)
+	}
+
+	if job.Result.Valid {
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, []byte(job.Result.String), "", "  "); err != nil {
+			fmt.Printf("Result:    %s\n", job.Result.String)
+		} else {
+			fmt.Printf("Result:\n%s\n", buf.String())
+		}
+	}
+}
+
+// Waits for a job to finish.
+func (app *App) waitJob(id int) (*jobs.Job, error) {
+	for {
+		resp, err := apiJobShow(id, app.AuthToken, app.BaseURL)
+		if err != nil {
+			return nil, err
+		} else if resp.Job.State != jobs.JobRunning {
+			return resp.Job, nil
+		}
+		time.Sleep(jobPollInterval)
+	}
+}
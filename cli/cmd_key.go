@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/terotoi/koticloud/server/api"
+)
+
+func keyUsage() {
+	fmt.Println("Usage: key status")
+	fmt.Println("       key unlock <passphrase>")
+	fmt.Println("       key lock")
+	fmt.Println("       key passphrase [passphrase]")
+	fmt.Println("   Without a passphrase, the key is protected by the master key of the server.")
+}
+
+func (app *App) key(cmd string, args []string) error {
+	if len(args) == 0 {
+		keyUsage()
+		return nil
+	}
+
+	client := http.Client{}
+	switch args[0] {
+	case "status":
+		res, err := RequestURL(&client, fmt.Sprintf("%s/user/key", app.BaseURL), "", app.AuthToken, nil, nil)
+		if err != nil {
+			return err
+		}
+
+		var status api.KeyStatusResponse
+		if err := json.Unmarshal(res, &status); err != nil {
+			return err
+		}
+
+		if !status.Enabled {
+			fmt.Println("Encryption is not enabled.")
+			return nil
+		}
+
+		protection := "master key"
+		if status.Passphrase {
+			protection = "passphrase"
+		}
+		fmt.Printf("Key version: %d\n", status.Version)
+		fmt.Printf("Protected by: %s\n", protection)
+		fmt.Printf("Locked: %t\n", status.Locked)
+	case "unlock", "passphrase":
+		if (args[0] == "unlock" && len(args) != 2) || len(args) > 2 {
+			keyUsage()
+			return nil
+		}
+
+		var req api.KeyPassphraseRequest
+		if len(args) == 2 {
+			req.Passphrase = args[1]
+		}
+
+		res, err := PostJSON(&client, fmt.Sprintf("%s/user/key/%s", app.BaseURL, args[0]), app.AuthToken, &req)
+		if err != nil {
+			return err
+		} else if _, err := ParseBoolResponse(res); err != nil {
+			return err
+		}
+
+		if args[0] == "unlock" {
+			fmt.Println("Key unlocked.")
+		} else {
+			fmt.Println("Key passphrase changed.")
+		}
+	case "lock":
+		res, err := PostJSON(&client, fmt.Sprintf("%s/user/key/lock", app.BaseURL), app.AuthToken, nil)
+		if err != nil {
+			return err
+		} else if _, err := ParseBoolResponse(res); err != nil {
+			return err
+		}
+		fmt.Println("Key locked.")
+	default:
+		keyUsage()
+	}
+	return nil
+}
@@ -6,6 +6,10 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/terotoi/koticloud/server/fs"
+	"github.com/volatiletech/sqlboiler/v4/types"
 )
 
 func (app *App) info(cmd string, args []string) error {
@@ -24,7 +28,98 @@ func (app *App) info(cmd string, args []string) error {
 		return err
 	}
 	printNodeHeader()
-	printNodeWithProgress(node)
+	printNodeWithProgress(&node.NodeWithProgress)
+
+	if node.Checksum.Valid {
+		fmt.Printf("SHA-256:     %s\n", node.Checksum.String)
+		if node.ChecksumFailed {
+			fmt.Printf("Verified:    %s, CONTENTS DO NOT MATCH\n", node.ChecksumVerifiedOn.Time.Format(time.UnixDate))
+		} else if node.ChecksumVerifiedOn.Valid {
+			fmt.Printf("Verified:    %s\n", node.ChecksumVerifiedOn.Time.Format(time.UnixDate))
+		}
+	}
+
+	if node.ProcState.Valid {
+		fmt.Printf("Processing:  %s, %d attempts\n", node.ProcState.String, node.ProcAttempts.Int)
+		if node.ProcError.Valid {
+			fmt.Printf("Error:       %s\n", node.ProcError.String)
+		}
+	}
+	return printInfos(node.Infos)
+}
+
+// Prints the metadata of a node.
+func printInfos(infos map[string]types.JSON) error {
+	if data, ok := infos[fs.InfoPhoto]; ok {
+		var p fs.PhotoInfo
+		if err := data.Unmarshal(&p); err != nil {
+			return err
+		}
+
+		fmt.Printf("Dimensions:  %dx%d\n", p.Width, p.Height)
+		if p.TakenOn.Valid {
+			fmt.Printf("Taken:       %s\n", p.TakenOn.Time.Format(time.UnixDate))
+		}
+
+		if p.Make != "" || p.Model != "" {
+			fmt.Printf("Camera:      %s\n", strings.TrimSpace(p.Make+" "+p.Model))
+		}
+
+		if p.Lens != "" {
+			fmt.Printf("Lens:        %s\n", p.Lens)
+		}
+
+		if p.ExposureTime != "" || p.FNumber > 0 || p.ISO > 0 {
+			fmt.Printf("Exposure:    %s s  f/%.1f  ISO %d  %.0f mm\n", p.ExposureTime, p.FNumber, p.ISO, p.FocalLength)
+		}
+
+		if p.Latitude.Valid && p.Longitude.Valid {
+			fmt.Printf("Location:    %.6f, %.6f\n", p.Latitude.Float64, p.Longitude.Float64)
+		}
+	}
+
+	if data, ok := infos[fs.InfoMedia]; ok {
+		var m fs.MediaInfo
+		if err := data.Unmarshal(&m); err != nil {
+			return err
+		}
+
+		fmt.Printf("Format:      %s  %s  %d kbit/s\n", m.Format,
+			time.Duration(m.Duration*float64(time.Second)).Round(time.Second), m.BitRate/1000)
+		for _, s := range m.Streams {
+			switch s.Type {
+			case "video":
+				fmt.Printf("Video:       %s %dx%d %.2f fps\n", s.Codec, s.Width, s.Height, s.FrameRate)
+			case "audio":
+				fmt.Printf("Audio:       %s %d Hz %d channels %s\n", s.Codec, s.SampleRate, s.Channels, s.Language)
+			default:
+				fmt.Printf("Stream:      %s %s %s\n", s.Type, s.Codec, s.Language)
+			}
+		}
+
+		for _, tag := range [][2]string{{"title", "Title:"}, {"artist", "Artist:"}, {"album", "Album:"},
+			{"date", "Date:"}, {"genre", "Genre:"}} {
+			if v, ok := m.Tags[tag[0]]; ok {
+				fmt.Printf("%-12s %s\n", tag[1], v)
+			}
+		}
+	}
+
+	if data, ok := infos[fs.InfoDocument]; ok {
+		var d fs.DocumentInfo
+		if err := data.Unmarshal(&d); err != nil {
+			return err
+		}
+
+		fmt.Printf("Pages:       %d\n", d.Pages)
+		if d.Title != "" {
+			fmt.Printf("Title:       %s\n", d.Title)
+		}
+
+		if d.Author != "" {
+			fmt.Printf("Author:      %s\n", d.Author)
+		}
+	}
 	return nil
 }
 
@@ -139,15 +234,17 @@ func (app *App) rename(cmd string, args []string) error {
 
 func (app *App) delete(cmd string, args []string) error {
 	if len(args) == 0 {
-		fmt.Println("Usage: delete <path> [path...]")
+		fmt.Println("Usage: delete [-r] [-p] <path> [path...]")
 		return nil
 	}
 
-	var recursive bool
+	var recursive, permanent bool
 	var rest []string
 	for _, arg := range args {
 		if arg == "-r" {
 			recursive = true
+		} else if arg == "-p" {
+			permanent = true
 		} else {
 			rest = append(rest, arg)
 		}
@@ -156,11 +253,15 @@ func (app *App) delete(cmd string, args []string) error {
 	for _, arg := range rest {
 		path := app.resolvePath(arg)
 
-		nodes, err := apiDelete(path, recursive, app.AuthToken, app.BaseURL)
+		nodes, err := apiDelete(path, recursive, permanent, app.AuthToken, app.BaseURL)
 		if err != nil {
 			fmt.Println(err.Error())
 		} else {
-			fmt.Printf("Deleted %d nodes:\n", len(nodes))
+			if permanent {
+				fmt.Printf("Deleted %d nodes:\n", len(nodes))
+			} else {
+				fmt.Printf("Moved to trash:\n")
+			}
 			printNodeHeader()
 			for _, n := range nodes {
 				printNode(&n, true)
@@ -169,22 +270,3 @@ func (app *App) delete(cmd string, args []string) error {
 	}
 	return nil
 }
-
-func (app *App) search(cmd string, args []string) error {
-	if len(args) > 0 {
-		nodes, err := apiSearch(strings.Join(args, " "), app.AuthToken, app.BaseURL)
-		if err != nil {
-			return err
-		}
-
-		if nodes == nil {
-			fmt.Printf("No matching nodes.\n")
-		} else {
-			printNodeHeader()
-			for _, node := range nodes {
-				printNode(node, true)
-			}
-		}
-	}
-	return nil
-}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+func processUsage() {
+	fmt.Println("Usage: process failed")
+	fmt.Println("       process requeue [<nodeid>...]")
+}
+
+func (app *App) process(cmd string, args []string) error {
+	if len(args) == 0 {
+		processUsage()
+		return nil
+	}
+
+	switch args[0] {
+	case "failed":
+		reqs, err := apiProcessFailed(app.AuthToken, app.BaseURL)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Node   Attempts  Failed                          Name\n")
+		fmt.Printf("===============================================================================================\n")
+		for _, r := range reqs {
+			fmt.Printf("%-5d  %-8d  %-30.30s  %s\n", r.NodeID, r.Attempts,
+				r.UpdatedOn.Time.Local().Format(time.UnixDate), r.NodeName.String)
+			fmt.Printf("       %s\n", r.Error.String)
+		}
+	case "requeue":
+		var ids []int
+		for _, a := range args[1:] {
+			id, err := strconv.Atoi(a)
+			if err != nil {
+				return err
+			}
+			ids = append(ids, id)
+		}
+
+		n, err := apiProcessRequeue(ids, app.AuthToken, app.BaseURL)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Requeued %d nodes.\n", n)
+	default:
+		processUsage()
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/terotoi/koticloud/server/api"
+	"github.com/terotoi/koticloud/server/fs"
+	"github.com/volatiletech/null/v8"
+)
+
+// Formats a quota for display.
+func quotaString(quota null.Int64) string {
+	if !quota.Valid {
+		return "unlimited"
+	}
+	return strconv.FormatInt(quota.Int64, 10)
+}
+
+func (app *App) quota(cmd string, args []string) error {
+	client := http.Client{}
+	res, err := PostJSON(&client, fmt.Sprintf("%s/user/settings", app.BaseURL), app.AuthToken, nil)
+	if err != nil {
+		return err
+	}
+
+	var settings api.SettingsResponse
+	if err := json.Unmarshal(res, &settings); err != nil {
+		return err
+	}
+
+	fmt.Printf("Used:  %d bytes\n", settings.StorageUsed)
+	fmt.Printf("Quota: %s\n", quotaString(settings.Quota))
+	return nil
+}
+
+func (app *App) setQuota(cmd string, args []string) error {
+	if len(args) != 2 || args[1] == "" {
+		fmt.Printf("Usage: setquota <username> <size|none>\n")
+		fmt.Printf("   Size is in bytes or with a suffix K, M, G or T, such as 10G.\n")
+		return nil
+	}
+
+	req := api.SetQuotaRequest{Username: args[0]}
+	if args[1] != "none" {
+		size, err := fs.ParseSize(args[1])
+		if err != nil {
+			return err
+		}
+		req.Quota = null.Int64From(size)
+	}
+
+	client := http.Client{}
+	if _, err := PostJSON(&client, fmt.Sprintf("%s/user/setquota", app.BaseURL), app.AuthToken, &req); err != nil {
+		return err
+	}
+
+	fmt.Printf("Quota of %s set to %s.\n", req.Username, quotaString(req.Quota))
+	return nil
+}
+
+func (app *App) listUsage(cmd string, args []string) error {
+	client := http.Client{}
+	res, err := RequestURL(&client, fmt.Sprintf("%s/user/usage", app.BaseURL),
+		"application/json", app.AuthToken, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	var users []*api.UserUsage
+	if err := json.Unmarshal(res, &users); err != nil {
+		return err
+	}
+
+	fmt.Printf("User                        Used         Quota\n")
+	fmt.Printf("==================================================\n")
+	for _, u := range users {
+		fmt.Printf("%-20.20s  %12d  %12s\n", u.Username, u.StorageUsed, quotaString(u.Quota))
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/terotoi/koticloud/server/api"
+	"github.com/terotoi/koticloud/server/fs"
+	"github.com/volatiletech/null/v8"
+)
+
+const searchDateFormat = "2006-01-02"
+
+func searchUsage() {
+	fmt.Println("Usage: search [options] <query>")
+	fmt.Println("Query:")
+	fmt.Println("  Words must all appear in the name. Filters can be added to the query:")
+	fmt.Println("  type:video, name:\"holiday*\", ext:jpg, in:/Photos, size>1G, modified:2024,")
+	fmt.Println("  content:\"some words\" and sort:size or sort:-size. Words and type, name and ext")
+	fmt.Println("  filters are negated with -, as in -ext:tmp.")
+	fmt.Println("Options:")
+	fmt.Println("  -c              search also the contents of files")
+	fmt.Println("  -n <count>      maximum number of results (default 100)")
+	fmt.Println("  -o <offset>     skip the first results")
+	fmt.Println("  -t <mimetype>   only files of the given type, a prefix such as image/ matches all images")
+	fmt.Println("  -min <size>     minimum size, such as 10M")
+	fmt.Println("  -max <size>     maximum size")
+	fmt.Println("  -after <date>   modified on or after the date (YYYY-MM-DD)")
+	fmt.Println("  -before <date>  modified before the date (YYYY-MM-DD)")
+	fmt.Println("  -in <path>      only under the given directory")
+}
+
+// Highlights matches in a search snippet.
+var snippetReplacer = strings.NewReplacer(fs.SnippetMatchStart, "\033[1m", fs.SnippetMatchEnd, "\033[0m",
+	"\n", " ", "\r", "")
+
+func (app *App) search(cmd string, args []string) error {
+	var req api.SearchRequest
+	var terms []string
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-c":
+			req.Content = true
+		case "-t", "-min", "-max", "-after", "-before", "-in", "-n", "-o":
+			if i+1 >= len(args) {
+				searchUsage()
+				return nil
+			}
+
+			opt, value := args[i], args[i+1]
+			i++
+
+			switch opt {
+			case "-t":
+				req.MimeType = value
+			case "-n", "-o":
+				n, err := strconv.Atoi(value)
+				if err != nil {
+					return err
+				}
+
+				if opt == "-n" {
+					req.Limit = n
+				} else {
+					req.Offset = n
+				}
+			case "-min", "-max":
+				size, err := fs.ParseSize(value)
+				if err != nil {
+					return err
+				}
+
+				if opt == "-min" {
+					req.MinSize = null.Int64From(size)
+				} else {
+					req.MaxSize = null.Int64From(size)
+				}
+			case "-after", "-before":
+				t, err := time.ParseInLocation(searchDateFormat, value, time.Local)
+				if err != nil {
+					return err
+				}
+
+				if opt == "-after" {
+					req.After = null.TimeFrom(t)
+				} else {
+					req.Before = null.TimeFrom(t)
+				}
+			case "-in":
+				id, err := apiNodeIDForPath(app.resolvePath(value), app.AuthToken, app.BaseURL)
+				if err != nil {
+					return err
+				}
+				req.Under = null.IntFrom(id)
+			}
+		default:
+			terms = append(terms, args[i])
+		}
+	}
+
+	req.Text = strings.Join(terms, " ")
+	if req.Text == "" && req.MimeType == "" && !req.MinSize.Valid && !req.MaxSize.Valid &&
+		!req.After.Valid && !req.Before.Valid && !req.Under.Valid {
+		searchUsage()
+		return nil
+	}
+
+	resp, err := apiSearch(req, app.AuthToken, app.BaseURL)
+	if err != nil {
+		return err
+	}
+
+	if resp.Total == 0 {
+		fmt.Printf("No matching nodes.\n")
+		return nil
+	}
+
+	printNodeHeader()
+	for _, r := range resp.Results {
+		printNode(&r.Node, true)
+		if r.Snippet.Valid {
+			fmt.Printf("       %s\n", snippetReplacer.Replace(r.Snippet.String))
+		}
+	}
+
+	if len(resp.Results) < int(resp.Total) {
+		fmt.Printf("\nShowing %d-%d of %d matches.\n", resp.Offset+1, resp.Offset+len(resp.Results), resp.Total)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/terotoi/koticloud/server/api"
+	"github.com/volatiletech/null/v8"
+)
+
+func shareUsage() {
+	fmt.Println("Usage: share create [-p password] [-e expiry] [-n maxdownloads] <path>")
+	fmt.Println("       share list")
+	fmt.Println("       share revoke <slug>")
+	fmt.Println("Expiry is a duration such as 12h or 7d.")
+}
+
+// Parses a duration. In addition to the units of time.ParseDuration, "d" for days is accepted.
+func parseDuration(s string) (time.Duration, error) {
+	if strings.HasSuffix(s, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(s, "d"))
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(s)
+}
+
+func (app *App) share(cmd string, args []string) error {
+	if len(args) == 0 {
+		shareUsage()
+		return nil
+	}
+
+	switch args[0] {
+	case "create":
+		return app.shareCreate(args[1:])
+	case "list":
+		return app.shareList()
+	case "revoke":
+		if len(args) != 2 {
+			shareUsage()
+			return nil
+		}
+
+		if err := apiShareRevoke(args[1], app.AuthToken, app.BaseURL); err != nil {
+			return err
+		}
+		fmt.Printf("Share %s revoked.\n", args[1])
+	default:
+		shareUsage()
+	}
+	return nil
+}
+
+func (app *App) shareCreate(args []string) error {
+	var req api.ShareCreateRequest
+	var path string
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-p", "-e", "-n":
+			if i+1 >= len(args) {
+				shareUsage()
+				return nil
+			}
+
+			opt, value := args[i], args[i+1]
+			i++
+
+			switch opt {
+			case "-p":
+				req.Password = value
+			case "-e":
+				d, err := parseDuration(value)
+				if err != nil {
+					return err
+				}
+				req.ExpiresOn = null.TimeFrom(time.Now().Add(d))
+			case "-n":
+				n, err := strconv.Atoi(value)
+				if err != nil {
+					return err
+				}
+				req.MaxDownloads = null.IntFrom(n)
+			}
+		default:
+			path = args[i]
+		}
+	}
+
+	if path == "" {
+		shareUsage()
+		return nil
+	}
+
+	entry, err := apiShareCreate(app.resolvePath(path), req, app.AuthToken, app.BaseURL)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Shared %s: %s/s/%s\n", entry.Path, app.BaseURL, entry.Slug)
+	return nil
+}
+
+func (app *App) shareList() error {
+	entries, err := apiShareList(app.AuthToken, app.BaseURL)
+	if err != nil {
+		return err
+	}
+
+	if len(entries) == 0 {
+		fmt.Printf("No shares.\n")
+		return nil
+	}
+
+	fmt.Printf("Slug                    Password  Downloads  Expires                         Path\n")
+	fmt.Printf("===============================================================================================\n")
+	for _, e := range entries {
+		password := "no"
+		if e.HasPassword {
+			password = "yes"
+		}
+
+		downloads := strconv.Itoa(e.Downloads)
+		if e.MaxDownloads.Valid {
+			downloads = fmt.Sprintf("%d/%d", e.Downloads, e.MaxDownloads.Int)
+		}
+
+		expires := "never"
+		if e.ExpiresOn.Valid {
+			expires = e.ExpiresOn.Time.Format(time.UnixDate)
+		}
+
+		fmt.Printf("%-22s  %-8s  %-9s  %-30.30s  %s\n", e.Slug, password, downloads, expires, e.Path)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+func (app *App) listTrash(cmd string, args []string) error {
+	entries, err := apiTrashList(app.AuthToken, app.BaseURL)
+	if err != nil {
+		return err
+	}
+
+	if len(entries) == 0 {
+		fmt.Printf("Trash is empty.\n")
+		return nil
+	}
+
+	fmt.Printf("ID     Deleted                         Original path\n")
+	fmt.Printf("===============================================================================================\n")
+	for _, e := range entries {
+		path := e.OriginalPath
+		if path == "" {
+			path = e.Node.Name + " (original directory deleted)"
+		}
+		fmt.Printf("%-5d  %-30.30s  %s\n", e.Node.ID, e.DeletedOn.Format(time.UnixDate), path)
+	}
+	return nil
+}
+
+func (app *App) restore(cmd string, args []string) error {
+	if len(args) == 0 {
+		fmt.Println("Usage: restore <nodeid> [nodeid...]")
+		return nil
+	}
+
+	for _, arg := range args {
+		id, err := strconv.Atoi(arg)
+		if err != nil {
+			return err
+		}
+
+		nodes, err := apiRestore(id, app.AuthToken, app.BaseURL)
+		if err != nil {
+			fmt.Println(err.Error())
+			continue
+		}
+
+		for _, n := range nodes {
+			fmt.Printf("Restored %d as %s\n", n.ID, n.Name)
+		}
+	}
+	return nil
+}
+
+func (app *App) emptyTrash(cmd string, args []string) error {
+	nodes, err := apiEmptyTrash(app.AuthToken, app.BaseURL)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Deleted %d nodes.\n", len(nodes))
+	return nil
+}
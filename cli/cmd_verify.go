@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/terotoi/koticloud/server/fs"
+)
+
+// Counts of verified files by result.
+type verifyResult struct {
+	ok, mismatch, missing, unknown int
+}
+
+// Compares a local file to the checksum of a remote node, and prints differences.
+func verifyFile(localPath string, node *fs.NodeWithProgress, res *verifyResult) error {
+	if node == nil || fs.IsDir(&node.Node) {
+		fmt.Printf("MISSING   %s\n", localPath)
+		res.missing++
+		return nil
+	}
+
+	if !node.Checksum.Valid {
+		fmt.Printf("UNKNOWN   %s (no checksum on the server yet)\n", localPath)
+		res.unknown++
+		return nil
+	}
+
+	hash, _, err := fs.HashFile(localPath)
+	if err != nil {
+		return err
+	}
+
+	if hash != node.Checksum.String {
+		fmt.Printf("MISMATCH  %s (%d)\n", localPath, node.ID)
+		res.mismatch++
+	} else if node.ChecksumFailed {
+		fmt.Printf("CORRUPT   %s (%d, the server copy does not match its checksum)\n", localPath, node.ID)
+		res.mismatch++
+	} else {
+		res.ok++
+	}
+	return nil
+}
+
+// Lists a remote directory by name. Returns an empty map if it does not exist.
+func (app *App) remoteChildren(path string, cache map[string]map[string]*fs.NodeWithProgress) map[string]*fs.NodeWithProgress {
+	if children, ok := cache[path]; ok {
+		return children
+	}
+
+	children := map[string]*fs.NodeWithProgress{}
+	if _, nodes, err := apiList(path, app.AuthToken, app.BaseURL); err == nil {
+		for _, n := range nodes {
+			children[n.Name] = n
+		}
+	}
+	cache[path] = children
+	return children
+}
+
+// verify compares local files to the checksums of the files on the server.
+func (app *App) verify(cmd string, args []string) error {
+	if len(args) != 2 {
+		fmt.Println("Usage: verify <local> <remote>")
+		return nil
+	}
+
+	local := filepath.Clean(args[0])
+	remote := app.resolvePath(args[1])
+
+	st, err := os.Stat(local)
+	if err != nil {
+		return err
+	}
+
+	cache := map[string]map[string]*fs.NodeWithProgress{}
+	var res verifyResult
+
+	if st.Mode().IsRegular() {
+		// The remote path may name the file or the directory containing it.
+		node := app.remoteChildren(filepath.Dir(remote), cache)[filepath.Base(remote)]
+		if node != nil && fs.IsDir(&node.Node) {
+			node = app.remoteChildren(remote, cache)[filepath.Base(local)]
+		}
+
+		if err := verifyFile(local, node, &res); err != nil {
+			return err
+		}
+	} else {
+		err = filepath.Walk(local, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %s\n", path, err)
+				return nil
+			}
+
+			if !info.Mode().IsRegular() {
+				return nil
+			}
+
+			rel, err := filepath.Rel(local, path)
+			if err != nil {
+				return err
+			}
+
+			dir := filepath.Join(remote, filepath.Dir(rel))
+			return verifyFile(path, app.remoteChildren(dir, cache)[filepath.Base(rel)], &res)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("%d files match, %d differ, %d missing, %d without a checksum.\n",
+		res.ok, res.mismatch, res.missing, res.unknown)
+
+	if res.mismatch > 0 || res.missing > 0 {
+		return fmt.Errorf("verification failed")
+	}
+	return nil
+}
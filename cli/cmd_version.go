@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+func (app *App) versions(cmd string, args []string) error {
+	if len(args) == 0 {
+		fmt.Println("Usage: versions <path>")
+		return nil
+	}
+
+	resp, err := apiVersions(app.resolvePath(args[0]), app.AuthToken, app.BaseURL)
+	if err != nil {
+		return err
+	}
+
+	if len(resp.Versions) == 0 {
+		fmt.Printf("No previous versions.\n")
+		return nil
+	}
+
+	fmt.Printf("ID     Version  Mime Type               Size  Modified\n")
+	fmt.Printf("===============================================================================================\n")
+	for _, v := range resp.Versions {
+		fmt.Printf("%-5d  %7d  %-16.16s %12d  %s\n", v.ID, v.Version, v.MimeType, v.Size,
+			v.ModifiedOn.Format(time.UnixDate))
+	}
+	fmt.Printf("\n%d versions, %d bytes\n", len(resp.Versions), resp.TotalSize)
+	return nil
+}
+
+func (app *App) getVersion(cmd string, args []string) error {
+	if len(args) != 2 {
+		fmt.Println("Usage: get-version <versionid> <localfile>")
+		return nil
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := apiGetVersion(id, args[1], app.AuthToken, app.BaseURL); err != nil {
+		return err
+	}
+	fmt.Printf("get-version: %d -> %s\n", id, args[1])
+	return nil
+}
+
+func (app *App) diffVersion(cmd string, args []string) error {
+	if len(args) == 0 || len(args) > 2 {
+		fmt.Println("Usage: diff-version <versionid> [versionid]")
+		return nil
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return err
+	}
+
+	var toID int
+	if len(args) == 2 {
+		if toID, err = strconv.Atoi(args[1]); err != nil {
+			return err
+		}
+	}
+
+	diff, err := apiDiffVersion(id, toID, app.AuthToken, app.BaseURL)
+	if err != nil {
+		return err
+	}
+	fmt.Print(diff)
+	return nil
+}
+
+func (app *App) restoreVersion(cmd string, args []string) error {
+	if len(args) != 1 {
+		fmt.Println("Usage: restore-version <versionid>")
+		return nil
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return err
+	}
+
+	nodes, err := apiRestoreVersion(id, app.AuthToken, app.BaseURL)
+	if err != nil {
+		return err
+	}
+
+	for _, n := range nodes {
+		fmt.Printf("Restored %s (%d) to version ID %d\n", n.Name, n.ID, id)
+	}
+	return nil
+}
@@ -19,21 +19,44 @@ func usage() {
 	fmt.Printf("  ls [path]                         - list a directory\n")
 	fmt.Printf("  cd <path>                         - change remote directory\n")
 	fmt.Printf("  cp <src> <dst>                    - copy a file or directory\n")
-	fmt.Printf("  rm <path>                         - remove a file or directory\n")
+	fmt.Printf("  rm [-r] [-p] <path>               - move a file or directory to the trash, -p deletes permanently\n")
 	fmt.Printf("  rename <src> <dst>                - rename a file or directory\n")
 	fmt.Printf("  mv <src> <dst>                    - move a file or directory\n")
 	fmt.Printf("  mkdir <path>                      - create a directory\n")
 	fmt.Printf("  info <path>                       - get information about a file or directory\n")
 	fmt.Printf("  get <path>                        - download a file or directory\n")
-	fmt.Printf("  upload <path>                     - upload a file or directory\n")
-	fmt.Printf("  search <text>                     - search for files\n")
+	fmt.Printf("  upload [-r] <path>                - upload files, resuming interrupted uploads\n")
+	fmt.Printf("  search [options] <text>           - search for files, see \"search\" for options\n")
+	fmt.Printf("  share create|list|revoke          - manage public share links, see \"share\" for options\n")
+	fmt.Printf("  grant [-w] <path> <user|@group>   - give a user or a group access to a file or directory\n")
+	fmt.Printf("  grants <path>                     - list who a file or directory is shared with\n")
+	fmt.Printf("  ungrant <grantid>                 - remove access given with grant\n")
+	fmt.Printf("  shared                            - list files and directories shared with you\n")
+	fmt.Printf("  quota                             - show storage usage and quota\n")
+	fmt.Printf("  key status|unlock|lock|passphrase - manage the encryption key, see \"key\" for options\n")
+	fmt.Printf("  trash                             - list the contents of the trash\n")
+	fmt.Printf("  restore <nodeid>                  - restore a node from the trash\n")
+	fmt.Printf("  empty-trash                       - permanently delete everything in the trash\n")
+	fmt.Printf("  versions <path>                   - list previous versions of a file\n")
+	fmt.Printf("  get-version <id> <localfile>      - download a previous version\n")
+	fmt.Printf("  diff-version <id> [id]            - compare a version to the current or another version\n")
+	fmt.Printf("  restore-version <id>              - restore a previous version\n")
+	fmt.Printf("  verify <local> <remote>           - compare local files to the checksums of remote files\n")
 
 	fmt.Printf("\nadminstrator commands:\n")
 	fmt.Printf("  create-user <username>            - add a new user to the system\n")
+	fmt.Printf("  group create|delete|add|remove|list - manage user groups\n")
+	fmt.Printf("  extract-meta [-m]                 - extract photo, audio, video and PDF metadata\n")
 	fmt.Printf("  generate-thumbs                   - regenerate thumbnails\n")
+	fmt.Printf("  index-text [-m]                   - extract text from files for content search\n")
 	fmt.Printf("  scan-deleted                      - scan for physically deleted files\n")
-	fmt.Printf("  scan                              - scan for new and physically deleted files\n")
+	fmt.Printf("  scan [options]                    - scan for new, modified and physically deleted files, see \"scan -h\"\n")
+	fmt.Printf("  scrub                             - verify the checksums of all files\n")
+	fmt.Printf("  jobs list|show|cancel             - list, show and cancel background jobs, see \"jobs\" for options\n")
+	fmt.Printf("  process failed|requeue [nodeid]   - list nodes whose processing failed, and process them again\n")
 	fmt.Printf("  setpassword <username> <password> - set a password for an user account\n")
+	fmt.Printf("  setquota <username> <size|none>   - set the storage quota of an user\n")
+	fmt.Printf("  usage                             - list storage usage and quotas of all users\n")
 }
 
 func main() {
@@ -66,19 +89,42 @@ func main() {
 		"cp":              app.copy,
 		"create-user":     app.createUser,
 		"delete":          app.delete,
+		"diff-version":    app.diffVersion,
+		"empty-trash":     app.emptyTrash,
+		"extract-meta":    app.extractInfo,
 		"generate-thumbs": app.generateThumbnails,
 		"get":             app.get,
+		"get-version":     app.getVersion,
+		"grant":           app.grant,
+		"grants":          app.listGrants,
+		"group":           app.group,
+		"index-text":      app.indexText,
+		"jobs":            app.jobs,
 		"info":            app.info,
+		"key":             app.key,
 		"login":           app.login,
 		"ls":              app.list,
 		"mkdir":           app.makeDir,
+		"process":         app.process,
 		"move":            app.copy,
+		"quota":           app.quota,
 		"rename":          app.rename,
+		"restore":         app.restore,
+		"restore-version": app.restoreVersion,
 		"scan-deleted":    app.scanDeleted,
 		"scan":            app.scanAll,
+		"scrub":           app.scrub,
 		"search":          app.search,
 		"setpassword":     app.setPassword,
+		"setquota":        app.setQuota,
+		"share":           app.share,
+		"shared":          app.sharedWithMe,
+		"trash":           app.listTrash,
+		"ungrant":         app.ungrant,
 		"upload":          app.upload,
+		"usage":           app.listUsage,
+		"verify":          app.verify,
+		"versions":        app.versions,
 	}
 
 	if len(args) > 0 {
@@ -30,7 +30,24 @@ func RequestURL(client *http.Client, url, contentType, authToken string, body []
 	}
 
 	req.Header.Add("Content-Type", contentType)
+	return sendRequest(client, req, authToken, w)
+}
+
+// PutData does a PUT request with the body read from r.
+func PutData(client *http.Client, url, authToken string, r io.Reader, length int64) ([]byte, error) {
+	req, err := http.NewRequest("PUT", url, r)
+	if err != nil {
+		return nil, err
+	}
+
+	req.ContentLength = length
+	req.Header.Add("Content-Type", "application/octet-stream")
+	return sendRequest(client, req, authToken, nil)
+}
 
+// Sends a request and reads the response. If w is not nil, the response body
+// is copied to that writer. Otherwise the body is returned as byte array.
+func sendRequest(client *http.Client, req *http.Request, authToken string, w io.Writer) ([]byte, error) {
 	if authToken != "" {
 		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", authToken))
 	}
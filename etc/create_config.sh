@@ -10,6 +10,7 @@ _timezone="`cat /etc/timezone`"
 _config_contents="
 {
   \"database\": \"dbname=koticloud user=kotidbuser password=${_db_password} host=db port=5432 sslmode=disable\",
+  \"auto_migrate\": true,
   \"jwt_secret\": \"${_jwt_secret}\",
   \"data_root\": \"/data\",
   \"file_root\": \"\",
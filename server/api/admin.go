@@ -3,9 +3,11 @@ package api
 import (
 	"context"
 	"database/sql"
-	"log"
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
-	"sync"
+	"time"
 
 	"github.com/go-chi/chi"
 	"github.com/terotoi/koticloud/server/core"
@@ -13,96 +15,111 @@ import (
 	"github.com/terotoi/koticloud/server/models"
 )
 
-var scanLock sync.Mutex
-var scanDeletedRunning bool
-var scanNewRunning bool
+// ScrubResult is the result of a scrub job.
+type ScrubResult struct {
+	Checked int // Number of verified files
+	Failed  int // Number of files not matching their checksums
+}
+
+// Starts a job and responds with it, or with 409 Conflict if a job of the
+// same kind is already running.
+func startJob(kind string, args interface{}, run jobs.JobFunc, user *models.User, db *sql.DB,
+	w http.ResponseWriter, r *http.Request) {
+	job, err := jobs.StartJob(kind, args, user, run, db)
+	if errors.Is(err, jobs.ErrJobRunning) {
+		reportIf(err, http.StatusConflict, "-", r, w)
+		return
+	} else if reportInt(err, r, w) != nil {
+		return
+	}
 
-// GenerateAllThumbnails regenerates all thumbnails.
+	respJSON(job, r, w)
+}
+
+// GenerateAllThumbnails queues all files with thumbnails for thumbnail
+// generation in a job.
+// output: jobs.Job
 func GenerateAllThumbnails(np *jobs.NodeProcessor, homeRoot string, db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
 	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
 		onlyMissing := chi.URLParam(r, "onlyMissing") == "true"
 
-		if onlyMissing {
-			log.Printf("[thumbnails] Generating missing thumbnails issued by %s", user.Name)
-		} else {
-			log.Printf("[thumbnails] Generating all thumbnails issued by %s", user.Name)
-		}
-		if err := np.GenerateAllThumbs(r.Context(), onlyMissing, homeRoot, db); err != nil {
-			log.Println(err)
-		}
-
-		log.Printf("[thumbnails] Generating all thumbnails finished (issued by %s)", user.Name)
+		startJob(jobs.JobThumbnails, map[string]bool{"OnlyMissing": onlyMissing},
+			func(ctx context.Context) (interface{}, error) {
+				return nil, np.GenerateAllThumbs(ctx, onlyMissing, homeRoot, db)
+			}, user, db, w, r)
 	}
 }
 
-// Scan for deleted nodes.
-func ScanDeleted(np *jobs.NodeProcessor, cfg *core.Config, db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+// IndexAllText queues all files containing text for text extraction in a job.
+// output: jobs.Job
+func IndexAllText(np *jobs.NodeProcessor, homeRoot string, db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
 	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		onlyMissing := chi.URLParam(r, "onlyMissing") == "true"
+
+		startJob(jobs.JobIndexText, map[string]bool{"OnlyMissing": onlyMissing},
+			func(ctx context.Context) (interface{}, error) {
+				return nil, np.IndexAllText(ctx, onlyMissing, homeRoot, db)
+			}, user, db, w, r)
+	}
+}
 
-		job := func() {
-			ctx := context.Background()
-			log.Printf("[scan-deleted] Scanning for deleted nodes issued by %s", user.Name)
+// ExtractAllInfo queues all images, audio and video files and PDFs for
+// metadata extraction in a job.
+// output: jobs.Job
+func ExtractAllInfo(np *jobs.NodeProcessor, homeRoot string, db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		onlyMissing := chi.URLParam(r, "onlyMissing") == "true"
 
-			err := jobs.ScanDeletedNodes(ctx, user, cfg, db)
-			if err != nil {
-				log.Printf("[scan-deleted] error: %s", err.Error())
-				return
-			}
+		startJob(jobs.JobExtractInfo, map[string]bool{"OnlyMissing": onlyMissing},
+			func(ctx context.Context) (interface{}, error) {
+				return nil, np.ExtractAllInfo(ctx, onlyMissing, homeRoot, db)
+			}, user, db, w, r)
+	}
+}
 
-			log.Printf("[scan-deleted] Scanning of deleted files finished (issued by %s)", user.Name)
-		}
+// ScrubAll verifies the checksums of all files in a job. The result of the
+// job is a ScrubResult.
+// output: jobs.Job
+func ScrubAll(homeRoot string, db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		startJob(jobs.JobScrub, nil, func(ctx context.Context) (interface{}, error) {
+			checked, failed, err := jobs.Scrub(ctx, homeRoot, time.Now(), db)
+			return &ScrubResult{Checked: checked, Failed: failed}, err
+		}, user, db, w, r)
+	}
+}
 
-		go job()
+// ScanDeleted scans all home directories for deleted files and directories
+// in a job.
+// output: jobs.Job
+func ScanDeleted(np *jobs.NodeProcessor, cfg *core.Config, db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		startScan(&jobs.ScanOptions{Mode: jobs.ScanModeDeleted}, np, cfg, user, db, w, r)
 	}
 }
 
-// Scan for deleted nodes and new files and directories from all home directories.
+// ScanAll scans the home directories for deleted, new and modified files and
+// directories in a job, see jobs.Scan. The scan can be limited to a user, a
+// path and new or deleted files with the optional request body. The result
+// of the job is a jobs.ScanResult, which lists the changes found by a dry run.
+// input: jobs.ScanOptions, optional
+// output: jobs.Job
 func ScanAll(np *jobs.NodeProcessor, cfg *core.Config, db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
 	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
-		scanLock.Lock()
-		defer scanLock.Unlock()
-
-		if !scanDeletedRunning && !scanNewRunning {
-			scanDeletedRunning = true
-			scanNewRunning = true
-
-			jobScanDeleted := func() {
-				ctx := context.Background()
-				log.Printf("[scan] Scanning for deleted nodes issued by %s", user.Name)
-
-				err := jobs.ScanDeletedNodes(ctx, user, cfg, db)
-				if err != nil {
-					log.Printf("[scan] Error: %s", err.Error())
-					return
-				}
-
-				log.Printf("[scan] Finished scanning of deleted files (issued by %s)", user.Name)
-				scanLock.Lock()
-				scanDeletedRunning = false
-				scanLock.Unlock()
-			}
-			go jobScanDeleted()
-
-			jobScanNew := func() {
-				ctx := context.Background()
-
-				log.Printf("[scan] Scanning for new files and directories issued by %s", user.Name)
-				err := jobs.ScanAllHomes(ctx, cfg, np, db)
-				if err != nil {
-					log.Printf("[scan] Error: %s", err.Error())
-					return
-				}
-				log.Printf("[scan] Finished scanning for new files and directories issued by %s.", user.Name)
-
-				scanLock.Lock()
-				scanNewRunning = false
-				scanLock.Unlock()
-			}
-
-			go jobScanNew()
-
-			respJSON(true, r, w)
+		var opts jobs.ScanOptions
+		if err := json.NewDecoder(r.Body).Decode(&opts); err != nil && err != io.EOF {
+			reportIf(err, http.StatusBadRequest, "", r, w)
+			return
 		}
-		respJSON(false, r, w)
+
+		startScan(&opts, np, cfg, user, db, w, r)
 	}
 }
+
+// Starts a scan job.
+func startScan(opts *jobs.ScanOptions, np *jobs.NodeProcessor, cfg *core.Config, user *models.User, db *sql.DB,
+	w http.ResponseWriter, r *http.Request) {
+	startJob(jobs.JobScan, opts, func(ctx context.Context) (interface{}, error) {
+		return jobs.Scan(ctx, opts, cfg, np, db)
+	}, user, db, w, r)
+}
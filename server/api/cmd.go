@@ -56,7 +56,7 @@ func RunCommand(auth *jwtauth.JWTAuth, cfg *core.Config, db *sql.DB) func(user *
 			return
 		}
 
-		if !fs.AccessAllowed(user, node, false) {
+		if !fs.AccessAllowed(ctx, user, node, false, db) {
 			reportUnauthorized("no access", r, w)
 			return
 		}
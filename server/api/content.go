@@ -4,16 +4,15 @@ import (
 	"database/sql"
 	"log"
 	"net/http"
-	"os"
 	"strconv"
 
 	"github.com/go-chi/chi"
 	"github.com/terotoi/koticloud/server/fs"
 	"github.com/terotoi/koticloud/server/models"
+	"github.com/terotoi/koticloud/server/storage"
 )
 
 // NodeGet returns contents of a node from the given root.
-//
 func NodeGet(homeRoot string, db *sql.DB) func(user *models.User, tokenNode *models.Node, w http.ResponseWriter, r *http.Request) {
 	return func(user *models.User, tokenNode *models.Node, w http.ResponseWriter, r *http.Request) {
 		id, err := strconv.Atoi(chi.URLParam(r, "nodeID"))
@@ -32,26 +31,33 @@ func NodeGet(homeRoot string, db *sql.DB) func(user *models.User, tokenNode *mod
 			return
 		}
 
-		if !fs.AccessAllowed(user, node, false) {
+		if !fs.AccessAllowed(r.Context(), user, node, false, db) {
 			reportUnauthorized("no access", r, w)
 			return
 		}
 
-		path, err := fs.PhysPath(r.Context(), node, homeRoot, db)
-		if reportInt(err, r, w); err != nil {
-			return
-		}
-
-		fh, err := os.Open(path)
-		if reportInt(err, r, w) != nil {
-			return
+		if serveNode(w, r, node, homeRoot, db) == nil {
+			log.Printf("NodeGet %s (%d) served %s (%d)", user.Name, user.ID,
+				node.Name, node.ID)
 		}
+	}
+}
 
-		log.Printf("NodeGet %s (%d) served %s (%d)", user.Name, user.ID,
-			node.Name, node.ID)
+// Serves the contents of a file node.
+func serveNode(w http.ResponseWriter, r *http.Request, node *models.Node, homeRoot string, db *sql.DB) error {
+	path, err := fs.PhysPath(r.Context(), node, homeRoot, db)
+	if reportInt(err, r, w) != nil {
+		return err
+	}
 
-		w.Header().Add("Content-Type", node.MimeType)
-		//w.Header().Add("Cache-Control", "private, max-age=0, no-cache")
-		http.ServeContent(w, r, node.Name, node.ModifiedOn, fh)
+	fh, err := storage.Open(path, node.Encrypted)
+	if reportSystemError(err, r, w) != nil {
+		return err
 	}
+	defer fh.Close()
+
+	w.Header().Add("Content-Type", node.MimeType)
+	//w.Header().Add("Cache-Control", "private, max-age=0, no-cache")
+	http.ServeContent(w, r, node.Name, node.ModifiedOn, fh)
+	return nil
 }
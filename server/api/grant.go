@@ -0,0 +1,144 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/terotoi/koticloud/server/fs"
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/terotoi/koticloud/server/mx"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// GrantAddRequest requests giving a user or a group access to a node.
+// Exactly one of User and Group must be given.
+type GrantAddRequest struct {
+	NodeID int
+	User   string // Name of the user
+	Group  string // Name of the group
+	Write  bool   // Allow modifications
+}
+
+// GrantRemoveRequest requests removal of a grant.
+type GrantRemoveRequest struct {
+	ID int // ID of the grant
+}
+
+// GrantAdd gives a user or a group access to a node and everything under it.
+// output: models.Grant
+func GrantAdd(db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		dec := json.NewDecoder(r.Body)
+		var req GrantAddRequest
+		err := dec.Decode(&req)
+		if reportIf(err, http.StatusBadRequest, "", r, w) != nil {
+			return
+		}
+
+		ctx := r.Context()
+		tx, err := db.BeginTx(ctx, nil)
+		if reportInt(err, r, w) != nil {
+			return
+		}
+		defer tx.Rollback()
+
+		node, err := fs.NodeByID(ctx, req.NodeID, tx)
+		if reportSystemError(err, r, w) != nil {
+			return
+		}
+
+		var toUserID, toGroupID null.Int
+		if req.User != "" {
+			u, err := models.Users(qm.Where("name=?", req.User)).One(ctx, tx)
+			if reportIf(err, http.StatusNotFound, "user not found", r, w) != nil {
+				return
+			}
+			toUserID = null.Int{Int: u.ID, Valid: true}
+		}
+
+		if req.Group != "" {
+			g, err := mx.GroupByName(ctx, req.Group, tx)
+			if reportSystemError(err, r, w) != nil {
+				return
+			}
+			toGroupID = null.Int{Int: g.ID, Valid: true}
+		}
+
+		g, err := fs.AddGrant(ctx, node, user, toUserID, toGroupID, req.Write, tx)
+		if reportSystemError(err, r, w) != nil {
+			return
+		}
+
+		if reportInt(tx.Commit(), r, w) != nil {
+			return
+		}
+
+		respJSON(g, r, w)
+	}
+}
+
+// GrantList lists the grants given on a node.
+// output: []fs.GrantInfo
+func GrantList(db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "nodeID"))
+		if reportIf(err, http.StatusBadRequest, "", r, w) != nil {
+			return
+		}
+
+		ctx := r.Context()
+		node, err := fs.NodeByID(ctx, id, db)
+		if reportSystemError(err, r, w) != nil {
+			return
+		}
+
+		grants, err := fs.GrantsForNode(ctx, node, user, db)
+		if reportSystemError(err, r, w) != nil {
+			return
+		}
+
+		respJSON(grants, r, w)
+	}
+}
+
+// GrantRemove removes a grant.
+// output: true
+func GrantRemove(db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		dec := json.NewDecoder(r.Body)
+		var req GrantRemoveRequest
+		err := dec.Decode(&req)
+		if reportIf(err, http.StatusBadRequest, "", r, w) != nil {
+			return
+		}
+
+		ctx := r.Context()
+		g, err := fs.GrantByID(ctx, req.ID, db)
+		if reportSystemError(err, r, w) != nil {
+			return
+		}
+
+		if reportSystemError(fs.RemoveGrant(ctx, g, user, db), r, w) != nil {
+			return
+		}
+
+		respJSON(true, r, w)
+	}
+}
+
+// NodeSharedWithMe lists the nodes other users have shared with the user.
+// output: []fs.SharedNode
+func NodeSharedWithMe(db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		nodes, err := fs.SharedWithUser(r.Context(), user, db)
+		if reportInt(err, r, w) != nil {
+			return
+		}
+
+		respJSON(nodes, r, w)
+	}
+}
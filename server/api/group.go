@@ -0,0 +1,119 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/terotoi/koticloud/server/mx"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// GroupRequest names a group and, for membership changes, a user.
+type GroupRequest struct {
+	Group string
+	User  string
+}
+
+// GroupCreate creates a group.
+// output: mx.Group
+func GroupCreate(db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		dec := json.NewDecoder(r.Body)
+		var req GroupRequest
+		err := dec.Decode(&req)
+		if reportIf(err, http.StatusBadRequest, "", r, w) != nil {
+			return
+		}
+
+		g, err := mx.GroupCreate(r.Context(), req.Group, db)
+		if reportSystemError(err, r, w) != nil {
+			return
+		}
+
+		respJSON(g, r, w)
+	}
+}
+
+// GroupDelete deletes a group.
+// output: true
+func GroupDelete(db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		dec := json.NewDecoder(r.Body)
+		var req GroupRequest
+		err := dec.Decode(&req)
+		if reportIf(err, http.StatusBadRequest, "", r, w) != nil {
+			return
+		}
+
+		ctx := r.Context()
+		g, err := mx.GroupByName(ctx, req.Group, db)
+		if reportSystemError(err, r, w) != nil {
+			return
+		}
+
+		if reportInt(mx.GroupDelete(ctx, g, db), r, w) != nil {
+			return
+		}
+
+		respJSON(true, r, w)
+	}
+}
+
+// GroupList lists all groups and their members.
+// output: []mx.Group
+func GroupList(db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		groups, err := mx.Groups(r.Context(), db)
+		if reportInt(err, r, w) != nil {
+			return
+		}
+
+		respJSON(groups, r, w)
+	}
+}
+
+// GroupAddUser adds a user to a group.
+// output: true
+func GroupAddUser(db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return groupMembership(mx.GroupAddUser, db)
+}
+
+// GroupRemoveUser removes a user from a group.
+// output: true
+func GroupRemoveUser(db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return groupMembership(mx.GroupRemoveUser, db)
+}
+
+// Creates a handler changing the members of a group.
+func groupMembership(f func(ctx context.Context, g *mx.Group, u *models.User, tx boil.ContextExecutor) error,
+	db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		dec := json.NewDecoder(r.Body)
+		var req GroupRequest
+		err := dec.Decode(&req)
+		if reportIf(err, http.StatusBadRequest, "", r, w) != nil {
+			return
+		}
+
+		ctx := r.Context()
+		g, err := mx.GroupByName(ctx, req.Group, db)
+		if reportSystemError(err, r, w) != nil {
+			return
+		}
+
+		u, err := models.Users(qm.Where("name=?", req.User)).One(ctx, db)
+		if reportIf(err, http.StatusNotFound, "user not found", r, w) != nil {
+			return
+		}
+
+		if reportInt(f(ctx, g, u, db), r, w) != nil {
+			return
+		}
+
+		respJSON(true, r, w)
+	}
+}
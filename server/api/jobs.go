@@ -0,0 +1,100 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/terotoi/koticloud/server/jobs"
+	"github.com/terotoi/koticloud/server/models"
+)
+
+// Number of jobs listed by default.
+const defaultJobListLimit = 50
+
+// JobResponse contains a job with its log lines.
+type JobResponse struct {
+	Job *jobs.Job
+	Log []*models.JobLog
+}
+
+// JobList lists the latest jobs, newest first. The number of jobs can be
+// given with the limit query parameter.
+// output: []jobs.Job
+func JobList(db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		limit := defaultJobListLimit
+		if l := r.URL.Query().Get("limit"); l != "" {
+			var err error
+			if limit, err = strconv.Atoi(l); reportIf(err, http.StatusBadRequest, "invalid limit", r, w) != nil {
+				return
+			}
+		}
+
+		// Jobs of stopped servers are shown as failed.
+		if _, err := jobs.FailStaleJobs(r.Context(), db); reportInt(err, r, w) != nil {
+			return
+		}
+
+		list, err := jobs.ListJobs(r.Context(), limit, db)
+		if reportInt(err, r, w) != nil {
+			return
+		}
+
+		if list == nil {
+			list = []*jobs.Job{}
+		}
+		respJSON(list, r, w)
+	}
+}
+
+// JobShow returns a job with its log lines.
+// output: JobResponse
+func JobShow(db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id, err := strconv.Atoi(chi.URLParam(r, "jobID"))
+		if reportIf(err, http.StatusBadRequest, "", r, w) != nil {
+			return
+		}
+
+		if _, err := jobs.FailStaleJobs(ctx, db); reportInt(err, r, w) != nil {
+			return
+		}
+
+		job, err := jobs.JobByID(ctx, id, db)
+		if reportInt(err, r, w) != nil {
+			return
+		} else if job == nil {
+			report(fmt.Sprintf("job %d not found", id), http.StatusNotFound, r, w)
+			return
+		}
+
+		lines, err := jobs.JobLog(ctx, id, db)
+		if reportInt(err, r, w) != nil {
+			return
+		}
+
+		respJSON(&JobResponse{Job: job, Log: lines}, r, w)
+	}
+}
+
+// JobCancel requests a running job to stop. Responds with false if the job
+// is not running.
+// output: bool
+func JobCancel(db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "jobID"))
+		if reportIf(err, http.StatusBadRequest, "", r, w) != nil {
+			return
+		}
+
+		ok, err := jobs.CancelJob(r.Context(), id, db)
+		if reportInt(err, r, w) != nil {
+			return
+		}
+		respJSON(ok, r, w)
+	}
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/terotoi/koticloud/server/crypt"
+	"github.com/terotoi/koticloud/server/fs"
+	"github.com/terotoi/koticloud/server/models"
+)
+
+// KeyPassphraseRequest is used for unlocking a data key and for changing its passphrase.
+type KeyPassphraseRequest struct {
+	Passphrase string
+}
+
+// KeyStatusResponse describes the data key of a user.
+type KeyStatusResponse struct {
+	Enabled    bool // Files of the user are encrypted
+	Passphrase bool // The key is protected by a passphrase
+	Locked     bool // The key is protected by a passphrase and has not been unlocked
+	Version    int  // Current version of the key
+}
+
+// KeyStatus returns the status of the data key of the user.
+// output: KeyStatusResponse
+func KeyStatus(db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		keys, err := fs.UserKeys(r.Context(), user.ID, db)
+		if reportInt(err, r, w) != nil {
+			return
+		}
+
+		var resp KeyStatusResponse
+		if len(keys) > 0 && crypt.Enabled() {
+			current := keys[len(keys)-1]
+			resp.Enabled = true
+			resp.Version = current.Version
+			resp.Passphrase = current.Salt.Valid
+			if _, err := crypt.Lookup(user.ID, current.Version); err == crypt.ErrLocked {
+				resp.Locked = true
+			}
+		}
+
+		respJSON(&resp, r, w)
+	}
+}
+
+// KeyUnlock unlocks the data key of the user with a passphrase, until the
+// server is restarted or the key is locked again.
+// input: KeyPassphraseRequest
+// output: true
+func KeyUnlock(db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		var req KeyPassphraseRequest
+		if reportIf(json.NewDecoder(r.Body).Decode(&req), http.StatusBadRequest, "", r, w) != nil {
+			return
+		}
+
+		if reportSystemError(fs.UnlockUserKeys(r.Context(), user.ID, req.Passphrase, db), r, w) != nil {
+			return
+		}
+
+		log.Printf("Data key unlocked by %s [%s]", user.Name, r.Host)
+		respJSON(true, r, w)
+	}
+}
+
+// KeyLock removes the data key of the user from memory. Files encrypted with
+// it cannot be read until it is unlocked again. Keys wrapped by the master key
+// are loaded again when needed.
+// output: true
+func KeyLock() func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		fs.LockUserKeys(user.ID)
+		log.Printf("Data key locked by %s [%s]", user.Name, r.Host)
+		respJSON(true, r, w)
+	}
+}
+
+// KeySetPassphrase protects the data key of the user with a passphrase, or
+// with the master key of the server if the passphrase is empty.
+// input: KeyPassphraseRequest
+// output: true
+func KeySetPassphrase(db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		var req KeyPassphraseRequest
+		if reportIf(json.NewDecoder(r.Body).Decode(&req), http.StatusBadRequest, "", r, w) != nil {
+			return
+		}
+
+		ctx := r.Context()
+		tx, err := db.BeginTx(ctx, nil)
+		if reportInt(err, r, w) != nil {
+			return
+		}
+		defer tx.Rollback()
+
+		if reportSystemError(fs.SetKeyPassphrase(ctx, user.ID, req.Passphrase, tx), r, w) != nil {
+			return
+		}
+
+		if reportInt(tx.Commit(), r, w) != nil {
+			return
+		}
+
+		log.Printf("Data key passphrase changed by %s [%s]", user.Name, r.Host)
+		respJSON(true, r, w)
+	}
+}
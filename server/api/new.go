@@ -1,17 +1,18 @@
 package api
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"os"
 	"strconv"
 	"strings"
 
+	"github.com/terotoi/koticloud/server/core"
 	"github.com/terotoi/koticloud/server/fs"
 	"github.com/terotoi/koticloud/server/jobs"
 	"github.com/terotoi/koticloud/server/models"
@@ -69,8 +70,43 @@ func dataFromMultipart(r *http.Request, uploadDir string) (string, error) {
 	return uploadFile, nil
 }
 
+// Creates a new file node from an uploaded file. Returns the node and its physical path.
+func newFileFromUpload(ctx context.Context, parent *models.Node, filename, uploadFile, homeRoot string,
+	useBlobs bool, user *models.User, tx *sql.Tx) (*models.Node, string, error) {
+	mimeType, err := fs.DetectMimeType(uploadFile, false)
+	if err != nil {
+		return nil, "", err
+	}
+
+	st, err := os.Stat(uploadFile)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := fs.CheckQuota(ctx, parent, user, st.Size(), tx); err != nil {
+		return nil, "", err
+	}
+
+	node, err := fs.NewFile(ctx, parent, filename, mimeType, st.Size(),
+		user, nil, false, tx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err = fs.CopyData(ctx, node, uploadFile, false, homeRoot, useBlobs, tx); err != nil {
+		return nil, "", err
+	}
+
+	path, err := fs.PhysPath(ctx, node, homeRoot, tx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return node, path, nil
+}
+
 // NodeNew creates a new file. Data is retrieved from multipart upload.
-func NodeNew(uploadDir, homeRoot, thumbRoot string, procCh chan jobs.NodeProcessRequest,
+func NodeNew(uploadDir, homeRoot, thumbRoot string, useBlobs bool, procCh chan jobs.NodeProcessRequest,
 	db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
 	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
 		multiPart := strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data")
@@ -121,7 +157,7 @@ func NodeNew(uploadDir, homeRoot, thumbRoot string, procCh chan jobs.NodeProcess
 			return
 		}
 
-		if !fs.AccessAllowed(user, parent, true) {
+		if !fs.AccessAllowed(ctx, user, parent, true, tx) {
 			reportUnauthorized("no access", r, w)
 			return
 		}
@@ -152,54 +188,30 @@ func NodeNew(uploadDir, homeRoot, thumbRoot string, procCh chan jobs.NodeProcess
 			}
 		}()
 
-		mimeType, err := fs.DetectMimeType(uploadFile)
-		if reportInt(err, r, w) != nil {
-			return
-		}
-
-		st, err := os.Stat(uploadFile)
-		if reportInt(err, r, w) != nil {
-			return
-		}
-
-		node, err := fs.NewFile(ctx, parent, filename, mimeType, st.Size(),
-			user, nil, false, tx)
-		if err != nil {
-			reportSystemError(err, r, w)
-			return
-		}
-
-		if err = fs.CopyData(ctx, node, uploadFile, homeRoot, tx); err != nil {
-			reportSystemError(err, r, w)
-			return
-		}
-
-		path, err := fs.PhysPath(ctx, node, homeRoot, tx)
+		node, path, err := newFileFromUpload(ctx, parent, filename, uploadFile, homeRoot, useBlobs, user, tx)
 		if err != nil {
 			reportSystemError(err, r, w)
 			return
 		}
 
 		logRequest(r, fmt.Sprintf("new: %s -> %s [%s] (%s, %d bytes)", uploadFile, filename,
-			path, node.MimeType, st.Size()))
+			path, node.MimeType, node.Size.Int64))
 
 		err = tx.Commit()
 		if reportInt(err, r, w) != nil {
 			return
 		}
 
-		if err := jobs.AddNodeProcessRequest(ctx, procCh, node, path, false, db); err != nil {
+		if err := jobs.AddNodeProcessRequest(ctx, procCh, node, path, db); err != nil {
 			reportIf(err, http.StatusInternalServerError, "failed to process upload", r, w)
 		}
-
-		// NodeProcessor will remove the upload file, if needed.
-		//removeUploadFile = false
 		respJSON([]*models.Node{node}, r, w)
 	}
 }
 
 // NodeUpdate updates an existing node. Data is retrieved from multipart upload.
-func NodeUpdate(uploadDir, homeRoot, thumbRoot string, procCh chan jobs.NodeProcessRequest,
+// The previous contents are kept as a version.
+func NodeUpdate(cfg *core.Config, procCh chan jobs.NodeProcessRequest,
 	db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
 	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
 		err := r.ParseMultipartForm(int64(32 << 20))
@@ -208,6 +220,9 @@ func NodeUpdate(uploadDir, homeRoot, thumbRoot string, procCh chan jobs.NodeProc
 		}
 
 		ctx := r.Context()
+		j := fs.NewJournal(cfg.HomeRoot)
+		defer j.Finish(db)
+
 		tx, err := db.BeginTx(ctx, nil)
 		if reportInt(err, r, w) != nil {
 			return
@@ -224,12 +239,12 @@ func NodeUpdate(uploadDir, homeRoot, thumbRoot string, procCh chan jobs.NodeProc
 			return
 		}
 
-		if !fs.AccessAllowed(user, node, true) {
+		if !fs.AccessAllowed(ctx, user, node, true, tx) {
 			reportUnauthorized("no access", r, w)
 			return
 		}
 
-		uploadFile, err := dataFromMultipart(r, uploadDir)
+		uploadFile, err := dataFromMultipart(r, cfg.UploadDir)
 		if reportInt(err, r, w) != nil {
 			return
 		}
@@ -241,7 +256,7 @@ func NodeUpdate(uploadDir, homeRoot, thumbRoot string, procCh chan jobs.NodeProc
 			}
 		}()
 
-		mimeType, err := fs.DetectMimeType(uploadFile)
+		mimeType, err := fs.DetectMimeType(uploadFile, false)
 		if reportInt(err, r, w) != nil {
 			return
 		}
@@ -251,23 +266,31 @@ func NodeUpdate(uploadDir, homeRoot, thumbRoot string, procCh chan jobs.NodeProc
 			return
 		}
 
-		if err := fs.UpdateFile(ctx, node, mimeType, st.Size(), user, nil, false, tx); err != nil {
+		// The old contents are kept as a version, so the whole new file counts toward the quota.
+		if err := fs.CheckQuota(ctx, node, user, st.Size(), tx); err != nil {
 			reportSystemError(err, r, w)
 			return
 		}
 
-		path, err := fs.PhysPath(ctx, node, homeRoot, tx)
-		if err != nil {
+		// Keep the old contents
+		if err := fs.KeepVersion(ctx, node, user, cfg.HomeRoot, cfg.VersionsKeep,
+			cfg.VersionsMaxAgeDuration(), j, tx); err != nil {
+			reportSystemError(err, r, w)
+			return
+		}
+
+		if err := fs.UpdateFile(ctx, node, mimeType, st.Size(), user, nil, false, tx); err != nil {
 			reportSystemError(err, r, w)
 			return
 		}
 
-		// Remove old contents
-		if err := os.Remove(path); err != nil {
-			log.Println(err)
+		path, err := fs.PhysPath(ctx, node, cfg.HomeRoot, tx)
+		if err != nil {
+			reportSystemError(err, r, w)
+			return
 		}
 
-		if err = fs.CopyData(ctx, node, uploadFile, homeRoot, tx); err != nil {
+		if err = fs.CopyData(ctx, node, uploadFile, false, cfg.HomeRoot, cfg.BlobStore, tx); err != nil {
 			reportSystemError(err, r, w)
 			return
 		}
@@ -280,10 +303,9 @@ func NodeUpdate(uploadDir, homeRoot, thumbRoot string, procCh chan jobs.NodeProc
 			return
 		}
 
-		if err := jobs.AddNodeProcessRequest(ctx, procCh, node, uploadFile, false, db); err != nil {
+		if err := jobs.AddNodeProcessRequest(ctx, procCh, node, path, db); err != nil {
 			reportIf(err, http.StatusInternalServerError, "failed to process upload", r, w)
 		}
-		//removeUploadFile = false
 		respJSON([]*models.Node{node}, r, w)
 	}
 }
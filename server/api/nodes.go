@@ -14,6 +14,7 @@ import (
 	"github.com/terotoi/koticloud/server/fs"
 	"github.com/terotoi/koticloud/server/models"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
 )
 
 // ListDirResponse is contains the directory node
@@ -41,10 +42,12 @@ type MoveRequest struct {
 }
 
 // DeleteRequest requests deletion of a node, potentially
-// recursively.
+// recursively. Nodes are moved to the trash, unless Permanent
+// is true or the node is already in the trash.
 type DeleteRequest struct {
 	ID        int
 	Recursive bool
+	Permanent bool
 }
 
 // RenameRequest requests renaming of a node.
@@ -99,7 +102,7 @@ func NodeIDForPath(auth *jwtauth.JWTAuth, db *sql.DB) func(user *models.User, w
 			return
 		}
 
-		if !fs.AccessAllowed(user, node, false) {
+		if !fs.AccessAllowed(ctx, user, node, false, tx) {
 			reportUnauthorized("no access", r, w)
 			return
 		}
@@ -117,7 +120,14 @@ func NodeIDForPath(auth *jwtauth.JWTAuth, db *sql.DB) func(user *models.User, w
 	}
 }
 
-// NodeInfo returns information on one node.
+// NodeInfoResponse contains a node and its metadata.
+type NodeInfoResponse struct {
+	fs.NodeWithProgress
+	Infos map[string]types.JSON `json:"infos"` // Info records by type, such as fs.InfoPhoto
+}
+
+// NodeInfo returns information on one node, including its metadata.
+// output: NodeInfoResponse
 func NodeInfo(auth *jwtauth.JWTAuth, db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
 	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -132,15 +142,16 @@ func NodeInfo(auth *jwtauth.JWTAuth, db *sql.DB) func(user *models.User, w http.
 			return
 		}
 
-		if !fs.AccessAllowed(user, &nwm.Node, false) {
+		if !fs.AccessAllowed(ctx, user, &nwm.Node, false, db) {
 			reportUnauthorized("no access", r, w)
 			return
 		}
 
+		infos, err := fs.NodeInfos(ctx, id, db)
 		if reportInt(err, r, w) != nil {
 			return
 		}
-		respJSON(&nwm, r, w)
+		respJSON(&NodeInfoResponse{NodeWithProgress: *nwm, Infos: infos}, r, w)
 	}
 }
 
@@ -169,7 +180,7 @@ func NodeList(auth *jwtauth.JWTAuth, db *sql.DB) func(user *models.User, w http.
 				return
 			}
 
-			if !fs.AccessAllowed(user, node, false) {
+			if !fs.AccessAllowed(ctx, user, node, false, tx) {
 				reportUnauthorized("no access", r, w)
 				return
 			}
@@ -232,7 +243,7 @@ func NodeMakeDir(cfg *core.Config, db *sql.DB) func(user *models.User, w http.Re
 			return
 		}
 
-		if !fs.AccessAllowed(user, parent, true) {
+		if !fs.AccessAllowed(ctx, user, parent, true, tx) {
 			reportUnauthorized("no access", r, w)
 			return
 		}
@@ -269,7 +280,8 @@ func NodeMakeDir(cfg *core.Config, db *sql.DB) func(user *models.User, w http.Re
 }
 
 // NodeCopy copies a node to a possibly different parent.
-func NodeCopy(homeRoot, thumbRoot string, db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+func NodeCopy(homeRoot, thumbRoot string, blobStore bool,
+	db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
 	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
 		dec := json.NewDecoder(r.Body)
 
@@ -280,6 +292,9 @@ func NodeCopy(homeRoot, thumbRoot string, db *sql.DB) func(user *models.User, w
 		}
 
 		ctx := r.Context()
+		j := fs.NewJournal(homeRoot)
+		defer j.Finish(db)
+
 		tx, err := db.BeginTx(ctx, nil)
 		if reportInt(err, r, w) != nil {
 			return
@@ -296,8 +311,8 @@ func NodeCopy(homeRoot, thumbRoot string, db *sql.DB) func(user *models.User, w
 			return
 		}
 
-		copied, err := fs.Copy(ctx, src, dest, req.NewName, homeRoot, thumbRoot,
-			user, tx)
+		copied, err := fs.Copy(ctx, src, dest, req.NewName, homeRoot, thumbRoot, blobStore,
+			user, j, tx)
 		if err != nil {
 			reportSystemError(err, r, w)
 			return
@@ -323,6 +338,9 @@ func NodeMove(cfg *core.Config, db *sql.DB) func(user *models.User, w http.Respo
 		}
 
 		ctx := r.Context()
+		j := fs.NewJournal(cfg.HomeRoot)
+		defer j.Finish(db)
+
 		tx, err := db.BeginTx(ctx, nil)
 		if reportInt(err, r, w) != nil {
 			return
@@ -339,7 +357,7 @@ func NodeMove(cfg *core.Config, db *sql.DB) func(user *models.User, w http.Respo
 			return
 		}
 
-		if err = fs.Move(ctx, &node.Node, dest, user, cfg.HomeRoot, tx); err != nil {
+		if err = fs.Move(ctx, &node.Node, dest, user, cfg.HomeRoot, cfg.ThumbRoot, j, tx); err != nil {
 			reportSystemError(err, r, w)
 			return
 		}
@@ -365,6 +383,9 @@ func NodeRename(cfg *core.Config, db *sql.DB) func(user *models.User, w http.Res
 
 		ctx := r.Context()
 
+		j := fs.NewJournal(cfg.HomeRoot)
+		defer j.Finish(db)
+
 		tx, err := db.BeginTx(ctx, nil)
 		if reportInt(err, r, w) != nil {
 			return
@@ -376,7 +397,7 @@ func NodeRename(cfg *core.Config, db *sql.DB) func(user *models.User, w http.Res
 			return
 		}
 
-		if err = fs.Rename(ctx, &node.Node, req.NewName, user, cfg.HomeRoot, tx); err != nil {
+		if err = fs.Rename(ctx, &node.Node, req.NewName, user, cfg.HomeRoot, j, tx); err != nil {
 			reportSystemError(err, r, w)
 			return
 		}
@@ -389,7 +410,7 @@ func NodeRename(cfg *core.Config, db *sql.DB) func(user *models.User, w http.Res
 	}
 }
 
-// NodeDelete deletes a node.
+// NodeDelete moves a node to the trash or deletes it permanently.
 func NodeDelete(homeRoot, thumbRoot string, db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
 	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
 		dec := json.NewDecoder(r.Body)
@@ -402,6 +423,9 @@ func NodeDelete(homeRoot, thumbRoot string, db *sql.DB) func(user *models.User,
 
 		ctx := r.Context()
 
+		j := fs.NewJournal(homeRoot)
+		defer j.Finish(db)
+
 		tx, err := db.BeginTx(ctx, nil)
 		if reportInt(err, r, w) != nil {
 			return
@@ -413,7 +437,19 @@ func NodeDelete(homeRoot, thumbRoot string, db *sql.DB) func(user *models.User,
 			return
 		}
 
-		deleted, err := fs.Delete(ctx, node, req.Recursive, user, homeRoot, thumbRoot, tx)
+		inTrash, err := fs.InTrash(ctx, node, tx)
+		if reportInt(err, r, w) != nil {
+			return
+		}
+
+		var deleted []*models.Node
+		if req.Permanent || inTrash {
+			deleted, err = fs.Delete(ctx, node, req.Recursive, user, homeRoot, thumbRoot, j, tx)
+		} else {
+			err = fs.Trash(ctx, node, req.Recursive, user, homeRoot, j, tx)
+			deleted = []*models.Node{node}
+		}
+
 		if err != nil {
 			reportSystemError(err, r, w)
 			return
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/terotoi/koticloud/server/jobs"
+	"github.com/terotoi/koticloud/server/models"
+)
+
+// RequeueRequest requests processing failed nodes again. All failed nodes
+// are requeued if NodeIDs is empty.
+type RequeueRequest struct {
+	NodeIDs []int
+}
+
+// ProcessFailed lists the nodes whose processing failed on every attempt,
+// with the error of the latest attempt.
+// output: []jobs.NodeProcess
+func ProcessFailed(db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		reqs, err := jobs.FailedRequests(r.Context(), db)
+		if reportInt(err, r, w) != nil {
+			return
+		}
+
+		if reqs == nil {
+			reqs = []*jobs.NodeProcess{}
+		}
+		respJSON(reqs, r, w)
+	}
+}
+
+// ProcessRequeue queues failed nodes for processing again.
+// input: RequeueRequest, optional
+// output: {int} number of requeued nodes
+func ProcessRequeue(procCh chan jobs.NodeProcessRequest, db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		var req RequeueRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+			reportIf(err, http.StatusBadRequest, "", r, w)
+			return
+		}
+
+		n, err := jobs.RequeueFailed(r.Context(), req.NodeIDs, procCh, db)
+		if reportInt(err, r, w) != nil {
+			return
+		}
+		respJSON(n, r, w)
+	}
+}
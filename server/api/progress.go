@@ -8,9 +8,7 @@ import (
 
 	"github.com/terotoi/koticloud/server/fs"
 	"github.com/terotoi/koticloud/server/models"
-	"github.com/volatiletech/null/v8"
-	"github.com/volatiletech/sqlboiler/v4/boil"
-	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries"
 )
 
 // ProgressUpdateRequest contains information about progress update.
@@ -45,45 +43,20 @@ func UpdateProgress(db *sql.DB) func(user *models.User, w http.ResponseWriter, r
 			return
 		}
 
-		if !fs.AccessAllowed(user, node, true) {
+		if !fs.AccessAllowed(ctx, user, node, false, tx) {
 			reportUnauthorized("no access", r, w)
 			return
 		}
 
-		prgs, err := models.Progresses(qm.Where("node_id=?", node.ID),
-			qm.And("user_id=?", user.ID)).All(ctx, tx)
-		if reportInt(err, r, w) != nil {
-			return
-		}
-
 		log.Printf("Update progress on node %d user: %d volume: %f progress: %f", req.NodeID, user.ID,
 			req.Volume, req.Progress)
-		if len(prgs) > 0 {
-			p := prgs[0]
-			p.Volume = null.Float32{Float32: req.Volume, Valid: true}
-			p.Progress = null.Float32{Float32: req.Progress, Valid: true}
 
-			_, err = p.Update(ctx, tx, boil.Infer())
-			if reportInt(err, r, w) != nil {
-				return
-			}
-
-			// Delete any duplicates
-			for _, p := range prgs[1:] {
-				_, err = p.Delete(ctx, tx)
-				if reportInt(err, r, w) != nil {
-					return
-				}
-			}
-		} else {
-			p := models.Progress{UserID: user.ID, NodeID: node.ID,
-				Volume:   null.Float32{Float32: req.Volume, Valid: true},
-				Progress: null.Float32{Float32: req.Progress, Valid: true}}
-
-			err = p.Insert(ctx, tx, boil.Infer())
-			if reportInt(err, r, w) != nil {
-				return
-			}
+		// There is at most one progress row per user and node.
+		_, err = queries.Raw("INSERT INTO progress (user_id, node_id, volume, progress) VALUES ($1, $2, $3, $4) "+
+			"ON CONFLICT (user_id, node_id) DO UPDATE SET volume=EXCLUDED.volume, progress=EXCLUDED.progress",
+			user.ID, node.ID, req.Volume, req.Progress).ExecContext(ctx, tx)
+		if reportInt(err, r, w) != nil {
+			return
 		}
 
 		err = tx.Commit()
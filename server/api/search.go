@@ -3,24 +3,126 @@ package api
 import (
 	"database/sql"
 	"encoding/json"
-	"fmt"
 	"log"
 	"net/http"
 	"strings"
 
+	"github.com/terotoi/koticloud/server/core"
 	"github.com/terotoi/koticloud/server/fs"
 	"github.com/terotoi/koticloud/server/models"
-	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/null/v8"
 )
 
+const defaultSearchLimit = 100
+const maxSearchLimit = 1000
+
 // SearchRequest is used to search for nodes.
-// All Terms must be found in a filename to match it.
+// Text is a query as described in fs.ParseSearchQuery, for example
+// `type:video size>1G modified:2024 in:/Photos name:"holiday*" -ext:tmp`.
+// The other fields are optional filters applied in addition to the query.
 type SearchRequest struct {
-	Text string
+	Text     string
+	Content  bool       // Match the words of the query also against the text content of files
+	MimeType string     // Mime type, or a prefix ending in "/", such as "image/"
+	MinSize  null.Int64 // Minimum size in bytes
+	MaxSize  null.Int64 // Maximum size in bytes
+	After    null.Time  // Modified on or after
+	Before   null.Time  // Modified before
+	Under    null.Int   // Search only under this directory
+	Sort     string     // "name", "size", "modified", "taken" or "relevance", overrides sort: in the query
+	Desc     bool       // Reverse the sort order given in Sort
+	Offset   int        // Number of results to skip
+	Limit    int        // Maximum number of results, default 100
+}
+
+// SearchResponse contains a page of search results.
+type SearchResponse struct {
+	Results []*fs.SearchResult
+	Total   int64 // Total number of matches
+	Offset  int
+	Limit   int
+}
+
+// Builds a search query from a request.
+func searchQueryFor(req *SearchRequest, user *models.User, db *sql.DB, r *http.Request) (*fs.SearchQuery, error) {
+	q, err := fs.ParseSearchQuery(req.Text)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Content && len(q.Terms) > 0 {
+		q.Text = strings.TrimSpace(q.Text + " " + strings.Join(q.Terms, " "))
+	}
+
+	if req.MimeType != "" {
+		q.Types = append(q.Types, req.MimeType)
+	}
+
+	if req.MinSize.Valid {
+		q.MinSize = req.MinSize
+	}
+
+	if req.MaxSize.Valid {
+		q.MaxSize = req.MaxSize
+	}
+
+	if req.After.Valid {
+		q.After = req.After
+	}
+
+	if req.Before.Valid {
+		q.Before = req.Before
+	}
+
+	if req.Sort != "" {
+		q.Sort, q.Desc = req.Sort, req.Desc
+	}
+
+	q.Offset, q.Limit = req.Offset, req.Limit
+	if q.Limit <= 0 {
+		q.Limit = defaultSearchLimit
+	} else if q.Limit > maxSearchLimit {
+		q.Limit = maxSearchLimit
+	}
+
+	ctx := r.Context()
+	if req.Under.Valid {
+		q.Under = req.Under
+	} else if q.In != "" {
+		if !user.RootID.Valid {
+			return nil, core.NewSystemError(http.StatusInternalServerError, "", "user has no valid home directory")
+		}
+
+		root, err := fs.NodeByID(ctx, user.RootID.Int, db)
+		if err != nil {
+			return nil, err
+		}
+
+		dir, err := fs.NodeByPath(ctx, q.In, root, db)
+		if err != nil {
+			return nil, err
+		} else if dir == nil {
+			return nil, core.NewSystemError(http.StatusNotFound, "", "not found: "+q.In)
+		}
+		q.Under = null.IntFrom(dir.ID)
+	}
+
+	if q.Under.Valid {
+		dir, err := fs.NodeByID(ctx, q.Under.Int, db)
+		if err != nil {
+			return nil, err
+		}
+
+		if !fs.AccessAllowed(ctx, user, dir, false, db) {
+			return nil, core.NewSystemError(http.StatusUnauthorized, "", "no access")
+		}
+	}
+	return q, nil
 }
 
 // NodeSearch searches for nodes matching specific creteria.
-func NodeSearch(db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+// output: SearchResponse
+func NodeSearch(cfg *core.Config, db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
 	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
 		dec := json.NewDecoder(r.Body)
 		var req SearchRequest
@@ -31,35 +133,16 @@ func NodeSearch(db *sql.DB) func(user *models.User, w http.ResponseWriter, r *ht
 
 		log.Printf("Node search by %s: %s", user.Name, req.Text)
 
-		qtext := "lower(name) LIKE ?"
-		var query []qm.QueryMod
-		for _, term := range strings.Split(strings.ToLower(req.Text), " ") {
-			t := fmt.Sprintf("%%%s%%", term)
-			if query == nil {
-				query = append(query, qm.Where(qtext, t))
-			} else {
-				query = append(query, qm.And(qtext, t))
-			}
-		}
-
-		var nodes []*models.Node
-		if len(query) > 0 {
-			query = append(query, qm.And("owner_id=?", user.ID))
-			query = append(query, qm.OrderBy("name"))
-
-			nodes, err = models.Nodes(query...).All(r.Context(), db)
-			if reportInt(err, r, w) != nil {
-				return
-			}
+		q, err := searchQueryFor(&req, user, db, r)
+		if reportSystemError(err, r, w) != nil {
+			return
 		}
 
-		var filtered []*models.Node
-		for _, n := range nodes {
-			if fs.AccessAllowed(user, n, false) {
-				filtered = append(filtered, n)
-			}
+		results, total, err := fs.Search(r.Context(), q, user, cfg.SearchLanguage, db)
+		if reportSystemError(err, r, w) != nil {
+			return
 		}
 
-		respJSON(filtered, r, w)
+		respJSON(&SearchResponse{Results: results, Total: total, Offset: q.Offset, Limit: q.Limit}, r, w)
 	}
 }
@@ -0,0 +1,334 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"github.com/terotoi/koticloud/server/core"
+	"github.com/terotoi/koticloud/server/fs"
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/volatiletech/null/v8"
+)
+
+// ShareCreateRequest requests a public share link for a node.
+type ShareCreateRequest struct {
+	NodeID       int
+	Password     string    // Optional
+	ExpiresOn    null.Time // Optional
+	MaxDownloads null.Int  // Optional
+}
+
+// ShareRevokeRequest requests deletion of a share link.
+type ShareRevokeRequest struct {
+	Slug string
+}
+
+// ShareEntry is a share link with information about the shared node.
+type ShareEntry struct {
+	models.Share
+	Path        string // Full path of the shared node
+	HasPassword bool
+}
+
+// SharedDirResponse is a directory listing through a share link.
+type SharedDirResponse struct {
+	Dir      models.Node
+	Children []*models.Node
+}
+
+// SharedInfoResponse describes the node behind a share link.
+type SharedInfoResponse struct {
+	Node          models.Node
+	ExpiresOn     null.Time
+	DownloadsLeft null.Int       // Null if there is no download limit
+	Children      []*models.Node // Contents of a shared directory
+}
+
+// ShareCreate creates a share link.
+// output: ShareEntry
+func ShareCreate(db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		dec := json.NewDecoder(r.Body)
+		var req ShareCreateRequest
+		err := dec.Decode(&req)
+		if reportIf(err, http.StatusBadRequest, "", r, w) != nil {
+			return
+		}
+
+		ctx := r.Context()
+		tx, err := db.BeginTx(ctx, nil)
+		if reportInt(err, r, w) != nil {
+			return
+		}
+		defer tx.Rollback()
+
+		node, err := fs.NodeByID(ctx, req.NodeID, tx)
+		if reportSystemError(err, r, w) != nil {
+			return
+		}
+
+		s, err := fs.CreateShare(ctx, node, user, req.Password, req.ExpiresOn, req.MaxDownloads, tx)
+		if reportSystemError(err, r, w) != nil {
+			return
+		}
+
+		path, err := fs.PathFor(ctx, node, tx)
+		if reportInt(err, r, w) != nil {
+			return
+		}
+
+		if reportInt(tx.Commit(), r, w) != nil {
+			return
+		}
+
+		respJSON(&ShareEntry{Share: *s, Path: path, HasPassword: fs.ShareHasPassword(s)}, r, w)
+	}
+}
+
+// ShareList lists the share links created by the user.
+// output: []ShareEntry
+func ShareList(db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		shares, err := fs.SharesByUserID(ctx, user.ID, db)
+		if reportInt(err, r, w) != nil {
+			return
+		}
+
+		entries := []*ShareEntry{}
+		for _, s := range shares {
+			node, err := fs.NodeByID(ctx, s.NodeID, db)
+			if reportSystemError(err, r, w) != nil {
+				return
+			}
+
+			path, err := fs.PathFor(ctx, node, db)
+			if reportInt(err, r, w) != nil {
+				return
+			}
+
+			entries = append(entries, &ShareEntry{Share: *s, Path: path, HasPassword: fs.ShareHasPassword(s)})
+		}
+
+		respJSON(entries, r, w)
+	}
+}
+
+// ShareRevoke deletes a share link.
+// output: true
+func ShareRevoke(db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		dec := json.NewDecoder(r.Body)
+		var req ShareRevokeRequest
+		err := dec.Decode(&req)
+		if reportIf(err, http.StatusBadRequest, "", r, w) != nil {
+			return
+		}
+
+		ctx := r.Context()
+		s, err := fs.ShareBySlug(ctx, req.Slug, db)
+		if reportSystemError(err, r, w) != nil {
+			return
+		}
+
+		if reportSystemError(fs.RevokeShare(ctx, s, user, db), r, w) != nil {
+			return
+		}
+
+		respJSON(true, r, w)
+	}
+}
+
+// Shared creates a handler for requests through a share link. The share is
+// identified by the "slug" URL parameter. If the share has a password, it is
+// read from the X-Share-Password header or the password of HTTP basic authentication.
+func Shared(f func(share *models.Share, w http.ResponseWriter, r *http.Request), db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		s, err := fs.ShareBySlug(ctx, chi.URLParam(r, "slug"), db)
+		if reportSystemError(err, r, w) != nil {
+			return
+		}
+
+		if reportSystemError(fs.ShareUsable(s), r, w) != nil {
+			return
+		}
+
+		if fs.ShareHasPassword(s) {
+			password := r.Header.Get("X-Share-Password")
+			if password == "" {
+				_, password, _ = r.BasicAuth()
+			}
+
+			if !fs.CheckSharePassword(s, password) {
+				w.Header().Set("WWW-Authenticate", `Basic realm="koticloud share"`)
+				report("password required", http.StatusUnauthorized, r, w)
+				return
+			}
+		}
+
+		node, err := fs.NodeByID(ctx, s.NodeID, db)
+		if reportSystemError(err, r, w) != nil {
+			return
+		}
+
+		// Nodes moved to the trash are not available through old links.
+		if inTrash, err := fs.InTrash(ctx, node, db); reportInt(err, r, w) != nil {
+			return
+		} else if inTrash {
+			report("share not found", http.StatusNotFound, r, w)
+			return
+		}
+
+		f(s, w, r)
+	}
+}
+
+// Returns the node given in the "nodeID" URL parameter, or the shared node if
+// the parameter is not given.
+func sharedNodeForRequest(s *models.Share, db *sql.DB, r *http.Request) (*models.Node, error) {
+	id := s.NodeID
+	if param := chi.URLParam(r, "nodeID"); param != "" {
+		var err error
+		if id, err = strconv.Atoi(param); err != nil {
+			return nil, core.NewSystemError(http.StatusBadRequest, err.Error(), "invalid node ID")
+		}
+	}
+	return fs.ShareNode(r.Context(), s, id, db)
+}
+
+// SharedInfo returns information about a shared node.
+// output: SharedInfoResponse
+func SharedInfo(db *sql.DB) func(s *models.Share, w http.ResponseWriter, r *http.Request) {
+	return func(s *models.Share, w http.ResponseWriter, r *http.Request) {
+		node, err := sharedNodeForRequest(s, db, r)
+		if reportSystemError(err, r, w) != nil {
+			return
+		}
+
+		resp := SharedInfoResponse{Node: *node, ExpiresOn: s.ExpiresOn}
+		if s.MaxDownloads.Valid {
+			resp.DownloadsLeft = null.Int{Int: s.MaxDownloads.Int - s.Downloads, Valid: true}
+		}
+
+		if fs.IsDir(node) {
+			resp.Children, err = fs.NodesByParentID(r.Context(), node.ID, db)
+			if reportInt(err, r, w) != nil {
+				return
+			}
+		}
+
+		respJSON(&resp, r, w)
+	}
+}
+
+// SharedList lists a directory through a share link.
+// output: SharedDirResponse
+func SharedList(db *sql.DB) func(s *models.Share, w http.ResponseWriter, r *http.Request) {
+	return func(s *models.Share, w http.ResponseWriter, r *http.Request) {
+		node, err := sharedNodeForRequest(s, db, r)
+		if reportSystemError(err, r, w) != nil {
+			return
+		}
+
+		if !fs.IsDir(node) {
+			report("not a directory", http.StatusBadRequest, r, w)
+			return
+		}
+
+		children, err := fs.NodesByParentID(r.Context(), node.ID, db)
+		if reportInt(err, r, w) != nil {
+			return
+		}
+
+		respJSON(&SharedDirResponse{Dir: *node, Children: children}, r, w)
+	}
+}
+
+// shareDownloadWriter counts a download of a share when the response starts
+// to serve the contents of the file, so that every request serving any bytes
+// is counted. If the download limit has been reached, an error is sent instead.
+type shareDownloadWriter struct {
+	http.ResponseWriter
+	r           *http.Request
+	s           *models.Share
+	db          *sql.DB
+	wroteHeader bool
+	err         error
+}
+
+func (w *shareDownloadWriter) WriteHeader(statusCode int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+
+	if w.r.Method != http.MethodHead && (statusCode == http.StatusOK || statusCode == http.StatusPartialContent) {
+		if w.err = fs.CountShareDownload(w.r.Context(), w.s, w.db); w.err != nil {
+			// Drop the headers describing the contents.
+			for k := range w.Header() {
+				w.Header().Del(k)
+			}
+			reportSystemError(w.err, w.r, w.ResponseWriter)
+			return
+		}
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *shareDownloadWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.err != nil {
+		return 0, w.err
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// SharedGet returns the contents of a file through a share link.
+// Each request serving the contents, or a range of them, counts toward the
+// download limit of the share. Requests for multiple ranges are rejected.
+func SharedGet(homeRoot string, db *sql.DB) func(s *models.Share, w http.ResponseWriter, r *http.Request) {
+	return func(s *models.Share, w http.ResponseWriter, r *http.Request) {
+		node, err := sharedNodeForRequest(s, db, r)
+		if reportSystemError(err, r, w) != nil {
+			return
+		}
+
+		if fs.IsDir(node) {
+			report("cannot download a directory", http.StatusBadRequest, r, w)
+			return
+		}
+
+		if strings.Contains(r.Header.Get("Range"), ",") {
+			report("multiple ranges are not supported", http.StatusRequestedRangeNotSatisfiable, r, w)
+			return
+		}
+
+		dw := &shareDownloadWriter{ResponseWriter: w, r: r, s: s, db: db}
+		if serveNode(dw, r, node, homeRoot, db) == nil && dw.err == nil {
+			log.Printf("SharedGet %s served %s (%d)", s.Slug, node.Name, node.ID)
+		}
+	}
+}
+
+// SharedThumb returns the thumbnail of a node through a share link.
+func SharedThumb(cfg *core.Config, db *sql.DB) func(s *models.Share, w http.ResponseWriter, r *http.Request) {
+	return func(s *models.Share, w http.ResponseWriter, r *http.Request) {
+		node, err := sharedNodeForRequest(s, db, r)
+		if reportSystemError(err, r, w) != nil {
+			return
+		}
+
+		serveThumb(w, r, node, cfg, db)
+	}
+}
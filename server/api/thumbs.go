@@ -3,7 +3,6 @@ package api
 import (
 	"database/sql"
 	"fmt"
-	"math/rand"
 	"net/http"
 	"os"
 	"strconv"
@@ -11,9 +10,11 @@ import (
 
 	"github.com/go-chi/chi"
 	"github.com/terotoi/koticloud/server/core"
+	"github.com/terotoi/koticloud/server/crypt"
 	"github.com/terotoi/koticloud/server/fs"
 	"github.com/terotoi/koticloud/server/models"
-	"github.com/volatiletech/sqlboiler/queries"
+	"github.com/terotoi/koticloud/server/storage"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 )
 
 // ThumbGet returns contents of a thumbnail for a node.
@@ -36,7 +37,7 @@ func ThumbGet(cfg *core.Config, db *sql.DB) func(user *models.User, tokenNode *m
 			return
 		}
 
-		if !fs.AccessAllowed(user, node, false) {
+		if !fs.AccessAllowed(r.Context(), user, node, false, db) {
 			reportUnauthorized("no access", r, w)
 			return
 		}
@@ -48,7 +49,7 @@ func ThumbGet(cfg *core.Config, db *sql.DB) func(user *models.User, tokenNode *m
 func serveThumb(w http.ResponseWriter, r *http.Request, node *models.Node, cfg *core.Config, db *sql.DB) {
 	path := fs.ThumbPath(cfg.ThumbRoot, node.ID, true)
 
-	stat, err := os.Stat(path)
+	stat, err := storage.Stat(path, node.ThumbEncrypted)
 	if err != nil && os.IsNotExist(err) {
 		logRequest(r, fmt.Sprintf("thumb not found, serving fallback: %s", path))
 		ContentServeThumbFallback(w, r, node, cfg, db)
@@ -57,13 +58,17 @@ func serveThumb(w http.ResponseWriter, r *http.Request, node *models.Node, cfg *
 			return
 		}
 
-		fh, err := os.Open(path)
-		if reportInt(err, r, w) != nil {
+		fh, err := storage.Open(path, node.ThumbEncrypted)
+		if err == crypt.ErrLocked {
+			ContentServeThumbFallback(w, r, node, cfg, db)
+			return
+		} else if reportInt(err, r, w) != nil {
 			return
 		}
+		defer fh.Close()
 
 		w.Header().Add("Content-Type", "image/jpeg")
-		http.ServeContent(w, r, node.Name, stat.ModTime(), fh)
+		http.ServeContent(w, r, node.Name, stat.ModTime, fh)
 	}
 }
 
@@ -97,25 +102,14 @@ func serveGenericThumb(w http.ResponseWriter, r *http.Request, node *models.Node
 func ContentServeThumbFallback(w http.ResponseWriter, r *http.Request,
 	node *models.Node, cfg *core.Config, db *sql.DB) {
 	if node.Type == "directory" {
-		var nodes []models.Node
-
-		query :=
-			"WITH RECURSIVE subs AS (" +
-				"SELECT nodes.* " +
-				"FROM nodes " +
-				"WHERE id = $1 " +
-				"UNION " +
-				"SELECT n.* FROM nodes n " +
-				"INNER JOIN subs c ON c.id = n.parent_id) " +
-				"SELECT *	FROM subs WHERE has_custom_thumb = true"
-		err := queries.Raw(query, node.ID).Bind(r.Context(), db, &nodes)
+		nodes, err := models.Nodes(qm.Where(fs.SubtreeClause(), node.ID), qm.And("has_custom_thumb=?", true),
+			qm.OrderBy("random()"), qm.Limit(1)).All(r.Context(), db)
 		if reportIf(err, http.StatusInternalServerError, "", r, w) != nil {
 			return
 		}
 
 		if len(nodes) > 0 {
-			n := nodes[rand.Intn(len(nodes))]
-			serveThumb(w, r, &n, cfg, db)
+			serveThumb(w, r, nodes[0], cfg, db)
 			//http.ServeFile(w, r, fs.ThumbPath(thumbRoot, p.ID, true))
 			return
 		} else {
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/terotoi/koticloud/server/fs"
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/volatiletech/null/v8"
+)
+
+// TrashEntry is a node in the trash and where it was deleted from.
+type TrashEntry struct {
+	Node             models.Node
+	OriginalParentID null.Int
+	OriginalPath     string // Empty if the original directory no longer exists
+	DeletedOn        time.Time
+}
+
+// RestoreRequest requests restoring a node from the trash.
+type RestoreRequest struct {
+	ID int
+}
+
+// TrashList lists the nodes in the user's trash.
+// output: []TrashEntry
+func TrashList(db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		tx, err := db.BeginTx(ctx, nil)
+		if reportInt(err, r, w) != nil {
+			return
+		}
+		defer tx.Rollback()
+
+		items, err := fs.TrashItems(ctx, user.ID, tx)
+		if reportInt(err, r, w) != nil {
+			return
+		}
+
+		entries := []*TrashEntry{}
+		for _, item := range items {
+			node, err := fs.NodeByID(ctx, item.NodeID, tx)
+			if reportSystemError(err, r, w) != nil {
+				return
+			}
+
+			entry := TrashEntry{
+				Node:             *node,
+				OriginalParentID: item.OriginalParentID,
+				DeletedOn:        item.DeletedOn,
+			}
+			entry.Node.Name = item.OriginalName
+
+			if item.OriginalParentID.Valid {
+				parent, err := fs.NodeByIDopt(ctx, item.OriginalParentID.Int, tx)
+				if reportInt(err, r, w) != nil {
+					return
+				}
+
+				if parent != nil {
+					path, err := fs.PathFor(ctx, parent, tx)
+					if reportInt(err, r, w) != nil {
+						return
+					}
+
+					if path == "/" {
+						path = ""
+					}
+					entry.OriginalPath = path + "/" + item.OriginalName
+				}
+			}
+
+			entries = append(entries, &entry)
+		}
+
+		if reportInt(tx.Commit(), r, w) != nil {
+			return
+		}
+
+		respJSON(entries, r, w)
+	}
+}
+
+// TrashRestore restores a node from the trash.
+// output: []models.Node
+func TrashRestore(homeRoot string, db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		dec := json.NewDecoder(r.Body)
+
+		var req RestoreRequest
+		err := dec.Decode(&req)
+		if reportIf(err, http.StatusBadRequest, "", r, w) != nil {
+			return
+		}
+
+		ctx := r.Context()
+
+		j := fs.NewJournal(homeRoot)
+		defer j.Finish(db)
+
+		tx, err := db.BeginTx(ctx, nil)
+		if reportInt(err, r, w) != nil {
+			return
+		}
+		defer tx.Rollback()
+
+		node, err := models.FindNode(ctx, tx, req.ID)
+		if reportIf(err, http.StatusNotFound, fmt.Sprintf("node not found: %d", req.ID), r, w) != nil {
+			return
+		}
+
+		if err := fs.Restore(ctx, node, user, homeRoot, j, tx); err != nil {
+			reportSystemError(err, r, w)
+			return
+		}
+
+		if reportInt(tx.Commit(), r, w) != nil {
+			return
+		}
+
+		respJSON([]*models.Node{node}, r, w)
+	}
+}
+
+// TrashEmpty permanently deletes all nodes in the user's trash.
+// output: []models.Node
+func TrashEmpty(homeRoot, thumbRoot string, db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		j := fs.NewJournal(homeRoot)
+		defer j.Finish(db)
+
+		tx, err := db.BeginTx(ctx, nil)
+		if reportInt(err, r, w) != nil {
+			return
+		}
+		defer tx.Rollback()
+
+		deleted, err := fs.EmptyTrash(ctx, user, homeRoot, thumbRoot, j, tx)
+		if err != nil {
+			reportSystemError(err, r, w)
+			return
+		}
+
+		if reportInt(tx.Commit(), r, w) != nil {
+			return
+		}
+
+		log.Printf("Trash of %s emptied, %d nodes deleted", user.Name, len(deleted))
+		respJSON(deleted, r, w)
+	}
+}
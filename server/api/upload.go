@@ -0,0 +1,169 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/terotoi/koticloud/server/fs"
+	"github.com/terotoi/koticloud/server/jobs"
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/terotoi/koticloud/server/uploads"
+)
+
+// UploadCreateRequest requests a resumable upload session for a new file.
+// If an unfinished session exists for the same file, it is returned instead.
+type UploadCreateRequest struct {
+	ParentID int // ID of the target directory
+	Filename string
+	Size     int64
+}
+
+// UploadCreate creates or resumes an upload session.
+// output: uploads.Session
+func UploadCreate(uploadDir string, db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		dec := json.NewDecoder(r.Body)
+		var req UploadCreateRequest
+		err := dec.Decode(&req)
+		if reportIf(err, http.StatusBadRequest, "", r, w) != nil {
+			return
+		}
+
+		if !fs.IsValidName(req.Filename) {
+			report(fmt.Sprintf("invalid node name: %s", req.Filename), http.StatusBadRequest, r, w)
+			return
+		}
+
+		ctx := r.Context()
+		parent, err := fs.NodeByID(ctx, req.ParentID, db)
+		if reportSystemError(err, r, w) != nil {
+			return
+		}
+
+		if !fs.AccessAllowed(ctx, user, parent, true, db) {
+			reportUnauthorized("no access", r, w)
+			return
+		}
+
+		if !fs.IsDir(parent) {
+			report("parent is not a directory", http.StatusBadRequest, r, w)
+			return
+		}
+
+		existing, err := fs.NodeChildByName(ctx, req.Filename, parent.ID, db)
+		if reportInt(err, r, w) != nil {
+			return
+		}
+
+		if existing != nil {
+			report(fmt.Sprintf("file already exists: %s", req.Filename), http.StatusConflict, r, w)
+			return
+		}
+
+		// Reject uploads that cannot fit before any data is sent.
+		if reportSystemError(fs.CheckQuota(ctx, parent, user, req.Size, db), r, w) != nil {
+			return
+		}
+
+		s, err := uploads.Create(uploadDir, user.ID, parent.ID, req.Filename, req.Size)
+		if reportSystemError(err, r, w) != nil {
+			return
+		}
+
+		respJSON(s, r, w)
+	}
+}
+
+// UploadStatus returns an upload session, including the received byte ranges.
+func UploadStatus(uploadDir string) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		s, err := uploads.Get(uploadDir, chi.URLParam(r, "sessionID"), user.ID)
+		if reportSystemError(err, r, w) != nil {
+			return
+		}
+
+		respJSON(s, r, w)
+	}
+}
+
+// UploadChunk writes the request body into an upload session at the offset given in the URL.
+// output: uploads.Session
+func UploadChunk(uploadDir string) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		offset, err := strconv.ParseInt(chi.URLParam(r, "offset"), 10, 64)
+		if reportIf(err, http.StatusBadRequest, "", r, w) != nil {
+			return
+		}
+
+		s, err := uploads.WriteChunk(uploadDir, chi.URLParam(r, "sessionID"), user.ID, offset, r.Body)
+		if reportSystemError(err, r, w) != nil {
+			return
+		}
+
+		respJSON(s, r, w)
+	}
+}
+
+// UploadFinalize creates a node from a completed upload session.
+// output: []models.Node
+func UploadFinalize(uploadDir, homeRoot string, useBlobs bool, procCh chan jobs.NodeProcessRequest,
+	db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		s, err := uploads.Get(uploadDir, chi.URLParam(r, "sessionID"), user.ID)
+		if reportSystemError(err, r, w) != nil {
+			return
+		}
+
+		if !s.Complete() {
+			report(fmt.Sprintf("upload incomplete: %d of %d bytes received", s.ReceivedBytes(), s.Size),
+				http.StatusBadRequest, r, w)
+			return
+		}
+
+		ctx := r.Context()
+		tx, err := db.BeginTx(ctx, nil)
+		if reportInt(err, r, w) != nil {
+			return
+		}
+		defer tx.Rollback()
+
+		parent, err := fs.NodeByID(ctx, s.ParentID, tx)
+		if reportSystemError(err, r, w) != nil {
+			return
+		}
+
+		if !fs.AccessAllowed(ctx, user, parent, true, tx) {
+			reportUnauthorized("no access", r, w)
+			return
+		}
+
+		uploadFile := uploads.DataPath(uploadDir, s.ID)
+		node, path, err := newFileFromUpload(ctx, parent, s.Filename, uploadFile, homeRoot, useBlobs, user, tx)
+		if err != nil {
+			reportSystemError(err, r, w)
+			return
+		}
+
+		logRequest(r, fmt.Sprintf("upload finalized: %s -> %s [%s] (%s, %d bytes)", s.ID, s.Filename,
+			path, node.MimeType, node.Size.Int64))
+
+		if reportInt(tx.Commit(), r, w) != nil {
+			return
+		}
+
+		if err := uploads.Remove(uploadDir, s.ID); err != nil {
+			logRequest(r, err.Error())
+		}
+
+		if err := jobs.AddNodeProcessRequest(ctx, procCh, node, path, db); err != nil {
+			reportIf(err, http.StatusInternalServerError, "failed to process upload", r, w)
+			return
+		}
+
+		respJSON([]*models.Node{node}, r, w)
+	}
+}
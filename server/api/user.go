@@ -10,6 +10,7 @@ import (
 
 	"github.com/go-chi/jwtauth"
 	"github.com/terotoi/koticloud/server/core"
+	"github.com/terotoi/koticloud/server/fs"
 	"github.com/terotoi/koticloud/server/models"
 	"github.com/terotoi/koticloud/server/mx"
 	"github.com/volatiletech/null/v8"
@@ -51,6 +52,21 @@ type SettingsResponse struct {
 		Entry        string   // Name of the entry in the action menu
 		ContentTypes []string // List of applicable content types
 	}
+	StorageUsed int64      // Bytes used by the user's files, including previous versions
+	Quota       null.Int64 // Storage quota in bytes, null if unlimited
+}
+
+// SetQuotaRequest is used for changing storage quotas.
+type SetQuotaRequest struct {
+	Username string
+	Quota    null.Int64 // Null removes the limit
+}
+
+// UserUsage is the storage usage of an user.
+type UserUsage struct {
+	Username    string
+	StorageUsed int64
+	Quota       null.Int64
 }
 
 // UserLogin logins in as an existing user.
@@ -193,7 +209,7 @@ func SetPassword(db *sql.DB) func(user *models.User, w http.ResponseWriter, r *h
 		}
 
 		u.Password = null.String{String: string(newHash), Valid: true}
-		_, err = u.Update(ctx, tx, boil.Infer())
+		_, err = u.Update(ctx, tx, boil.Whitelist(models.UserColumns.Password))
 		if reportInt(err, r, w) != nil {
 			return
 		}
@@ -226,6 +242,74 @@ func QuerySettings(cfg *core.Config, db *sql.DB) func(user *models.User, w http.
 			}
 		}
 
+		used, err := fs.StorageUsed(r.Context(), user.ID, db)
+		if reportInt(err, r, w) != nil {
+			return
+		}
+		resp.StorageUsed = used
+
+		resp.Quota, err = fs.UserQuota(r.Context(), user.ID, db)
+		if reportSystemError(err, r, w) != nil {
+			return
+		}
+
+		respJSON(resp, r, w)
+	}
+}
+
+// SetQuota changes the storage quota of an user.
+// output: true
+func SetQuota(db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		var req SetQuotaRequest
+		dec := json.NewDecoder(r.Body)
+		err := dec.Decode(&req)
+		if reportIf(err, http.StatusBadRequest, "", r, w) != nil {
+			return
+		}
+
+		ctx := r.Context()
+		u, err := models.Users(qm.Where("name=?", req.Username)).One(ctx, db)
+		if reportIf(err, http.StatusNotFound, "user not found", r, w) != nil {
+			return
+		}
+
+		if reportSystemError(fs.SetUserQuota(ctx, u.ID, req.Quota, db), r, w) != nil {
+			return
+		}
+
+		if req.Quota.Valid {
+			log.Printf("Quota of %s set to %d bytes by %s", u.Name, req.Quota.Int64, user.Name)
+		} else {
+			log.Printf("Quota of %s removed by %s", u.Name, user.Name)
+		}
+		respJSON(true, r, w)
+	}
+}
+
+// UsageList returns the storage usage and quotas of all users.
+// output: []UserUsage
+func UsageList(db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		users, err := models.Users(qm.OrderBy("name")).All(ctx, db)
+		if reportInt(err, r, w) != nil {
+			return
+		}
+
+		resp := []*UserUsage{}
+		for _, u := range users {
+			usage := UserUsage{Username: u.Name}
+			if usage.StorageUsed, err = fs.StorageUsed(ctx, u.ID, db); reportInt(err, r, w) != nil {
+				return
+			}
+
+			if usage.Quota, err = fs.UserQuota(ctx, u.ID, db); reportSystemError(err, r, w) != nil {
+				return
+			}
+			resp = append(resp, &usage)
+		}
+
 		respJSON(resp, r, w)
 	}
 }
@@ -0,0 +1,247 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/terotoi/koticloud/server/core"
+	"github.com/terotoi/koticloud/server/fs"
+	"github.com/terotoi/koticloud/server/jobs"
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/terotoi/koticloud/server/storage"
+	"github.com/terotoi/koticloud/server/util"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// VersionListResponse is returned by VersionList.
+type VersionListResponse struct {
+	Versions  []*models.Version // Newest first
+	TotalSize int64             // Combined size of all versions
+}
+
+// VersionRestoreRequest requests replacing the contents of a node with a version.
+type VersionRestoreRequest struct {
+	ID int // ID of the version
+}
+
+// Returns a version and its node, if the user has access to the node.
+func versionForRequest(user *models.User, id int, write bool, tx boil.ContextExecutor,
+	r *http.Request) (*models.Version, *models.Node, error) {
+	v, err := fs.VersionByID(r.Context(), id, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	node, err := fs.NodeByID(r.Context(), v.NodeID, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !fs.AccessAllowed(r.Context(), user, node, write, tx) {
+		return nil, nil, core.NewSystemError(http.StatusUnauthorized, "", "no access")
+	}
+	return v, node, nil
+}
+
+// VersionList lists the previous versions of a node.
+// output: VersionListResponse
+func VersionList(db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "nodeID"))
+		if reportIf(err, http.StatusBadRequest, "", r, w) != nil {
+			return
+		}
+
+		ctx := r.Context()
+		node, err := fs.NodeByID(ctx, id, db)
+		if reportSystemError(err, r, w) != nil {
+			return
+		}
+
+		if !fs.AccessAllowed(ctx, user, node, false, db) {
+			reportUnauthorized("no access", r, w)
+			return
+		}
+
+		versions, err := fs.Versions(ctx, node.ID, db)
+		if reportInt(err, r, w) != nil {
+			return
+		}
+
+		resp := VersionListResponse{Versions: versions}
+		for _, v := range versions {
+			resp.TotalSize += v.Size
+		}
+
+		respJSON(&resp, r, w)
+	}
+}
+
+// VersionGet returns the contents of a version.
+func VersionGet(homeRoot string, db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "versionID"))
+		if reportIf(err, http.StatusBadRequest, "", r, w) != nil {
+			return
+		}
+
+		v, node, err := versionForRequest(user, id, false, db, r)
+		if reportSystemError(err, r, w) != nil {
+			return
+		}
+
+		fh, err := storage.Open(fs.VersionDataPath(homeRoot, v), v.Encrypted)
+		if reportSystemError(err, r, w) != nil {
+			return
+		}
+		defer fh.Close()
+
+		log.Printf("VersionGet %s (%d) served version %d of %s (%d)", user.Name, user.ID,
+			v.Version, node.Name, node.ID)
+
+		w.Header().Add("Content-Type", v.MimeType)
+		http.ServeContent(w, r, node.Name, v.ModifiedOn, fh)
+	}
+}
+
+// VersionDiff returns an unified diff from a version to the current contents
+// of the node. If the query parameter "to" is given, the diff is made to
+// the version with that ID instead. Only text files can be compared.
+// output: text/plain
+func VersionDiff(homeRoot string, db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "versionID"))
+		if reportIf(err, http.StatusBadRequest, "", r, w) != nil {
+			return
+		}
+
+		ctx := r.Context()
+		v, node, err := versionForRequest(user, id, false, db, r)
+		if reportSystemError(err, r, w) != nil {
+			return
+		}
+
+		aPath := fs.VersionDataPath(homeRoot, v)
+		aLabel := fmt.Sprintf("%s (version %d)", node.Name, v.Version)
+
+		var bPath, bLabel, bMimeType string
+		var bEncrypted bool
+		if to := r.URL.Query().Get("to"); to != "" {
+			toID, err := strconv.Atoi(to)
+			if reportIf(err, http.StatusBadRequest, "", r, w) != nil {
+				return
+			}
+
+			vTo, err := fs.VersionByID(ctx, toID, db)
+			if reportSystemError(err, r, w) != nil {
+				return
+			}
+
+			if vTo.NodeID != node.ID {
+				report("versions belong to different nodes", http.StatusBadRequest, r, w)
+				return
+			}
+
+			bPath = fs.VersionDataPath(homeRoot, vTo)
+			bLabel = fmt.Sprintf("%s (version %d)", node.Name, vTo.Version)
+			bMimeType = vTo.MimeType
+			bEncrypted = vTo.Encrypted
+		} else {
+			bPath, err = fs.PhysPath(ctx, node, homeRoot, db)
+			if reportInt(err, r, w) != nil {
+				return
+			}
+			bLabel = node.Name
+			bMimeType = node.MimeType
+			bEncrypted = node.Encrypted
+		}
+
+		if !util.IsText(v.MimeType) || !util.IsText(bMimeType) {
+			report("only text files can be compared", http.StatusBadRequest, r, w)
+			return
+		}
+
+		// diff needs local copies of files in remote storage.
+		aPath, aCleanup, err := storage.Fetch(aPath, "", v.Encrypted)
+		if reportInt(err, r, w) != nil {
+			return
+		}
+		defer aCleanup()
+
+		bPath, bCleanup, err := storage.Fetch(bPath, "", bEncrypted)
+		if reportInt(err, r, w) != nil {
+			return
+		}
+		defer bCleanup()
+
+		diff, err := util.Diff(aPath, aLabel, bPath, bLabel)
+		if reportInt(err, r, w) != nil {
+			return
+		}
+
+		w.Header().Add("Content-Type", "text/plain; charset=utf-8")
+		w.Write([]byte(diff))
+	}
+}
+
+// VersionRestore replaces the contents of a node with a version.
+// The current contents are kept as a new version.
+// output: []models.Node
+func VersionRestore(cfg *core.Config, procCh chan jobs.NodeProcessRequest,
+	db *sql.DB) func(user *models.User, w http.ResponseWriter, r *http.Request) {
+	return func(user *models.User, w http.ResponseWriter, r *http.Request) {
+		dec := json.NewDecoder(r.Body)
+		var req VersionRestoreRequest
+		err := dec.Decode(&req)
+		if reportIf(err, http.StatusBadRequest, "", r, w) != nil {
+			return
+		}
+
+		ctx := r.Context()
+		j := fs.NewJournal(cfg.HomeRoot)
+		defer j.Finish(db)
+
+		tx, err := db.BeginTx(ctx, nil)
+		if reportInt(err, r, w) != nil {
+			return
+		}
+		defer tx.Rollback()
+
+		v, node, err := versionForRequest(user, req.ID, true, tx, r)
+		if reportSystemError(err, r, w) != nil {
+			return
+		}
+
+		if err := fs.RestoreVersion(ctx, node, v, user, cfg.HomeRoot, j, tx); err != nil {
+			reportSystemError(err, r, w)
+			return
+		}
+
+		_, err = fs.PruneVersions(ctx, node.ID, cfg.VersionsKeep, cfg.VersionsMaxAgeDuration(), cfg.HomeRoot, j, tx)
+		if reportInt(err, r, w) != nil {
+			return
+		}
+
+		path, err := fs.PhysPath(ctx, node, cfg.HomeRoot, tx)
+		if reportInt(err, r, w) != nil {
+			return
+		}
+
+		if reportInt(tx.Commit(), r, w) != nil {
+			return
+		}
+
+		// Refresh the thumbnail and other derived data.
+		if err := jobs.AddNodeProcessRequest(ctx, procCh, node, path, db); err != nil {
+			reportIf(err, http.StatusInternalServerError, "failed to process restored version", r, w)
+			return
+		}
+
+		respJSON([]*models.Node{node}, r, w)
+	}
+}
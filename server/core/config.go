@@ -7,6 +7,9 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/terotoi/koticloud/server/util"
 )
@@ -21,10 +24,22 @@ type ExtCommand struct {
 	Admin        bool     // Admin access is required
 }
 
+// StorageConfig specifies where the contents of files and thumbnails are stored.
+type StorageConfig struct {
+	Type      string `json:"type"`       // "local" (default) or "s3"
+	Endpoint  string `json:"endpoint"`   // URL of an S3-compatible server, such as http://localhost:9000
+	Region    string `json:"region"`     // Default: us-east-1
+	Bucket    string `json:"bucket"`     // The bucket must exist
+	AccessKey string `json:"access_key"` // Access key ID
+	SecretKey string `json:"secret_key"` // Secret access key
+	Prefix    string `json:"prefix"`     // Prepended to the names of all objects (optional)
+}
+
 // Config contains the application base configuration
 type Config struct {
 	ListenAddress string `json:"listen_address"` // In format [host]:port
 	Database      string
+	AutoMigrate   bool   `json:"auto_migrate"` // Apply pending schema migrations at startup.
 	DataRoot      string `json:"data_root"`
 	HomeRoot      string `json:"home_root"`
 	ThumbRoot     string `json:"thumb_root"`
@@ -38,9 +53,68 @@ type Config struct {
 
 	ThumbMethod string `json:"thumb_method"`
 
+	Storage StorageConfig `json:"storage"`
+
+	UploadMaxAge   int `json:"upload_max_age"`   // Hours after which an idle upload session is removed.
+	TrashRetention int `json:"trash_retention"`  // Days to keep nodes in the trash. Use -1 to keep forever.
+	VersionsKeep   int `json:"versions_keep"`    // Number of previous versions kept per file. Use -1 for no limit.
+	VersionsMaxAge int `json:"versions_max_age"` // Days to keep previous versions. Use 0 to keep forever.
+
+	SearchLanguage string `json:"search_language"` // PostgreSQL text search configuration, such as "english".
+
+	BlobStore    bool `json:"blob_store"`      // Store file contents by their hash, sharing identical contents.
+	BlobGCMaxAge int  `json:"blob_gc_max_age"` // Hours before a blob file unknown to the database is removed.
+
+	ScrubInterval int `json:"scrub_interval"` // Days between verifications of the checksum of each file. Use -1 to disable.
+
+	MasterKeyFile string `json:"master_key_file"` // File containing the master key. Enables encryption of files at rest.
+
+	WatchHomes    bool `json:"watch_homes"`    // Update the nodes of files changed in the home directories outside of the server.
+	WatchDebounce int  `json:"watch_debounce"` // Seconds without changes before changes seen by the watcher are handled.
+
+	ScanExclude []string `json:"scan_exclude"` // Names or glob patterns of files and directories ignored by scans and the watcher.
+
 	ExtCommands []ExtCommand `json:"ext_commands"`
 }
 
+// VersionsMaxAgeDuration returns the maximum age of versions as a duration.
+func (cfg *Config) VersionsMaxAgeDuration() time.Duration {
+	return time.Duration(cfg.VersionsMaxAge) * 24 * time.Hour
+}
+
+// ScrubIntervalDuration returns the interval of checksum verifications as a duration.
+func (cfg *Config) ScrubIntervalDuration() time.Duration {
+	return time.Duration(cfg.ScrubInterval) * 24 * time.Hour
+}
+
+// ScanExcluded tells if a path relative to a home directory is excluded from
+// scans: if the name of the file or directory, or of any directory above it
+// in the home directory, matches one of the patterns of ScanExclude.
+func (cfg *Config) ScanExcluded(path string) bool {
+	for _, name := range strings.Split(path, "/") {
+		if name == "" {
+			continue
+		}
+
+		for _, pattern := range cfg.ScanExclude {
+			if ok, _ := filepath.Match(pattern, name); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// WatchDebounceDuration returns the debounce time of the watcher as a duration.
+func (cfg *Config) WatchDebounceDuration() time.Duration {
+	return time.Duration(cfg.WatchDebounce) * time.Second
+}
+
+// BlobGCMaxAgeDuration returns the grace period of unknown blob files as a duration.
+func (cfg *Config) BlobGCMaxAgeDuration() time.Duration {
+	return time.Duration(cfg.BlobGCMaxAge) * time.Hour
+}
+
 // loadConfig loads a config file from the given path.
 func loadConfig(path string) (*Config, error) {
 	cfg := Config{}
@@ -73,6 +147,13 @@ func saveConfig(cfg *Config, path string) error {
 // ParseArgs parses command line arguments and loads the configuration file.
 func ParseArgs() (*Config, error) {
 	const defaultListenAddress = ":7070"
+	const defaultUploadMaxAge = 24
+	const defaultTrashRetention = 30
+	const defaultVersionsKeep = 10
+	const defaultSearchLanguage = "simple"
+	const defaultBlobGCMaxAge = 24
+	const defaultScrubInterval = 30
+	const defaultWatchDebounce = 2
 
 	var configFile, address, dbString, DataRoot, homeRoot, thumbRoot, uploadDir, StaticRoot string
 	var save bool
@@ -90,7 +171,7 @@ func ParseArgs() (*Config, error) {
 
 	if len(flag.Args()) == 0 {
 		flag.Usage()
-		return nil, fmt.Errorf("commands: scan, serve")
+		return nil, fmt.Errorf("commands: scan, serve, worker, migrate, scrub, migrate-blobs, gc-blobs, rotate-keys, repair-tree, convert-db")
 	}
 
 	configFile = util.ReplaceEnvs(configFile)
@@ -134,6 +215,40 @@ func ParseArgs() (*Config, error) {
 		cfg.UploadDir = cfg.DataRoot + "/upload"
 	}
 
+	if cfg.UploadMaxAge <= 0 {
+		cfg.UploadMaxAge = defaultUploadMaxAge
+	}
+
+	if cfg.TrashRetention == 0 {
+		cfg.TrashRetention = defaultTrashRetention
+	}
+
+	if cfg.VersionsKeep == 0 {
+		cfg.VersionsKeep = defaultVersionsKeep
+	}
+
+	if cfg.SearchLanguage == "" {
+		cfg.SearchLanguage = defaultSearchLanguage
+	}
+
+	if cfg.BlobGCMaxAge <= 0 {
+		cfg.BlobGCMaxAge = defaultBlobGCMaxAge
+	}
+
+	if cfg.ScrubInterval == 0 {
+		cfg.ScrubInterval = defaultScrubInterval
+	}
+
+	if cfg.WatchDebounce <= 0 {
+		cfg.WatchDebounce = defaultWatchDebounce
+	}
+
+	for _, pattern := range cfg.ScanExclude {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid scan_exclude pattern %q: %w", pattern, err)
+		}
+	}
+
 	if cfg.StaticRoot == "" && cfg.DataRoot != "" {
 		cfg.StaticRoot = cfg.StaticRoot + "/static"
 	}
@@ -143,6 +258,7 @@ func ParseArgs() (*Config, error) {
 	cfg.ThumbRoot = util.ReplaceEnvs(cfg.ThumbRoot)
 	cfg.UploadDir = util.ReplaceEnvs(cfg.UploadDir)
 	cfg.StaticRoot = util.ReplaceEnvs(cfg.StaticRoot)
+	cfg.MasterKeyFile = util.ReplaceEnvs(cfg.MasterKeyFile)
 
 	log.Printf("Homeroot: %s", cfg.HomeRoot)
 	log.Printf("Thumbfiles root: %s", cfg.ThumbRoot)
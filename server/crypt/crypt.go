@@ -0,0 +1,354 @@
+// Package crypt encrypts file contents at rest.
+//
+// Each user has a data key, which is stored wrapped by the server master key
+// or by a key derived from a passphrase of the user. Each encrypted file has
+// a random file key, stored in the header of the file wrapped by the data key
+// of its owner. The contents are encrypted with AES-256-GCM in chunks, so
+// that any part of a file can be read without decrypting what comes before it.
+//
+// The header is followed by the encrypted chunks:
+//
+//	magic "KCE1" | user ID (4) | key version (4) | nonce (12) | wrapped file key (48)
+//
+// Each chunk holds chunkSize bytes of plaintext, except the last one, which
+// is marked as final in its nonce so that truncated files are detected.
+package crypt
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+const (
+	magic     = "KCE1"
+	keySize   = 32
+	nonceSize = 12
+	tagSize   = 16
+	chunkSize = 64 * 1024
+
+	// HeaderSize is the size of the header of an encrypted file.
+	HeaderSize = len(magic) + 4 + 4 + nonceSize + keySize + tagSize
+)
+
+// ErrCorrupt is returned for encrypted data which fails to decrypt.
+var ErrCorrupt = errors.New("encrypted data is corrupt")
+
+// Key is a data key of a user.
+type Key struct {
+	UserID  int
+	Version int
+	data    []byte
+}
+
+// NewKey generates a random data key.
+func NewKey(userID, version int) (*Key, error) {
+	data, err := randomBytes(keySize)
+	if err != nil {
+		return nil, err
+	}
+	return &Key{UserID: userID, Version: version, data: data}, nil
+}
+
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Encrypts data with key, binding it to ad. The nonce is prepended to the result.
+func seal(key, data, ad []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := randomBytes(nonceSize)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, data, ad), nil
+}
+
+// Decrypts data encrypted by seal.
+func open(key, data, ad []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < nonceSize+tagSize {
+		return nil, ErrCorrupt
+	}
+
+	plain, err := aead.Open(nil, data[:nonceSize], data[nonceSize:], ad)
+	if err != nil {
+		return nil, ErrCorrupt
+	}
+	return plain, nil
+}
+
+// Returns the nonce of a chunk.
+func chunkNonce(index int64, final bool) []byte {
+	nonce := make([]byte, nonceSize)
+	binary.BigEndian.PutUint64(nonce, uint64(index))
+	if final {
+		nonce[nonceSize-1] = 1
+	}
+	return nonce
+}
+
+// IsEncrypted tells if data starts with the header of an encrypted file.
+func IsEncrypted(header []byte) bool {
+	return len(header) >= HeaderSize && string(header[:len(magic)]) == magic
+}
+
+// KeyOf returns the user ID and the version of the data key an encrypted file
+// is encrypted with.
+func KeyOf(header []byte) (int, int) {
+	n := len(magic)
+	return int(binary.BigEndian.Uint32(header[n:])), int(binary.BigEndian.Uint32(header[n+4:]))
+}
+
+// PlainSize returns the size of the plaintext of an encrypted file of the given size.
+func PlainSize(size int64) int64 {
+	n := size - int64(HeaderSize)
+	chunks := (n + chunkSize + tagSize - 1) / (chunkSize + tagSize)
+	if plain := n - chunks*tagSize; plain > 0 {
+		return plain
+	}
+	return 0
+}
+
+// Writer encrypts data written to it. The data is complete only after Close.
+type Writer struct {
+	dst   io.Writer
+	aead  cipher.AEAD
+	buf   []byte
+	chunk int64
+}
+
+// NewWriter writes the header of an encrypted file to dst and returns a
+// writer which encrypts the contents with a new file key.
+func NewWriter(dst io.Writer, key *Key) (*Writer, error) {
+	fileKey, err := randomBytes(keySize)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, len(magic)+8, HeaderSize)
+	copy(header, magic)
+	binary.BigEndian.PutUint32(header[len(magic):], uint32(key.UserID))
+	binary.BigEndian.PutUint32(header[len(magic)+4:], uint32(key.Version))
+
+	wrapped, err := seal(key.data, fileKey, header)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newGCM(fileKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := dst.Write(append(header, wrapped...)); err != nil {
+		return nil, err
+	}
+	return &Writer{dst: dst, aead: aead, buf: make([]byte, 0, chunkSize)}, nil
+}
+
+func (w *Writer) flush(final bool) error {
+	out := w.aead.Seal(nil, chunkNonce(w.chunk, final), w.buf, nil)
+	w.buf = w.buf[:0]
+	w.chunk++
+	_, err := w.dst.Write(out)
+	return err
+}
+
+func (w *Writer) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		// A full chunk is written only when more data follows, so that the
+		// last chunk can be marked as final.
+		if len(w.buf) == chunkSize {
+			if err := w.flush(false); err != nil {
+				return written, err
+			}
+		}
+
+		n := copy(w.buf[len(w.buf):chunkSize], p)
+		w.buf = w.buf[:len(w.buf)+n]
+		p = p[n:]
+		written += n
+	}
+	return written, nil
+}
+
+// Close writes the last chunk and closes the destination, if it is a closer.
+func (w *Writer) Close() error {
+	err := w.flush(true)
+	if c, ok := w.dst.(io.Closer); ok {
+		if cerr := c.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// Reader decrypts an encrypted file. Seeking is supported, reading only the
+// chunks containing the data read.
+type Reader struct {
+	src    io.ReadSeeker
+	aead   cipher.AEAD
+	size   int64 // Size of the plaintext
+	chunks int64
+	offset int64
+	chunk  int64 // Index of the chunk in buf, or -1
+	buf    []byte
+	cbuf   []byte
+}
+
+// NewReader returns a reader decrypting src. header is the header of the
+// file, already read from src. The data key is looked up from the keyring.
+func NewReader(src io.ReadSeeker, header []byte) (*Reader, error) {
+	if !IsEncrypted(header) {
+		return nil, fmt.Errorf("not an encrypted file")
+	}
+
+	key, err := Lookup(KeyOf(header))
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(magic) + 8
+	fileKey, err := open(key.data, header[n:HeaderSize], header[:n])
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newGCM(fileKey)
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := src.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	size -= int64(HeaderSize)
+	chunks := (size + chunkSize + tagSize - 1) / (chunkSize + tagSize)
+	if chunks == 0 || size-(chunks-1)*(chunkSize+tagSize) < tagSize {
+		return nil, ErrCorrupt
+	}
+
+	return &Reader{src: src, aead: aead, size: size - chunks*tagSize, chunks: chunks,
+		chunk: -1, cbuf: make([]byte, chunkSize+tagSize)}, nil
+}
+
+// Decrypts a chunk into buf.
+func (r *Reader) readChunk(index int64) error {
+	if _, err := r.src.Seek(int64(HeaderSize)+index*(chunkSize+tagSize), io.SeekStart); err != nil {
+		return err
+	}
+
+	n, err := io.ReadFull(r.src, r.cbuf)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return err
+	}
+
+	r.chunk = -1
+	r.buf, err = r.aead.Open(r.buf[:0], chunkNonce(index, index == r.chunks-1), r.cbuf[:n], nil)
+	if err != nil {
+		return ErrCorrupt
+	}
+	r.chunk = index
+	return nil
+}
+
+func (r *Reader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+
+	index := r.offset / chunkSize
+	if index != r.chunk {
+		if err := r.readChunk(index); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, r.buf[r.offset-index*chunkSize:])
+	r.offset += int64(n)
+	return n, nil
+}
+
+func (r *Reader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	}
+
+	if offset < 0 {
+		return r.offset, fmt.Errorf("seek: negative offset")
+	}
+	r.offset = offset
+	return offset, nil
+}
+
+// Close closes the source, if it is a closer.
+func (r *Reader) Close() error {
+	if c, ok := r.src.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// Seal encrypts a small value, such as metadata, in the format of an encrypted file.
+func Seal(key *Key, data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Open decrypts a value encrypted by Seal.
+func Open(data []byte) ([]byte, error) {
+	if !IsEncrypted(data) {
+		return nil, ErrCorrupt
+	}
+
+	r, err := NewReader(bytes.NewReader(data), data[:HeaderSize])
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(r)
+}
@@ -0,0 +1,252 @@
+package crypt
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"testing"
+)
+
+// Returns a new data key, remembered in the keyring.
+func testKey(t *testing.T, userID, version int) *Key {
+	key, err := NewKey(userID, version)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Remember(key)
+	t.Cleanup(func() { Forget(userID) })
+	return key
+}
+
+// Returns data encrypted with key.
+func encrypt(t *testing.T, key *Key, data []byte) []byte {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Written in odd pieces, so that chunks are filled over several writes.
+	for p := data; len(p) > 0; {
+		n := 1000
+		if n > len(p) {
+			n = len(p)
+		}
+		if _, err := w.Write(p[:n]); err != nil {
+			t.Fatal(err)
+		}
+		p = p[n:]
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// Returns a reader decrypting data.
+func decrypt(data []byte) (*Reader, error) {
+	if len(data) < HeaderSize {
+		return nil, ErrCorrupt
+	}
+	return NewReader(bytes.NewReader(data), data[:HeaderSize])
+}
+
+func testData(size int) []byte {
+	data := make([]byte, size)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+	return data
+}
+
+func TestWriterReader(t *testing.T) {
+	key := testKey(t, 1, 1)
+
+	for _, size := range []int{0, 1, chunkSize - 1, chunkSize, chunkSize + 1, 3*chunkSize + 123} {
+		data := testData(size)
+		enc := encrypt(t, key, data)
+
+		if !IsEncrypted(enc) {
+			t.Fatalf("%d bytes: no header", size)
+		} else if PlainSize(int64(len(enc))) != int64(size) {
+			t.Errorf("%d bytes: PlainSize = %d", size, PlainSize(int64(len(enc))))
+		}
+
+		r, err := decrypt(enc)
+		if err != nil {
+			t.Fatalf("%d bytes: %v", size, err)
+		}
+
+		plain, err := ioutil.ReadAll(r)
+		if err != nil {
+			t.Fatalf("%d bytes: %v", size, err)
+		} else if !bytes.Equal(plain, data) {
+			t.Errorf("%d bytes: decrypted data differs", size)
+		}
+	}
+}
+
+func TestReaderSeek(t *testing.T) {
+	key := testKey(t, 1, 1)
+	data := testData(2*chunkSize + 500)
+	r, err := decrypt(encrypt(t, key, data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		offset int64
+		whence int
+		pos    int64
+	}{
+		{chunkSize - 10, io.SeekStart, chunkSize - 10},
+		{100, io.SeekCurrent, chunkSize + 110},
+		{-300, io.SeekEnd, int64(len(data)) - 300},
+		{5, io.SeekStart, 5},
+	} {
+		pos, err := r.Seek(c.offset, c.whence)
+		if err != nil || pos != c.pos {
+			t.Fatalf("Seek(%d, %d) = %d, %v, want %d", c.offset, c.whence, pos, err, c.pos)
+		}
+
+		buf := make([]byte, 20)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			t.Fatal(err)
+		} else if !bytes.Equal(buf, data[pos:pos+20]) {
+			t.Errorf("read at %d differs", pos)
+		}
+	}
+
+	if _, err := r.Seek(-1, io.SeekStart); err == nil {
+		t.Error("seeked to a negative offset")
+	}
+
+	if _, err := r.Seek(0, io.SeekEnd); err != nil {
+		t.Fatal(err)
+	} else if n, err := r.Read(make([]byte, 1)); n != 0 || err != io.EOF {
+		t.Errorf("Read at the end = %d, %v", n, err)
+	}
+}
+
+func TestReaderCorrupt(t *testing.T) {
+	key := testKey(t, 1, 1)
+	enc := encrypt(t, key, testData(2*chunkSize+10))
+
+	// A file cut at a chunk boundary would decrypt without the final flag.
+	truncated := enc[:HeaderSize+chunkSize+tagSize]
+	r, err := decrypt(truncated)
+	if err == nil {
+		_, err = ioutil.ReadAll(r)
+	}
+	if err != ErrCorrupt {
+		t.Errorf("reading a truncated file: %v", err)
+	}
+
+	modified := append([]byte{}, enc...)
+	modified[HeaderSize+chunkSize+tagSize+5] ^= 1
+	r, err = decrypt(modified)
+	if err != nil {
+		t.Fatal(err)
+	} else if _, err := ioutil.ReadAll(r); err != ErrCorrupt {
+		t.Errorf("reading a modified file: %v", err)
+	}
+
+	header := append([]byte{}, enc...)
+	header[HeaderSize-1] ^= 1
+	if _, err := decrypt(header); err != ErrCorrupt {
+		t.Errorf("reading a file with a modified header: %v", err)
+	}
+}
+
+func TestSealOpen(t *testing.T) {
+	key := testKey(t, 2, 3)
+	data := []byte(`{"width":640}`)
+
+	sealed, err := Seal(key, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if userID, version := KeyOf(sealed); userID != 2 || version != 3 {
+		t.Errorf("KeyOf = %d, %d", userID, version)
+	}
+
+	if plain, err := Open(sealed); err != nil || !bytes.Equal(plain, data) {
+		t.Errorf("Open = %q, %v", plain, err)
+	}
+
+	if _, err := Open(sealed[:HeaderSize-1]); err != ErrCorrupt {
+		t.Errorf("Open of a short value: %v", err)
+	}
+
+	// Without the key in the keyring and without a loader, the value cannot be opened.
+	Forget(2)
+	if _, err := Open(sealed); err != ErrLocked {
+		t.Errorf("Open without the key: %v", err)
+	}
+}
+
+func TestWrapKey(t *testing.T) {
+	key, err := NewKey(4, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kek, err := randomBytes(keySize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wrapped, err := WrapKey(key, kek)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unwrapped, err := UnwrapKey(wrapped, kek, 4, 2)
+	if err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(unwrapped.data, key.data) {
+		t.Error("unwrapped key differs")
+	}
+
+	// The wrapped key is bound to its user and version.
+	if _, err := UnwrapKey(wrapped, kek, 4, 3); err != ErrCorrupt {
+		t.Errorf("UnwrapKey with another version: %v", err)
+	}
+
+	other, _ := randomBytes(keySize)
+	if _, err := UnwrapKey(wrapped, other, 4, 2); err != ErrCorrupt {
+		t.Errorf("UnwrapKey with another key: %v", err)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	key := testKey(t, 5, 1)
+	defer SetLoader(nil)
+
+	loads := 0
+	SetLoader(func(userID, version int) (*Key, error) {
+		loads++
+		return &Key{UserID: userID, Version: version, data: key.data}, nil
+	})
+
+	if k, err := Lookup(5, 1); err != nil || k != key {
+		t.Errorf("Lookup of a remembered key = %v, %v", k, err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if k, err := Lookup(5, 2); err != nil || k.Version != 2 {
+			t.Errorf("Lookup of a loaded key = %v, %v", k, err)
+		}
+	}
+
+	if loads != 1 {
+		t.Errorf("key loaded %d times", loads)
+	}
+
+	Forget(5)
+	if _, err := Lookup(5, 2); err != nil || loads != 2 {
+		t.Errorf("Lookup of a forgotten key: %v, %d loads", err, loads)
+	}
+}
@@ -0,0 +1,146 @@
+package crypt
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/terotoi/koticloud/server/core"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Size of the salt used when deriving a key from a passphrase.
+const saltSize = 16
+
+// ErrLocked is returned when the data key of a user is protected by a
+// passphrase, and the user has not unlocked it.
+var ErrLocked = core.NewSystemError(http.StatusForbidden, "encryption key is locked",
+	"encryption key is locked")
+
+type keyID struct {
+	userID  int
+	version int
+}
+
+// The keyring holds data keys which have been unwrapped, by key ID.
+var keyring = struct {
+	sync.Mutex
+	master []byte
+	keys   map[keyID]*Key
+	loader func(userID, version int) (*Key, error)
+}{keys: map[keyID]*Key{}}
+
+// LoadMasterKey reads a master key from a file containing 32 bytes encoded
+// in hexadecimal, such as the output of "openssl rand -hex 32".
+func LoadMasterKey(path string) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := hex.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("master key %s: %s", path, err)
+	} else if len(key) != keySize {
+		return nil, fmt.Errorf("master key %s: expected %d bytes, got %d", path, keySize, len(key))
+	}
+	return key, nil
+}
+
+// SetMasterKey sets the master key, which enables encryption.
+func SetMasterKey(key []byte) {
+	keyring.Lock()
+	defer keyring.Unlock()
+	keyring.master = key
+}
+
+// MasterKey returns the master key, or nil if encryption is not enabled.
+func MasterKey() []byte {
+	keyring.Lock()
+	defer keyring.Unlock()
+	return keyring.master
+}
+
+// Enabled tells if encryption has been enabled with a master key.
+func Enabled() bool {
+	return MasterKey() != nil
+}
+
+// SetLoader sets the function used to load data keys missing from the keyring.
+func SetLoader(loader func(userID, version int) (*Key, error)) {
+	keyring.Lock()
+	defer keyring.Unlock()
+	keyring.loader = loader
+}
+
+// Lookup returns a data key from the keyring, loading it if necessary.
+func Lookup(userID, version int) (*Key, error) {
+	keyring.Lock()
+	key, ok := keyring.keys[keyID{userID, version}]
+	loader := keyring.loader
+	keyring.Unlock()
+
+	if ok {
+		return key, nil
+	} else if loader == nil {
+		return nil, ErrLocked
+	}
+
+	key, err := loader(userID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	Remember(key)
+	return key, nil
+}
+
+// Remember adds an unwrapped data key into the keyring.
+func Remember(key *Key) {
+	keyring.Lock()
+	defer keyring.Unlock()
+	keyring.keys[keyID{key.UserID, key.Version}] = key
+}
+
+// Forget removes all data keys of a user from the keyring.
+func Forget(userID int) {
+	keyring.Lock()
+	defer keyring.Unlock()
+	for id := range keyring.keys {
+		if id.userID == userID {
+			delete(keyring.keys, id)
+		}
+	}
+}
+
+// Binds a wrapped data key to its ID.
+func keyAD(userID, version int) []byte {
+	return []byte(fmt.Sprintf("%d:%d", userID, version))
+}
+
+// WrapKey encrypts a data key with a key encryption key, such as the master key.
+func WrapKey(key *Key, kek []byte) ([]byte, error) {
+	return seal(kek, key.data, keyAD(key.UserID, key.Version))
+}
+
+// UnwrapKey decrypts a data key wrapped by WrapKey.
+func UnwrapKey(wrapped, kek []byte, userID, version int) (*Key, error) {
+	data, err := open(kek, wrapped, keyAD(userID, version))
+	if err != nil {
+		return nil, err
+	}
+	return &Key{UserID: userID, Version: version, data: data}, nil
+}
+
+// NewSalt returns a random salt for PassphraseKey.
+func NewSalt() ([]byte, error) {
+	return randomBytes(saltSize)
+}
+
+// PassphraseKey derives a key encryption key from a passphrase.
+func PassphraseKey(passphrase string, salt []byte) ([]byte, error) {
+	return scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, keySize)
+}
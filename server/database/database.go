@@ -0,0 +1,122 @@
+// Package database opens the database of the server, which is either
+// PostgreSQL or SQLite, and tells the queries which differ between the two
+// which one is in use.
+//
+// PostgreSQL is configured with a connection string, such as "dbname=koticloud
+// user=koticloud sslmode=disable". SQLite is configured with "sqlite:" and
+// the path of the database file, such as "sqlite:/data/koticloud.db".
+package database
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// Dialect is the SQL dialect of a database.
+type Dialect string
+
+// Supported dialects.
+const (
+	Postgres Dialect = "postgres"
+	SQLite   Dialect = "sqlite"
+)
+
+const sqlitePrefix = "sqlite:"
+
+// Connection parameters of SQLite databases. Foreign keys are enforced, and
+// transactions take the write lock when they begin, waiting for other
+// writers instead of failing. Times are stored as text in UTC, so that they
+// compare in order, and text which looks like a time is read as one.
+const sqliteParams = "_pragma=foreign_keys(1)&_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)" +
+	"&_txlock=immediate&_time_format=sqlite&_timezone=UTC&_texttotime=1"
+
+// Format of times stored in SQLite databases, see _time_format=sqlite.
+const sqliteTimeFormat = "2006-01-02 15:04:05.999999999-07:00"
+
+// The dialect of the database opened with Open.
+var current = Postgres
+
+func init() {
+	// now() is used as in PostgreSQL.
+	sqlite.MustRegisterScalarFunction("now", 0,
+		func(ctx *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+			return time.Now().UTC().Format(sqliteTimeFormat), nil
+		})
+}
+
+// Current returns the dialect of the database opened with Open.
+func Current() Dialect {
+	return current
+}
+
+// IsSQLite tells if the database opened with Open is an SQLite database.
+func IsSQLite() bool {
+	return current == SQLite
+}
+
+// Open opens the database of a configuration string. Queries use the
+// dialect of the database from then on.
+func Open(config string) (*sql.DB, error) {
+	db, dialect, err := OpenDialect(config)
+	if err != nil {
+		return nil, err
+	}
+
+	current = dialect
+	return db, nil
+}
+
+// OpenDialect opens the database of a configuration string, without
+// changing the dialect used by queries. Returns the dialect of the database.
+func OpenDialect(config string) (*sql.DB, Dialect, error) {
+	if !strings.HasPrefix(config, sqlitePrefix) {
+		db, err := sql.Open("postgres", config)
+		return db, Postgres, err
+	}
+
+	path := strings.TrimPrefix(config, sqlitePrefix)
+	db, err := sql.Open("sqlite", "file:"+path+"?"+sqliteParams)
+	return db, SQLite, err
+}
+
+// DialectOf returns the dialect of an open database.
+func DialectOf(db *sql.DB) Dialect {
+	if _, ok := db.Driver().(*sqlite.Driver); ok {
+		return SQLite
+	}
+	return Postgres
+}
+
+// IsUniqueViolation tells if err is caused by a violation of a unique constraint.
+func IsUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code.Name() == "unique_violation"
+	} else if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE ||
+			sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY
+	}
+	return false
+}
+
+// IsCheckViolation tells if err is caused by a violation of a check
+// constraint, or of a rule enforced by a trigger.
+func IsCheckViolation(err error) bool {
+	var pqErr *pq.Error
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code.Name() == "check_violation"
+	} else if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_CHECK ||
+			sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_TRIGGER
+	}
+	return false
+}
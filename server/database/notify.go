@@ -0,0 +1,73 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Reconnection intervals of listeners.
+const (
+	listenMinReconnect = 10 * time.Second
+	listenMaxReconnect = time.Minute
+)
+
+// Notify wakes up the listeners of a channel, see Listen. Does nothing on
+// SQLite, which has no notifications; its listeners need to poll.
+func Notify(ctx context.Context, channel string, db *sql.DB) error {
+	if DialectOf(db) == SQLite {
+		return nil
+	}
+	_, err := db.ExecContext(ctx, "SELECT pg_notify($1, '')", channel)
+	return err
+}
+
+// Listen listens for notifications sent with Notify on a channel of the
+// database of a configuration string. The returned channel receives a value
+// after one or more notifications, and after reconnecting, since
+// notifications may have been lost. The listener stops with stop. Returns a
+// nil channel for SQLite databases.
+func Listen(config, channel string) (c <-chan struct{}, stop func(), err error) {
+	if strings.HasPrefix(config, sqlitePrefix) {
+		return nil, func() {}, nil
+	}
+
+	l := pq.NewListener(config, listenMinReconnect, listenMaxReconnect, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("[listen] %s: %s", channel, err)
+		}
+	})
+	if err := l.Listen(channel); err != nil {
+		l.Close()
+		return nil, nil, err
+	}
+
+	wake := make(chan struct{}, 1)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case _, ok := <-l.Notify:
+				if !ok {
+					return
+				}
+				// Notifications are coalesced, since a wakeup handles all of them.
+				select {
+				case wake <- struct{}{}:
+				default:
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return wake, func() {
+		close(done)
+		l.Close()
+	}, nil
+}
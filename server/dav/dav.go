@@ -0,0 +1,210 @@
+package dav
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/terotoi/koticloud/server/core"
+	"github.com/terotoi/koticloud/server/jobs"
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/net/webdav"
+)
+
+// Methods is a list of the HTTP methods used by WebDAV clients.
+var Methods = []string{"PROPFIND", "PROPPATCH", "MKCOL", "COPY", "MOVE", "LOCK", "UNLOCK"}
+
+const realm = "KotiCloud"
+
+// Server serves users' home directories over WebDAV.
+// The users are authenticated with HTTP basic authentication.
+type Server struct {
+	prefix string
+	cfg    *core.Config
+	np     *jobs.NodeProcessor
+	db     *sql.DB
+
+	lockMutex sync.Mutex
+	locks     map[int]webdav.LockSystem // Lock systems by user ID
+}
+
+// NewServer creates a WebDAV server mounted at the given URL prefix.
+func NewServer(prefix string, cfg *core.Config, np *jobs.NodeProcessor, db *sql.DB) *Server {
+	return &Server{
+		prefix: prefix,
+		cfg:    cfg,
+		np:     np,
+		db:     db,
+		locks:  map[int]webdav.LockSystem{},
+	}
+}
+
+// Returns the lock system of a user. Paths are relative to the user's root
+// so every user has a separate lock namespace.
+func (s *Server) lockSystem(user *models.User) webdav.LockSystem {
+	s.lockMutex.Lock()
+	defer s.lockMutex.Unlock()
+
+	ls, ok := s.locks[user.ID]
+	if !ok {
+		ls = webdav.NewMemLS()
+		s.locks[user.ID] = ls
+	}
+	return ls
+}
+
+// Checks username and password given with HTTP basic authentication.
+// Returns nil user if the authentication failed.
+func (s *Server) authenticate(ctx context.Context, r *http.Request) (*models.User, error) {
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		return nil, nil
+	}
+
+	users, err := models.Users(qm.Where("name=?", username)).All(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	user := users[0]
+	if !user.Password.Valid ||
+		bcrypt.CompareHashAndPassword([]byte(user.Password.String), []byte(password)) != nil {
+		return nil, nil
+	}
+	return user, nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, err := s.authenticate(r.Context(), r)
+	if err != nil {
+		log.Printf("[dav] %s %s %s error: %s", r.RemoteAddr, r.Method, r.URL.Path, err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if user == nil {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf("Basic realm=\"%s\"", realm))
+		http.Error(w, "not authorized", http.StatusUnauthorized)
+		return
+	}
+
+	if !user.RootID.Valid {
+		log.Printf("[dav] User %s (%d) has no valid home directory.", user.Name, user.ID)
+		http.Error(w, "user has no valid home directory", http.StatusInternalServerError)
+		return
+	}
+
+	nfs := &nodeFS{user: user, cfg: s.cfg, np: s.np, db: s.db}
+	ls := s.lockSystem(user)
+
+	// COPY is handled here to make it a single fs.Copy instead of
+	// the read-write cycle of webdav.Handler.
+	if r.Method == "COPY" {
+		status, err := s.handleCopy(w, r, nfs, ls)
+		if status != 0 {
+			w.WriteHeader(status)
+			if status != http.StatusNoContent {
+				w.Write([]byte(webdav.StatusText(status)))
+			}
+		}
+		s.logRequest(user, r, err)
+		return
+	}
+
+	h := webdav.Handler{
+		Prefix:     s.prefix,
+		FileSystem: nfs,
+		LockSystem: ls,
+		Logger: func(r *http.Request, err error) {
+			s.logRequest(user, r, err)
+		},
+	}
+	h.ServeHTTP(w, r)
+}
+
+func (s *Server) logRequest(user *models.User, r *http.Request, err error) {
+	if err != nil {
+		log.Printf("[dav] %s %s %s %s error: %s", user.Name, r.RemoteAddr, r.Method, r.URL.Path, err)
+	} else {
+		log.Printf("[dav] %s %s %s %s", user.Name, r.RemoteAddr, r.Method, r.URL.Path)
+	}
+}
+
+// Handles a COPY request. Returns a HTTP status code and an error.
+func (s *Server) handleCopy(w http.ResponseWriter, r *http.Request, nfs *nodeFS,
+	ls webdav.LockSystem) (int, error) {
+	src, dst, status, err := s.copyPaths(r)
+	if err != nil {
+		return status, err
+	}
+
+	if r.Header.Get("Depth") != "" && r.Header.Get("Depth") != "0" &&
+		r.Header.Get("Depth") != "infinity" {
+		return http.StatusBadRequest, fmt.Errorf("invalid depth: %s", r.Header.Get("Depth"))
+	}
+
+	// Only the destination needs to be locked, see RFC 4918 section 7.5.1.
+	now := time.Now()
+	token, err := ls.Create(now, webdav.LockDetails{Root: dst, Duration: -1, ZeroDepth: true})
+	if err == webdav.ErrLocked {
+		return webdav.StatusLocked, err
+	} else if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	defer ls.Unlock(now, token)
+
+	created, err := nfs.copy(r.Context(), src, dst, r.Header.Get("Overwrite") != "F",
+		r.Header.Get("Depth") == "0")
+	if err != nil {
+		return statusFor(err), err
+	}
+
+	if created {
+		return http.StatusCreated, nil
+	}
+	return http.StatusNoContent, nil
+}
+
+// Returns the source and destination paths of a COPY or MOVE request
+// with the prefix stripped.
+func (s *Server) copyPaths(r *http.Request) (string, string, int, error) {
+	hdr := r.Header.Get("Destination")
+	if hdr == "" {
+		return "", "", http.StatusBadRequest, fmt.Errorf("missing destination")
+	}
+
+	u, err := r.URL.Parse(hdr)
+	if err != nil {
+		return "", "", http.StatusBadRequest, err
+	}
+
+	if u.Host != "" && u.Host != r.Host {
+		return "", "", http.StatusBadGateway, fmt.Errorf("invalid destination host: %s", u.Host)
+	}
+
+	src, ok := stripPrefix(r.URL.Path, s.prefix)
+	if !ok {
+		return "", "", http.StatusNotFound, fmt.Errorf("prefix mismatch: %s", r.URL.Path)
+	}
+
+	dst, ok := stripPrefix(u.Path, s.prefix)
+	if !ok || dst == "" {
+		return "", "", http.StatusBadGateway, fmt.Errorf("invalid destination: %s", u.Path)
+	}
+
+	if src == dst {
+		return "", "", http.StatusForbidden, fmt.Errorf("destination equals source")
+	}
+
+	return src, dst, 0, nil
+}
@@ -0,0 +1,253 @@
+package dav
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	"github.com/terotoi/koticloud/server/fs"
+	"github.com/terotoi/koticloud/server/jobs"
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/terotoi/koticloud/server/storage"
+)
+
+// Name of the virtual directory in the root, which lists the nodes other
+// users have shared with the user. A node of the same name in the root hides it.
+const sharedDirName = "Shared"
+
+// Returns the virtual shared directory, which is not stored.
+func newSharedDir() *models.Node {
+	return &models.Node{Name: sharedDirName, Type: "directory", MimeType: "inode/directory",
+		ModifiedOn: time.Now()}
+}
+
+// Tells if a node is the virtual shared directory.
+func isSharedDir(node *models.Node) bool {
+	return node.ID == 0
+}
+
+// fileInfo implements os.FileInfo for a node.
+type fileInfo struct {
+	node *models.Node
+	name string // Name in the path, if not the name of the node
+}
+
+// Returns information about a node found by a path.
+func newFileInfo(node *models.Node, name string) *fileInfo {
+	if name := path.Base(cleanPath(name)); name != "/" && name != node.Name {
+		return &fileInfo{node: node, name: name}
+	}
+	return &fileInfo{node: node}
+}
+
+func (fi *fileInfo) Name() string {
+	if fi.name != "" {
+		return fi.name
+	}
+	return fi.node.Name
+}
+
+func (fi *fileInfo) Size() int64        { return fi.node.Size.Int64 }
+func (fi *fileInfo) ModTime() time.Time { return fi.node.ModifiedOn }
+func (fi *fileInfo) IsDir() bool        { return fs.IsDir(fi.node) }
+func (fi *fileInfo) Sys() interface{}   { return nil }
+
+func (fi *fileInfo) Mode() os.FileMode {
+	if fs.IsDir(fi.node) {
+		return os.ModeDir | 0700
+	}
+	return 0600
+}
+
+// ContentType implements webdav.ContentTyper. It avoids sniffing the
+// contents of the file on every PROPFIND.
+func (fi *fileInfo) ContentType(ctx context.Context) (string, error) {
+	return fi.node.MimeType, nil
+}
+
+// readFile is a file node opened for reading.
+type readFile struct {
+	storage.File
+	info *fileInfo
+}
+
+func (f *readFile) Stat() (os.FileInfo, error) {
+	return f.info, nil
+}
+
+func (f *readFile) Readdir(count int) ([]os.FileInfo, error) {
+	return nil, os.ErrInvalid
+}
+
+func (f *readFile) Write(p []byte) (int, error) {
+	return 0, os.ErrPermission
+}
+
+// dirFile is an opened directory node.
+type dirFile struct {
+	nfs      *nodeFS
+	node     *models.Node
+	info     *fileInfo
+	children []os.FileInfo
+	loaded   bool
+	pos      int
+}
+
+func (f *dirFile) Close() error                       { return nil }
+func (f *dirFile) Read(p []byte) (int, error)         { return 0, os.ErrInvalid }
+func (f *dirFile) Write(p []byte) (int, error)        { return 0, os.ErrPermission }
+func (f *dirFile) Stat() (os.FileInfo, error)         { return f.info, nil }
+func (f *dirFile) Seek(o int64, w int) (int64, error) { return 0, nil }
+
+func (f *dirFile) Readdir(count int) ([]os.FileInfo, error) {
+	if !f.loaded {
+		if err := f.load(context.Background()); err != nil {
+			return nil, err
+		}
+		f.loaded = true
+	}
+
+	rest := f.children[f.pos:]
+	if count <= 0 {
+		f.pos = len(f.children)
+		return rest, nil
+	}
+
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+
+	if count > len(rest) {
+		count = len(rest)
+	}
+	f.pos += count
+	return rest[:count], nil
+}
+
+// Lists the children of the directory. The root also lists the shared
+// directory, if anything has been shared with the user.
+func (f *dirFile) load(ctx context.Context) error {
+	if isSharedDir(f.node) {
+		shared, err := f.nfs.sharedNodes(ctx, f.nfs.db)
+		if err != nil {
+			return err
+		}
+
+		for name, n := range shared {
+			f.children = append(f.children, newFileInfo(n, name))
+		}
+		return nil
+	}
+
+	nodes, err := fs.NodesByParentID(ctx, f.node.ID, f.nfs.db)
+	if err != nil {
+		return err
+	}
+
+	// Access is inherited, so all children of a readable directory are readable.
+	hidden := false
+	for _, n := range nodes {
+		f.children = append(f.children, &fileInfo{node: n})
+		hidden = hidden || n.Name == sharedDirName
+	}
+
+	if f.node.ID == f.nfs.user.RootID.Int && !hidden {
+		shared, err := f.nfs.sharedNodes(ctx, f.nfs.db)
+		if err != nil {
+			return err
+		} else if len(shared) > 0 {
+			f.children = append(f.children, &fileInfo{node: newSharedDir()})
+		}
+	}
+	return nil
+}
+
+// uploadFile is a file opened for writing. The data is stored into
+// a node when the file is closed.
+type uploadFile struct {
+	*os.File
+	ctx    context.Context
+	nfs    *nodeFS
+	parent *models.Node
+	name   string
+}
+
+func (f *uploadFile) Readdir(count int) ([]os.FileInfo, error) {
+	return nil, os.ErrInvalid
+}
+
+func (f *uploadFile) Close() error {
+	uploadPath := f.File.Name()
+	defer os.Remove(uploadPath)
+
+	if err := f.File.Close(); err != nil {
+		return err
+	}
+
+	mimeType, err := fs.DetectMimeType(uploadPath, false)
+	if err != nil {
+		return err
+	}
+
+	st, err := os.Stat(uploadPath)
+	if err != nil {
+		return err
+	}
+
+	nfs := f.nfs
+	var node *models.Node
+	var path string
+
+	err = nfs.withJournal(f.ctx, func(j *fs.Journal, tx *sql.Tx) error {
+		existing, err := fs.NodeChildByName(f.ctx, f.name, f.parent.ID, tx)
+		if err != nil {
+			return err
+		}
+
+		if existing != nil {
+			if !fs.AccessAllowed(f.ctx, nfs.user, existing, true, tx) {
+				return os.ErrPermission
+			}
+
+			node = existing
+			if err := fs.CheckQuota(f.ctx, node, nfs.user, st.Size(), tx); err != nil {
+				return err
+			}
+
+			cfg := nfs.cfg
+			if err := fs.KeepVersion(f.ctx, node, nfs.user, cfg.HomeRoot, cfg.VersionsKeep,
+				cfg.VersionsMaxAgeDuration(), j, tx); err != nil {
+				return err
+			}
+
+			if err := fs.UpdateFile(f.ctx, node, mimeType, st.Size(), nfs.user, nil, false, tx); err != nil {
+				return err
+			}
+		} else {
+			if err := fs.CheckQuota(f.ctx, f.parent, nfs.user, st.Size(), tx); err != nil {
+				return err
+			}
+
+			node, err = fs.NewFile(f.ctx, f.parent, f.name, mimeType, st.Size(),
+				nfs.user, nil, false, tx)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := fs.CopyData(f.ctx, node, uploadPath, false, nfs.cfg.HomeRoot, nfs.cfg.BlobStore, tx); err != nil {
+			return err
+		}
+
+		path, err = fs.PhysPath(f.ctx, node, nfs.cfg.HomeRoot, tx)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return jobs.AddNodeProcessRequest(f.ctx, nfs.np.Channel, node, path, nfs.db)
+}
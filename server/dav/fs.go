@@ -0,0 +1,443 @@
+package dav
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/terotoi/koticloud/server/core"
+	"github.com/terotoi/koticloud/server/fs"
+	"github.com/terotoi/koticloud/server/jobs"
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/terotoi/koticloud/server/storage"
+	"github.com/terotoi/koticloud/server/util"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"golang.org/x/net/webdav"
+)
+
+// nodeFS implements webdav.FileSystem on top of the node tree of one user.
+// All paths are relative to the user's root directory. The nodes other users
+// have shared with the user are under a virtual directory, see sharedDirName.
+type nodeFS struct {
+	user *models.User
+	cfg  *core.Config
+	np   *jobs.NodeProcessor
+	db   *sql.DB
+}
+
+// Strips prefix from a path. Returns false if the path does not have the prefix.
+func stripPrefix(p, prefix string) (string, bool) {
+	if prefix == "" {
+		return p, true
+	}
+	if r := strings.TrimPrefix(p, prefix); len(r) < len(p) {
+		return r, true
+	}
+	return p, false
+}
+
+// Returns a clean absolute path.
+func cleanPath(name string) string {
+	if name == "" || name[0] != '/' {
+		name = "/" + name
+	}
+	return path.Clean(name)
+}
+
+// Converts errors from the fs package to errors understood by webdav.Handler.
+func toOSError(err error) error {
+	if serr, ok := err.(*core.SystemError); ok {
+		switch serr.StatusCode {
+		case http.StatusNotFound:
+			return os.ErrNotExist
+		case http.StatusConflict:
+			return os.ErrExist
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return os.ErrPermission
+		}
+	}
+	return err
+}
+
+// Returns a HTTP status code for an error.
+func statusFor(err error) int {
+	switch {
+	case os.IsNotExist(err):
+		return http.StatusNotFound
+	case os.IsExist(err):
+		return http.StatusPreconditionFailed
+	case os.IsPermission(err):
+		return http.StatusForbidden
+	}
+	if serr, ok := err.(*core.SystemError); ok {
+		return serr.StatusCode
+	}
+	return http.StatusInternalServerError
+}
+
+// Finds a node by its path. Returns os.ErrNotExist if the node is not found.
+func (nfs *nodeFS) resolve(ctx context.Context, name string, tx *sql.Tx) (*models.Node, error) {
+	root, err := fs.NodeByID(ctx, nfs.user.RootID.Int, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	p := cleanPath(name)
+	node, err := fs.NodeByPath(ctx, p, root, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if node == nil {
+		if node, err = nfs.resolveShared(ctx, p, root, tx); err != nil {
+			return nil, err
+		}
+	}
+
+	if node == nil {
+		return nil, os.ErrNotExist
+	}
+
+	if !isSharedDir(node) && !fs.AccessAllowed(ctx, nfs.user, node, false, tx) {
+		return nil, os.ErrPermission
+	}
+	return node, nil
+}
+
+// Finds the parent directory of a path. Nothing can be created in the shared
+// directory itself.
+func (nfs *nodeFS) resolveParent(ctx context.Context, name string, tx *sql.Tx) (*models.Node, error) {
+	parent, err := nfs.resolve(ctx, path.Dir(cleanPath(name)), tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !fs.IsDir(parent) {
+		return nil, os.ErrNotExist
+	} else if isSharedDir(parent) {
+		return nil, os.ErrPermission
+	}
+	return parent, nil
+}
+
+// Finds a node by its path under the shared directory. Returns nil if the
+// path is not under it, or a node of the same name in the root hides it.
+func (nfs *nodeFS) resolveShared(ctx context.Context, p string, root *models.Node,
+	tx *sql.Tx) (*models.Node, error) {
+	rest, ok := stripPrefix(p, "/"+sharedDirName)
+	if !ok || rest != "" && rest[0] != '/' {
+		return nil, nil
+	}
+
+	if dup, err := fs.NodeChildByName(ctx, sharedDirName, root.ID, tx); err != nil || dup != nil {
+		return nil, err
+	} else if rest == "" {
+		return newSharedDir(), nil
+	}
+
+	shared, err := nfs.sharedNodes(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.SplitN(rest[1:], "/", 2)
+	node := shared[parts[0]]
+	if node == nil || len(parts) == 1 {
+		return node, nil
+	}
+	return fs.NodeByPath(ctx, parts[1], node, tx)
+}
+
+// Returns the nodes other users have shared with the user by their names in
+// the shared directory. Nodes with the same name are told apart by the names
+// of their owners.
+func (nfs *nodeFS) sharedNodes(ctx context.Context, tx boil.ContextExecutor) (map[string]*models.Node, error) {
+	nodes, err := fs.SharedWithUser(ctx, nfs.user, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	count := map[string]int{}
+	for _, n := range nodes {
+		count[n.Name]++
+	}
+
+	shared := map[string]*models.Node{}
+	for _, n := range nodes {
+		name := n.Name
+		if count[name] > 1 {
+			name = fmt.Sprintf("%s (%s)", n.Name, n.OwnerName.String)
+		}
+		shared[name] = &n.Node
+	}
+	return shared, nil
+}
+
+// Runs f inside a transaction.
+func (nfs *nodeFS) withTx(ctx context.Context, f func(tx *sql.Tx) error) error {
+	return toOSError(util.WithTransaction(ctx, nfs.db, f))
+}
+
+// Like withTx, with a journal for the stored files changed in the transaction.
+func (nfs *nodeFS) withJournal(ctx context.Context, f func(j *fs.Journal, tx *sql.Tx) error) error {
+	j := fs.NewJournal(nfs.cfg.HomeRoot)
+	defer j.Finish(nfs.db)
+	return nfs.withTx(ctx, func(tx *sql.Tx) error { return f(j, tx) })
+}
+
+func (nfs *nodeFS) Mkdir(ctx context.Context, name string, perm os.FileMode) error {
+	return nfs.withTx(ctx, func(tx *sql.Tx) error {
+		parent, err := nfs.resolveParent(ctx, name, tx)
+		if err != nil {
+			return err
+		}
+
+		filename := path.Base(cleanPath(name))
+		if !fs.IsValidName(filename) {
+			return os.ErrInvalid
+		}
+
+		dup, err := fs.NodeChildByName(ctx, filename, parent.ID, tx)
+		if err != nil {
+			return err
+		}
+
+		if dup != nil {
+			return os.ErrExist
+		}
+
+		_, err = fs.MakeDir(ctx, parent, filename, nfs.user, nfs.cfg.HomeRoot, false, tx)
+		return err
+	})
+}
+
+func (nfs *nodeFS) OpenFile(ctx context.Context, name string, flag int,
+	perm os.FileMode) (webdav.File, error) {
+	if flag&(os.O_WRONLY|os.O_RDWR|os.O_CREATE|os.O_TRUNC) != 0 {
+		return nfs.openUpload(ctx, name, flag)
+	}
+
+	var node *models.Node
+	err := nfs.withTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		node, err = nfs.resolve(ctx, name, tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	info := newFileInfo(node, name)
+	if fs.IsDir(node) {
+		return &dirFile{nfs: nfs, node: node, info: info}, nil
+	}
+
+	physPath, err := fs.PhysPath(ctx, node, nfs.cfg.HomeRoot, nfs.db)
+	if err != nil {
+		return nil, err
+	}
+
+	fh, err := storage.Open(physPath, node.Encrypted)
+	if err != nil {
+		return nil, err
+	}
+	return &readFile{File: fh, info: info}, nil
+}
+
+// Opens a file for writing. The data is written to a temporary file
+// in the upload directory and stored into the node tree when the file is closed.
+func (nfs *nodeFS) openUpload(ctx context.Context, name string, flag int) (webdav.File, error) {
+	var parent *models.Node
+	filename := path.Base(cleanPath(name))
+
+	err := nfs.withTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		parent, err = nfs.resolveParent(ctx, name, tx)
+		if err != nil {
+			return err
+		}
+
+		if !fs.AccessAllowed(ctx, nfs.user, parent, true, tx) {
+			return os.ErrPermission
+		}
+
+		if !fs.IsValidName(filename) {
+			return os.ErrInvalid
+		}
+
+		existing, err := fs.NodeChildByName(ctx, filename, parent.ID, tx)
+		if err != nil {
+			return err
+		}
+
+		if existing != nil {
+			if fs.IsDir(existing) {
+				return os.ErrExist
+			}
+
+			if flag&os.O_EXCL != 0 {
+				return os.ErrExist
+			}
+		} else if flag&os.O_CREATE == 0 {
+			return os.ErrNotExist
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := util.EnsureDirExists(nfs.cfg.UploadDir); err != nil {
+		return nil, err
+	}
+
+	fh, err := ioutil.TempFile(nfs.cfg.UploadDir, "upload-*")
+	if err != nil {
+		return nil, err
+	}
+
+	return &uploadFile{File: fh, ctx: ctx, nfs: nfs, parent: parent, name: filename}, nil
+}
+
+func (nfs *nodeFS) RemoveAll(ctx context.Context, name string) error {
+	return nfs.withJournal(ctx, func(j *fs.Journal, tx *sql.Tx) error {
+		node, err := nfs.resolve(ctx, name, tx)
+		if err != nil {
+			return err
+		}
+
+		if !node.ParentID.Valid {
+			return os.ErrPermission
+		}
+
+		return fs.Trash(ctx, node, true, nfs.user, nfs.cfg.HomeRoot, j, tx)
+	})
+}
+
+func (nfs *nodeFS) Rename(ctx context.Context, oldName, newName string) error {
+	return nfs.withJournal(ctx, func(j *fs.Journal, tx *sql.Tx) error {
+		node, err := nfs.resolve(ctx, oldName, tx)
+		if err != nil {
+			return err
+		}
+
+		if !node.ParentID.Valid {
+			return os.ErrPermission
+		}
+
+		dest, err := nfs.resolveParent(ctx, newName, tx)
+		if err != nil {
+			return err
+		}
+
+		filename := path.Base(cleanPath(newName))
+		if dup, err := fs.NodeChildByName(ctx, filename, dest.ID, tx); err != nil {
+			return err
+		} else if dup != nil {
+			return os.ErrExist
+		}
+
+		homeRoot := nfs.cfg.HomeRoot
+		if node.ParentID.Int == dest.ID {
+			return fs.Rename(ctx, node, filename, nfs.user, homeRoot, j, tx)
+		} else if node.Name == filename {
+			return fs.Move(ctx, node, dest, nfs.user, homeRoot, nfs.cfg.ThumbRoot, j, tx)
+		}
+
+		// Both the name and the directory change. Rename first, unless the
+		// new name is taken in the old directory.
+		dup, err := fs.NodeChildByName(ctx, filename, node.ParentID.Int, tx)
+		if err != nil {
+			return err
+		}
+
+		if dup == nil {
+			if err := fs.Rename(ctx, node, filename, nfs.user, homeRoot, j, tx); err != nil {
+				return err
+			}
+			return fs.Move(ctx, node, dest, nfs.user, homeRoot, nfs.cfg.ThumbRoot, j, tx)
+		}
+
+		if dup, err := fs.NodeChildByName(ctx, node.Name, dest.ID, tx); err != nil {
+			return err
+		} else if dup != nil {
+			return os.ErrExist
+		}
+
+		if err := fs.Move(ctx, node, dest, nfs.user, homeRoot, nfs.cfg.ThumbRoot, j, tx); err != nil {
+			return err
+		}
+		return fs.Rename(ctx, node, filename, nfs.user, homeRoot, j, tx)
+	})
+}
+
+func (nfs *nodeFS) Stat(ctx context.Context, name string) (os.FileInfo, error) {
+	var node *models.Node
+	err := nfs.withTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		node, err = nfs.resolve(ctx, name, tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newFileInfo(node, name), nil
+}
+
+// Copies src to dst using fs.Copy. If dst exists and overwrite is true, it is
+// moved to the trash first. If shallow is true, only a directory itself is copied,
+// not its contents. Returns true if a new node was created.
+func (nfs *nodeFS) copy(ctx context.Context, src, dst string, overwrite, shallow bool) (bool, error) {
+	created := true
+	err := nfs.withJournal(ctx, func(j *fs.Journal, tx *sql.Tx) error {
+		node, err := nfs.resolve(ctx, src, tx)
+		if err != nil {
+			return err
+		}
+
+		dest, err := nfs.resolveParent(ctx, dst, tx)
+		if err != nil {
+			return err
+		}
+
+		filename := path.Base(cleanPath(dst))
+		if !fs.IsValidName(filename) {
+			return os.ErrInvalid
+		}
+
+		existing, err := fs.NodeChildByName(ctx, filename, dest.ID, tx)
+		if err != nil {
+			return err
+		}
+
+		if existing != nil {
+			if !overwrite {
+				return os.ErrExist
+			}
+
+			created = false
+			if err := fs.Trash(ctx, existing, true, nfs.user, nfs.cfg.HomeRoot, j, tx); err != nil {
+				return err
+			}
+		}
+
+		if shallow && fs.IsDir(node) {
+			_, err = fs.MakeDir(ctx, dest, filename, nfs.user, nfs.cfg.HomeRoot, false, tx)
+		} else {
+			_, err = fs.Copy(ctx, node, dest, filename, nfs.cfg.HomeRoot, nfs.cfg.ThumbRoot, nfs.cfg.BlobStore,
+				nfs.user, j, tx)
+		}
+		return err
+	})
+
+	if err == nil {
+		log.Printf("[dav] copied %s to %s", src, dst)
+	}
+	return created, err
+}
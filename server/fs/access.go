@@ -1,8 +1,66 @@
 package fs
 
-import "github.com/terotoi/koticloud/server/models"
+import (
+	"context"
+	"log"
 
-// Checks if the given user has access to the given node.
-func AccessAllowed(user *models.User, node *models.Node, write bool) bool {
-	return user.Admin || (node.OwnerID.Valid && node.OwnerID.Int == user.ID)
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+// Selects the IDs of the groups of the user given as the parameter.
+const userGroupsQuery = "SELECT group_id FROM group_members WHERE user_id=?"
+
+// AccessibleClause is a query condition matching nodes the user given as
+// both parameters has been granted access to, directly or through a directory above them.
+// Grants do not give access to nodes in the trash.
+var AccessibleClause = "id IN (" + subtreeQuery(
+	"SELECT g.node_id FROM grants g WHERE g.user_id=? OR g.group_id IN ("+userGroupsQuery+")", true) +
+	" AND s.id NOT IN (" + trashNodesQuery + "))"
+
+// AccessAllowed checks if the given user has access to the given node.
+// Admins and owners have full access. Other users need a grant on the node
+// or on a directory above it. Read-only grants do not allow writing, and
+// grants do not give access to nodes in the trash.
+func AccessAllowed(ctx context.Context, user *models.User, node *models.Node, write bool,
+	tx boil.ContextExecutor) bool {
+	if user.Admin || (node.OwnerID.Valid && node.OwnerID.Int == user.ID) {
+		return true
+	}
+
+	var allowed bool
+	err := queries.Raw("SELECT EXISTS(SELECT 1 FROM grants g JOIN nodes a ON g.node_id=a.id "+
+		"JOIN nodes n ON n.id=a.id OR (n.path > a.path || '/' AND n.path < a.path || '0') "+
+		"WHERE n.id=$1 "+
+		"AND (g.user_id=$2 OR g.group_id IN (SELECT group_id FROM group_members WHERE user_id=$2)) "+
+		"AND (g.write OR NOT $3) AND n.id NOT IN ("+trashNodesQuery+"))", node.ID, user.ID, write).
+		QueryRowContext(ctx, tx).Scan(&allowed)
+	if err != nil {
+		log.Printf("AccessAllowed: %s", err)
+		return false
+	}
+	return allowed
+}
+
+// Returns the owner for a new node under parent. Nodes created by other users
+// in a shared directory belong to the owner of the directory.
+func ownerFor(parent *models.Node, user *models.User) null.Int {
+	if parent != nil && parent.OwnerID.Valid {
+		return parent.OwnerID
+	}
+	return null.Int{Int: user.ID, Valid: true}
+}
+
+// Sets the owner of a node and all nodes under it.
+func setSubtreeOwner(ctx context.Context, node *models.Node, ownerID null.Int,
+	tx boil.ContextExecutor) error {
+	_, err := queries.Raw("UPDATE nodes SET owner_id=$2 WHERE id IN ("+subtreeQuery("$1", true)+")",
+		node.ID, ownerID).
+		ExecContext(ctx, tx)
+	if err == nil {
+		node.OwnerID = ownerID
+	}
+	return err
 }
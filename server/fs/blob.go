@@ -0,0 +1,191 @@
+package fs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/terotoi/koticloud/server/storage"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+// Directory under the home root where the contents of files are stored by
+// their SHA-256 hash, when the blob store is used. Like versionDir, it cannot
+// clash with home directories.
+const blobDir = ".blobs"
+
+// Prefix of partially written blobs.
+const tempBlobPrefix = "tmp-"
+
+// BlobPath returns the path for the contents of a blob.
+func BlobPath(homeRoot, hash string) string {
+	return fmt.Sprintf("%s/%s/%s/%s/%s", homeRoot, blobDir, hash[0:2], hash[2:4], hash)
+}
+
+// BlobRoot returns the directory containing all blobs.
+func BlobRoot(homeRoot string) string {
+	return fmt.Sprintf("%s/%s", homeRoot, blobDir)
+}
+
+// HashFile returns the SHA-256 hash of the plaintext of a file as a hex
+// string, and its size. encrypted tells if the file is encrypted.
+func HashFile(path string, encrypted bool) (string, int64, error) {
+	fh, err := storage.Open(path, encrypted)
+	if err != nil {
+		return "", 0, err
+	}
+	defer fh.Close()
+
+	h := sha256.New()
+	n, err := io.Copy(h, fh)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), n, nil
+}
+
+// StoreBlob stores the contents of a plaintext file in the blob store and returns its hash.
+// If the store already has the same contents, nothing is written. The file is
+// hard linked into the store when possible, so it should not be modified afterwards.
+// The blob row stays locked until tx ends, so that the garbage collector cannot
+// remove it before a node references it.
+func StoreBlob(ctx context.Context, sourceFile, homeRoot string, tx boil.ContextExecutor) (string, error) {
+	hash, size, err := HashFile(sourceFile, false)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := queries.Raw("INSERT INTO blobs (hash, size) VALUES ($1, $2) "+
+		"ON CONFLICT (hash) DO UPDATE SET size=excluded.size", hash, size).ExecContext(ctx, tx); err != nil {
+		return "", err
+	}
+
+	path := BlobPath(homeRoot, hash)
+	if _, err := storage.Stat(path, false); err == nil {
+		return hash, nil
+	} else if !os.IsNotExist(err) {
+		return "", err
+	}
+
+	if err := storage.MkdirAll(filepath.Dir(path)); err != nil {
+		return "", err
+	}
+
+	// Linking sets the modification time, which tells the garbage collector
+	// how long ago the blob was stored.
+	if err := storage.Link(sourceFile, path); err == nil {
+		return hash, nil
+	}
+
+	// Write into a temporary file first, so that a partial blob is never visible.
+	tmp := fmt.Sprintf("%s/%s%s-%d", filepath.Dir(path), tempBlobPrefix, hash, time.Now().UnixNano())
+	if err := CopyFile(sourceFile, tmp); err != nil {
+		storage.Remove(tmp)
+		return "", err
+	}
+
+	if err := storage.Rename(tmp, path); err != nil {
+		storage.Remove(tmp)
+		return "", err
+	}
+	return hash, nil
+}
+
+// Moves the contents of node into the blob store before they are copied to
+// copy, so that the copy does not duplicate them. Encrypted contents, and
+// contents to be encrypted for the owner of the copy, are not moved, and an
+// invalid hash is returned. The file of the node is removed by j once tx has
+// been committed.
+func moveToBlob(ctx context.Context, node, copy *models.Node, homeRoot string, j *Journal,
+	tx boil.ContextExecutor) (null.String, error) {
+	path, err := PhysPath(ctx, node, homeRoot, tx)
+	if err != nil {
+		return null.String{}, err
+	}
+
+	if encrypted, _, err := NodeEncrypted(ctx, node.ID, tx); err != nil || encrypted {
+		return null.String{}, err
+	}
+
+	if key, err := UserDataKey(ctx, copy.OwnerID.Int, tx); err != nil || key != nil {
+		return null.String{}, err
+	}
+
+	hash, err := StoreBlob(ctx, path, homeRoot, tx)
+	if err != nil {
+		return null.String{}, err
+	}
+
+	blob := null.StringFrom(hash)
+	if err := SetNodeBlob(ctx, node, blob, tx); err != nil {
+		return null.String{}, err
+	}
+
+	if err := SetNodeChecksum(ctx, node, blob, tx); err != nil {
+		return null.String{}, err
+	}
+
+	if err := j.Remove(ctx, path, tx); err != nil {
+		return null.String{}, err
+	}
+	return blob, nil
+}
+
+// NodeBlob returns the hash of the blob holding the contents of a node, if any.
+func NodeBlob(ctx context.Context, nodeID int, tx boil.ContextExecutor) (null.String, error) {
+	var hash null.String
+	err := queries.Raw("SELECT blob FROM nodes WHERE id=$1", nodeID).QueryRowContext(ctx, tx).Scan(&hash)
+	return hash, err
+}
+
+// SetNodeBlob makes a node reference a blob, or no blob if hash is not valid.
+// Blobs are not encrypted, and contents stored for the node later set their
+// own encryption, so the node is marked as not encrypted.
+func SetNodeBlob(ctx context.Context, node *models.Node, hash null.String, tx boil.ContextExecutor) error {
+	if _, err := queries.Raw("UPDATE nodes SET blob=$2, encrypted=false WHERE id=$1", node.ID, hash).
+		ExecContext(ctx, tx); err != nil {
+		return err
+	}
+	node.Blob = hash
+	node.Encrypted = false
+	return nil
+}
+
+// RecountBlobRefs recomputes the reference counts of all blobs.
+// Returns the number of blobs whose count was wrong.
+func RecountBlobRefs(ctx context.Context, tx boil.ContextExecutor) (int64, error) {
+	res, err := queries.Raw("UPDATE blobs SET refs=c.refs FROM ("+
+		"SELECT hash, (SELECT count(*) FROM nodes WHERE blob=hash) + "+
+		"(SELECT count(*) FROM versions WHERE blob=hash) AS refs FROM blobs) c "+
+		"WHERE blobs.hash=c.hash AND blobs.refs<>c.refs").ExecContext(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// DeleteBlob deletes an unreferenced blob and its contents. Returns false if
+// the blob is referenced.
+func DeleteBlob(ctx context.Context, hash, homeRoot string, tx boil.ContextExecutor) (bool, error) {
+	res, err := queries.Raw("DELETE FROM blobs WHERE hash=$1 AND refs=0", hash).ExecContext(ctx, tx)
+	if err != nil {
+		return false, err
+	}
+
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return false, err
+	}
+
+	if err := storage.Remove(BlobPath(homeRoot, hash)); err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,62 @@
+package fs
+
+import (
+	"context"
+	"time"
+
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+// The checksum of a file node is the SHA-256 hash of its contents, see HashFile.
+// For contents in the blob store, it is the same as the hash of the blob.
+
+// SetNodeChecksum sets the checksum of the current contents of a node.
+// The checksum is considered verified at the time of the call. If checksum is
+// not valid, the next scrub computes it.
+func SetNodeChecksum(ctx context.Context, node *models.Node, checksum null.String,
+	tx boil.ContextExecutor) error {
+	_, err := queries.Raw("UPDATE nodes SET checksum=$2, checksum_failed=false, "+
+		"checksum_verified_on=(CASE WHEN $2 IS NULL THEN NULL ELSE now() END) WHERE id=$1",
+		node.ID, checksum).ExecContext(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	node.Checksum = checksum
+	node.ChecksumFailed = false
+	node.ChecksumVerifiedOn = null.Time{}
+	if checksum.Valid {
+		node.ChecksumVerifiedOn = null.TimeFrom(time.Now())
+	}
+	return nil
+}
+
+// NodeChecksum returns the checksum of a node, which is not valid if it has
+// not been computed yet.
+func NodeChecksum(ctx context.Context, nodeID int, tx boil.ContextExecutor) (null.String, error) {
+	var checksum null.String
+	err := queries.Raw("SELECT checksum FROM nodes WHERE id=$1", nodeID).QueryRowContext(ctx, tx).Scan(&checksum)
+	return checksum, err
+}
+
+// VerifyNodeChecksum compares a hash of the contents of a node to its stored
+// checksum, and flags the node if they differ. A node without a checksum gets
+// the hash as its checksum. Returns false on a mismatch.
+func VerifyNodeChecksum(ctx context.Context, nodeID int, hash string, tx boil.ContextExecutor) (bool, error) {
+	var failed bool
+	err := queries.Raw("UPDATE nodes SET checksum=COALESCE(checksum, $2), checksum_verified_on=now(), "+
+		"checksum_failed=(COALESCE(checksum, $2)<>$2) WHERE id=$1 RETURNING checksum_failed",
+		nodeID, hash).QueryRowContext(ctx, tx).Scan(&failed)
+	return !failed, err
+}
+
+// Copies the checksum of a node to another node with the same contents.
+func copyNodeChecksum(ctx context.Context, src, dst *models.Node, tx boil.ContextExecutor) error {
+	_, err := queries.Raw("UPDATE nodes SET checksum=s.checksum, checksum_verified_on=s.checksum_verified_on, "+
+		"checksum_failed=s.checksum_failed FROM nodes s WHERE s.id=$1 AND nodes.id=$2",
+		src.ID, dst.ID).ExecContext(ctx, tx)
+	return err
+}
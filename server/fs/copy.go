@@ -10,16 +10,53 @@ import (
 
 	"github.com/terotoi/koticloud/server/core"
 	"github.com/terotoi/koticloud/server/models"
+	"github.com/terotoi/koticloud/server/storage"
+	"github.com/volatiletech/null/v8"
 )
 
+// Copy a node and everything under it to filename under the parent directory.
+// Fails if the copy would exceed the quota of the owner of parent.
+// If useBlobs is true, the contents of copied files are moved into the blob
+// store, to be shared by the copies, see moveToBlob.
 func Copy(ctx context.Context, src *models.Node, parent *models.Node, filename string,
-	homeRoot, thumbRoot string, user *models.User,
-	tx *sql.Tx) ([]*models.Node, error) {
-	if !AccessAllowed(user, src, false) {
+	homeRoot, thumbRoot string, useBlobs bool, user *models.User,
+	j *Journal, tx *sql.Tx) ([]*models.Node, error) {
+	size, err := SubtreeSize(ctx, src, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := CheckQuota(ctx, parent, user, size, tx); err != nil {
+		return nil, err
+	}
+
+	// The subtree is read before copying, so that a directory copied under
+	// itself does not copy the copy.
+	nodes, err := subtreeNodes(ctx, src, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	children := map[int][]*models.Node{}
+	for _, n := range nodes {
+		if n.ID != src.ID {
+			children[n.ParentID.Int] = append(children[n.ParentID.Int], n)
+		}
+	}
+
+	return copyNode(ctx, src, parent, filename, children, homeRoot, thumbRoot, useBlobs, user, j, tx)
+}
+
+// Copies a node, and the nodes under it by children, which maps the ID of a
+// directory to the nodes in it.
+func copyNode(ctx context.Context, src *models.Node, parent *models.Node, filename string,
+	children map[int][]*models.Node, homeRoot, thumbRoot string, useBlobs bool, user *models.User,
+	j *Journal, tx *sql.Tx) ([]*models.Node, error) {
+	if !AccessAllowed(ctx, user, src, false, tx) {
 		return nil, core.NewSystemError(http.StatusUnauthorized, "", "not allowed")
 	}
 
-	if !AccessAllowed(user, parent, false) {
+	if !AccessAllowed(ctx, user, parent, true, tx) {
 		return nil, core.NewSystemError(http.StatusUnauthorized, "", "not allowed")
 	}
 
@@ -27,10 +64,6 @@ func Copy(ctx context.Context, src *models.Node, parent *models.Node, filename s
 		return nil, core.NewSystemError(http.StatusUnauthorized, "", "destination is not a directory")
 	}
 
-	if !parent.OwnerID.Valid || parent.OwnerID.Int != src.OwnerID.Int {
-		return nil, core.NewSystemError(http.StatusUnauthorized, "", "destination directory has an invalid owner")
-	}
-
 	var copied []*models.Node
 
 	log.Printf("Copying %s (%s, %d) to %s/%s (%d)", src.Name, src.Type, src.ID, parent.Name,
@@ -50,8 +83,47 @@ func Copy(ctx context.Context, src *models.Node, parent *models.Node, filename s
 			return nil, err
 		}
 
-		srcPath, err := PhysPath(ctx, src, homeRoot, tx)
-		if err = CopyData(ctx, copy, srcPath, homeRoot, tx); err != nil {
+		blob, err := NodeBlob(ctx, src.ID, tx)
+		if err != nil {
+			return nil, err
+		}
+
+		encrypted, thumbEncrypted, err := NodeEncrypted(ctx, src.ID, tx)
+		if err != nil {
+			return nil, err
+		}
+
+		if !blob.Valid && useBlobs {
+			if blob, err = moveToBlob(ctx, src, copy, homeRoot, j, tx); err != nil {
+				return nil, err
+			}
+		}
+
+		// Contents in the blob store are shared by the copies.
+		if blob.Valid {
+			if err := SetNodeBlob(ctx, copy, blob, tx); err != nil {
+				return nil, err
+			}
+
+			if err := copyNodeChecksum(ctx, src, copy, tx); err != nil {
+				return nil, err
+			}
+		} else {
+			srcPath, err := PhysPath(ctx, src, homeRoot, tx)
+			if err != nil {
+				return nil, err
+			}
+
+			if err = CopyData(ctx, copy, srcPath, encrypted, homeRoot, false, tx); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := copyNodeText(ctx, src.ID, copy.ID, tx); err != nil {
+			return nil, err
+		}
+
+		if err := copyNodeInfos(ctx, src, copy, tx); err != nil {
 			return nil, err
 		}
 
@@ -66,6 +138,10 @@ func Copy(ctx context.Context, src *models.Node, parent *models.Node, filename s
 				log.Println(err)
 				return nil, core.NewInternalError(err)
 			}
+
+			if err := SetThumbEncrypted(ctx, copy, thumbEncrypted, tx); err != nil {
+				return nil, err
+			}
 		}
 	} else {
 		newDir, err := MakeDir(ctx, parent, filename, user, homeRoot, false, tx)
@@ -75,13 +151,8 @@ func Copy(ctx context.Context, src *models.Node, parent *models.Node, filename s
 
 		copied = append(copied, newDir)
 
-		children, err := NodesByParentID(ctx, src.ID, tx)
-		if err != nil {
-			return nil, err
-		}
-
-		for _, ch := range children {
-			chops, err := Copy(ctx, ch, newDir, ch.Name, homeRoot, thumbRoot, user, tx)
+		for _, ch := range children[src.ID] {
+			chops, err := copyNode(ctx, ch, newDir, ch.Name, children, homeRoot, thumbRoot, useBlobs, user, j, tx)
 			if err != nil {
 				return nil, err
 			}
@@ -93,25 +164,64 @@ func Copy(ctx context.Context, src *models.Node, parent *models.Node, filename s
 	return copied, nil
 }
 
-// Copy data to a node from source file.
-func CopyData(ctx context.Context, node *models.Node, sourceFile string, homeRoot string, tx *sql.Tx) error {
-	st, err := os.Stat(sourceFile)
+// Copy data to a node from source file and set the checksum of the node.
+// encrypted tells if the source file is encrypted. If useBlobs is true, the data is stored in the blob store, and costs nothing
+// if the store already has the same data. If the owner of the node has a data
+// key, the data is encrypted with it instead, and not stored in the blob store,
+// where it would be shared with other users.
+func CopyData(ctx context.Context, node *models.Node, sourceFile string, encrypted bool, homeRoot string,
+	useBlobs bool, tx *sql.Tx) error {
+	st, err := storage.Stat(sourceFile, false)
 	if err != nil {
 		return err
 	}
 
-	if !st.Mode().IsRegular() {
+	if st.IsDir {
 		return fmt.Errorf("upload file %s not a regular file", sourceFile)
 	}
 
+	// The node no longer references the blob of its previous contents.
+	if err := SetNodeBlob(ctx, node, null.String{}, tx); err != nil {
+		return err
+	}
+
 	path, err := PhysPath(ctx, node, homeRoot, tx)
 	if err != nil {
 		return core.NewInternalError(err)
 	}
 
-	if err := CopyFile(sourceFile, path); err != nil {
+	key, err := UserDataKey(ctx, node.OwnerID.Int, tx)
+	if err != nil {
+		return err
+	}
+
+	if useBlobs && key == nil && !encrypted {
+		hash, err := StoreBlob(ctx, sourceFile, homeRoot, tx)
+		if err != nil {
+			return core.NewInternalError(err)
+		}
+
+		if err := storage.Remove(path); err != nil && !os.IsNotExist(err) {
+			log.Println(err)
+		}
+
+		if err := SetNodeBlob(ctx, node, null.StringFrom(hash), tx); err != nil {
+			return err
+		}
+		return SetNodeChecksum(ctx, node, null.StringFrom(hash), tx)
+	}
+
+	hash, _, err := HashFile(sourceFile, encrypted)
+	if err != nil {
+		return core.NewInternalError(err)
+	}
+
+	if err := storage.Store(sourceFile, encrypted, path, key); err != nil {
 		return core.NewInternalError(err)
 	}
 
-	return nil
+	if err := SetNodeEncrypted(ctx, node, key != nil, tx); err != nil {
+		return err
+	}
+	return SetNodeChecksum(ctx, node, null.StringFrom(hash), tx)
 }
@@ -11,55 +11,73 @@ import (
 	"github.com/volatiletech/sqlboiler/v4/boil"
 )
 
-// Delete a filesystem node.
+// Delete a filesystem node. The stored files are removed by j when tx is committed.
 func Delete(ctx context.Context, node *models.Node, recursive bool,
-	user *models.User, homeRoot, thumbRoot string, tx boil.ContextExecutor) ([]*models.Node, error) {
-	if !AccessAllowed(user, node, true) {
+	user *models.User, homeRoot, thumbRoot string, j *Journal, tx boil.ContextExecutor) ([]*models.Node, error) {
+	if !AccessAllowed(ctx, user, node, true, tx) {
 		return nil, core.NewSystemError(http.StatusUnauthorized, "", "not allowed")
 	}
 
-	var deleted []*models.Node
-
-	children, err := NodesByParentID(ctx, node.ID, tx)
+	nodes, err := subtreeNodes(ctx, node, tx)
 	if err != nil {
 		return nil, err
 	}
 
-	if children != nil {
-		if recursive {
-			for _, n := range children {
-				dels, err := Delete(ctx, n, true, user, homeRoot, thumbRoot, tx)
-				if err != nil {
-					return nil, err
-				}
+	if len(nodes) > 1 && !recursive {
+		return nil, core.NewSystemError(http.StatusBadRequest, "",
+			"directory not empty and recursive deletion not requested")
+	}
+
+	// The nodes under a directory are deleted before it.
+	var deleted []*models.Node
+	for i := len(nodes) - 1; i >= 0; i-- {
+		n := nodes[i]
+		if n.ID == node.ID {
+			n = node
+		} else if !AccessAllowed(ctx, user, n, true, tx) {
+			return nil, core.NewSystemError(http.StatusUnauthorized, "", "not allowed")
+		}
 
-				deleted = append(deleted, dels...)
-			}
-		} else {
-			return nil, core.NewSystemError(http.StatusBadRequest, "",
-				"directory not empty and recursive deletion not requested")
+		if err := deleteNode(ctx, n, homeRoot, thumbRoot, j, tx); err != nil {
+			return nil, err
 		}
+		deleted = append(deleted, n)
 	}
+	return deleted, nil
+}
 
-	path, err := PhysPath(ctx, node, homeRoot, tx)
+// Deletes a single node and its stored files.
+func deleteNode(ctx context.Context, node *models.Node, homeRoot, thumbRoot string, j *Journal,
+	tx boil.ContextExecutor) error {
+	// Blobs are shared between nodes and removed by the garbage collector
+	// when no longer referenced.
+	blob, err := NodeBlob(ctx, node.ID, tx)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	if err := os.Remove(path); err != nil {
-		log.Println(err)
+	if !blob.Valid {
+		path, err := PhysPath(ctx, node, homeRoot, tx)
+		if err != nil {
+			return err
+		}
+
+		if err := j.Remove(ctx, path, tx); err != nil && !os.IsNotExist(err) {
+			log.Println(err)
+		}
 	}
 
 	// Technically should only try for files.
 	// if node.Type == "file" {
-	if err := os.Remove(ThumbPath(thumbRoot, node.ID, true)); err != nil {
+	if err := j.Remove(ctx, ThumbPath(thumbRoot, node.ID, true), tx); err != nil && !os.IsNotExist(err) {
 		log.Println(err)
 	}
 
-	if _, err = node.Delete(ctx, tx); err != nil {
-		return nil, err
+	// Version rows are removed by the database cascade.
+	if err := j.RemoveAll(ctx, VersionPath(homeRoot, node.ID, 0, false), tx); err != nil && !os.IsNotExist(err) {
+		log.Println(err)
 	}
 
-	deleted = append(deleted, node)
-	return deleted, nil
+	_, err = node.Delete(ctx, tx)
+	return err
 }
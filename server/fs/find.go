@@ -28,15 +28,23 @@ func NodeByID(ctx context.Context, id int, db boil.ContextExecutor) (*models.Nod
 	return node, err
 }
 
+// Returns the columns selected into NodeWithProgress.
+func nodeWithProgressColumns() []string {
+	return []string{"nodes.*", "progress.progress", "progress.volume", takenOnSelect(),
+		"node_process_reqs.state AS proc_state", "node_process_reqs.attempts AS proc_attempts",
+		"node_process_reqs.error AS proc_error"}
+}
+
 // NodeWithProgressByID returns a node with progress information.
 func NodeWithProgressByID(ctx context.Context, nodeID, userID int, db boil.ContextExecutor) (*NodeWithProgress, error) {
 	// NOTE: Current system supports only one progress datum per user-node.
 	// This means that progress for multiple users cannot be returned.
 	var nwm NodeWithProgress
 	err := models.NewQuery(
-		qm.Select("nodes.*", "progress.progress", "progress.volume"),
+		qm.Select(nodeWithProgressColumns()...),
 		qm.From("nodes"),
 		qm.LeftOuterJoin("progress on nodes.id=progress.node_id and progress.user_id=?", userID),
+		qm.LeftOuterJoin("node_process_reqs on nodes.id=node_process_reqs.node_id"),
 		qm.Where("nodes.id=?", nodeID)).Bind(ctx, db, &nwm)
 	return &nwm, err
 }
@@ -53,9 +61,10 @@ func NodesWithProgressByParentID(ctx context.Context, parentID, userID int, db b
 	// This means that progress for  ultiple users cannot be returned.
 	var nwm []*NodeWithProgress
 	err := models.NewQuery(
-		qm.Select("nodes.*", "progress.progress", "progress.volume"),
+		qm.Select(nodeWithProgressColumns()...),
 		qm.From("nodes"),
-		qm.FullOuterJoin("progress on nodes.id=progress.node_id and progress.user_id=?", userID),
+		qm.LeftOuterJoin("progress on nodes.id=progress.node_id and progress.user_id=?", userID),
+		qm.LeftOuterJoin("node_process_reqs on nodes.id=node_process_reqs.node_id"),
 		qm.Where("parent_id=?", parentID)).Bind(ctx, db, &nwm)
 	return nwm, err
 }
@@ -79,27 +88,31 @@ func NodeByPath(ctx context.Context, path string, root *models.Node, tx boil.Con
 		return root, nil
 	}
 
-	node := root
-
-	parts := strings.Split(path, "/")
-	for _, p := range parts {
-		if p == "" {
-			continue
+	var parts []string
+	for _, p := range strings.Split(path, "/") {
+		if p != "" {
+			parts = append(parts, p)
 		}
+	}
 
-		n, err := NodeChildByName(ctx, p, node.ID, tx)
-		if err != nil {
-			return nil, err
-		}
+	if len(parts) == 0 {
+		return root, nil
+	}
 
-		if n != nil {
-			node = n
-		} else {
-			return nil, nil
-		}
+	rootPath, err := nodePath(ctx, root, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := models.Nodes(qm.Where("path=?", rootPath+"/"+strings.Join(parts, "/"))).All(ctx, tx)
+	if err != nil {
+		return nil, err
 	}
 
-	return node, nil
+	if len(n) > 0 {
+		return n[0], nil
+	}
+	return nil, nil
 }
 
 // NodeChildByName returns a child with the given name in the parent node.
@@ -0,0 +1,127 @@
+package fs
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"net/http"
+
+	"github.com/terotoi/koticloud/server/core"
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+// GrantInfo is a grant with the name of the user or group it was given to.
+type GrantInfo struct {
+	models.Grant `boil:",bind"`
+	UserName     null.String `boil:"user_name" json:"user_name"`
+	GroupName    null.String `boil:"group_name" json:"group_name"`
+}
+
+// SharedNode is a node another user has shared with a user.
+type SharedNode struct {
+	models.Node `boil:",bind"`
+	OwnerName   null.String `boil:"owner_name" json:"owner_name"`
+	Write       bool        `boil:"write" json:"write"`
+}
+
+// Only the owner of a node or an admin can manage its grants.
+func canGrant(user *models.User, node *models.Node) bool {
+	return user.Admin || (node.OwnerID.Valid && node.OwnerID.Int == user.ID)
+}
+
+// AddGrant gives a user or a group access to a node. An existing grant
+// for the same user or group is replaced.
+func AddGrant(ctx context.Context, node *models.Node, user *models.User, toUserID, toGroupID null.Int,
+	write bool, tx boil.ContextExecutor) (*models.Grant, error) {
+	if !canGrant(user, node) {
+		return nil, core.NewSystemError(http.StatusUnauthorized, "", "not allowed")
+	}
+
+	if toUserID.Valid == toGroupID.Valid {
+		return nil, core.NewSystemError(http.StatusBadRequest, "", "either a user or a group is required")
+	}
+
+	if toUserID.Valid && node.OwnerID.Valid && toUserID.Int == node.OwnerID.Int {
+		return nil, core.NewSystemError(http.StatusBadRequest, "", "the owner already has access")
+	}
+
+	if inTrash, err := InTrash(ctx, node, tx); err != nil {
+		return nil, err
+	} else if inTrash {
+		return nil, core.NewSystemError(http.StatusBadRequest, "", "nodes in the trash cannot be shared")
+	}
+
+	_, err := queries.Raw("DELETE FROM grants WHERE node_id=$1 AND (user_id=$2 OR group_id=$3)",
+		node.ID, toUserID, toGroupID).ExecContext(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	g := models.Grant{NodeID: node.ID, UserID: toUserID, GroupID: toGroupID, Write: write}
+	err = queries.Raw("INSERT INTO grants (node_id, user_id, group_id, write) "+
+		"VALUES ($1, $2, $3, $4) RETURNING id, created_on",
+		g.NodeID, g.UserID, g.GroupID, g.Write).QueryRowContext(ctx, tx).Scan(&g.ID, &g.CreatedOn)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Grant %d on node %d (%s) added by %s", g.ID, node.ID, node.Name, user.Name)
+	return &g, nil
+}
+
+// GrantByID returns a grant by its ID.
+func GrantByID(ctx context.Context, id int, tx boil.ContextExecutor) (*models.Grant, error) {
+	g, err := models.FindGrant(ctx, tx, id)
+	if err == sql.ErrNoRows {
+		return nil, core.NewSystemError(http.StatusNotFound, "", "grant not found")
+	}
+	return g, err
+}
+
+// GrantsForNode returns the grants given directly on a node.
+func GrantsForNode(ctx context.Context, node *models.Node, user *models.User,
+	tx boil.ContextExecutor) ([]*GrantInfo, error) {
+	if !canGrant(user, node) {
+		return nil, core.NewSystemError(http.StatusUnauthorized, "", "not allowed")
+	}
+
+	grants := []*GrantInfo{}
+	err := queries.Raw("SELECT g.*, u.name AS user_name, gr.name AS group_name FROM grants g "+
+		"LEFT JOIN users u ON g.user_id=u.id LEFT JOIN groups gr ON g.group_id=gr.id "+
+		"WHERE g.node_id=$1 ORDER BY g.id", node.ID).Bind(ctx, tx, &grants)
+	return grants, err
+}
+
+// RemoveGrant deletes a grant. Only the owner of the node or an admin can remove it.
+func RemoveGrant(ctx context.Context, g *models.Grant, user *models.User, tx boil.ContextExecutor) error {
+	node, err := NodeByID(ctx, g.NodeID, tx)
+	if err != nil {
+		return err
+	}
+
+	if !canGrant(user, node) {
+		return core.NewSystemError(http.StatusUnauthorized, "", "not allowed")
+	}
+
+	if _, err := queries.Raw("DELETE FROM grants WHERE id=$1", g.ID).ExecContext(ctx, tx); err != nil {
+		return err
+	}
+
+	log.Printf("Grant %d on node %d (%s) removed by %s", g.ID, node.ID, node.Name, user.Name)
+	return nil
+}
+
+// SharedWithUser returns the nodes other users have shared with a user,
+// directly or through the user's groups. Nodes in the trash are not included.
+func SharedWithUser(ctx context.Context, user *models.User, tx boil.ContextExecutor) ([]*SharedNode, error) {
+	nodes := []*SharedNode{}
+	err := queries.Raw("SELECT n.*, u.name AS owner_name, MAX(CASE WHEN g.write THEN 1 ELSE 0 END)=1 AS write FROM grants g "+
+		"JOIN nodes n ON g.node_id=n.id LEFT JOIN users u ON n.owner_id=u.id "+
+		"WHERE (g.user_id=$1 OR g.group_id IN (SELECT group_id FROM group_members WHERE user_id=$1)) "+
+		"AND (n.owner_id IS NULL OR n.owner_id<>$1) AND n.id NOT IN ("+trashNodesQuery+") "+
+		"GROUP BY n.id, u.name ORDER BY n.name", user.ID).Bind(ctx, tx, &nodes)
+	return nodes, err
+}
@@ -0,0 +1,202 @@
+package fs
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/terotoi/koticloud/server/crypt"
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// Types of info records.
+const (
+	InfoPhoto    = "photo"    // PhotoInfo, from the EXIF data of an image
+	InfoMedia    = "media"    // MediaInfo, from ffprobe
+	InfoDocument = "document" // DocumentInfo, from a PDF
+)
+
+// PhotoInfo contains the EXIF metadata of an image.
+type PhotoInfo struct {
+	Width        int          `json:"width,omitempty"`
+	Height       int          `json:"height,omitempty"`
+	Make         string       `json:"make,omitempty"`
+	Model        string       `json:"model,omitempty"`
+	Lens         string       `json:"lens,omitempty"`
+	TakenOn      null.Time    `json:"taken_on"`
+	Latitude     null.Float64 `json:"latitude"`
+	Longitude    null.Float64 `json:"longitude"`
+	Altitude     null.Float64 `json:"altitude"`
+	ExposureTime string       `json:"exposure_time,omitempty"` // Such as 1/250
+	FNumber      float64      `json:"f_number,omitempty"`
+	FocalLength  float64      `json:"focal_length,omitempty"` // In millimeters
+	ISO          int          `json:"iso,omitempty"`
+	Orientation  int          `json:"orientation,omitempty"`
+}
+
+// MediaStream describes one stream of an audio or video file.
+type MediaStream struct {
+	Type       string  `json:"type"` // video, audio or subtitle
+	Codec      string  `json:"codec"`
+	Width      int     `json:"width,omitempty"`
+	Height     int     `json:"height,omitempty"`
+	FrameRate  float64 `json:"frame_rate,omitempty"`
+	SampleRate int     `json:"sample_rate,omitempty"`
+	Channels   int     `json:"channels,omitempty"`
+	BitRate    int64   `json:"bit_rate,omitempty"`
+	Language   string  `json:"language,omitempty"`
+}
+
+// MediaInfo contains the metadata of an audio or video file.
+type MediaInfo struct {
+	Format   string            `json:"format"`
+	Duration float64           `json:"duration,omitempty"` // In seconds
+	BitRate  int64             `json:"bit_rate,omitempty"`
+	Streams  []MediaStream     `json:"streams"`
+	Tags     map[string]string `json:"tags,omitempty"` // Such as artist, album and title
+}
+
+// DocumentInfo contains the metadata of a document.
+type DocumentInfo struct {
+	Pages     int       `json:"pages"`
+	Title     string    `json:"title,omitempty"`
+	Author    string    `json:"author,omitempty"`
+	CreatedOn null.Time `json:"created_on"`
+}
+
+// encryptedInfo is stored instead of the data of an info record, if the
+// owner of the node has a data key.
+type encryptedInfo struct {
+	Encrypted []byte `json:"encrypted"`
+}
+
+// SetNodeInfo stores an info record of the given type for a node, replacing
+// an earlier record of the same type. The data is encrypted with the data key
+// of the owner of the node, if it has one.
+func SetNodeInfo(ctx context.Context, node *models.Node, infoType string, data interface{},
+	tx boil.ContextExecutor) error {
+	js, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	key, err := UserDataKey(ctx, node.OwnerID.Int, tx)
+	if err != nil {
+		return err
+	}
+	return setNodeInfo(ctx, node, infoType, js, key, tx)
+}
+
+// Stores an info record, encrypted with key unless it is nil. The capture
+// time of a photo is kept in plaintext, so that photos can be sorted by it.
+func setNodeInfo(ctx context.Context, node *models.Node, infoType string, js []byte, key *crypt.Key,
+	tx boil.ContextExecutor) error {
+	var photo PhotoInfo
+	if infoType == InfoPhoto {
+		if err := json.Unmarshal(js, &photo); err != nil {
+			return err
+		}
+	}
+
+	if key != nil {
+		sealed, err := crypt.Seal(key, js)
+		if err != nil {
+			return err
+		}
+
+		if js, err = json.Marshal(&encryptedInfo{Encrypted: sealed}); err != nil {
+			return err
+		}
+	}
+
+	if err := DeleteNodeInfo(ctx, node.ID, infoType, tx); err != nil {
+		return err
+	}
+
+	info := models.Info{NodeID: node.ID, UserID: node.OwnerID.Int, Type: infoType, Data: types.JSON(js)}
+	if photo.TakenOn.Valid {
+		info.TakenOn = null.TimeFrom(photo.TakenOn.Time.UTC())
+	}
+	return info.Insert(ctx, tx, boil.Infer())
+}
+
+// DeleteNodeInfo removes the info records of the given type from a node.
+func DeleteNodeInfo(ctx context.Context, nodeID int, infoType string, tx boil.ContextExecutor) error {
+	_, err := models.Infos(qm.Where("node_id=? AND type=?", nodeID, infoType)).DeleteAll(ctx, tx)
+	return err
+}
+
+// NodeInfos returns the info records of a node by type. Encrypted records
+// are decrypted, and left out if the data key of their owner is locked.
+func NodeInfos(ctx context.Context, nodeID int, tx boil.ContextExecutor) (map[string]types.JSON, error) {
+	infos, err := models.Infos(qm.Where("node_id=?", nodeID), qm.OrderBy("id")).All(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	m := map[string]types.JSON{}
+	for _, info := range infos {
+		var enc encryptedInfo
+		if err := json.Unmarshal(info.Data, &enc); err != nil || enc.Encrypted == nil {
+			m[info.Type] = info.Data
+		} else if data, err := crypt.Open(enc.Encrypted); err == nil {
+			m[info.Type] = types.JSON(data)
+		} else if err != crypt.ErrLocked {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// Encrypts the info records of a node again with key, or stores them as
+// plaintext if key is nil, unless they already are stored that way.
+func reencryptInfos(ctx context.Context, node *models.Node, key *crypt.Key, tx boil.ContextExecutor) error {
+	infos, err := models.Infos(qm.Where("node_id=?", node.ID), qm.OrderBy("id")).All(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	for _, info := range infos {
+		var enc encryptedInfo
+		data := []byte(info.Data)
+		if err := json.Unmarshal(info.Data, &enc); err != nil || enc.Encrypted == nil {
+			if key == nil {
+				continue
+			}
+		} else {
+			if key != nil && crypt.IsEncrypted(enc.Encrypted) {
+				if userID, version := crypt.KeyOf(enc.Encrypted); userID == key.UserID && version == key.Version {
+					continue
+				}
+			}
+
+			if data, err = crypt.Open(enc.Encrypted); err != nil {
+				return err
+			}
+		}
+
+		if err := setNodeInfo(ctx, node, info.Type, data, key, tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Copies the info records of a node to another node.
+func copyNodeInfos(ctx context.Context, src, dst *models.Node, tx boil.ContextExecutor) error {
+	_, err := queries.Raw("INSERT INTO infos (node_id, user_id, type, data, taken_on) "+
+		"SELECT $2, COALESCE($3, user_id), type, data, taken_on FROM infos WHERE node_id=$1",
+		src.ID, dst.ID, dst.OwnerID).ExecContext(ctx, tx)
+	return err
+}
+
+// Selects the capture time of a photo, which is stored beside its info
+// record, since the record may be encrypted.
+func takenOnSelect() string {
+	return "(SELECT infos.taken_on FROM infos WHERE infos.node_id=nodes.id AND infos.type='" + InfoPhoto +
+		"' LIMIT 1) AS taken_on"
+}
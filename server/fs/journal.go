@@ -0,0 +1,364 @@
+package fs
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/terotoi/koticloud/server/storage"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+// Directory under the home root where journal entries are stored. Like
+// blobDir, it cannot clash with home directories.
+const journalDir = ".journal"
+
+// Markers older than this are removed by RecoverJournal even without an entry.
+const journalMarkerMaxAge = 24 * time.Hour
+
+// Journal operations.
+const (
+	journalRename    = "rename"
+	journalCopy      = "copy"
+	journalBackup    = "backup"
+	journalRemove    = "remove"
+	journalRemoveAll = "remove-all"
+)
+
+// A journal entry describes a change to stored files made as part of a
+// database transaction.
+type journalEntry struct {
+	ID  string `json:"id"`
+	Op  string `json:"op"`
+	Src string `json:"src"`
+	Dst string `json:"dst,omitempty"`
+}
+
+// Journal keeps stored files consistent with the database when a
+// transaction changing both fails, or the server crashes in between.
+//
+// Each change is written into an entry file before it is made, and a marker
+// row with the ID of the entry is inserted in the transaction. When the
+// transaction has ended, Finish looks up the markers: renames without a
+// committed marker are undone, copies without one are removed, files backed
+// up without one are restored, and removals with one are done. Removals are
+// delayed until then, so that they never need to be undone. Entries left
+// behind by a crash are handled the same way by RecoverJournal.
+type Journal struct {
+	homeRoot string
+	entries  []*journalEntry
+}
+
+// NewJournal returns a journal for changes made in one transaction.
+func NewJournal(homeRoot string) *Journal {
+	return &Journal{homeRoot: homeRoot}
+}
+
+// Returns the path of the entry file with the given ID.
+func journalPath(homeRoot, id string) string {
+	return fmt.Sprintf("%s/%s/%s.json", homeRoot, journalDir, id)
+}
+
+// Returns a new entry ID. IDs sort in the order they were created in.
+func newJournalID() (string, error) {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%016x-%s", time.Now().UnixNano(), hex.EncodeToString(b)), nil
+}
+
+// Writes an entry file and inserts its marker in tx.
+func (j *Journal) begin(ctx context.Context, op, src, dst string, tx boil.ContextExecutor) (*journalEntry, error) {
+	id, err := newJournalID()
+	if err != nil {
+		return nil, err
+	}
+
+	e := &journalEntry{ID: id, Op: op, Src: src, Dst: dst}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	path := journalPath(j.homeRoot, id)
+	if err := storage.MkdirAll(fmt.Sprintf("%s/%s", j.homeRoot, journalDir)); err != nil {
+		return nil, err
+	}
+
+	if err := storage.WriteAtomic(path, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if _, err := queries.Raw("INSERT INTO journal (id) VALUES ($1)", id).ExecContext(ctx, tx); err != nil {
+		storage.Remove(path)
+		return nil, err
+	}
+	return e, nil
+}
+
+// Discards an entry for a change which was not made.
+func (j *Journal) abort(ctx context.Context, e *journalEntry, tx boil.ContextExecutor) {
+	if _, err := queries.Raw("DELETE FROM journal WHERE id=$1", e.ID).ExecContext(ctx, tx); err != nil {
+		log.Printf("[journal] %s: %s", e.ID, err)
+	}
+	storage.Remove(journalPath(j.homeRoot, e.ID))
+}
+
+// Moves a stored file or directory, copying it if it cannot be renamed.
+func moveFile(src, dst string) error {
+	if err := storage.Rename(src, dst); err != nil {
+		if err = CopyFile(src, dst); err != nil {
+			storage.Remove(dst)
+			return err
+		}
+
+		if err = storage.Remove(src); err != nil {
+			log.Printf("Move: Failed to remove original file: %s", src)
+		}
+	}
+	return nil
+}
+
+// Rename renames a stored file or directory as part of the transaction tx.
+// The file is renamed back if the transaction is not committed.
+func (j *Journal) Rename(ctx context.Context, src, dst string, tx boil.ContextExecutor) error {
+	e, err := j.begin(ctx, journalRename, src, dst, tx)
+	if err != nil {
+		return err
+	}
+
+	if err := moveFile(src, dst); err != nil {
+		j.abort(ctx, e, tx)
+		return err
+	}
+
+	j.entries = append(j.entries, e)
+	return nil
+}
+
+// Copy copies a stored file as part of the transaction tx. The copy is
+// removed if the transaction is not committed.
+func (j *Journal) Copy(ctx context.Context, src, dst string, tx boil.ContextExecutor) error {
+	e, err := j.begin(ctx, journalCopy, src, dst, tx)
+	if err != nil {
+		return err
+	}
+
+	if err := CopyFile(src, dst); err != nil {
+		storage.Remove(dst)
+		j.abort(ctx, e, tx)
+		return err
+	}
+
+	j.entries = append(j.entries, e)
+	return nil
+}
+
+// Backup copies a stored file to backupPath as part of the transaction tx.
+// If the transaction is not committed, the file is restored from the copy,
+// undoing any changes made to it after this.
+//
+// The entry is written after the copy, so that a partial copy is never
+// restored. A crash in between leaves a stray copy behind.
+func (j *Journal) Backup(ctx context.Context, path, backupPath string, tx boil.ContextExecutor) error {
+	if err := CopyFile(path, backupPath); err != nil {
+		storage.Remove(backupPath)
+		return err
+	}
+
+	e, err := j.begin(ctx, journalBackup, path, backupPath, tx)
+	if err != nil {
+		storage.Remove(backupPath)
+		return err
+	}
+
+	j.entries = append(j.entries, e)
+	return nil
+}
+
+// Remove removes a stored file or an empty directory when the transaction tx
+// is committed. The path must not be reused in the same transaction.
+func (j *Journal) Remove(ctx context.Context, path string, tx boil.ContextExecutor) error {
+	return j.remove(ctx, journalRemove, path, tx)
+}
+
+// RemoveAll removes a stored file or a directory with everything under it
+// when the transaction tx is committed. The path must not be reused in the
+// same transaction.
+func (j *Journal) RemoveAll(ctx context.Context, path string, tx boil.ContextExecutor) error {
+	return j.remove(ctx, journalRemoveAll, path, tx)
+}
+
+func (j *Journal) remove(ctx context.Context, op, path string, tx boil.ContextExecutor) error {
+	if _, err := storage.Stat(path, false); err != nil {
+		return err
+	}
+
+	e, err := j.begin(ctx, op, path, "", tx)
+	if err != nil {
+		return err
+	}
+
+	j.entries = append(j.entries, e)
+	return nil
+}
+
+// Finish completes the changes after the transaction has been committed or
+// rolled back. Errors are logged, the entries are left for RecoverJournal.
+func (j *Journal) Finish(db *sql.DB) {
+	finishEntries(context.Background(), j.homeRoot, j.entries, false, db)
+	j.entries = nil
+}
+
+// Completes or undoes the changes of entries, depending on whether their
+// markers have been committed. Renames and backups are undone in reverse order.
+func finishEntries(ctx context.Context, homeRoot string, entries []*journalEntry, recovering bool, db *sql.DB) {
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].Op == journalRename || entries[i].Op == journalBackup {
+			finishEntry(ctx, homeRoot, entries[i], recovering, db)
+		}
+	}
+
+	for _, e := range entries {
+		if e.Op != journalRename && e.Op != journalBackup {
+			finishEntry(ctx, homeRoot, e, recovering, db)
+		}
+	}
+}
+
+func finishEntry(ctx context.Context, homeRoot string, e *journalEntry, recovering bool, db *sql.DB) {
+	committed, err := journalCommitted(ctx, e.ID, recovering, db)
+	if err != nil {
+		log.Printf("[journal] %s: %s", e.ID, err)
+		return
+	}
+
+	switch {
+	case e.Op == journalRename && !committed:
+		// The file may never have been renamed, if the server crashed before that.
+		if _, err := storage.Stat(e.Src, false); !os.IsNotExist(err) {
+			break
+		} else if _, err := storage.Stat(e.Dst, false); err != nil {
+			break
+		}
+
+		log.Printf("[journal] renaming %s back to %s", e.Dst, e.Src)
+		if err := moveFile(e.Dst, e.Src); err != nil {
+			log.Printf("[journal] %s: %s", e.ID, err)
+			return
+		}
+
+	case e.Op == journalCopy && !committed:
+		if err := storage.Remove(e.Dst); err != nil && !os.IsNotExist(err) {
+			log.Printf("[journal] %s: %s", e.ID, err)
+			return
+		}
+
+	case e.Op == journalBackup && !committed:
+		if _, err := storage.Stat(e.Dst, false); err != nil {
+			break
+		}
+
+		log.Printf("[journal] restoring %s from %s", e.Src, e.Dst)
+		if err := moveFile(e.Dst, e.Src); err != nil {
+			log.Printf("[journal] %s: %s", e.ID, err)
+			return
+		}
+
+	case e.Op == journalRemove && committed:
+		if err := storage.Remove(e.Src); err != nil && !os.IsNotExist(err) {
+			log.Println(err)
+		}
+
+	case e.Op == journalRemoveAll && committed:
+		if err := storage.RemoveAll(e.Src); err != nil {
+			log.Println(err)
+		}
+	}
+
+	if err := storage.Remove(journalPath(homeRoot, e.ID)); err != nil && !os.IsNotExist(err) {
+		log.Printf("[journal] %s: %s", e.ID, err)
+		return
+	}
+
+	if _, err := queries.Raw("DELETE FROM journal WHERE id=$1", e.ID).ExecContext(ctx, db); err != nil {
+		log.Printf("[journal] %s: %s", e.ID, err)
+	}
+}
+
+// Tells if the marker of an entry has been committed. When recovering, the
+// entry may belong to a transaction still running in another process. The
+// marker is then inserted instead, which waits for that transaction to end.
+func journalCommitted(ctx context.Context, id string, recovering bool, db *sql.DB) (bool, error) {
+	if !recovering {
+		var found bool
+		err := queries.Raw("SELECT EXISTS(SELECT 1 FROM journal WHERE id=$1)", id).
+			QueryRowContext(ctx, db).Scan(&found)
+		return found, err
+	}
+
+	res, err := queries.Raw("INSERT INTO journal (id) VALUES ($1) ON CONFLICT DO NOTHING", id).
+		ExecContext(ctx, db)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+	return n == 0, err
+}
+
+// RecoverJournal completes or undoes the changes of journal entries left
+// behind by a crash, and removes stale markers. Returns the number of entries.
+func RecoverJournal(ctx context.Context, homeRoot string, db *sql.DB) (int, error) {
+	var entries []*journalEntry
+	root := fmt.Sprintf("%s/%s", homeRoot, journalDir)
+	err := storage.Walk(root, func(path string, info *storage.FileInfo, err error) error {
+		if err != nil {
+			return err
+		} else if info.IsDir || !strings.HasSuffix(path, ".json") {
+			return nil
+		}
+
+		fh, err := storage.Open(path, false)
+		if err != nil {
+			return err
+		}
+		defer fh.Close()
+
+		var e journalEntry
+		if data, err := ioutil.ReadAll(fh); err != nil {
+			return err
+		} else if err := json.Unmarshal(data, &e); err != nil || e.ID == "" {
+			log.Printf("[journal] %s: invalid entry, removed", path)
+			return storage.Remove(path)
+		}
+
+		entries = append(entries, &e)
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return 0, err
+	}
+
+	sort.Slice(entries, func(i, k int) bool { return entries[i].ID < entries[k].ID })
+	finishEntries(ctx, homeRoot, entries, true, db)
+
+	_, err = queries.Raw("DELETE FROM journal WHERE created_on < $1", time.Now().Add(-journalMarkerMaxAge)).
+		ExecContext(ctx, db)
+	return len(entries), err
+}
@@ -0,0 +1,237 @@
+package fs
+
+import (
+	"context"
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/terotoi/koticloud/server/database"
+	"github.com/terotoi/koticloud/server/migrate"
+)
+
+// Returns a scratch SQLite database with the current schema.
+func openTestDB(tb testing.TB) *sql.DB {
+	db, err := database.Open("sqlite:" + filepath.Join(tb.TempDir(), "test.db"))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() { db.Close() })
+
+	if _, err := migrate.Up(context.Background(), db); err != nil {
+		tb.Fatal(err)
+	}
+	return db
+}
+
+func writeTestFile(t *testing.T, path, data string) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	} else if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Checks that a file has the given contents, or does not exist if data is empty.
+func checkTestFile(t *testing.T, path, data string) {
+	t.Helper()
+	b, err := ioutil.ReadFile(path)
+	if data == "" {
+		if !os.IsNotExist(err) {
+			t.Errorf("%s exists", filepath.Base(path))
+		}
+	} else if err != nil {
+		t.Error(err)
+	} else if string(b) != data {
+		t.Errorf("%s contains %q, want %q", filepath.Base(path), b, data)
+	}
+}
+
+// Checks that no entries or markers are left in the journal.
+func checkJournalEmpty(t *testing.T, homeRoot string, db *sql.DB) {
+	t.Helper()
+	files, _ := ioutil.ReadDir(filepath.Join(homeRoot, journalDir))
+	if len(files) > 0 {
+		t.Errorf("%d journal entries left", len(files))
+	}
+
+	var markers int
+	if err := db.QueryRow("SELECT COUNT(*) FROM journal").Scan(&markers); err != nil {
+		t.Fatal(err)
+	} else if markers > 0 {
+		t.Errorf("%d journal markers left", markers)
+	}
+}
+
+// Runs change in a transaction, which is committed if commit is true, and
+// finishes the journal.
+func withTestJournal(t *testing.T, homeRoot string, commit bool, db *sql.DB,
+	change func(j *Journal, tx *sql.Tx) error) {
+	j := NewJournal(homeRoot)
+	defer j.Finish(db)
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+
+	if err := change(j, tx); err != nil {
+		t.Fatal(err)
+	}
+
+	if commit {
+		if err := tx.Commit(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestJournal(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+
+	for _, c := range []struct {
+		name   string
+		commit bool
+		change func(j *Journal, a, b string, tx *sql.Tx) error
+		a, b   string // Contents of the files afterwards
+	}{
+		{"rename", true, func(j *Journal, a, b string, tx *sql.Tx) error {
+			return j.Rename(ctx, a, b, tx)
+		}, "", "a"},
+		{"rename rolled back", false, func(j *Journal, a, b string, tx *sql.Tx) error {
+			return j.Rename(ctx, a, b, tx)
+		}, "a", ""},
+		{"copy", true, func(j *Journal, a, b string, tx *sql.Tx) error {
+			return j.Copy(ctx, a, b, tx)
+		}, "a", "a"},
+		{"copy rolled back", false, func(j *Journal, a, b string, tx *sql.Tx) error {
+			return j.Copy(ctx, a, b, tx)
+		}, "a", ""},
+		{"backup", true, func(j *Journal, a, b string, tx *sql.Tx) error {
+			if err := j.Backup(ctx, a, b, tx); err != nil {
+				return err
+			}
+			return ioutil.WriteFile(a, []byte("changed"), 0644)
+		}, "changed", "a"},
+		{"backup rolled back", false, func(j *Journal, a, b string, tx *sql.Tx) error {
+			if err := j.Backup(ctx, a, b, tx); err != nil {
+				return err
+			}
+			return ioutil.WriteFile(a, []byte("changed"), 0644)
+		}, "a", ""},
+		{"remove", true, func(j *Journal, a, b string, tx *sql.Tx) error {
+			return j.Remove(ctx, a, tx)
+		}, "", ""},
+		{"remove rolled back", false, func(j *Journal, a, b string, tx *sql.Tx) error {
+			return j.Remove(ctx, a, tx)
+		}, "a", ""},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			homeRoot := t.TempDir()
+			a, b := filepath.Join(homeRoot, "user", "a"), filepath.Join(homeRoot, "user", "b")
+			writeTestFile(t, a, "a")
+
+			withTestJournal(t, homeRoot, c.commit, db, func(j *Journal, tx *sql.Tx) error {
+				return c.change(j, a, b, tx)
+			})
+
+			checkTestFile(t, a, c.a)
+			checkTestFile(t, b, c.b)
+			checkJournalEmpty(t, homeRoot, db)
+		})
+	}
+}
+
+// Renames are undone in reverse order, so that a file renamed twice ends up
+// where it was.
+func TestJournalRenameTwice(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	homeRoot := t.TempDir()
+	a, b, c := filepath.Join(homeRoot, "a"), filepath.Join(homeRoot, "b"), filepath.Join(homeRoot, "c")
+	writeTestFile(t, a, "a")
+
+	withTestJournal(t, homeRoot, false, db, func(j *Journal, tx *sql.Tx) error {
+		if err := j.Rename(ctx, a, b, tx); err != nil {
+			return err
+		}
+		return j.Rename(ctx, b, c, tx)
+	})
+
+	checkTestFile(t, a, "a")
+	checkTestFile(t, b, "")
+	checkTestFile(t, c, "")
+	checkJournalEmpty(t, homeRoot, db)
+}
+
+func TestJournalRemoveAll(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	homeRoot := t.TempDir()
+	dir := filepath.Join(homeRoot, "dir")
+	writeTestFile(t, filepath.Join(dir, "sub", "a"), "a")
+
+	withTestJournal(t, homeRoot, true, db, func(j *Journal, tx *sql.Tx) error {
+		return j.RemoveAll(ctx, dir, tx)
+	})
+
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Error("directory not removed")
+	}
+	checkJournalEmpty(t, homeRoot, db)
+
+	if err := NewJournal(homeRoot).Remove(ctx, dir, db); !os.IsNotExist(err) {
+		t.Errorf("removing a missing file: %v", err)
+	}
+}
+
+// Entries left behind by a crash are completed or undone by RecoverJournal.
+func TestRecoverJournal(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	homeRoot := t.TempDir()
+	a, b := filepath.Join(homeRoot, "a"), filepath.Join(homeRoot, "b")
+	removed := filepath.Join(homeRoot, "removed")
+	writeTestFile(t, a, "a")
+	writeTestFile(t, removed, "removed")
+
+	// Finish is not called, as if the server crashed.
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := NewJournal(homeRoot).Remove(ctx, removed, tx); err != nil {
+		t.Fatal(err)
+	} else if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err = db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := NewJournal(homeRoot).Rename(ctx, a, b, tx); err != nil {
+		t.Fatal(err)
+	} else if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+
+	// An invalid entry is removed.
+	writeTestFile(t, journalPath(homeRoot, "invalid"), "{")
+
+	n, err := RecoverJournal(ctx, homeRoot, db)
+	if err != nil {
+		t.Fatal(err)
+	} else if n != 2 {
+		t.Errorf("recovered %d entries, want 2", n)
+	}
+
+	checkTestFile(t, a, "a")
+	checkTestFile(t, b, "")
+	checkTestFile(t, removed, "")
+	checkJournalEmpty(t, homeRoot, db)
+}
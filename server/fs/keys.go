@@ -0,0 +1,402 @@
+package fs
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/terotoi/koticloud/server/core"
+	"github.com/terotoi/koticloud/server/crypt"
+	"github.com/terotoi/koticloud/server/database"
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/terotoi/koticloud/server/storage"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// UserKeys returns the stored data keys of a user, oldest first.
+func UserKeys(ctx context.Context, userID int, tx boil.ContextExecutor) ([]*models.UserKey, error) {
+	return models.UserKeys(qm.Where("user_id=?", userID), qm.OrderBy("version")).All(ctx, tx)
+}
+
+// KeyLoader returns a function loading data keys wrapped by the master key
+// from the database, for crypt.SetLoader.
+func KeyLoader(db *sql.DB) func(userID, version int) (*crypt.Key, error) {
+	return func(userID, version int) (*crypt.Key, error) {
+		k, err := models.FindUserKey(context.Background(), db, userID, version)
+		if err != nil {
+			return nil, err
+		} else if k.Salt.Valid {
+			return nil, crypt.ErrLocked
+		}
+		return crypt.UnwrapKey(k.WrappedKey, crypt.MasterKey(), userID, version)
+	}
+}
+
+// LockDataKey locks the data key of a user until the transaction tx ends.
+// Writers take a shared lock, which UserDataKey does, so that the key they
+// encrypt with stays current until they have committed. Key rotation takes
+// an exclusive lock, which waits for the writers and keeps out new ones.
+// Transactions on SQLite are serialized, so nothing needs to be locked.
+func LockDataKey(ctx context.Context, userID int, exclusive bool, tx boil.ContextExecutor) error {
+	if database.IsSQLite() {
+		return nil
+	}
+
+	// A key share lock does not block other updates of the user.
+	lock := "FOR KEY SHARE"
+	if exclusive {
+		lock = "FOR UPDATE"
+	}
+	_, err := queries.Raw("SELECT id FROM users WHERE id=$1 "+lock, userID).ExecContext(ctx, tx)
+	return err
+}
+
+// UserDataKey returns the current data key of a user, for encrypting new
+// files. Returns nil if encryption is not enabled or the user has no key.
+// The key is locked with LockDataKey until tx ends.
+func UserDataKey(ctx context.Context, userID int, tx boil.ContextExecutor) (*crypt.Key, error) {
+	if !crypt.Enabled() {
+		return nil, nil
+	}
+
+	if err := LockDataKey(ctx, userID, false, tx); err != nil {
+		return nil, err
+	}
+
+	var version null.Int
+	if err := queries.Raw("SELECT max(version) FROM user_keys WHERE user_id=$1", userID).
+		QueryRowContext(ctx, tx).Scan(&version); err != nil {
+		return nil, err
+	} else if !version.Valid {
+		return nil, nil
+	}
+	return crypt.Lookup(userID, version.Int)
+}
+
+// CreateUserKey creates a new version of the data key of a user, wrapped by
+// the master key.
+func CreateUserKey(ctx context.Context, userID int, tx boil.ContextExecutor) (*crypt.Key, error) {
+	var version int
+	if err := queries.Raw("SELECT COALESCE(max(version), 0)+1 FROM user_keys WHERE user_id=$1", userID).
+		QueryRowContext(ctx, tx).Scan(&version); err != nil {
+		return nil, err
+	}
+
+	key, err := crypt.NewKey(userID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapped, err := crypt.WrapKey(key, crypt.MasterKey())
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = queries.Raw("INSERT INTO user_keys (user_id, version, wrapped_key) VALUES ($1, $2, $3)",
+		userID, version, wrapped).ExecContext(ctx, tx)
+	return key, err
+}
+
+// EnsureUserKeys creates a data key for each user without one.
+// Returns the number of created keys.
+func EnsureUserKeys(ctx context.Context, tx boil.ContextExecutor) (int, error) {
+	var ids []int
+	rows, err := queries.Raw("SELECT id FROM users WHERE id NOT IN (SELECT user_id FROM user_keys)").
+		QueryContext(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+
+	for _, id := range ids {
+		if _, err := CreateUserKey(ctx, id, tx); err != nil {
+			return 0, err
+		}
+	}
+	return len(ids), nil
+}
+
+// DeleteUserKeysBefore removes the versions of the data key of a user older
+// than version. No file may be encrypted with them anymore.
+func DeleteUserKeysBefore(ctx context.Context, userID, version int, tx boil.ContextExecutor) error {
+	_, err := queries.Raw("DELETE FROM user_keys WHERE user_id=$1 AND version<$2", userID, version).
+		ExecContext(ctx, tx)
+	return err
+}
+
+// UnlockUserKeys unwraps the data keys of a user protected by a passphrase,
+// and adds them into the keyring until the server is restarted or LockUserKeys is called.
+func UnlockUserKeys(ctx context.Context, userID int, passphrase string, tx boil.ContextExecutor) error {
+	keys, err := UserKeys(ctx, userID, tx)
+	if err != nil {
+		return err
+	}
+
+	for _, k := range keys {
+		if !k.Salt.Valid {
+			continue
+		}
+
+		kek, err := crypt.PassphraseKey(passphrase, k.Salt.Bytes)
+		if err != nil {
+			return err
+		}
+
+		key, err := crypt.UnwrapKey(k.WrappedKey, kek, userID, k.Version)
+		if err != nil {
+			return core.NewSystemError(http.StatusForbidden, "wrong passphrase", "wrong passphrase")
+		}
+		crypt.Remember(key)
+	}
+	return nil
+}
+
+// LockUserKeys removes the data keys of a user protected by a passphrase from the keyring.
+func LockUserKeys(userID int) {
+	crypt.Forget(userID)
+}
+
+// SetKeyPassphrase wraps all data keys of a user with a key derived from a
+// passphrase, or with the master key if the passphrase is empty. Keys already
+// protected by a passphrase must be unlocked first.
+func SetKeyPassphrase(ctx context.Context, userID int, passphrase string, tx boil.ContextExecutor) error {
+	keys, err := UserKeys(ctx, userID, tx)
+	if err != nil {
+		return err
+	} else if len(keys) == 0 || !crypt.Enabled() {
+		return core.NewSystemError(http.StatusBadRequest, "user has no encryption key",
+			"encryption is not enabled")
+	}
+
+	kek := crypt.MasterKey()
+	var salt null.Bytes
+	if passphrase != "" {
+		s, err := crypt.NewSalt()
+		if err != nil {
+			return err
+		}
+
+		if kek, err = crypt.PassphraseKey(passphrase, s); err != nil {
+			return err
+		}
+		salt = null.BytesFrom(s)
+	}
+
+	for _, k := range keys {
+		key, err := crypt.Lookup(userID, k.Version)
+		if err != nil {
+			return err
+		}
+
+		wrapped, err := crypt.WrapKey(key, kek)
+		if err != nil {
+			return err
+		}
+
+		if _, err := queries.Raw("UPDATE user_keys SET wrapped_key=$3, salt=$4 WHERE user_id=$1 AND version=$2",
+			userID, k.Version, wrapped, salt).ExecContext(ctx, tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RewrapKeys wraps the data keys wrapped by an old master key with the
+// current master key. Returns the number of rewrapped keys.
+func RewrapKeys(ctx context.Context, oldMaster []byte, tx boil.ContextExecutor) (int, error) {
+	keys, err := models.UserKeys(qm.Where("salt IS NULL")).All(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, k := range keys {
+		key, err := crypt.UnwrapKey(k.WrappedKey, oldMaster, k.UserID, k.Version)
+		if err != nil {
+			return 0, err
+		}
+
+		wrapped, err := crypt.WrapKey(key, crypt.MasterKey())
+		if err != nil {
+			return 0, err
+		}
+
+		if _, err := queries.Raw("UPDATE user_keys SET wrapped_key=$3 WHERE user_id=$1 AND version=$2",
+			k.UserID, k.Version, wrapped).ExecContext(ctx, tx); err != nil {
+			return 0, err
+		}
+	}
+	return len(keys), nil
+}
+
+// NodeEncrypted tells if the stored contents and the custom thumbnail of a
+// node are encrypted.
+func NodeEncrypted(ctx context.Context, nodeID int, tx boil.ContextExecutor) (contents, thumb bool, err error) {
+	err = queries.Raw("SELECT encrypted, thumb_encrypted FROM nodes WHERE id=$1", nodeID).
+		QueryRowContext(ctx, tx).Scan(&contents, &thumb)
+	return contents, thumb, err
+}
+
+// SetNodeEncrypted records whether the stored contents of a node are encrypted.
+func SetNodeEncrypted(ctx context.Context, node *models.Node, encrypted bool, tx boil.ContextExecutor) error {
+	if _, err := queries.Raw("UPDATE nodes SET encrypted=$2 WHERE id=$1", node.ID, encrypted).
+		ExecContext(ctx, tx); err != nil {
+		return err
+	}
+	node.Encrypted = encrypted
+	return nil
+}
+
+// SetThumbEncrypted records whether the custom thumbnail of a node is encrypted.
+func SetThumbEncrypted(ctx context.Context, node *models.Node, encrypted bool, tx boil.ContextExecutor) error {
+	if _, err := queries.Raw("UPDATE nodes SET thumb_encrypted=$2 WHERE id=$1", node.ID, encrypted).
+		ExecContext(ctx, tx); err != nil {
+		return err
+	}
+	node.ThumbEncrypted = encrypted
+	return nil
+}
+
+// ReencryptFile encrypts a stored file again with key, or stores it as
+// plaintext if key is nil, unless it already is encrypted that way.
+// encrypted tells if the file is encrypted now. Returns true if the file
+// was re-encrypted.
+func ReencryptFile(path string, encrypted bool, key *crypt.Key) (bool, error) {
+	if key == nil && !encrypted {
+		return false, nil
+	} else if key != nil && encrypted {
+		userID, version, err := storage.KeyOf(path)
+		if err != nil {
+			return false, err
+		} else if userID == key.UserID && version == key.Version {
+			return false, nil
+		}
+	}
+
+	tmp := path + ".rekey"
+	if err := storage.Store(path, encrypted, tmp, key); err != nil {
+		storage.Remove(tmp)
+		return false, err
+	}
+
+	if err := storage.Rename(tmp, path); err != nil {
+		storage.Remove(tmp)
+		return false, err
+	}
+	return true, nil
+}
+
+// ReencryptNode encrypts the contents, versions, custom thumbnail and info
+// records of a file node again with the current data key of its owner, key,
+// or stores them as plaintext if key is nil. Contents in the blob store are
+// left as they are, since they may be shared with other users.
+// Returns the number of re-encrypted files.
+func ReencryptNode(ctx context.Context, node *models.Node, key *crypt.Key, homeRoot, thumbRoot string,
+	tx boil.ContextExecutor) (int, error) {
+	count := 0
+	reencrypt := func(path string, encrypted bool) (bool, error) {
+		ok, err := ReencryptFile(path, encrypted, key)
+		if os.IsNotExist(err) {
+			return false, nil
+		} else if err != nil {
+			return false, fmt.Errorf("%s: %w", path, err)
+		} else if ok {
+			count++
+		}
+		return ok, nil
+	}
+
+	encrypted, thumbEncrypted, err := NodeEncrypted(ctx, node.ID, tx)
+	if err != nil {
+		return count, err
+	}
+
+	if blob, err := NodeBlob(ctx, node.ID, tx); err != nil {
+		return count, err
+	} else if !blob.Valid {
+		path, err := PhysPath(ctx, node, homeRoot, tx)
+		if err != nil {
+			return count, err
+		}
+
+		if ok, err := reencrypt(path, encrypted); err != nil {
+			return count, err
+		} else if ok {
+			if err := SetNodeEncrypted(ctx, node, key != nil, tx); err != nil {
+				return count, err
+			}
+		}
+	}
+
+	if node.HasCustomThumb {
+		if ok, err := reencrypt(ThumbPath(thumbRoot, node.ID, true), thumbEncrypted); err != nil {
+			return count, err
+		} else if ok {
+			if err := SetThumbEncrypted(ctx, node, key != nil, tx); err != nil {
+				return count, err
+			}
+		}
+	}
+
+	versions, err := Versions(ctx, node.ID, tx)
+	if err != nil {
+		return count, err
+	}
+
+	for _, v := range versions {
+		if v.Blob.Valid {
+			continue
+		}
+
+		if ok, err := reencrypt(VersionPath(homeRoot, node.ID, v.ID, true), v.Encrypted); err != nil {
+			return count, err
+		} else if ok {
+			v.Encrypted = key != nil
+			if _, err := v.Update(ctx, tx, boil.Whitelist(models.VersionColumns.Encrypted)); err != nil {
+				return count, err
+			}
+		}
+	}
+	return count, reencryptInfos(ctx, node, key, tx)
+}
+
+// Encrypts the files under a node again with the current data key of their
+// owner. Files that are already encrypted stay readable if tx is not
+// committed, since all keys are kept in the database.
+func reencryptSubtree(ctx context.Context, node *models.Node, homeRoot, thumbRoot string,
+	tx boil.ContextExecutor) error {
+	if !crypt.Enabled() {
+		return nil
+	}
+
+	key, err := UserDataKey(ctx, node.OwnerID.Int, tx)
+	if err != nil {
+		return err
+	}
+
+	var nodes []*models.Node
+	if err := queries.Raw("SELECT * FROM nodes WHERE type='file' AND id IN ("+subtreeQuery("$1", true)+")",
+		node.ID).Bind(ctx, tx, &nodes); err != nil {
+		return err
+	}
+
+	for _, n := range nodes {
+		if _, err := ReencryptNode(ctx, n, key, homeRoot, thumbRoot, tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -8,17 +8,19 @@ import (
 
 	"github.com/terotoi/koticloud/server/core"
 	"github.com/terotoi/koticloud/server/models"
+	"github.com/terotoi/koticloud/server/storage"
 	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 )
 
-// MakeDir creates a filesystem directory.
+// MakeDir creates a filesystem directory. The directory is owned by the owner
+// of the parent directory, or by the user if there is no parent.
 func MakeDir(ctx context.Context, parent *models.Node, filename string,
 	user *models.User, homeRoot string, dontCreatePhys bool,
 	tx boil.ContextExecutor) (*models.Node, error) {
 
 	if parent != nil {
-		if !AccessAllowed(user, parent, false) {
+		if !AccessAllowed(ctx, user, parent, true, tx) {
 			return nil, core.NewSystemError(http.StatusUnauthorized, "", "not allowed")
 		}
 	}
@@ -26,7 +28,7 @@ func MakeDir(ctx context.Context, parent *models.Node, filename string,
 	node := models.Node{Name: filename,
 		Type:     "directory",
 		MimeType: "inode/directory",
-		OwnerID:  null.Int{Int: user.ID, Valid: true},
+		OwnerID:  ownerFor(parent, user),
 	}
 
 	if parent != nil {
@@ -38,11 +40,16 @@ func MakeDir(ctx context.Context, parent *models.Node, filename string,
 		return nil, err
 	}
 
+	// A directory created here is removed if the node cannot be inserted.
+	created := false
 	if !dontCreatePhys {
-		log.Printf("Creating physical directory %s", path)
+		if _, err := storage.Stat(path, false); os.IsNotExist(err) {
+			log.Printf("Creating physical directory %s", path)
 
-		if err := os.MkdirAll(path, 0700); err != nil && !os.IsNotExist(err) {
-			return nil, err
+			if err := storage.MkdirAll(path); err != nil && !os.IsNotExist(err) {
+				return nil, err
+			}
+			created = true
 		}
 	}
 
@@ -53,8 +60,10 @@ func MakeDir(ctx context.Context, parent *models.Node, filename string,
 		}*/
 
 	if err = node.Insert(ctx, tx, boil.Infer()); err != nil {
-		os.Remove(path)
-		return nil, err
+		if created {
+			storage.Remove(path)
+		}
+		return nil, treeError(err, filename)
 	}
 
 	return &node, nil
@@ -3,24 +3,28 @@ package fs
 import (
 	"context"
 	"database/sql"
-	"log"
 	"net/http"
-	"os"
 
 	"github.com/terotoi/koticloud/server/core"
 	"github.com/terotoi/koticloud/server/models"
 	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
 )
 
 // Move a node src under the dest directory node.
+// If dest is owned by another user, the ownership of the moved nodes is transferred,
+// which fails if it would exceed the quota of that user. Only the owner of the
+// nodes or an admin may transfer them, and their stored files are encrypted
+// again for the new owner. The stored files are moved back by j if tx is not
+// committed.
 func Move(ctx context.Context, node *models.Node, dest *models.Node,
-	user *models.User, homeRoot string, tx *sql.Tx) error {
-	if !AccessAllowed(user, node, false) {
+	user *models.User, homeRoot, thumbRoot string, j *Journal, tx *sql.Tx) error {
+	if !AccessAllowed(ctx, user, node, true, tx) {
 		return core.NewSystemError(http.StatusUnauthorized, "", "not allowed")
 	}
 
-	if !AccessAllowed(user, dest, false) {
+	if !AccessAllowed(ctx, user, dest, true, tx) {
 		return core.NewSystemError(http.StatusUnauthorized, "", "not allowed")
 	}
 
@@ -28,30 +32,60 @@ func Move(ctx context.Context, node *models.Node, dest *models.Node,
 		return core.NewSystemError(http.StatusUnauthorized, "", "destination is not a directory")
 	}
 
+	var cycle bool
+	if err := queries.Raw("SELECT EXISTS("+subtreeQuery("$1", true)+" AND s.id=$2)", node.ID, dest.ID).
+		QueryRowContext(ctx, tx).Scan(&cycle); err != nil {
+		return err
+	} else if cycle {
+		return core.NewSystemError(http.StatusBadRequest, "", "a directory cannot be moved under itself")
+	}
+
+	if dest.OwnerID.Valid && dest.OwnerID != node.OwnerID {
+		// Write access to the nodes of another user does not allow taking them over.
+		if !user.Admin && !(node.OwnerID.Valid && node.OwnerID.Int == user.ID) {
+			return core.NewSystemError(http.StatusUnauthorized, "",
+				"files of another user cannot be moved to a different owner")
+		}
+
+		size, err := SubtreeSize(ctx, node, tx)
+		if err != nil {
+			return err
+		}
+
+		if err := CheckQuota(ctx, dest, user, size, tx); err != nil {
+			return err
+		}
+	}
+
 	srcPath, err := PhysPath(ctx, node, homeRoot, tx)
 	if err != nil {
 		return err
 	}
 	node.ParentID = null.Int{Int: dest.ID, Valid: true}
+
+	dstPath, err := PhysPath(ctx, node, homeRoot, tx)
 	if err != nil {
 		return err
 	}
 
-	dstPath, err := PhysPath(ctx, node, homeRoot, tx)
-
-	if err := os.Rename(srcPath, dstPath); err != nil {
-		if err = CopyFile(srcPath, dstPath); err != nil {
-			os.Remove(dstPath)
+	// Contents in the blob store do not move with the node.
+	if srcPath != dstPath {
+		if err := j.Rename(ctx, srcPath, dstPath, tx); err != nil {
 			return err
 		}
+	}
 
-		if err = os.Remove(srcPath); err != nil {
-			log.Printf("Move: Failed to remove original file: %s", srcPath)
-		}
+	if _, err := node.Update(ctx, tx, boil.Whitelist(models.NodeColumns.ParentID)); err != nil {
+		return treeError(err, node.Name)
 	}
 
-	if _, err := node.Update(ctx, tx, boil.Infer()); err != nil {
-		return err
+	// Nodes moved into a directory of another user are owned by that user.
+	if dest.OwnerID.Valid && dest.OwnerID != node.OwnerID {
+		if err := setSubtreeOwner(ctx, node, dest.OwnerID, tx); err != nil {
+			return err
+		} else if err := reencryptSubtree(ctx, node, homeRoot, thumbRoot, tx); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -12,6 +12,8 @@ import (
 	"github.com/volatiletech/sqlboiler/v4/boil"
 )
 
+// NewFile creates a file node. The node is owned by the owner of the parent
+// directory, which is not necessarily the user creating it.
 func NewFile(ctx context.Context, parent *models.Node, filename,
 	mimeType string, size int64, owner *models.User,
 	length *float64, hasCustomThumb bool, tx boil.ContextExecutor) (*models.Node, error) {
@@ -37,7 +39,7 @@ func NewFile(ctx context.Context, parent *models.Node, filename,
 	node.MimeType = mimeType
 	node.Size = null.Int64{Int64: size, Valid: true}
 	node.ParentID = null.Int{Int: parent.ID, Valid: true}
-	node.OwnerID = null.Int{Int: owner.ID, Valid: true}
+	node.OwnerID = ownerFor(parent, owner)
 	node.HasCustomThumb = hasCustomThumb
 	node.ModifiedOn = time.Now()
 
@@ -46,27 +48,32 @@ func NewFile(ctx context.Context, parent *models.Node, filename,
 	}
 
 	if err := node.Insert(ctx, tx, boil.Infer()); err != nil {
-		return nil, err
+		return nil, treeError(err, filename)
 	}
 
 	return &node, nil
 }
 
-// Update data on an existing node. Does not update the filename or parent ID.
+// Update data on an existing node. Does not update the filename, parent ID or
+// the owner, unless the node has none.
 func UpdateFile(ctx context.Context, node *models.Node,
 	mimeType string, size int64, owner *models.User,
 	length *float64, hasCustomThumb bool, tx boil.ContextExecutor) error {
 
 	node.MimeType = mimeType
 	node.Size = null.Int64{Int64: size, Valid: true}
-	node.OwnerID = null.Int{Int: owner.ID, Valid: true}
+	if !node.OwnerID.Valid {
+		node.OwnerID = null.Int{Int: owner.ID, Valid: true}
+	}
 	node.HasCustomThumb = hasCustomThumb
 	node.ModifiedOn = time.Now()
 	if length != nil {
 		node.Length = null.Float64{Float64: *length, Valid: true}
 	}
 
-	if _, err := node.Update(ctx, tx, boil.Infer()); err != nil {
+	if _, err := node.Update(ctx, tx, boil.Whitelist(models.NodeColumns.MimeType, models.NodeColumns.Size,
+		models.NodeColumns.OwnerID, models.NodeColumns.HasCustomThumb, models.NodeColumns.ModifiedOn,
+		models.NodeColumns.Length)); err != nil {
 		return err
 	}
 
@@ -2,78 +2,129 @@ package fs
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"strings"
 
 	"github.com/terotoi/koticloud/server/models"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
 )
 
+// Returns a query selecting the IDs of all nodes under the nodes with the
+// IDs selected by roots, which may be a placeholder such as "$1" or "?", or a
+// subquery. If self is true, the root nodes themselves are included. Nodes
+// under a node are found by its materialized path with a single index scan.
+func subtreeQuery(roots string, self bool) string {
+	cond := "s.path > r.path || '/' AND s.path < r.path || '0'"
+	if self {
+		cond = "(s.id=r.id OR " + cond + ")"
+	}
+	return "SELECT s.id FROM nodes r JOIN nodes s ON " + cond + " WHERE r.id IN (" + roots + ")"
+}
+
+// SubtreeClause returns a query condition matching the node with the ID given
+// as the parameter and all nodes under it.
+func SubtreeClause() string {
+	return "id IN (" + subtreeQuery("?", true) + ")"
+}
+
+// Returns the materialized path of a node: the names of the node and the
+// nodes above it starting from its root, each preceded by a slash. The path
+// is derived from the parent, so that it is also correct for a node renamed
+// or moved in memory, but not yet updated in the database.
+func nodePath(ctx context.Context, node *models.Node, tx boil.ContextExecutor) (string, error) {
+	if !node.ParentID.Valid {
+		return "/" + node.Name, nil
+	}
+
+	var path string
+	err := queries.Raw("SELECT path FROM nodes WHERE id=$1", node.ParentID.Int).
+		QueryRowContext(ctx, tx).Scan(&path)
+	return path + "/" + node.Name, err
+}
+
 // PathFor returns a full path for a node.
 func PathFor(ctx context.Context, node *models.Node, tx boil.ContextExecutor) (string, error) {
-	path := []string{}
+	path, err := nodePath(ctx, node, tx)
+	if err != nil {
+		return "", err
+	}
 
-	var err error
-	for node.ParentID.Valid {
-		path = append(path, node.Name)
+	// The path is relative to the root, which is not included.
+	if i := strings.Index(path[1:], "/"); i >= 0 {
+		return path[i+1:], nil
+	}
+	return "/", nil
+}
 
-		node, err = models.FindNode(ctx, tx, node.ParentID.Int)
-		if err != nil {
+// PhysPath returns full path for a node. For files stored in the blob store,
+// the path of the blob is returned.
+func PhysPath(ctx context.Context, node *models.Node, homeRoot string, tx boil.ContextExecutor) (string, error) {
+	if !IsDir(node) && node.ID != 0 {
+		hash, err := NodeBlob(ctx, node.ID, tx)
+		if err != nil && err != sql.ErrNoRows {
 			return "", err
+		} else if hash.Valid {
+			return BlobPath(homeRoot, hash.String), nil
 		}
 	}
 
-	if len(path) == 0 {
-		return "/", nil
-	} else {
-		path = append(path, "")
-
-		// Reverse the list
-		for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
-			path[i], path[j] = path[j], path[i]
-		}
+	path, err := nodePath(ctx, node, tx)
+	if err != nil {
+		return "", err
 	}
+	return strings.TrimSuffix(homeRoot, "/") + path, nil
+}
 
-	return strings.Join(path, "/"), nil
+// NodeWithPath contains models.Node data and the physical path of the node.
+type NodeWithPath struct {
+	models.Node `boil:",bind"`
+	Path        string      `boil:"path"`
+	Blob        null.String `boil:"blob"`
 }
 
-// PhysPath returns full path for a node. It queries nodes recursive upwards until
-// it finds a stored path or ends up at the root.
-func PhysPath(ctx context.Context, node *models.Node, homeRoot string, tx boil.ContextExecutor) (string, error) {
-	path := homeRoot
-
-	var names []string
-	for n := node; n != nil; {
-		// Found a stored path, use it as root and stop recursing upwards.
-		/*
-			if n.Path.Valid && n.Path.String != "" {
-				path = n.Path.String
-				break
-			}*/
-
-		names = append(names, n.Name)
-		if n.ParentID.Valid {
-			if parent, err := NodeByID(ctx, n.ParentID.Int, tx); err != nil {
-				return "", err
-			} else {
-				n = parent
-			}
-		} else {
-			n = nil
-		}
+// NodesWithPaths returns all nodes with their physical paths, ordered by path.
+func NodesWithPaths(ctx context.Context, homeRoot string, tx boil.ContextExecutor) ([]*NodeWithPath, error) {
+	var nodes []*NodeWithPath
+	if err := queries.Raw("SELECT * FROM nodes ORDER BY path").Bind(ctx, tx, &nodes); err != nil {
+		return nil, err
 	}
+	return physPaths(nodes, homeRoot), nil
+}
 
-	if !strings.HasSuffix(path, "/") {
-		path = path + "/"
+// SubtreeWithPaths returns a node and all nodes under it with their physical
+// paths, ordered by path.
+func SubtreeWithPaths(ctx context.Context, node *models.Node, homeRoot string, tx boil.ContextExecutor) ([]*NodeWithPath, error) {
+	var nodes []*NodeWithPath
+	err := queries.Raw("SELECT * FROM nodes WHERE id IN ("+subtreeQuery("$1", true)+") ORDER BY path",
+		node.ID).Bind(ctx, tx, &nodes)
+	if err != nil {
+		return nil, err
 	}
+	return physPaths(nodes, homeRoot), nil
+}
 
-	// Reverse the list
-	for i, j := 0, len(names)-1; i < j; i, j = i+1, j-1 {
-		names[i], names[j] = names[j], names[i]
+// Replaces the materialized paths of nodes with their physical paths.
+func physPaths(nodes []*NodeWithPath, homeRoot string) []*NodeWithPath {
+	for _, n := range nodes {
+		if n.Blob.Valid && !IsDir(&n.Node) {
+			n.Path = BlobPath(homeRoot, n.Blob.String)
+		} else {
+			n.Path = strings.TrimSuffix(homeRoot, "/") + n.Path
+		}
 	}
+	return nodes
+}
 
-	path = path + strings.Join(names, "/")
-	return path, nil
+// Returns a node and all nodes under it, ordered by path, so that each node
+// comes after the nodes above it.
+func subtreeNodes(ctx context.Context, node *models.Node, tx boil.ContextExecutor) ([]*models.Node, error) {
+	var nodes []*models.Node
+	err := queries.Raw("SELECT * FROM nodes WHERE id IN ("+subtreeQuery("$1", true)+") ORDER BY path",
+		node.ID).Bind(ctx, tx, &nodes)
+	return nodes, err
 }
 
 // ThumbPath returns path for a thumbnail file.
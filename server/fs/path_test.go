@@ -0,0 +1,221 @@
+package fs
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"testing"
+
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+var benchNodes = flag.Int("bench-nodes", 100000, "number of nodes in the tree of BenchmarkPaths")
+
+const (
+	benchFanout  = 20   // Directories in each directory above the files
+	benchFiles   = 50   // Minimum number of files in each directory at the bottom
+	benchSamples = 1000 // Number of nodes to resolve
+)
+
+// Returns a query prefix selecting the numbers from 1 to the parameter limit
+// as s(i), in both PostgreSQL and SQLite.
+func benchSeries(limit string) string {
+	return "WITH RECURSIVE s(i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM s WHERE i < " + limit + ") "
+}
+
+// Creates a tree of about count nodes under a new root directory. Returns
+// the root and the number of created nodes.
+func benchTree(ctx context.Context, count int, db *sql.DB) (*models.Node, int64, error) {
+	root := &models.Node{Name: "bench", Type: "directory", MimeType: "inode/directory"}
+	if err := root.Insert(ctx, db, boil.Infer()); err != nil {
+		return nil, 0, err
+	}
+
+	// Parents of each level are selected by their path and name.
+	rootPath := "/" + root.Name
+	parentPath, parentName := rootPath, "%"
+	leaves, total := 1, int64(1)
+	for level := 1; leaves*benchFanout*benchFiles <= count; level++ {
+		res, err := queries.Raw(benchSeries("$2")+"INSERT INTO nodes (name, type, mime_type, parent_id) "+
+			"SELECT $1 || i, 'directory', 'inode/directory', p.id "+
+			"FROM nodes p, s WHERE p.path LIKE $3 AND p.name LIKE $4",
+			fmt.Sprintf("d%d-", level), benchFanout, parentPath, parentName).ExecContext(ctx, db)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		n, _ := res.RowsAffected()
+		total += n
+		leaves *= benchFanout
+		parentPath, parentName = rootPath+"/%", fmt.Sprintf("d%d-%%", level)
+	}
+
+	files := (int64(count) - total) / int64(leaves)
+	if files < 1 {
+		files = 1
+	}
+
+	res, err := queries.Raw(benchSeries("$1")+"INSERT INTO nodes (name, type, mime_type, parent_id, size) "+
+		"SELECT 'f' || i || '.txt', 'file', 'text/plain', p.id, 1000 "+
+		"FROM nodes p, s WHERE p.path LIKE $2 AND p.name LIKE $3",
+		files, parentPath, parentName).ExecContext(ctx, db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	n, _ := res.RowsAffected()
+	return root, total + n, nil
+}
+
+// BenchmarkPaths measures path resolution, subtree queries, renames and
+// moves on a generated tree in a scratch database. The size of the tree is
+// set with -bench-nodes.
+func BenchmarkPaths(b *testing.B) {
+	ctx := context.Background()
+	db := openTestDB(b)
+
+	root, total, err := benchTree(ctx, *benchNodes, db)
+	if err != nil {
+		b.Fatal(err)
+	} else if _, err := db.ExecContext(ctx, "ANALYZE nodes"); err != nil {
+		b.Fatal(err)
+	}
+
+	samples, err := models.Nodes(qm.Where(SubtreeClause(), root.ID), qm.And("type='file'"),
+		qm.OrderBy("random()"), qm.Limit(benchSamples)).All(ctx, db)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	paths := make([]string, len(samples))
+	for i, n := range samples {
+		if paths[i], err = PathFor(ctx, n, db); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	b.Run("NodeByPath", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			k := i % len(samples)
+			if n, err := NodeByPath(ctx, paths[k], root, db); err != nil {
+				b.Fatal(err)
+			} else if n == nil || n.ID != samples[k].ID {
+				b.Fatalf("%s resolved to a wrong node", paths[k])
+			}
+		}
+	})
+
+	b.Run("PhysPath", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := PhysPath(ctx, samples[i%len(samples)], "", db); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	// For comparison, the path built by walking the ancestors one query at a time.
+	b.Run("AncestorWalk", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for n := samples[i%len(samples)]; n.ParentID.Valid; {
+				if n, err = models.FindNode(ctx, db, n.ParentID.Int); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+
+	b.Run(fmt.Sprintf("SubtreeSize/%d", total), func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := SubtreeSize(ctx, root, db); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	dirs, err := NodesByParentID(ctx, root.ID, db)
+	if err != nil {
+		b.Fatal(err)
+	} else if len(dirs) < 3 {
+		return
+	}
+
+	// Renaming and moving a directory update the paths of all nodes under it.
+	dir := dirs[0]
+	size, err := models.Nodes(qm.Where(SubtreeClause(), dir.ID)).Count(ctx, db)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	name := dir.Name
+	b.Run(fmt.Sprintf("RenameDir/%d", size), func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			dir.Name = fmt.Sprintf("%s-%d", name, i)
+			if _, err := dir.Update(ctx, db, boil.Infer()); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run(fmt.Sprintf("MoveDir/%d", size), func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			dir.ParentID.Int = dirs[1+i%2].ID
+			if _, err := dir.Update(ctx, db, boil.Infer()); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// Nodes are found under a trash directory and a shared node by their paths,
+// but not under a sibling whose name has the same prefix.
+func TestPathAncestors(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+
+	nodes := make(map[string]*models.Node)
+	for _, n := range [][2]string{
+		{"alice", ""}, {".trash-alice", ""}, {"a", "alice"}, {"a b", "alice"},
+		{"b", "a"}, {"c.txt", "b"}, {"d.txt", "a b"}, {"e.txt", ".trash-alice"},
+	} {
+		node := &models.Node{Name: n[0], Type: "directory", MimeType: "inode/directory"}
+		if n[1] != "" {
+			node.ParentID.SetValid(nodes[n[1]].ID)
+		}
+		if err := node.Insert(ctx, db, boil.Infer()); err != nil {
+			t.Fatal(err)
+		}
+		nodes[n[0]] = node
+	}
+
+	user := &models.User{Name: "alice"}
+	user.RootID.SetValid(nodes["alice"].ID)
+	if err := user.Insert(ctx, db, boil.Infer()); err != nil {
+		t.Fatal(err)
+	} else if _, err := db.ExecContext(ctx, "UPDATE users SET trash_id=$1", nodes[".trash-alice"].ID); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, want := range map[string]bool{
+		"alice": false, ".trash-alice": true, "c.txt": false, "e.txt": true,
+	} {
+		if got, err := InTrash(ctx, nodes[name], db); err != nil || got != want {
+			t.Errorf("InTrash(%s) = %t, %v", name, got, err)
+		}
+	}
+
+	share := &models.Share{NodeID: nodes["a"].ID}
+	for name, want := range map[string]bool{
+		"a": true, "b": true, "c.txt": true, "a b": false, "d.txt": false, "alice": false,
+	} {
+		node, err := ShareNode(ctx, share, nodes[name].ID, db)
+		if want && (err != nil || node.ID != nodes[name].ID) {
+			t.Errorf("ShareNode(%s) = %v, %v", name, node, err)
+		} else if !want && err == nil {
+			t.Errorf("ShareNode(%s) found a node outside the share", name)
+		}
+	}
+}
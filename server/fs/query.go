@@ -0,0 +1,275 @@
+package fs
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/terotoi/koticloud/server/core"
+	"github.com/volatiletech/null/v8"
+)
+
+// Sort orders of search results.
+const (
+	SortName      = "name"
+	SortSize      = "size"
+	SortModified  = "modified"
+	SortTaken     = "taken" // Capture time of photos
+	SortRelevance = "relevance"
+)
+
+// A term of a search query, such as -ext:tmp or size>1G.
+type queryTerm struct {
+	negate bool
+	key    string // Empty for free text
+	op     string // ":", "=", ">", ">=", "<" or "<="
+	value  string
+}
+
+// Splits a query into terms. Double quotes group words, as in name:"my file".
+func splitQuery(text string) ([]string, error) {
+	var terms []string
+	var sb strings.Builder
+	inQuotes := false
+
+	for _, c := range text {
+		switch {
+		case c == '"':
+			inQuotes = !inQuotes
+			sb.WriteRune(c)
+		case unicode.IsSpace(c) && !inQuotes:
+			if sb.Len() > 0 {
+				terms = append(terms, sb.String())
+				sb.Reset()
+			}
+		default:
+			sb.WriteRune(c)
+		}
+	}
+
+	if inQuotes {
+		return nil, core.NewSystemError(http.StatusBadRequest, "", "unterminated quote in search query")
+	}
+
+	if sb.Len() > 0 {
+		terms = append(terms, sb.String())
+	}
+	return terms, nil
+}
+
+var queryKeys = []string{"type", "name", "ext", "in", "size", "modified", "content", "sort"}
+
+// Parses a single term of a query.
+func parseQueryTerm(s string) queryTerm {
+	var t queryTerm
+	if len(s) > 1 && s[0] == '-' {
+		t.negate = true
+		s = s[1:]
+	}
+
+	for _, key := range queryKeys {
+		if !strings.HasPrefix(s, key) {
+			continue
+		}
+
+		rest := s[len(key):]
+		for _, op := range []string{">=", "<=", ":", "=", ">", "<"} {
+			if strings.HasPrefix(rest, op) {
+				t.key, t.op, t.value = key, op, strings.Trim(rest[len(op):], `"`)
+				return t
+			}
+		}
+	}
+
+	t.value = strings.Trim(s, `"`)
+	return t
+}
+
+// ParseSize parses a size in bytes. The suffixes K, M, G and T, optionally
+// followed by B, multiply by powers of 1024. Fractions such as 1.5G are allowed.
+func ParseSize(s string) (int64, error) {
+	u := strings.TrimSuffix(strings.ToUpper(s), "B")
+	mult := 1.0
+	if u != "" {
+		switch u[len(u)-1] {
+		case 'K':
+			mult = 1 << 10
+		case 'M':
+			mult = 1 << 20
+		case 'G':
+			mult = 1 << 30
+		case 'T':
+			mult = 1 << 40
+		}
+	}
+
+	if mult > 1 {
+		u = u[:len(u)-1]
+	}
+
+	n, err := strconv.ParseFloat(u, 64)
+	if err != nil || n < 0 {
+		return 0, core.NewSystemError(http.StatusBadRequest, "", fmt.Sprintf("invalid size: %s", s))
+	}
+	return int64(n * mult), nil
+}
+
+// Parses a date of the form YYYY, YYYY-MM or YYYY-MM-DD. Returns the start
+// of the period and the start of the next period.
+func parsePeriod(s string) (time.Time, time.Time, error) {
+	for _, f := range []struct {
+		layout      string
+		years, mons int
+		days        int
+	}{{"2006", 1, 0, 0}, {"2006-01", 0, 1, 0}, {"2006-01-02", 0, 0, 1}} {
+		if t, err := time.ParseInLocation(f.layout, s, time.Local); err == nil {
+			return t, t.AddDate(f.years, f.mons, f.days), nil
+		}
+	}
+	return time.Time{}, time.Time{}, core.NewSystemError(http.StatusBadRequest, "",
+		fmt.Sprintf("invalid date: %s, use YYYY, YYYY-MM or YYYY-MM-DD", s))
+}
+
+// Applies a size term to the query.
+func (q *SearchQuery) addSize(t queryTerm) error {
+	size, err := ParseSize(t.value)
+	if err != nil {
+		return err
+	}
+
+	if t.negate {
+		return core.NewSystemError(http.StatusBadRequest, "", "size cannot be negated")
+	}
+
+	switch t.op {
+	case ">":
+		q.MinSize = null.Int64From(size + 1)
+	case ">=":
+		q.MinSize = null.Int64From(size)
+	case "<":
+		q.MaxSize = null.Int64From(size - 1)
+	case "<=":
+		q.MaxSize = null.Int64From(size)
+	default:
+		q.MinSize = null.Int64From(size)
+		q.MaxSize = null.Int64From(size)
+	}
+	return nil
+}
+
+// Applies a modification date term to the query.
+func (q *SearchQuery) addModified(t queryTerm) error {
+	start, end, err := parsePeriod(t.value)
+	if err != nil {
+		return err
+	}
+
+	if t.negate {
+		return core.NewSystemError(http.StatusBadRequest, "", "modified cannot be negated")
+	}
+
+	switch t.op {
+	case ">":
+		q.After = null.TimeFrom(end)
+	case ">=":
+		q.After = null.TimeFrom(start)
+	case "<":
+		q.Before = null.TimeFrom(start)
+	case "<=":
+		q.Before = null.TimeFrom(end)
+	default:
+		q.After = null.TimeFrom(start)
+		q.Before = null.TimeFrom(end)
+	}
+	return nil
+}
+
+// ParseSearchQuery parses a search query. A query consists of words, which
+// must all appear in the name of a node, and filters:
+//
+//	type:video        type of the node: image, video, audio, text, pdf,
+//	                  document, dir, file or a mime type such as image/png
+//	name:"holiday*"   name matches a pattern, * and ? are wildcards
+//	ext:jpg           name has the extension
+//	in:/Photos        only under the directory, relative to the home directory
+//	size>1G           size, also with >=, <, <= or : for an exact size
+//	modified:2024     modified during a year, month (2024-05) or day (2024-05-03),
+//	                  also with >, >=, < and <=
+//	content:"words"   the text content of the file matches
+//	sort:size         sort by name, size, modified, taken (capture time of
+//	                  photos) or relevance, sort:-size reverses
+//
+// Words and the filters type, name and ext can be negated with -, as in -ext:tmp.
+func ParseSearchQuery(text string) (*SearchQuery, error) {
+	var q SearchQuery
+
+	words, err := splitQuery(text)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, w := range words {
+		t := parseQueryTerm(w)
+		if t.value == "" {
+			continue
+		}
+
+		var inc, exc *[]string
+		switch t.key {
+		case "":
+			inc, exc = &q.Terms, &q.ExcludeTerms
+		case "type":
+			if _, _, err := typeCondition(t.value); err != nil {
+				return nil, err
+			}
+			inc, exc = &q.Types, &q.ExcludeTypes
+		case "name":
+			inc, exc = &q.Names, &q.ExcludeNames
+		case "ext":
+			t.value = strings.TrimPrefix(t.value, ".")
+			inc, exc = &q.Exts, &q.ExcludeExts
+		case "in":
+			if t.negate {
+				return nil, core.NewSystemError(http.StatusBadRequest, "", "in cannot be negated")
+			}
+			q.In = t.value
+		case "size":
+			if err := q.addSize(t); err != nil {
+				return nil, err
+			}
+		case "modified":
+			if err := q.addModified(t); err != nil {
+				return nil, err
+			}
+		case "content":
+			q.Text = strings.TrimSpace(q.Text + " " + t.value)
+		case "sort":
+			q.Desc = t.negate || strings.HasPrefix(t.value, "-")
+			q.Sort = strings.TrimPrefix(t.value, "-")
+			if err := q.checkSort(); err != nil {
+				return nil, err
+			}
+		}
+
+		if inc != nil {
+			if t.negate {
+				*exc = append(*exc, t.value)
+			} else {
+				*inc = append(*inc, t.value)
+			}
+		}
+	}
+
+	return &q, nil
+}
+
+func (q *SearchQuery) checkSort() error {
+	switch q.Sort {
+	case "", SortName, SortSize, SortModified, SortTaken, SortRelevance:
+		return nil
+	}
+	return core.NewSystemError(http.StatusBadRequest, "", fmt.Sprintf("invalid sort order: %s", q.Sort))
+}
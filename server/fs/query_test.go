@@ -0,0 +1,156 @@
+package fs
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/volatiletech/null/v8"
+)
+
+func TestSplitQuery(t *testing.T) {
+	terms, err := splitQuery(`  holiday name:"my  file" -ext:tmp	"two words" `)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"holiday", `name:"my  file"`, "-ext:tmp", `"two words"`}
+	if !reflect.DeepEqual(terms, want) {
+		t.Errorf("splitQuery = %q, want %q", terms, want)
+	}
+
+	if _, err := splitQuery(`name:"unterminated`); err == nil {
+		t.Error("accepted an unterminated quote")
+	}
+}
+
+func TestParseQueryTerm(t *testing.T) {
+	for s, want := range map[string]queryTerm{
+		"photo":          {value: "photo"},
+		"-photo":         {negate: true, value: "photo"},
+		"-":              {value: "-"},
+		"ext:jpg":        {key: "ext", op: ":", value: "jpg"},
+		"-type:dir":      {negate: true, key: "type", op: ":", value: "dir"},
+		`name:"a b*"`:    {key: "name", op: ":", value: "a b*"},
+		"size>=1G":       {key: "size", op: ">=", value: "1G"},
+		"size<10k":       {key: "size", op: "<", value: "10k"},
+		"modified=2024":  {key: "modified", op: "=", value: "2024"},
+		"sizeable":       {value: "sizeable"},
+		`"quoted words"`: {value: "quoted words"},
+	} {
+		if got := parseQueryTerm(s); got != want {
+			t.Errorf("parseQueryTerm(%q) = %+v, want %+v", s, got, want)
+		}
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	for s, want := range map[string]int64{
+		"0":    0,
+		"123":  123,
+		"1k":   1 << 10,
+		"2KB":  2 << 10,
+		"1.5M": 3 << 19,
+		"1G":   1 << 30,
+		"2tb":  2 << 40,
+	} {
+		if got, err := ParseSize(s); err != nil || got != want {
+			t.Errorf("ParseSize(%q) = %d, %v, want %d", s, got, err, want)
+		}
+	}
+
+	for _, s := range []string{"", "G", "-1", "1X", "one"} {
+		if _, err := ParseSize(s); err == nil {
+			t.Errorf("ParseSize(%q) succeeded", s)
+		}
+	}
+}
+
+func TestParsePeriod(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.Local) }
+
+	for s, want := range map[string][2]time.Time{
+		"2024":       {date(2024, 1, 1), date(2025, 1, 1)},
+		"2024-12":    {date(2024, 12, 1), date(2025, 1, 1)},
+		"2024-02-29": {date(2024, 2, 29), date(2024, 3, 1)},
+	} {
+		start, end, err := parsePeriod(s)
+		if err != nil || !start.Equal(want[0]) || !end.Equal(want[1]) {
+			t.Errorf("parsePeriod(%q) = %s, %s, %v", s, start, end, err)
+		}
+	}
+
+	for _, s := range []string{"24", "2024-13", "2024/01/01", "yesterday"} {
+		if _, _, err := parsePeriod(s); err == nil {
+			t.Errorf("parsePeriod(%q) succeeded", s)
+		}
+	}
+}
+
+func TestParseSearchQuery(t *testing.T) {
+	q, err := ParseSearchQuery(`beach -draft type:image -type:dir name:"IMG_*" -name:*.tmp ext:.jpg -ext:png ` +
+		`in:/Photos/2024 size>1M size<=2G content:sunset content:"sea view" sort:-modified`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := SearchQuery{
+		Terms:        []string{"beach"},
+		ExcludeTerms: []string{"draft"},
+		Text:         "sunset sea view",
+		Names:        []string{"IMG_*"},
+		ExcludeNames: []string{"*.tmp"},
+		Types:        []string{"image"},
+		ExcludeTypes: []string{"dir"},
+		Exts:         []string{"jpg"},
+		ExcludeExts:  []string{"png"},
+		MinSize:      null.Int64From(1<<20 + 1),
+		MaxSize:      null.Int64From(2 << 30),
+		In:           "/Photos/2024",
+		Sort:         SortModified,
+		Desc:         true,
+	}
+	if !reflect.DeepEqual(*q, want) {
+		t.Errorf("ParseSearchQuery = %+v, want %+v", *q, want)
+	}
+}
+
+func TestParseSearchQueryModified(t *testing.T) {
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.Local)
+	end := time.Date(2024, 6, 1, 0, 0, 0, 0, time.Local)
+
+	for s, want := range map[string][2]null.Time{
+		"modified:2024-05":  {null.TimeFrom(start), null.TimeFrom(end)},
+		"modified>2024-05":  {null.TimeFrom(end), {}},
+		"modified>=2024-05": {null.TimeFrom(start), {}},
+		"modified<2024-05":  {{}, null.TimeFrom(start)},
+		"modified<=2024-05": {{}, null.TimeFrom(end)},
+	} {
+		q, err := ParseSearchQuery(s)
+		if err != nil {
+			t.Errorf("%s: %v", s, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(q.After, want[0]) || !reflect.DeepEqual(q.Before, want[1]) {
+			t.Errorf("%s: after %v, before %v", s, q.After, q.Before)
+		}
+	}
+}
+
+func TestParseSearchQueryErrors(t *testing.T) {
+	for _, s := range []string{
+		`name:"open`,
+		"type:spreadsheet",
+		"-in:/Photos",
+		"-size>1M",
+		"size>lots",
+		"-modified:2024",
+		"modified:today",
+		"sort:color",
+	} {
+		if _, err := ParseSearchQuery(s); err == nil {
+			t.Errorf("ParseSearchQuery(%q) succeeded", s)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package fs
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/terotoi/koticloud/server/core"
+	"github.com/terotoi/koticloud/server/database"
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+// UserQuota returns the storage quota of a user in bytes. Null means no limit.
+func UserQuota(ctx context.Context, userID int, tx boil.ContextExecutor) (null.Int64, error) {
+	var quota null.Int64
+	err := queries.Raw("SELECT quota FROM users WHERE id=$1", userID).QueryRowContext(ctx, tx).Scan(&quota)
+	if err == sql.ErrNoRows {
+		return quota, core.NewSystemError(http.StatusNotFound, "", "user not found")
+	}
+	return quota, err
+}
+
+// SetUserQuota sets the storage quota of a user. Null removes the limit.
+func SetUserQuota(ctx context.Context, userID int, quota null.Int64, tx boil.ContextExecutor) error {
+	if quota.Valid && quota.Int64 < 0 {
+		return core.NewSystemError(http.StatusBadRequest, "", "invalid quota")
+	}
+
+	_, err := queries.Raw("UPDATE users SET quota=$1 WHERE id=$2", quota, userID).ExecContext(ctx, tx)
+	return err
+}
+
+// SubtreeSize returns the combined size of the files in a node and all nodes under it.
+func SubtreeSize(ctx context.Context, node *models.Node, tx boil.ContextExecutor) (int64, error) {
+	var size int64
+	err := queries.Raw("SELECT COALESCE(SUM(size), 0) FROM nodes WHERE id IN ("+subtreeQuery("$1", true)+")",
+		node.ID).QueryRowContext(ctx, tx).Scan(&size)
+	return size, err
+}
+
+// Locks the quota of a user until the transaction tx ends. The lock does not
+// conflict with the shared lock of LockDataKey, which writers may already
+// hold. Transactions on SQLite are serialized, so nothing needs to be locked.
+func lockQuota(ctx context.Context, userID int, tx boil.ContextExecutor) error {
+	if database.IsSQLite() {
+		return nil
+	}
+
+	_, err := queries.Raw("SELECT id FROM users WHERE id=$1 FOR NO KEY UPDATE", userID).ExecContext(ctx, tx)
+	return err
+}
+
+// CheckQuota returns an error if adding the given number of bytes under
+// parent would exceed the quota of the owner of parent. The user is charged
+// if parent has no owner. The owner is locked until tx ends, so that
+// concurrent writers are checked against each other's usage.
+func CheckQuota(ctx context.Context, parent *models.Node, user *models.User, additional int64,
+	tx boil.ContextExecutor) error {
+	ownerID := ownerFor(parent, user)
+	if err := lockQuota(ctx, ownerID.Int, tx); err != nil {
+		return err
+	}
+
+	quota, err := UserQuota(ctx, ownerID.Int, tx)
+	if err != nil || !quota.Valid {
+		return err
+	}
+
+	used, err := StorageUsed(ctx, ownerID.Int, tx)
+	if err != nil {
+		return err
+	}
+
+	if used+additional > quota.Int64 {
+		msg := fmt.Sprintf("storage quota exceeded: %d + %d bytes, quota %d bytes", used, additional, quota.Int64)
+		if ownerID.Int != user.ID {
+			return core.NewSystemError(http.StatusInsufficientStorage, msg,
+				"storage quota of the owner of the directory exceeded")
+		}
+		return core.NewSystemError(http.StatusInsufficientStorage, msg, "storage quota exceeded")
+	}
+	return nil
+}
@@ -6,22 +6,21 @@ import (
 	"fmt"
 	"log"
 	"net/http"
-	"os"
 
 	"github.com/terotoi/koticloud/server/core"
 	"github.com/terotoi/koticloud/server/models"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 )
 
-// Rename a filesystem node.
+// Rename a filesystem node. The stored file is renamed back by j if tx is not committed.
 func Rename(ctx context.Context, node *models.Node, filename string, user *models.User,
-	homeRoot string, tx *sql.Tx) error {
+	homeRoot string, j *Journal, tx *sql.Tx) error {
 	if !IsValidName(filename) {
 		return core.NewSystemError(http.StatusBadRequest, "",
 			fmt.Sprintf("invalid node name: %s", filename))
 	}
 
-	if !AccessAllowed(user, node, false) {
+	if !AccessAllowed(ctx, user, node, true, tx) {
 		return core.NewSystemError(http.StatusUnauthorized, "", "not allowed")
 	}
 
@@ -49,12 +48,15 @@ func Rename(ctx context.Context, node *models.Node, filename string, user *model
 		return err
 	}
 
-	if err := os.Rename(srcPath, dstPath); err != nil {
-		return err
+	// Contents in the blob store do not move with the node.
+	if srcPath != dstPath {
+		if err := j.Rename(ctx, srcPath, dstPath, tx); err != nil {
+			return err
+		}
 	}
 
-	if _, err := node.Update(ctx, tx, boil.Infer()); err != nil {
-		return err
+	if _, err := node.Update(ctx, tx, boil.Whitelist(models.NodeColumns.Name)); err != nil {
+		return treeError(err, filename)
 	}
 
 	log.Printf("Node %d renamed as: %s to %s", node.ID, oldName, node.Name)
@@ -0,0 +1,261 @@
+package fs
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/terotoi/koticloud/server/database"
+	"github.com/terotoi/koticloud/server/migrate"
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/terotoi/koticloud/server/storage"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+// TreeRepair counts the violations of the tree invariants repaired by RepairTree.
+type TreeRepair struct {
+	Cycles     int // Nodes reattached to break a cycle
+	Duplicates int // Nodes merged or renamed because a sibling had the same name
+	Progress   int // Duplicate progress rows removed
+}
+
+// Statements run before the repair. The path triggers and the constraints
+// are removed, and created again by the migrations of the node paths and
+// the tree invariants once the tree is valid.
+var repairPrepare = []string{
+	`ALTER TABLE nodes ADD COLUMN IF NOT EXISTS path character varying COLLATE pg_catalog."C"`,
+	`ALTER TABLE nodes ALTER COLUMN path DROP NOT NULL`,
+	`DROP TRIGGER IF EXISTS nodes_path ON nodes`,
+	`DROP TRIGGER IF EXISTS nodes_subtree_paths ON nodes`,
+	`ALTER TABLE nodes DROP CONSTRAINT IF EXISTS nodes_parent_id_name_key`,
+	`DROP INDEX IF EXISTS nodes_root_name_key`,
+	`ALTER TABLE progress DROP CONSTRAINT IF EXISTS progress_user_id_node_id_key`,
+}
+
+// Selects the IDs of all nodes reachable from a root node.
+const reachableQuery = "WITH RECURSIVE t AS (SELECT id FROM nodes WHERE parent_id IS NULL " +
+	"UNION SELECT n.id FROM nodes n JOIN t ON n.parent_id=t.id) SELECT id FROM t"
+
+// RepairTree finds and repairs the violations of the invariants of the node
+// tree in a database created before they were enforced: nodes in cycles are
+// moved into the home directory of their owner, directories with the same
+// name in the same directory are merged, other nodes with the same name are
+// renamed, and duplicate progress rows are removed. The database is migrated
+// up to the version before the node paths, and the migrations of the node
+// paths and the tree invariants are applied within the repair, which is
+// done in a single transaction. Only PostgreSQL databases may need to be
+// repaired.
+func RepairTree(ctx context.Context, homeRoot string, db *sql.DB) (*TreeRepair, error) {
+	dialect := database.DialectOf(db)
+	if dialect == database.SQLite {
+		return nil, fmt.Errorf("SQLite databases are created with the invariants enforced, no repair is needed")
+	}
+
+	paths, err := migrate.Find(dialect, "node_paths")
+	if err != nil {
+		return nil, err
+	}
+
+	invariants, err := migrate.Find(dialect, "tree_invariants")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := migrate.UpTo(ctx, paths.Version-1, db); err != nil {
+		return nil, err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := execAll(ctx, repairPrepare, tx); err != nil {
+		return nil, err
+	}
+
+	var rep TreeRepair
+	if rep.Cycles, err = repairCycles(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	// The paths are built from the roots, and maintained by the triggers
+	// while the duplicates are repaired.
+	if _, err := tx.ExecContext(ctx, paths.Up); err != nil {
+		return nil, fmt.Errorf("migration %04d %s: %s", paths.Version, paths.Name, err)
+	}
+
+	if rep.Duplicates, err = repairDuplicates(ctx, homeRoot, tx); err != nil {
+		return nil, err
+	}
+
+	res, err := queries.Raw("DELETE FROM progress p USING progress q "+
+		"WHERE p.user_id=q.user_id AND p.node_id=q.node_id AND p.id < q.id").ExecContext(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	progress, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	rep.Progress = int(progress)
+
+	if _, err := tx.ExecContext(ctx, invariants.Up); err != nil {
+		return nil, fmt.Errorf("migration %04d %s: %s", invariants.Version, invariants.Name, err)
+	} else if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	// The statements of both migrations may be run again, so applying them
+	// records them as applied.
+	if _, err := migrate.UpTo(ctx, invariants.Version, db); err != nil {
+		return nil, err
+	}
+	return &rep, nil
+}
+
+func execAll(ctx context.Context, stmts []string, tx boil.ContextExecutor) error {
+	for _, stmt := range stmts {
+		if _, err := queries.Raw(stmt).ExecContext(ctx, tx); err != nil {
+			return fmt.Errorf("%s: %s", stmt, err)
+		}
+	}
+	return nil
+}
+
+// Breaks cycles by moving a node in each cycle into the home directory of
+// its owner, or into a new root without one. Nodes in a cycle cannot be
+// reached from any root. Returns the number of moved nodes.
+func repairCycles(ctx context.Context, tx *sql.Tx) (int, error) {
+	count := 0
+	for {
+		var nodes []*models.Node
+		if err := queries.Raw("SELECT * FROM nodes WHERE id NOT IN ("+reachableQuery+") ORDER BY id LIMIT 1").
+			Bind(ctx, tx, &nodes); err != nil {
+			return count, err
+		} else if len(nodes) == 0 {
+			return count, nil
+		}
+
+		node := nodes[0]
+		var rootID null.Int
+		if node.OwnerID.Valid {
+			if err := queries.Raw("SELECT u.root_id FROM users u JOIN nodes r ON r.id=u.root_id "+
+				"WHERE u.id=$1 AND r.parent_id IS NULL", node.OwnerID.Int).
+				QueryRowContext(ctx, tx).Scan(&rootID); err != nil && err != sql.ErrNoRows {
+				return count, err
+			}
+		}
+
+		// Nodes without a home directory become roots.
+		name := fmt.Sprintf("%s-%d", node.Name, node.ID)
+		if rootID.Valid {
+			var err error
+			if name, err = uniqueName(ctx, node.Name, rootID.Int, tx); err != nil {
+				return count, err
+			}
+		}
+
+		log.Printf("[repair] node %d (%s) is in a cycle, moved to %s in the home directory", node.ID, node.Name, name)
+		if _, err := queries.Raw("UPDATE nodes SET parent_id=$2, name=$3 WHERE id=$1", node.ID, rootID, name).
+			ExecContext(ctx, tx); err != nil {
+			return count, err
+		}
+		count++
+	}
+}
+
+// Merges directories with the same name in the same directory, and renames
+// other nodes with the same name as a sibling. The oldest node keeps the
+// name. Returns the number of merged or renamed nodes.
+func repairDuplicates(ctx context.Context, homeRoot string, tx *sql.Tx) (int, error) {
+	count := 0
+	for {
+		// Merging directories may create new duplicates among their contents.
+		var dups []struct {
+			ID   int `boil:"id"`
+			Keep int `boil:"keep"`
+		}
+		if err := queries.Raw("SELECT id, keep FROM ("+
+			"SELECT id, min(id) OVER (PARTITION BY parent_id, name) AS keep FROM nodes) d "+
+			"WHERE id <> keep ORDER BY id").Bind(ctx, tx, &dups); err != nil {
+			return count, err
+		} else if len(dups) == 0 {
+			return count, nil
+		}
+
+		for _, d := range dups {
+			if err := repairDuplicate(ctx, d.ID, d.Keep, homeRoot, tx); err != nil {
+				return count, err
+			}
+			count++
+		}
+	}
+}
+
+func repairDuplicate(ctx context.Context, id, keepID int, homeRoot string, tx *sql.Tx) error {
+	node, err := NodeByIDopt(ctx, id, tx)
+	if err != nil || node == nil {
+		return err
+	}
+
+	keep, err := NodeByIDopt(ctx, keepID, tx)
+	if err != nil || keep == nil {
+		return err
+	}
+
+	// Directories with the same name share the same stored directory, so
+	// their contents are already together.
+	if IsDir(node) && IsDir(keep) {
+		log.Printf("[repair] directory %d (%s) merged into directory %d", node.ID, node.Name, keep.ID)
+		for _, q := range []string{
+			"UPDATE nodes SET parent_id=$2 WHERE parent_id=$1",
+			"UPDATE users SET root_id=$2 WHERE root_id=$1",
+			"UPDATE users SET trash_id=$2 WHERE trash_id=$1",
+			"UPDATE trash SET original_parent_id=$2 WHERE original_parent_id=$1",
+			"DELETE FROM nodes WHERE id=$1",
+		} {
+			if _, err := queries.Raw(q, node.ID, keep.ID).ExecContext(ctx, tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	oldPath, err := PhysPath(ctx, node, homeRoot, tx)
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%d", node.Name, node.ID)
+	if node.ParentID.Valid {
+		if name, err = uniqueName(ctx, node.Name, node.ParentID.Int, tx); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("[repair] node %d (%s) renamed to %s", node.ID, node.Name, name)
+	node.Name = name
+	if _, err := node.Update(ctx, tx, boil.Whitelist(models.NodeColumns.Name)); err != nil {
+		return err
+	}
+
+	newPath, err := PhysPath(ctx, node, homeRoot, tx)
+	if err != nil {
+		return err
+	}
+
+	// Files with the same name share the same stored file, which is copied
+	// for the renamed node. Contents in the blob store are not affected.
+	if !IsDir(node) && newPath != oldPath {
+		if err := storage.CopyFile(oldPath, newPath); err != nil {
+			log.Printf("[repair] node %d: %s", node.ID, err)
+		}
+	}
+	return nil
+}
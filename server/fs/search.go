@@ -0,0 +1,334 @@
+package fs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/terotoi/koticloud/server/core"
+	"github.com/terotoi/koticloud/server/database"
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/terotoi/koticloud/server/util"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// SearchQuery describes a search for nodes. All given criteria must match.
+// See ParseSearchQuery for the query syntax.
+type SearchQuery struct {
+	Terms        []string   // Terms that must all appear in the name
+	ExcludeTerms []string   // Terms that must not appear in the name
+	Text         string     // Full-text query on file contents. Nodes matching Terms by name also match.
+	Names        []string   // Patterns the name must match, * and ? are wildcards
+	ExcludeNames []string   // Patterns the name must not match
+	Types        []string   // Node types, one of which must match, see typeCondition
+	ExcludeTypes []string   // Node types that must not match
+	Exts         []string   // Extensions, one of which the name must have
+	ExcludeExts  []string   // Extensions the name must not have
+	MinSize      null.Int64 // Minimum size in bytes
+	MaxSize      null.Int64 // Maximum size in bytes
+	After        null.Time  // Modified on or after
+	Before       null.Time  // Modified before
+	In           string     // Path of the directory to search in, resolved into Under by the caller
+	Under        null.Int   // Only nodes under this directory
+	Sort         string     // SortName, SortSize, SortModified or SortRelevance
+	Desc         bool       // Reverse the sort order
+	Limit        int        // Maximum number of results, 0 for no limit
+	Offset       int        // Number of results to skip
+}
+
+// Matches in snippets are enclosed in these control characters, rather than
+// in HTML markup, so that the text can be shown as it is and the matches
+// highlighted by the client.
+const (
+	SnippetMatchStart = "\x02"
+	SnippetMatchEnd   = "\x03"
+)
+
+// Options of ts_headline making snippets.
+var headlineOptions = fmt.Sprintf(`StartSel="%s", StopSel="%s", MaxFragments=2, MinWords=5, MaxWords=20`,
+	SnippetMatchStart, SnippetMatchEnd)
+
+// SearchResult is a node found by Search.
+type SearchResult struct {
+	models.Node `boil:",bind"`
+	Rank        float32     `boil:"rank" json:"rank"`         // Relevance of the text content
+	Snippet     null.String `boil:"snippet" json:"snippet"`   // Matching text, see SnippetMatchStart
+	TakenOn     null.Time   `boil:"taken_on" json:"taken_on"` // Capture time of a photo
+}
+
+// Empty returns true if the query has no criteria.
+func (q *SearchQuery) Empty() bool {
+	return len(q.Terms) == 0 && len(q.ExcludeTerms) == 0 && q.Text == "" && len(q.Names) == 0 &&
+		len(q.ExcludeNames) == 0 && len(q.Types) == 0 && len(q.ExcludeTypes) == 0 &&
+		len(q.Exts) == 0 && len(q.ExcludeExts) == 0 && !q.MinSize.Valid && !q.MaxSize.Valid &&
+		!q.After.Valid && !q.Before.Valid && !q.Under.Valid
+}
+
+// Escapes the special characters of LIKE patterns. SQLite has no default
+// escape character, so conditions using the escaped patterns name it.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+const likeEscape = ` ESCAPE '\'`
+
+// Converts a pattern with * and ? wildcards into a LIKE pattern.
+func globToLike(pattern string) string {
+	return strings.NewReplacer("*", "%", "?", "_").Replace(likeEscaper.Replace(pattern))
+}
+
+// Returns a condition for a node type: a keyword such as "video" or a mime
+// type. A mime type ending in "/" matches all subtypes.
+func typeCondition(t string) (string, []interface{}, error) {
+	switch t {
+	case "image", "video", "audio", "text":
+		return "nodes.mime_type LIKE ?", []interface{}{t + "/%"}, nil
+	case "pdf":
+		return "nodes.mime_type=?", []interface{}{"application/pdf"}, nil
+	case "document":
+		var args []interface{}
+		for _, mt := range util.DocumentTypes() {
+			args = append(args, mt)
+		}
+		return "nodes.mime_type IN (" + strings.TrimSuffix(strings.Repeat("?,", len(args)), ",") + ")", args, nil
+	case "dir", "directory", "folder":
+		return "nodes.type='directory'", nil, nil
+	case "file":
+		return "nodes.type='file'", nil, nil
+	}
+
+	if strings.HasSuffix(t, "/") {
+		return "nodes.mime_type LIKE ?" + likeEscape, []interface{}{likeEscaper.Replace(t) + "%"}, nil
+	} else if strings.Contains(t, "/") {
+		return "nodes.mime_type=?", []interface{}{t}, nil
+	}
+	return "", nil, core.NewSystemError(http.StatusBadRequest, "", fmt.Sprintf("unknown type: %s", t))
+}
+
+// Builds a condition for a list of values, combined with sep, and
+// negated if negate is set.
+func listCondition(values []string, sep string, negate bool,
+	cond func(v string) (string, []interface{}, error)) (string, []interface{}, error) {
+	var conds []string
+	var args []interface{}
+	for _, v := range values {
+		c, a, err := cond(v)
+		if err != nil {
+			return "", nil, err
+		}
+		conds = append(conds, "("+c+")")
+		args = append(args, a...)
+	}
+
+	if len(conds) == 0 {
+		return "", nil, nil
+	}
+
+	c := strings.Join(conds, sep)
+	if negate {
+		c = "NOT (" + c + ")"
+	}
+	return c, args, nil
+}
+
+func termCondition(term string) (string, []interface{}, error) {
+	return "lower(nodes.name) LIKE ?" + likeEscape, []interface{}{"%" + likeEscaper.Replace(strings.ToLower(term)) + "%"}, nil
+}
+
+func nameCondition(pattern string) (string, []interface{}, error) {
+	return "lower(nodes.name) LIKE ?" + likeEscape, []interface{}{globToLike(strings.ToLower(pattern))}, nil
+}
+
+func extCondition(ext string) (string, []interface{}, error) {
+	return "lower(nodes.name) LIKE ?" + likeEscape, []interface{}{"%." + likeEscaper.Replace(strings.ToLower(ext))}, nil
+}
+
+// Returns the conditions of the query, except those on the text content.
+func (q *SearchQuery) conditions() ([]qm.QueryMod, error) {
+	var mods []qm.QueryMod
+	for _, l := range []struct {
+		values []string
+		sep    string
+		negate bool
+		cond   func(string) (string, []interface{}, error)
+	}{
+		{q.ExcludeTerms, " OR ", true, termCondition},
+		{q.Names, " OR ", false, nameCondition},
+		{q.ExcludeNames, " OR ", true, nameCondition},
+		{q.Types, " OR ", false, typeCondition},
+		{q.ExcludeTypes, " OR ", true, typeCondition},
+		{q.Exts, " OR ", false, extCondition},
+		{q.ExcludeExts, " OR ", true, extCondition},
+	} {
+		c, args, err := listCondition(l.values, l.sep, l.negate, l.cond)
+		if err != nil {
+			return nil, err
+		}
+
+		if c != "" {
+			mods = append(mods, qm.And(c, args...))
+		}
+	}
+
+	if q.MinSize.Valid {
+		mods = append(mods, qm.And("nodes.size >= ?", q.MinSize.Int64))
+	}
+
+	if q.MaxSize.Valid {
+		mods = append(mods, qm.And("nodes.size <= ?", q.MaxSize.Int64))
+	}
+
+	if q.After.Valid {
+		mods = append(mods, qm.And("nodes.modified_on >= ?", q.After.Time))
+	}
+
+	if q.Before.Valid {
+		mods = append(mods, qm.And("nodes.modified_on < ?", q.Before.Time))
+	}
+
+	if q.Under.Valid {
+		mods = append(mods, qm.And("nodes.id IN ("+subtreeQuery("?", false)+")", q.Under.Int))
+	}
+	return mods, nil
+}
+
+// Returns a condition matching stored text containing each word of a text
+// query, ignoring case. Used instead of text search with SQLite.
+func contentCondition(text string) (string, []interface{}) {
+	var conds []string
+	var args []interface{}
+	for _, w := range strings.Fields(strings.ToLower(text)) {
+		if w = strings.Trim(w, `"`); w != "" {
+			conds = append(conds, "lower(node_texts.content) LIKE ?"+likeEscape)
+			args = append(args, "%"+likeEscaper.Replace(w)+"%")
+		}
+	}
+
+	if len(conds) == 0 {
+		return "false", nil
+	}
+	return "(" + strings.Join(conds, " AND ") + ")", args
+}
+
+// Search returns the nodes accessible to the user matching the query and
+// the total number of matches. Nodes in the trash are not included.
+// Full-text queries are parsed with the given text search configuration.
+// Results are ordered by relevance for full-text queries and by name otherwise,
+// unless the query specifies a sort order.
+func Search(ctx context.Context, q *SearchQuery, user *models.User, language string,
+	tx boil.ContextExecutor) ([]*SearchResult, int64, error) {
+	results := []*SearchResult{}
+	if q.Empty() {
+		return results, 0, nil
+	}
+
+	if err := q.checkSort(); err != nil {
+		return nil, 0, err
+	}
+
+	// Own nodes and nodes shared with the user.
+	mods := []qm.QueryMod{
+		qm.From("nodes"),
+		qm.Where("(nodes.owner_id=? OR nodes."+AccessibleClause+")", user.ID, user.ID, user.ID),
+		qm.And("nodes." + NotInTrashClause),
+	}
+
+	cond, err := q.conditions()
+	if err != nil {
+		return nil, 0, err
+	}
+	mods = append(mods, cond...)
+
+	nameCond, nameArgs, _ := listCondition(q.Terms, " AND ", false, termCondition)
+	var selectMods, orderMods []qm.QueryMod
+	sort := q.Sort
+
+	if q.Text != "" && database.IsSQLite() {
+		// SQLite has no text search. The stored text must contain each word.
+		textCond, textArgs := contentCondition(q.Text)
+		mods = append(mods, qm.LeftOuterJoin("node_texts ON node_texts.node_id=nodes.id"))
+		if nameCond != "" {
+			mods = append(mods, qm.Expr(qm.Where(nameCond, nameArgs...), qm.Or(textCond, textArgs...)))
+		} else {
+			mods = append(mods, qm.And(textCond, textArgs...))
+		}
+
+		selectMods = append(selectMods, qm.Select("nodes.*", "CAST(0 AS real) AS rank", "NULL AS snippet",
+			takenOnSelect()))
+		if sort == "" {
+			sort = SortRelevance
+		}
+	} else if q.Text != "" {
+		mods = append(mods,
+			qm.LeftOuterJoin("node_texts ON node_texts.node_id=nodes.id"),
+			qm.InnerJoin("(SELECT ?::regconfig AS config, websearch_to_tsquery(?::regconfig, ?) AS query, "+
+				"CAST(? AS text) AS headline) q ON true", language, language, q.Text, headlineOptions))
+
+		if nameCond != "" {
+			mods = append(mods, qm.Expr(qm.Where(nameCond, nameArgs...), qm.Or("node_texts.tsv @@ q.query")))
+		} else {
+			mods = append(mods, qm.And("node_texts.tsv @@ q.query"))
+		}
+
+		selectMods = append(selectMods, qm.Select("nodes.*", "COALESCE(ts_rank(node_texts.tsv, q.query), 0) AS rank",
+			"CASE WHEN node_texts.tsv @@ q.query THEN ts_headline(q.config, node_texts.content, q.query, q.headline) "+
+				"END AS snippet", takenOnSelect()))
+
+		if sort == "" {
+			sort = SortRelevance
+		}
+	} else {
+		if nameCond != "" {
+			mods = append(mods, qm.And(nameCond, nameArgs...))
+		}
+
+		selectMods = append(selectMods, qm.Select("nodes.*", "CAST(0 AS real) AS rank", "NULL AS snippet", takenOnSelect()))
+		if sort == "" || sort == SortRelevance {
+			sort = SortName
+		}
+	}
+
+	dir := ""
+	if q.Desc {
+		dir = " DESC"
+	}
+
+	switch sort {
+	case SortRelevance:
+		if nameCond != "" {
+			orderMods = append(orderMods, qm.OrderBy("("+nameCond+") DESC", nameArgs...))
+		}
+		orderMods = append(orderMods, qm.OrderBy("rank DESC"), qm.OrderBy("nodes.name"))
+	case SortSize:
+		orderMods = append(orderMods, qm.OrderBy("nodes.size"+dir+" NULLS LAST"))
+	case SortModified:
+		orderMods = append(orderMods, qm.OrderBy("nodes.modified_on"+dir))
+	case SortTaken:
+		orderMods = append(orderMods, qm.OrderBy("taken_on"+dir+" NULLS LAST"))
+	default:
+		orderMods = append(orderMods, qm.OrderBy("nodes.name"+dir))
+	}
+	orderMods = append(orderMods, qm.OrderBy("nodes.id"))
+
+	var total int64
+	countQuery := models.NewQuery(mods...)
+	queries.SetCount(countQuery)
+	if err := countQuery.QueryRowContext(ctx, tx).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	mods = append(mods, selectMods...)
+	mods = append(mods, orderMods...)
+	if q.Limit > 0 {
+		mods = append(mods, qm.Limit(q.Limit))
+	}
+
+	if q.Offset > 0 {
+		mods = append(mods, qm.Offset(q.Offset))
+	}
+
+	err = models.NewQuery(mods...).Bind(ctx, tx, &results)
+	return results, total, err
+}
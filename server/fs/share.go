@@ -0,0 +1,154 @@
+package fs
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/terotoi/koticloud/server/core"
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ShareHasPassword returns true if the share is protected by a password.
+func ShareHasPassword(s *models.Share) bool {
+	return s.Password.Valid
+}
+
+// CheckSharePassword returns true if the password matches or no password is required.
+func CheckSharePassword(s *models.Share, password string) bool {
+	return !s.Password.Valid ||
+		bcrypt.CompareHashAndPassword([]byte(s.Password.String), []byte(password)) == nil
+}
+
+// ShareUsable returns an error if the share has expired or its downloads are used up.
+func ShareUsable(s *models.Share) error {
+	if s.ExpiresOn.Valid && time.Now().After(s.ExpiresOn.Time) {
+		return core.NewSystemError(http.StatusGone, "", "share link has expired")
+	}
+
+	if s.MaxDownloads.Valid && s.Downloads >= s.MaxDownloads.Int {
+		return core.NewSystemError(http.StatusGone, "", "share link download limit reached")
+	}
+	return nil
+}
+
+func newSlug() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// CreateShare creates a share link for a node. An empty password means no password.
+// Users the node has been shared with need write access to create links to it.
+func CreateShare(ctx context.Context, node *models.Node, user *models.User, password string,
+	expiresOn null.Time, maxDownloads null.Int, tx boil.ContextExecutor) (*models.Share, error) {
+	if !AccessAllowed(ctx, user, node, true, tx) {
+		return nil, core.NewSystemError(http.StatusUnauthorized, "", "not allowed")
+	}
+
+	if inTrash, err := InTrash(ctx, node, tx); err != nil {
+		return nil, err
+	} else if inTrash {
+		return nil, core.NewSystemError(http.StatusBadRequest, "", "nodes in the trash cannot be shared")
+	}
+
+	if maxDownloads.Valid && maxDownloads.Int <= 0 {
+		return nil, core.NewSystemError(http.StatusBadRequest, "", "invalid download limit")
+	}
+
+	slug, err := newSlug()
+	if err != nil {
+		return nil, err
+	}
+
+	s := models.Share{
+		Slug:         slug,
+		NodeID:       node.ID,
+		UserID:       user.ID,
+		ExpiresOn:    expiresOn,
+		MaxDownloads: maxDownloads,
+	}
+
+	if password != "" {
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, err
+		}
+		s.Password = null.String{String: string(hash), Valid: true}
+	}
+
+	err = queries.Raw("INSERT INTO shares (slug, node_id, user_id, password, expires_on, max_downloads) "+
+		"VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_on",
+		s.Slug, s.NodeID, s.UserID, s.Password, s.ExpiresOn, s.MaxDownloads).
+		QueryRowContext(ctx, tx).Scan(&s.ID, &s.CreatedOn)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Share %s created for node %d (%s) by %s", s.Slug, node.ID, node.Name, user.Name)
+	return &s, nil
+}
+
+// ShareBySlug returns a share by its slug.
+func ShareBySlug(ctx context.Context, slug string, tx boil.ContextExecutor) (*models.Share, error) {
+	s, err := models.Shares(qm.Where("slug=?", slug)).One(ctx, tx)
+	if err == sql.ErrNoRows {
+		return nil, core.NewSystemError(http.StatusNotFound, "", "share not found")
+	}
+	return s, err
+}
+
+// SharesByUserID returns the shares created by a user, newest first.
+func SharesByUserID(ctx context.Context, userID int, tx boil.ContextExecutor) ([]*models.Share, error) {
+	return models.Shares(qm.Where("user_id=?", userID), qm.OrderBy("created_on DESC")).All(ctx, tx)
+}
+
+// RevokeShare deletes a share. Only the creator of the share or an admin can revoke it.
+func RevokeShare(ctx context.Context, s *models.Share, user *models.User, tx boil.ContextExecutor) error {
+	if !user.Admin && s.UserID != user.ID {
+		return core.NewSystemError(http.StatusUnauthorized, "", "not allowed")
+	}
+
+	if _, err := queries.Raw("DELETE FROM shares WHERE id=$1", s.ID).ExecContext(ctx, tx); err != nil {
+		return err
+	}
+
+	log.Printf("Share %s revoked by %s", s.Slug, user.Name)
+	return nil
+}
+
+// CountShareDownload increments the download count of a share.
+// Fails if the download limit has been reached in the meantime.
+func CountShareDownload(ctx context.Context, s *models.Share, tx boil.ContextExecutor) error {
+	err := queries.Raw("UPDATE shares SET downloads=downloads+1 WHERE id=$1 AND "+
+		"(max_downloads IS NULL OR downloads < max_downloads) RETURNING downloads", s.ID).
+		QueryRowContext(ctx, tx).Scan(&s.Downloads)
+	if err == sql.ErrNoRows {
+		return core.NewSystemError(http.StatusGone, "", "share link download limit reached")
+	}
+	return err
+}
+
+// ShareNode returns a node through a share. The node must be the shared node
+// or be located under it.
+func ShareNode(ctx context.Context, s *models.Share, nodeID int, tx boil.ContextExecutor) (*models.Node, error) {
+	node, err := models.Nodes(qm.Where("id=?", nodeID), qm.And(SubtreeClause(), s.NodeID)).One(ctx, tx)
+	if err == sql.ErrNoRows {
+		return nil, core.NewSystemError(http.StatusNotFound,
+			fmt.Sprintf("node %d not under shared node %d", nodeID, s.NodeID),
+			fmt.Sprintf("node %d not found", nodeID))
+	}
+	return node, err
+}
@@ -0,0 +1,44 @@
+package fs
+
+import (
+	"context"
+
+	"github.com/terotoi/koticloud/server/database"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+// SetNodeText stores the text content of a node for full-text search.
+// The text is indexed with the given text search configuration, such as
+// "english". SQLite databases store the text without an index.
+func SetNodeText(ctx context.Context, nodeID int, text, language string, tx boil.ContextExecutor) error {
+	if database.IsSQLite() {
+		_, err := queries.Raw("INSERT INTO node_texts (node_id, content) VALUES ($1, $2) "+
+			"ON CONFLICT (node_id) DO UPDATE SET content=excluded.content", nodeID, text).ExecContext(ctx, tx)
+		return err
+	}
+
+	_, err := queries.Raw("INSERT INTO node_texts (node_id, content, tsv) "+
+		"VALUES ($1, $2, to_tsvector($3::regconfig, $2)) "+
+		"ON CONFLICT (node_id) DO UPDATE SET content=excluded.content, tsv=excluded.tsv",
+		nodeID, text, language).ExecContext(ctx, tx)
+	return err
+}
+
+// DeleteNodeText removes the stored text content of a node.
+func DeleteNodeText(ctx context.Context, nodeID int, tx boil.ContextExecutor) error {
+	_, err := queries.Raw("DELETE FROM node_texts WHERE node_id=$1", nodeID).ExecContext(ctx, tx)
+	return err
+}
+
+// Copies the stored text content of a node to another node.
+func copyNodeText(ctx context.Context, srcID, dstID int, tx boil.ContextExecutor) error {
+	cols := "content, tsv"
+	if database.IsSQLite() {
+		cols = "content"
+	}
+
+	_, err := queries.Raw("INSERT INTO node_texts (node_id, "+cols+") "+
+		"SELECT $2, "+cols+" FROM node_texts WHERE node_id=$1", srcID, dstID).ExecContext(ctx, tx)
+	return err
+}
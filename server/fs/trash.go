@@ -0,0 +1,298 @@
+package fs
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/terotoi/koticloud/server/core"
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// Selects the IDs of all nodes in the trash of any user.
+var trashNodesQuery = subtreeQuery("SELECT trash_id FROM users", true)
+
+// NotInTrashClause is a query condition excluding the nodes in the trash.
+var NotInTrashClause = "id NOT IN (" + trashNodesQuery + ")"
+
+// TrashRoot returns the trash directory of a user, creating it if needed.
+// Trash directories are root nodes, so they are not visible under the user's
+// home directory.
+func TrashRoot(ctx context.Context, user *models.User, homeRoot string, tx boil.ContextExecutor) (*models.Node, error) {
+	var trashID null.Int
+	err := queries.Raw("SELECT trash_id FROM users WHERE id=$1", user.ID).
+		QueryRowContext(ctx, tx).Scan(&trashID)
+	if err != nil {
+		return nil, err
+	}
+
+	if trashID.Valid {
+		return NodeByID(ctx, trashID.Int, tx)
+	}
+
+	log.Printf("Creating a trash directory for user %s.", user.Name)
+	node, err := MakeDir(ctx, nil, ".trash-"+user.Name, user, homeRoot, false, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = queries.Raw("UPDATE users SET trash_id=$1 WHERE id=$2", node.ID, user.ID).ExecContext(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// InTrash returns true if the node is in a trash directory.
+func InTrash(ctx context.Context, node *models.Node, tx boil.ContextExecutor) (bool, error) {
+	var found bool
+	err := queries.Raw("SELECT EXISTS(SELECT 1 FROM nodes n JOIN users u ON u.trash_id IS NOT NULL "+
+		"JOIN nodes t ON t.id=u.trash_id WHERE n.id=$1 AND "+
+		"(n.id=t.id OR n.path > t.path || '/' AND n.path < t.path || '0'))", node.ID).
+		QueryRowContext(ctx, tx).Scan(&found)
+	return found, err
+}
+
+// TrashItemByNodeID returns the trash record of a node or nil if the node
+// was not deleted into the trash directly.
+func TrashItemByNodeID(ctx context.Context, nodeID int, tx boil.ContextExecutor) (*models.Trash, error) {
+	item, err := models.FindTrash(ctx, tx, nodeID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return item, err
+}
+
+// TrashItems returns trashed nodes of a user, oldest first.
+func TrashItems(ctx context.Context, userID int, tx boil.ContextExecutor) ([]*models.Trash, error) {
+	return models.Trashes(qm.Where("user_id=?", userID), qm.OrderBy("deleted_on")).All(ctx, tx)
+}
+
+// TrashItemsBefore returns trashed nodes of all users deleted before t.
+func TrashItemsBefore(ctx context.Context, t time.Time, tx boil.ContextExecutor) ([]*models.Trash, error) {
+	return models.Trashes(qm.Where("deleted_on < ?", t), qm.OrderBy("deleted_on")).All(ctx, tx)
+}
+
+// Columns of a node changed by relocate.
+var relocateColumns = boil.Whitelist(models.NodeColumns.ParentID, models.NodeColumns.Name)
+
+// Moves a node under a new parent with a new name. The caller must make sure
+// that the name is not taken.
+func relocate(ctx context.Context, node *models.Node, parentID int, name string,
+	homeRoot string, j *Journal, tx boil.ContextExecutor) error {
+	srcPath, err := PhysPath(ctx, node, homeRoot, tx)
+	if err != nil {
+		return err
+	}
+
+	node.ParentID = null.Int{Int: parentID, Valid: true}
+	node.Name = name
+
+	dstPath, err := PhysPath(ctx, node, homeRoot, tx)
+	if err != nil {
+		return err
+	}
+
+	// Contents in the blob store do not move with the node.
+	if srcPath == dstPath {
+		_, err := node.Update(ctx, tx, relocateColumns)
+		return err
+	}
+
+	if err := j.Rename(ctx, srcPath, dstPath, tx); err != nil {
+		return err
+	}
+
+	_, err = node.Update(ctx, tx, relocateColumns)
+	return treeError(err, name)
+}
+
+// Trash moves a node into the trash directory of its owner. The node is renamed
+// by its ID to avoid name conflicts inside the trash. The stored files are
+// moved back by j if tx is not committed.
+func Trash(ctx context.Context, node *models.Node, recursive bool,
+	user *models.User, homeRoot string, j *Journal, tx *sql.Tx) error {
+	if !AccessAllowed(ctx, user, node, true, tx) {
+		return core.NewSystemError(http.StatusUnauthorized, "", "not allowed")
+	}
+
+	if !node.ParentID.Valid {
+		return core.NewSystemError(http.StatusBadRequest, "", "root directories cannot be deleted")
+	}
+
+	if !recursive {
+		children, err := NodesByParentID(ctx, node.ID, tx)
+		if err != nil {
+			return err
+		}
+
+		if len(children) > 0 {
+			return core.NewSystemError(http.StatusBadRequest, "",
+				"directory not empty and recursive deletion not requested")
+		}
+	}
+
+	// Nodes in shared directories go to the trash of their owner.
+	owner := user
+	if node.OwnerID.Valid && node.OwnerID.Int != user.ID {
+		var err error
+		if owner, err = models.FindUser(ctx, tx, node.OwnerID.Int); err != nil {
+			return err
+		}
+	}
+
+	trash, err := TrashRoot(ctx, owner, homeRoot, tx)
+	if err != nil {
+		return err
+	}
+
+	item := models.Trash{
+		NodeID:           node.ID,
+		UserID:           owner.ID,
+		OriginalParentID: node.ParentID,
+		OriginalName:     node.Name,
+		DeletedOn:        time.Now(),
+	}
+
+	if err := relocate(ctx, node, trash.ID, strconv.Itoa(node.ID), homeRoot, j, tx); err != nil {
+		return err
+	}
+
+	_, err = queries.Raw("INSERT INTO trash (node_id, user_id, original_parent_id, original_name, deleted_on) "+
+		"VALUES ($1, $2, $3, $4, $5)",
+		item.NodeID, item.UserID, item.OriginalParentID, item.OriginalName, item.DeletedOn).
+		ExecContext(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Node %d (%s) moved to trash by %s", node.ID, item.OriginalName, user.Name)
+	return nil
+}
+
+// Returns a name not used by any child of parentID. If name is taken,
+// a number is added before the extension: "name (1).ext", "name (2).ext", ...
+func uniqueName(ctx context.Context, name string, parentID int, tx boil.ContextExecutor) (string, error) {
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	if base == "" {
+		base, ext = name, ""
+	}
+
+	candidate := name
+	for i := 1; ; i++ {
+		dup, err := NodeChildByName(ctx, candidate, parentID, tx)
+		if err != nil {
+			return "", err
+		}
+
+		if dup == nil {
+			return candidate, nil
+		}
+		candidate = fmt.Sprintf("%s (%d)%s", base, i, ext)
+	}
+}
+
+// Restore moves a node from the trash back to its original directory.
+// If the original directory no longer exists or is in the trash itself,
+// the node is restored into the owner's home directory. If the original name
+// is taken, a number is added to the name. The stored files are moved back
+// by j if tx is not committed.
+func Restore(ctx context.Context, node *models.Node, user *models.User,
+	homeRoot string, j *Journal, tx *sql.Tx) error {
+	if !AccessAllowed(ctx, user, node, true, tx) {
+		return core.NewSystemError(http.StatusUnauthorized, "", "not allowed")
+	}
+
+	item, err := TrashItemByNodeID(ctx, node.ID, tx)
+	if err != nil {
+		return err
+	}
+
+	if item == nil {
+		return core.NewSystemError(http.StatusBadRequest, "",
+			fmt.Sprintf("node %d is not in the trash", node.ID))
+	}
+
+	var parent *models.Node
+	if item.OriginalParentID.Valid {
+		parent, err = NodeByIDopt(ctx, item.OriginalParentID.Int, tx)
+		if err != nil {
+			return err
+		}
+
+		if parent != nil {
+			if inTrash, err := InTrash(ctx, parent, tx); err != nil {
+				return err
+			} else if inTrash {
+				parent = nil
+			}
+		}
+	}
+
+	if parent == nil {
+		owner, err := models.FindUser(ctx, tx, item.UserID)
+		if err != nil {
+			return err
+		}
+
+		if !owner.RootID.Valid {
+			return core.NewSystemError(http.StatusInternalServerError, "", "user has no valid home directory")
+		}
+
+		if parent, err = NodeByID(ctx, owner.RootID.Int, tx); err != nil {
+			return err
+		}
+	}
+
+	name, err := uniqueName(ctx, item.OriginalName, parent.ID, tx)
+	if err != nil {
+		return err
+	}
+
+	if err := relocate(ctx, node, parent.ID, name, homeRoot, j, tx); err != nil {
+		return err
+	}
+
+	if _, err := queries.Raw("DELETE FROM trash WHERE node_id=$1", node.ID).ExecContext(ctx, tx); err != nil {
+		return err
+	}
+
+	log.Printf("Node %d restored from trash as %s by %s", node.ID, name, user.Name)
+	return nil
+}
+
+// EmptyTrash permanently deletes all nodes in the user's trash. The stored
+// files are removed by j when tx is committed.
+func EmptyTrash(ctx context.Context, user *models.User, homeRoot, thumbRoot string,
+	j *Journal, tx boil.ContextExecutor) ([]*models.Node, error) {
+	items, err := TrashItems(ctx, user.ID, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	var deleted []*models.Node
+	for _, item := range items {
+		node, err := NodeByID(ctx, item.NodeID, tx)
+		if err != nil {
+			return nil, err
+		}
+
+		dels, err := Delete(ctx, node, true, user, homeRoot, thumbRoot, j, tx)
+		if err != nil {
+			return nil, err
+		}
+		deleted = append(deleted, dels...)
+	}
+	return deleted, nil
+}
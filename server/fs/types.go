@@ -10,4 +10,15 @@ type NodeWithProgress struct {
 	models.Node `boil:",bind"`
 	Progress    null.Float32 `boil:"progress.progress" json:"progress"`
 	Volume      null.Float32 `boil:"progress.volume" json:"volume"`
+	TakenOn     null.Time    `boil:"taken_on" json:"taken_on"` // Capture time of a photo
+
+	Checksum           null.String `boil:"checksum" json:"checksum"` // SHA-256 hash of the contents
+	ChecksumVerifiedOn null.Time   `boil:"checksum_verified_on" json:"checksum_verified_on"`
+	ChecksumFailed     bool        `boil:"checksum_failed" json:"checksum_failed"` // Contents do not match the checksum
+
+	// Processing of thumbnails, metadata and text: pending, running, done or
+	// failed, and the number of attempts and the error of the latest one.
+	ProcState    null.String `boil:"proc_state" json:"proc_state"`
+	ProcAttempts null.Int    `boil:"proc_attempts" json:"proc_attempts"`
+	ProcError    null.String `boil:"proc_error" json:"proc_error"`
 }
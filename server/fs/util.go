@@ -1,13 +1,16 @@
 package fs
 
 import (
-	"io"
+	"fmt"
 	"log"
-	"os"
+	"net/http"
 	"strings"
 
 	"github.com/gabriel-vasile/mimetype"
+	"github.com/terotoi/koticloud/server/core"
+	"github.com/terotoi/koticloud/server/database"
 	"github.com/terotoi/koticloud/server/models"
+	"github.com/terotoi/koticloud/server/storage"
 )
 
 const emptyFileType = "text/plain"
@@ -23,40 +26,46 @@ func IsValidName(filename string) bool {
 		strings.ContainsAny(filename, "\r\n/"))
 }
 
+// Translates violations of the constraints of the node tree into errors
+// for the user. name is the name of the node being created or changed.
+func treeError(err error, name string) error {
+	switch {
+	case database.IsUniqueViolation(err):
+		msg := fmt.Sprintf("node already exists: %s", name)
+		return core.NewSystemError(http.StatusConflict, err.Error(), msg)
+	case database.IsCheckViolation(err):
+		return core.NewSystemError(http.StatusBadRequest, err.Error(),
+			"a directory cannot be moved under itself")
+	}
+	return err
+}
+
 // CopyFile copies while from srcPath to dstPath.
 func CopyFile(srcPath, dstPath string) error {
 	log.Printf("Copying file %s to %s", srcPath, dstPath)
+	return storage.CopyFile(srcPath, dstPath)
+}
 
-	// Copy the file
-	sfh, err := os.Open(srcPath)
-	if err != nil {
-		return err
-	}
-	defer sfh.Close()
-
-	dfh, err := os.Create(dstPath)
+// Returns the mime type of a file or a default type. encrypted tells if the
+// file is encrypted.
+func DetectMimeType(filename string, encrypted bool) (string, error) {
+	st, err := storage.Stat(filename, encrypted)
 	if err != nil {
-		return err
+		return "", err
 	}
-	defer dfh.Close()
-
-	_, err = io.Copy(dfh, sfh)
-	return err
-}
 
-// Returns the mime type of a file or a default type.
-func DetectMimeType(filename string) (string, error) {
-	st, err := os.Stat(filename)
+	fh, err := storage.Open(filename, encrypted)
 	if err != nil {
 		return "", err
 	}
+	defer fh.Close()
 
 	// Detect the mime tpye
 	var mimeType string
-	mt, err := mimetype.DetectFile(filename)
+	mt, err := mimetype.DetectReader(fh)
 	if err != nil {
 		log.Printf("unknown file type: %s", filename)
-		if st.Size() == 0 {
+		if st.Size == 0 {
 			mimeType = emptyFileType
 		} else {
 			mimeType = "application/octet-stream"
@@ -0,0 +1,255 @@
+package fs
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/terotoi/koticloud/server/core"
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/terotoi/koticloud/server/storage"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// Directory under the home root where the contents of previous versions are
+// stored. Usernames cannot start with a dot, so it cannot clash with home directories.
+const versionDir = ".versions"
+
+// VersionPath returns the path for the stored contents of a version.
+// If includeFile is false, the directory containing all versions of the node is returned.
+func VersionPath(homeRoot string, nodeID, versionID int, includeFile bool) string {
+	dir := fmt.Sprintf("%s/%s/%08d/%d", homeRoot, versionDir, nodeID/1000*1000, nodeID)
+	if includeFile {
+		return fmt.Sprintf("%s/%d", dir, versionID)
+	}
+	return dir
+}
+
+// VersionDataPath returns the path for the stored contents of a version,
+// which is in the blob store if the version has a blob.
+func VersionDataPath(homeRoot string, v *models.Version) string {
+	if v.Blob.Valid {
+		return BlobPath(homeRoot, v.Blob.String)
+	}
+	return VersionPath(homeRoot, v.NodeID, v.ID, true)
+}
+
+// Versions returns the stored versions of a node, newest first.
+func Versions(ctx context.Context, nodeID int, tx boil.ContextExecutor) ([]*models.Version, error) {
+	return models.Versions(qm.Where("node_id=?", nodeID), qm.OrderBy("version DESC")).All(ctx, tx)
+}
+
+// VersionByID returns a version by its ID.
+func VersionByID(ctx context.Context, id int, tx boil.ContextExecutor) (*models.Version, error) {
+	v, err := models.FindVersion(ctx, tx, id)
+	if err == sql.ErrNoRows {
+		return nil, core.NewSystemError(http.StatusNotFound, "", fmt.Sprintf("version not found: %d", id))
+	}
+	return v, err
+}
+
+// VersionsBefore returns versions of all nodes which were replaced before t.
+func VersionsBefore(ctx context.Context, t time.Time, tx boil.ContextExecutor) ([]*models.Version, error) {
+	return models.Versions(qm.Where("created_on < ?", t)).All(ctx, tx)
+}
+
+// SaveVersion stores the current contents of a file node as a new version.
+// The physical file is copied, and restored from the copy by the journal j if
+// the transaction is not committed, so the caller may write new contents for
+// the node in the same transaction.
+// Returns nil if the node has no contents to save.
+func SaveVersion(ctx context.Context, node *models.Node, user *models.User, homeRoot string,
+	j *Journal, tx boil.ContextExecutor) (*models.Version, error) {
+	if IsDir(node) {
+		return nil, core.NewSystemError(http.StatusBadRequest, "", "directories do not have versions")
+	}
+
+	blob, err := NodeBlob(ctx, node.ID, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, _, err := NodeEncrypted(ctx, node.ID, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := PhysPath(ctx, node, homeRoot, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	st, err := storage.Stat(path, encrypted)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	v := models.Version{
+		NodeID:     node.ID,
+		MimeType:   node.MimeType,
+		Size:       st.Size,
+		ModifiedOn: node.ModifiedOn,
+		UserID:     null.Int{Int: user.ID, Valid: true},
+		Blob:       blob,
+		Encrypted:  encrypted,
+	}
+
+	err = queries.Raw("INSERT INTO versions (node_id, version, mime_type, size, modified_on, user_id, blob, encrypted, checksum) "+
+		"SELECT $1, COALESCE(MAX(version), 0) + 1, $2, $3, $4, $5, $6, $7, "+
+		"(SELECT checksum FROM nodes WHERE id=$1) FROM versions WHERE node_id=$1 "+
+		"RETURNING id, version, created_on, checksum",
+		v.NodeID, v.MimeType, v.Size, v.ModifiedOn, v.UserID, v.Blob, v.Encrypted).
+		QueryRowContext(ctx, tx).Scan(&v.ID, &v.Version, &v.CreatedOn, &v.Checksum)
+	if err != nil {
+		return nil, err
+	}
+
+	// The version takes over the blob from the node.
+	if blob.Valid {
+		log.Printf("Saved version %d of node %d (%s)", v.Version, node.ID, node.Name)
+		return &v, SetNodeBlob(ctx, node, null.String{}, tx)
+	}
+
+	if err := storage.MkdirAll(VersionPath(homeRoot, node.ID, 0, false)); err != nil {
+		return nil, err
+	}
+
+	if err := j.Backup(ctx, path, VersionPath(homeRoot, node.ID, v.ID, true), tx); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Saved version %d of node %d (%s)", v.Version, node.ID, node.Name)
+	return &v, nil
+}
+
+// KeepVersion saves the current contents of a node as a version and prunes
+// old versions of the node. See PruneVersions for keep and maxAge.
+func KeepVersion(ctx context.Context, node *models.Node, user *models.User, homeRoot string,
+	keep int, maxAge time.Duration, j *Journal, tx boil.ContextExecutor) error {
+	if _, err := SaveVersion(ctx, node, user, homeRoot, j, tx); err != nil {
+		return err
+	}
+
+	_, err := PruneVersions(ctx, node.ID, keep, maxAge, homeRoot, j, tx)
+	return err
+}
+
+// RestoreVersion replaces the contents of a node with the contents of a version.
+// The current contents are saved as a new version first.
+func RestoreVersion(ctx context.Context, node *models.Node, v *models.Version, user *models.User,
+	homeRoot string, j *Journal, tx boil.ContextExecutor) error {
+	if !AccessAllowed(ctx, user, node, true, tx) {
+		return core.NewSystemError(http.StatusUnauthorized, "", "not allowed")
+	}
+
+	if v.NodeID != node.ID {
+		return core.NewSystemError(http.StatusBadRequest, "",
+			fmt.Sprintf("version %d does not belong to node %d", v.ID, node.ID))
+	}
+
+	if _, err := SaveVersion(ctx, node, user, homeRoot, j, tx); err != nil {
+		return err
+	}
+
+	if v.Blob.Valid {
+		if err := SetNodeBlob(ctx, node, v.Blob, tx); err != nil {
+			return err
+		}
+	} else {
+		path, err := PhysPath(ctx, node, homeRoot, tx)
+		if err != nil {
+			return err
+		}
+
+		// A node whose contents were in a blob has no file to restore on rollback.
+		vPath := VersionPath(homeRoot, node.ID, v.ID, true)
+		if _, err = storage.Stat(path, false); os.IsNotExist(err) {
+			err = j.Copy(ctx, vPath, path, tx)
+		} else {
+			err = CopyFile(vPath, path)
+		}
+		if err != nil {
+			return core.NewInternalError(err)
+		}
+
+		if err := SetNodeEncrypted(ctx, node, v.Encrypted, tx); err != nil {
+			return err
+		}
+	}
+
+	if err := SetNodeChecksum(ctx, node, v.Checksum, tx); err != nil {
+		return err
+	}
+
+	node.MimeType = v.MimeType
+	node.Size = null.Int64{Int64: v.Size, Valid: true}
+	node.ModifiedOn = time.Now()
+	if _, err := node.Update(ctx, tx, boil.Whitelist(models.NodeColumns.MimeType, models.NodeColumns.Size,
+		models.NodeColumns.ModifiedOn)); err != nil {
+		return err
+	}
+
+	log.Printf("Node %d (%s) restored to version %d by %s", node.ID, node.Name, v.Version, user.Name)
+	return nil
+}
+
+// DeleteVersion deletes a version. Its stored contents are removed by the
+// journal j once the transaction has been committed.
+func DeleteVersion(ctx context.Context, v *models.Version, homeRoot string, j *Journal, tx boil.ContextExecutor) error {
+	if _, err := queries.Raw("DELETE FROM versions WHERE id=$1", v.ID).ExecContext(ctx, tx); err != nil {
+		return err
+	}
+
+	// Blobs are removed by the garbage collector when no longer referenced.
+	if v.Blob.Valid {
+		return nil
+	}
+
+	if err := j.Remove(ctx, VersionPath(homeRoot, v.NodeID, v.ID, true), tx); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// PruneVersions deletes versions of a node exceeding the retention policy.
+// Only the newest keep versions are kept, unless keep is negative.
+// Versions older than maxAge are deleted, unless maxAge is zero.
+// Returns the number of deleted versions.
+func PruneVersions(ctx context.Context, nodeID int, keep int, maxAge time.Duration,
+	homeRoot string, j *Journal, tx boil.ContextExecutor) (int, error) {
+	versions, err := Versions(ctx, nodeID, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for i, v := range versions {
+		if (keep >= 0 && i >= keep) || (maxAge > 0 && time.Since(v.CreatedOn) > maxAge) {
+			if err := DeleteVersion(ctx, v, homeRoot, j, tx); err != nil {
+				return count, err
+			}
+			count++
+		}
+	}
+	return count, nil
+}
+
+// StorageUsed returns the number of bytes used by the files of a user,
+// including stored versions.
+func StorageUsed(ctx context.Context, userID int, tx boil.ContextExecutor) (int64, error) {
+	var used int64
+	err := queries.Raw("SELECT "+
+		"(SELECT COALESCE(SUM(size), 0) FROM nodes WHERE owner_id=$1) + "+
+		"(SELECT COALESCE(SUM(v.size), 0) FROM versions v JOIN nodes n ON v.node_id=n.id WHERE n.owner_id=$1)",
+		userID).QueryRowContext(ctx, tx).Scan(&used)
+	return used, err
+}
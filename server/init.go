@@ -3,10 +3,19 @@ package main
 import (
 	"context"
 	"database/sql"
+	"flag"
+	"fmt"
 	"log"
+	"path"
+	"time"
 
+	"github.com/terotoi/koticloud/server/core"
+	"github.com/terotoi/koticloud/server/crypt"
+	"github.com/terotoi/koticloud/server/fs"
+	"github.com/terotoi/koticloud/server/migrate"
 	"github.com/terotoi/koticloud/server/models"
 	"github.com/terotoi/koticloud/server/mx"
+	"github.com/terotoi/koticloud/server/storage"
 )
 
 // Creates an initial user if there are no users in the database.
@@ -38,3 +47,134 @@ func createInitialUser(username, password, homeRoot string, db *sql.DB) error {
 
 	return nil
 }
+
+// Loads the master key, if encryption is enabled, and creates data keys for
+// users without one.
+func setupEncryption(cfg *core.Config, db *sql.DB) error {
+	if cfg.MasterKeyFile == "" {
+		return nil
+	}
+
+	key, err := crypt.LoadMasterKey(cfg.MasterKeyFile)
+	if err != nil {
+		return err
+	}
+
+	crypt.SetMasterKey(key)
+	crypt.SetLoader(fs.KeyLoader(db))
+
+	count, err := fs.EnsureUserKeys(context.Background(), db)
+	if err != nil {
+		return err
+	} else if count > 0 {
+		log.Printf("Created data keys for %d users", count)
+	}
+	return nil
+}
+
+// Completes or undoes the file operations left unfinished when the server
+// was stopped.
+func recoverJournal(cfg *core.Config, db *sql.DB) error {
+	count, err := fs.RecoverJournal(context.Background(), cfg.HomeRoot, db)
+	if err != nil {
+		return err
+	} else if count > 0 {
+		log.Printf("Recovered %d unfinished file operations", count)
+	}
+	return nil
+}
+
+// Mounts the storage backend for the home and thumbnail roots.
+func setupStorage(cfg *core.Config) error {
+	switch sc := cfg.Storage; sc.Type {
+	case "", "local":
+		storage.Mount(cfg.HomeRoot, storage.NewLocal(cfg.HomeRoot))
+		storage.Mount(cfg.ThumbRoot, storage.NewLocal(cfg.ThumbRoot))
+
+	case "s3":
+		home, err := storage.NewS3(sc.Endpoint, sc.Region, sc.Bucket, sc.AccessKey, sc.SecretKey,
+			path.Join(sc.Prefix, "home"))
+		if err != nil {
+			return err
+		}
+
+		thumbs, err := storage.NewS3(sc.Endpoint, sc.Region, sc.Bucket, sc.AccessKey, sc.SecretKey,
+			path.Join(sc.Prefix, "thumbs"))
+		if err != nil {
+			return err
+		}
+
+		log.Printf("Storing files in S3 bucket %s at %s", sc.Bucket, sc.Endpoint)
+		storage.Mount(cfg.HomeRoot, home)
+		storage.Mount(cfg.ThumbRoot, thumbs)
+
+	default:
+		return fmt.Errorf("unknown storage type: %s", sc.Type)
+	}
+	return nil
+}
+
+// Applies pending schema migrations and checks that the models match the schema.
+func applyMigrations(db *sql.DB) error {
+	ctx := context.Background()
+	count, err := migrate.Up(ctx, db)
+	if err != nil {
+		return err
+	} else if count > 0 {
+		log.Printf("Applied %d schema migrations", count)
+	}
+
+	if err := migrate.CheckModels(ctx, db); err != nil {
+		log.Printf("Warning: %s", err)
+	}
+	return nil
+}
+
+// Runs the migrate command: up, down [-steps n], status or check.
+func runMigrate(args []string, db *sql.DB) error {
+	if len(args) == 0 {
+		return fmt.Errorf("migrate commands: up, down, status, check")
+	}
+
+	ctx := context.Background()
+	switch args[0] {
+	case "up":
+		count, err := migrate.Up(ctx, db)
+		log.Printf("Applied %d migrations", count)
+		if err != nil {
+			return err
+		}
+		return migrate.CheckModels(ctx, db)
+
+	case "down":
+		flags := flag.NewFlagSet("migrate down", flag.ExitOnError)
+		steps := flags.Int("steps", 1, "number of migrations to revert")
+		flags.Parse(args[1:])
+		count, err := migrate.Down(ctx, *steps, db)
+		log.Printf("Reverted %d migrations", count)
+		return err
+
+	case "status":
+		states, err := migrate.Status(ctx, db)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range states {
+			if s.Applied() {
+				fmt.Printf("%04d %-30s applied on %s\n", s.Version, s.Name, s.AppliedOn.Time.Format(time.RFC3339))
+			} else {
+				fmt.Printf("%04d %-30s pending\n", s.Version, s.Name)
+			}
+		}
+		return nil
+
+	case "check":
+		if err := migrate.CheckModels(ctx, db); err != nil {
+			return err
+		}
+		log.Printf("The models match the database schema")
+		return nil
+	}
+	return fmt.Errorf("unknown migrate command: %s", args[0])
+}
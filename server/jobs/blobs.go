@@ -0,0 +1,222 @@
+package jobs
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"log"
+	"os"
+	"time"
+
+	vfs "github.com/terotoi/koticloud/server/fs"
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/terotoi/koticloud/server/storage"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+const blobGCInterval = 24 * time.Hour
+
+// Moves the contents of one file into the blob store. The original file is
+// removed only after the node references the blob.
+func migrateFile(ctx context.Context, path string, homeRoot string, db *sql.DB,
+	setBlob func(tx *sql.Tx, hash string) error) error {
+	if _, err := storage.Stat(path, false); err != nil {
+		return err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	hash, err := vfs.StoreBlob(ctx, path, homeRoot, tx)
+	if err != nil {
+		return err
+	}
+
+	if err := setBlob(tx, hash); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return storage.Remove(path)
+}
+
+// MigrateToBlobs moves the contents of all files and versions outside the blob
+// store into it. Files with identical contents are stored only once. Files of
+// users with a data key are not migrated, since they are encrypted.
+// Returns the number of migrated files and versions.
+func MigrateToBlobs(ctx context.Context, homeRoot string, db *sql.DB) (int, int, error) {
+	var nodes []*models.Node
+	if err := queries.Raw("SELECT * FROM nodes WHERE type='file' AND blob IS NULL AND NOT encrypted "+
+		"AND NOT EXISTS (SELECT 1 FROM user_keys WHERE user_id=nodes.owner_id) ORDER BY id").
+		Bind(ctx, db, &nodes); err != nil {
+		return 0, 0, err
+	}
+
+	log.Printf("[blobs] Migrating %d files into the blob store", len(nodes))
+
+	files := 0
+	for _, n := range nodes {
+		path, err := vfs.PhysPath(ctx, n, homeRoot, db)
+		if err != nil {
+			return files, 0, err
+		}
+
+		err = migrateFile(ctx, path, homeRoot, db, func(tx *sql.Tx, hash string) error {
+			return vfs.SetNodeBlob(ctx, n, null.StringFrom(hash), tx)
+		})
+		if err != nil {
+			log.Printf("[blobs] %d %s: %s", n.ID, path, err)
+			continue
+		}
+
+		files++
+		if files%1000 == 0 {
+			log.Printf("[blobs] Migrated %d/%d files", files, len(nodes))
+		}
+	}
+
+	var versions []*models.Version
+	if err := queries.Raw("SELECT * FROM versions WHERE blob IS NULL AND NOT encrypted AND node_id NOT IN "+
+		"(SELECT id FROM nodes WHERE owner_id IN (SELECT user_id FROM user_keys)) ORDER BY id").
+		Bind(ctx, db, &versions); err != nil {
+		return files, 0, err
+	}
+
+	log.Printf("[blobs] Migrating %d versions into the blob store", len(versions))
+
+	count := 0
+	for _, v := range versions {
+		path := vfs.VersionDataPath(homeRoot, v)
+		err := migrateFile(ctx, path, homeRoot, db, func(tx *sql.Tx, hash string) error {
+			_, err := queries.Raw("UPDATE versions SET blob=$2 WHERE id=$1", v.ID, hash).ExecContext(ctx, tx)
+			return err
+		})
+		if err != nil {
+			log.Printf("[blobs] version %d %s: %s", v.ID, path, err)
+			continue
+		}
+		count++
+	}
+
+	return files, count, nil
+}
+
+// CollectBlobs removes blobs no longer referenced by any node or version, and
+// files in the blob store unknown to the database older than maxAge, which are
+// left over from failed writes. Returns the number of removed blobs.
+func CollectBlobs(ctx context.Context, homeRoot string, maxAge time.Duration, db *sql.DB) (int, error) {
+	fixed, err := vfs.RecountBlobRefs(ctx, db)
+	if err != nil {
+		return 0, err
+	} else if fixed > 0 {
+		log.Printf("[blobs] Fixed reference counts of %d blobs", fixed)
+	}
+
+	var hashes []string
+	rows, err := db.QueryContext(ctx, "SELECT hash FROM blobs WHERE refs=0")
+	if err != nil {
+		return 0, err
+	}
+
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		hashes = append(hashes, hash)
+	}
+	rows.Close()
+
+	count := 0
+	for _, hash := range hashes {
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return count, err
+		}
+
+		// The file is removed while the row is locked, see StoreBlob.
+		deleted, err := vfs.DeleteBlob(ctx, hash, homeRoot, tx)
+		if err == nil {
+			err = tx.Commit()
+		}
+		tx.Rollback()
+
+		if err != nil {
+			log.Printf("[blobs] %s: %s", hash, err)
+		} else if deleted {
+			count++
+		}
+	}
+
+	orphans, err := removeOrphanBlobs(ctx, homeRoot, maxAge, db)
+	return count + orphans, err
+}
+
+// Removes files in the blob store without a row in the database.
+func removeOrphanBlobs(ctx context.Context, homeRoot string, maxAge time.Duration, db *sql.DB) (int, error) {
+	root := vfs.BlobRoot(homeRoot)
+	if _, err := storage.Stat(root, false); os.IsNotExist(err) {
+		return 0, nil
+	}
+
+	count := 0
+	err := storage.Walk(root, func(path string, info *storage.FileInfo, err error) error {
+		if err != nil || info.IsDir {
+			return err
+		}
+
+		if time.Since(info.ModTime) < maxAge {
+			return nil
+		}
+
+		// Partially written blobs have temporary names.
+		name := info.Name
+		if len(name) == sha256.Size*2 {
+			var exists bool
+			if err := db.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM blobs WHERE hash=$1)",
+				name).Scan(&exists); err != nil {
+				return err
+			} else if exists {
+				return nil
+			}
+		}
+
+		log.Printf("[blobs] Removing unknown file %s", path)
+		if err := storage.Remove(path); err != nil {
+			log.Println(err)
+		} else {
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// RunBlobCollector periodically removes unreferenced blobs.
+// Returns when ctx is cancelled.
+func RunBlobCollector(ctx context.Context, homeRoot string, maxAge time.Duration, db *sql.DB) {
+	ticker := time.NewTicker(blobGCInterval)
+	defer ticker.Stop()
+
+	for {
+		count, err := CollectBlobs(ctx, homeRoot, maxAge, db)
+		if err != nil {
+			log.Printf("[blobs] %s", err)
+		} else if count > 0 {
+			log.Printf("[blobs] removed %d unreferenced blobs", count)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
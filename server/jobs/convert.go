@@ -0,0 +1,244 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/terotoi/koticloud/server/database"
+	"github.com/terotoi/koticloud/server/migrate"
+)
+
+// Tables copied by ConvertDB, parents before the tables referring to them.
+// The journal and schema_migrations are not copied.
+var convertTables = []string{
+	"users", "groups", "group_members", "blobs", "nodes", "versions", "infos", "node_texts",
+	"node_process_reqs", "progress", "shares", "grants", "trash", "user_keys", "jobs", "job_logs",
+}
+
+// Orders rows so that referenced rows are copied first. Parents of nodes
+// have shorter paths.
+var convertOrder = map[string]string{
+	"nodes": "path",
+}
+
+// ConvertDB copies all data from the database src into the database of the
+// configuration string dstConfig, which may use another dialect. The schema
+// of the destination is migrated first, and it must not contain any nodes or
+// users. Text copied into PostgreSQL is indexed with the given text search
+// configuration. The data is copied in a single transaction. Returns the
+// number of copied rows.
+func ConvertDB(ctx context.Context, dstConfig, language string, src *sql.DB) (int64, error) {
+	states, err := migrate.Status(ctx, src)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, s := range states {
+		if !s.Applied() {
+			return 0, fmt.Errorf("migration %04d %s is not applied to the source database", s.Version, s.Name)
+		}
+	}
+
+	dst, dialect, err := database.OpenDialect(dstConfig)
+	if err != nil {
+		return 0, err
+	}
+	defer dst.Close()
+
+	if _, err := migrate.Up(ctx, dst); err != nil {
+		return 0, err
+	}
+
+	var count int
+	if err := dst.QueryRowContext(ctx, "SELECT (SELECT count(*) FROM nodes) + (SELECT count(*) FROM users)").
+		Scan(&count); err != nil {
+		return 0, err
+	} else if count > 0 {
+		return 0, fmt.Errorf("the destination database is not empty")
+	}
+
+	tx, err := dst.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var total int64
+	var serial []string
+	for _, table := range convertTables {
+		n, cols, err := convertTable(ctx, table, language, src, tx)
+		if err != nil {
+			return total, fmt.Errorf("%s: %s", table, err)
+		}
+
+		log.Printf("[convert] %s: %d rows", table, n)
+		total += n
+		if _, ok := cols["id"]; ok {
+			serial = append(serial, table)
+		}
+	}
+
+	// Home directories are set once the nodes have been copied.
+	rows, err := src.QueryContext(ctx, "SELECT id, root_id, trash_id FROM users")
+	if err != nil {
+		return total, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int
+		var rootID, trashID sql.NullInt64
+		if err := rows.Scan(&id, &rootID, &trashID); err != nil {
+			return total, err
+		} else if _, err := tx.ExecContext(ctx, "UPDATE users SET root_id=$2, trash_id=$3 WHERE id=$1",
+			id, rootID, trashID); err != nil {
+			return total, err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return total, err
+	}
+
+	// Sequences of PostgreSQL continue after the copied IDs. SQLite keeps
+	// track of the largest ID itself.
+	if dialect == database.Postgres {
+		for _, table := range serial {
+			if _, err := tx.ExecContext(ctx, "SELECT setval(pg_get_serial_sequence($1, 'id'), "+
+				"COALESCE(max(id), 0) + 1, false) FROM "+table, table); err != nil {
+				return total, err
+			}
+		}
+	}
+	return total, tx.Commit()
+}
+
+// Copies the rows of a table from src into the destination database in tx.
+// Columns missing from the destination are left out. Returns the number of
+// copied rows and the columns of the destination table by type.
+func convertTable(ctx context.Context, table, language string, src *sql.DB,
+	tx *sql.Tx) (int64, map[string]string, error) {
+	dstCols, err := tableColumns(ctx, table, tx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	query := "SELECT * FROM " + table
+	if order := convertOrder[table]; order != "" {
+		query += " ORDER BY " + order
+	}
+
+	rows, err := src.QueryContext(ctx, query)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer rows.Close()
+
+	srcCols, err := rows.Columns()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var cols, params []string
+	var copied []int
+	content, hasTsv := 0, false
+	for i, c := range srcCols {
+		if _, ok := dstCols[c]; ok {
+			cols = append(cols, c)
+			params = append(params, fmt.Sprintf("$%d", len(cols)))
+			copied = append(copied, i)
+		}
+
+		if c == "content" {
+			content = len(cols)
+		} else if c == "tsv" {
+			hasTsv = true
+		}
+	}
+
+	// Text copied from SQLite into PostgreSQL is indexed.
+	var extra []interface{}
+	if _, ok := dstCols["tsv"]; ok && content > 0 && !hasTsv {
+		cols = append(cols, "tsv")
+		params = append(params, fmt.Sprintf("to_tsvector($%d::regconfig, $%d)", len(params)+1, content))
+		extra = append(extra, language)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, "INSERT INTO "+table+" ("+strings.Join(cols, ", ")+") VALUES ("+
+		strings.Join(params, ", ")+")")
+	if err != nil {
+		return 0, nil, err
+	}
+	defer stmt.Close()
+
+	values := make([]interface{}, len(srcCols))
+	ptrs := make([]interface{}, len(srcCols))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+
+	var count int64
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return count, nil, err
+		}
+
+		args := make([]interface{}, 0, len(cols))
+		for _, i := range copied {
+			args = append(args, convertValue(table, srcCols[i], dstCols[srcCols[i]], values[i]))
+		}
+
+		if _, err := stmt.ExecContext(ctx, append(args, extra...)...); err != nil {
+			return count, nil, err
+		}
+		count++
+	}
+	return count, dstCols, rows.Err()
+}
+
+// Values replaced while copying. Reference counts of blobs are counted
+// again by triggers as nodes and versions are copied, and home directories
+// are set after the nodes have been copied.
+var convertReplace = map[string]map[string]interface{}{
+	"blobs": {"refs": 0},
+	"users": {"root_id": nil, "trash_id": nil},
+}
+
+// Returns a value of a column of the source database as a value for the
+// destination column of the given type. Binary values are only kept for
+// binary columns, others such as JSON are copied as text.
+func convertValue(table, col, dstType string, v interface{}) interface{} {
+	if r, ok := convertReplace[table]; ok {
+		if rv, ok := r[col]; ok {
+			return rv
+		}
+	}
+
+	if b, ok := v.([]byte); ok && dstType != "BYTEA" && dstType != "BLOB" {
+		return string(b)
+	}
+	return v
+}
+
+// Returns the columns of a table in tx by their database type, such as "BYTEA".
+func tableColumns(ctx context.Context, table string, tx *sql.Tx) (map[string]string, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT * FROM "+table+" WHERE 1=0")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	cols := make(map[string]string, len(types))
+	for _, t := range types {
+		cols[t.Name()] = strings.ToUpper(t.DatabaseTypeName())
+	}
+	return cols, rows.Err()
+}
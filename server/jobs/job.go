@@ -0,0 +1,350 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/terotoi/koticloud/server/database"
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// Kinds of jobs. Only one job of a kind runs at a time.
+const (
+	JobScan        = "scan"
+	JobThumbnails  = "thumbnails"
+	JobIndexText   = "index-text"
+	JobExtractInfo = "extract-info"
+	JobScrub       = "scrub"
+)
+
+// States of jobs.
+const (
+	JobRunning   = "running"
+	JobDone      = "done"
+	JobFailed    = "failed"
+	JobCancelled = "cancelled"
+)
+
+// Interval of storing the progress and the log lines of running jobs, and
+// of checking whether they have been cancelled.
+const jobFlushInterval = time.Second
+
+// Number of log lines stored for a job. Later lines are only logged.
+const jobMaxLogLines = 10000
+
+// Finished jobs are removed after this long.
+const jobMaxAge = 30 * 24 * time.Hour
+
+// A running job whose progress has not been stored for this long has
+// stopped with its process. updated_on serves as the heartbeat of a job.
+const jobStaleAfter = time.Minute
+
+// ErrJobRunning is returned by StartJob if a job of the same kind is already running.
+var ErrJobRunning = errors.New("a job of the same kind is already running")
+
+// Job is a background task started by an administrator, such as a scan.
+// Jobs are stored in the database, so that their progress and results can
+// be followed by clients and remain after a restart.
+type Job struct {
+	models.Job `boil:",bind"`
+	User       null.String `boil:"user_name" json:"user_name"` // Name of the user who started the job
+}
+
+// JobFunc does the work of a job, and should return soon after ctx is
+// cancelled. The result is stored as JSON, also with an error.
+type JobFunc func(ctx context.Context) (interface{}, error)
+
+// A job running in this process.
+type runningJob struct {
+	id     int
+	cancel context.CancelFunc
+
+	mutex  sync.Mutex
+	done   int64
+	total  int64
+	lines  []*models.JobLog // Lines not stored yet
+	logged int              // Number of lines logged
+}
+
+type jobKey struct{}
+
+// Jobs running in this process by ID, and a lock serialising the starts of
+// jobs in this process. A unique index allows one running job of a kind
+// across processes.
+var runningJobs = map[int]*runningJob{}
+var runningMutex sync.Mutex
+
+// StartJob records a new job and runs it in the background. Returns an
+// error wrapping ErrJobRunning if a job of the same kind is already running.
+// args are stored as JSON. Lines logged with the context of the job, see
+// logf, are stored with the job.
+func StartJob(kind string, args interface{}, user *models.User, run JobFunc, db *sql.DB) (*Job, error) {
+	ctx := context.Background()
+	data, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+
+	runningMutex.Lock()
+	defer runningMutex.Unlock()
+
+	if _, err := FailStaleJobs(ctx, db); err != nil {
+		return nil, err
+	}
+
+	var id int
+	err = db.QueryRowContext(ctx, "SELECT id FROM jobs WHERE kind=$1 AND state=$2", kind, JobRunning).Scan(&id)
+	if err == nil {
+		return nil, fmt.Errorf("%w: job %d", ErrJobRunning, id)
+	} else if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	if _, err := db.ExecContext(ctx, "DELETE FROM jobs WHERE state<>$1 AND finished_on<$2",
+		JobRunning, time.Now().Add(-jobMaxAge)); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	err = db.QueryRowContext(ctx, "INSERT INTO jobs (kind, args, user_id, state, created_on, updated_on) "+
+		"VALUES ($1, $2, $3, $4, $5, $5) RETURNING id", kind, string(data), user.ID, JobRunning, now).Scan(&id)
+	if database.IsUniqueViolation(err) {
+		// Started by another server at the same time.
+		return nil, ErrJobRunning
+	} else if err != nil {
+		return nil, err
+	}
+
+	jobCtx, cancel := context.WithCancel(ctx)
+	j := &runningJob{id: id, cancel: cancel}
+	runningJobs[id] = j
+
+	log.Printf("[jobs] started job %d: %s issued by %s", id, kind, user.Name)
+	go j.run(context.WithValue(jobCtx, jobKey{}, j), kind, run, db)
+
+	return JobByID(ctx, id, db)
+}
+
+// Runs a job, storing its progress and log lines until it returns.
+func (j *runningJob) run(ctx context.Context, kind string, run JobFunc, db *sql.DB) {
+	type result struct {
+		value interface{}
+		err   error
+	}
+
+	finished := make(chan result, 1)
+	go func() {
+		value, err := run(ctx)
+		finished <- result{value, err}
+	}()
+
+	tick := time.NewTicker(jobFlushInterval)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-tick.C:
+			if cancel, err := j.flush(db); err != nil {
+				log.Printf("[jobs] job %d: %s", j.id, err)
+			} else if cancel {
+				j.cancel()
+			}
+
+		case res := <-finished:
+			state := JobDone
+			var errText null.String
+			if res.err != nil {
+				errText = null.StringFrom(res.err.Error())
+				if ctx.Err() != nil {
+					state = JobCancelled
+				} else {
+					state = JobFailed
+				}
+			}
+
+			var resText null.String
+			if res.value != nil {
+				if data, err := json.Marshal(res.value); err != nil {
+					log.Printf("[jobs] job %d: %s", j.id, err)
+				} else {
+					resText = null.StringFrom(string(data))
+				}
+			}
+
+			if _, err := j.flush(db); err != nil {
+				log.Printf("[jobs] job %d: %s", j.id, err)
+			}
+
+			if _, err := db.Exec("UPDATE jobs SET state=$2, result=$3, error=$4, finished_on=$5 WHERE id=$1",
+				j.id, state, resText, errText, time.Now()); err != nil {
+				log.Printf("[jobs] job %d: %s", j.id, err)
+			}
+
+			runningMutex.Lock()
+			delete(runningJobs, j.id)
+			runningMutex.Unlock()
+			j.cancel()
+
+			log.Printf("[jobs] job %d: %s %s", j.id, kind, state)
+			return
+		}
+	}
+}
+
+// Runs fn in a transaction, which is committed if fn succeeds.
+func withTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Stores the progress and the new log lines of a job. Returns true if the
+// job has been cancelled.
+func (j *runningJob) flush(db *sql.DB) (bool, error) {
+	j.mutex.Lock()
+	done, total, lines := j.done, j.total, j.lines
+	j.lines = nil
+	j.mutex.Unlock()
+
+	ctx := context.Background()
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	for _, l := range lines {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO job_logs (job_id, logged_on, line) VALUES ($1, $2, $3)",
+			j.id, l.LoggedOn, l.Line); err != nil {
+			return false, err
+		}
+	}
+
+	var cancel bool
+	if err := tx.QueryRowContext(ctx, "UPDATE jobs SET done=$2, total=$3, updated_on=$4 WHERE id=$1 RETURNING cancel",
+		j.id, done, total, time.Now()).Scan(&cancel); err != nil {
+		return false, err
+	}
+	return cancel, tx.Commit()
+}
+
+// Returns the job running with a context, or nil.
+func jobOf(ctx context.Context) *runningJob {
+	j, _ := ctx.Value(jobKey{}).(*runningJob)
+	return j
+}
+
+// Sets the number of items a job handles.
+func (j *runningJob) setTotal(total int) {
+	if j != nil {
+		j.mutex.Lock()
+		j.total = int64(total)
+		j.mutex.Unlock()
+	}
+}
+
+// Counts an item handled by a job.
+func (j *runningJob) step() {
+	if j != nil {
+		j.mutex.Lock()
+		j.done++
+		j.mutex.Unlock()
+	}
+}
+
+// Logs a line, and stores it with the job running with ctx, if any.
+func logf(ctx context.Context, format string, args ...interface{}) {
+	line := fmt.Sprintf(format, args...)
+	log.Print(line)
+
+	if j := jobOf(ctx); j != nil {
+		j.mutex.Lock()
+		defer j.mutex.Unlock()
+
+		if j.logged < jobMaxLogLines {
+			j.lines = append(j.lines, &models.JobLog{LoggedOn: time.Now(), Line: line})
+		} else if j.logged == jobMaxLogLines {
+			j.lines = append(j.lines, &models.JobLog{LoggedOn: time.Now(), Line: "(further lines are not stored)"})
+		}
+		j.logged++
+	}
+}
+
+// CancelJob requests a running job to stop. Returns false if the job is not running.
+func CancelJob(ctx context.Context, id int, db *sql.DB) (bool, error) {
+	res, err := db.ExecContext(ctx, "UPDATE jobs SET cancel=true WHERE id=$1 AND state=$2", id, JobRunning)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil || n == 0 {
+		return false, err
+	}
+
+	// Jobs of other processes stop when they see the request.
+	runningMutex.Lock()
+	if j := runningJobs[id]; j != nil {
+		j.cancel()
+	}
+	runningMutex.Unlock()
+	return true, nil
+}
+
+// Query selecting jobs with the names of the users who started them.
+const jobsQuery = "SELECT j.*, u.name AS user_name FROM jobs j LEFT JOIN users u ON u.id=j.user_id"
+
+// ListJobs returns the latest jobs, newest first.
+func ListJobs(ctx context.Context, limit int, db *sql.DB) ([]*Job, error) {
+	var jobs []*Job
+	err := queries.Raw(jobsQuery+" ORDER BY j.id DESC LIMIT $1", limit).Bind(ctx, db, &jobs)
+	return jobs, err
+}
+
+// JobByID returns a job, or nil if it does not exist.
+func JobByID(ctx context.Context, id int, db *sql.DB) (*Job, error) {
+	var jobs []*Job
+	if err := queries.Raw(jobsQuery+" WHERE j.id=$1", id).Bind(ctx, db, &jobs); err != nil || len(jobs) == 0 {
+		return nil, err
+	}
+	return jobs[0], nil
+}
+
+// JobLog returns the stored log lines of a job.
+func JobLog(ctx context.Context, id int, db *sql.DB) ([]*models.JobLog, error) {
+	return models.JobLogs(qm.Where("job_id=?", id), qm.OrderBy("id")).All(ctx, db)
+}
+
+// FailStaleJobs marks the running jobs which have not stored their progress
+// lately as failed, since the server running them has stopped. Returns the
+// number of failed jobs.
+func FailStaleJobs(ctx context.Context, db *sql.DB) (int64, error) {
+	now := time.Now()
+	res, err := db.ExecContext(ctx, "UPDATE jobs SET state=$1, error=$2, finished_on=$3 WHERE state=$4 AND updated_on<$5",
+		JobFailed, "interrupted by a restart of the server", now, JobRunning, now.Add(-jobStaleAfter))
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := res.RowsAffected()
+	if n > 0 {
+		log.Printf("[jobs] %d interrupted jobs failed", n)
+	}
+	return n, err
+}
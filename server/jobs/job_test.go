@@ -0,0 +1,180 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// Inserts the user starting the jobs of a test.
+func insertJobUser(t *testing.T, db *sql.DB) *models.User {
+	user := &models.User{Name: "admin", Admin: true}
+	if err := user.Insert(context.Background(), db, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	return user
+}
+
+// Waits until a job is no longer running, and returns it.
+func waitJob(t *testing.T, id int, db *sql.DB) *Job {
+	t.Helper()
+	for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); {
+		j, err := JobByID(context.Background(), id, db)
+		if err != nil {
+			t.Fatal(err)
+		} else if j.State != JobRunning {
+			return j
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("job %d is still running", id)
+	return nil
+}
+
+// Runs until the job is cancelled.
+func runUntilCancelled(ctx context.Context) (interface{}, error) {
+	<-ctx.Done()
+	return "stopped", ctx.Err()
+}
+
+// The progress, the log lines and the result of a job are stored.
+func TestStartJob(t *testing.T) {
+	forEachDB(t, func(t *testing.T, db *sql.DB) {
+		ctx := context.Background()
+		user := insertJobUser(t, db)
+
+		j, err := StartJob(JobScan, map[string]string{"Path": "/a"}, user, func(ctx context.Context) (interface{}, error) {
+			jobOf(ctx).setTotal(2)
+			for i := 0; i < 2; i++ {
+				logf(ctx, "item %d", i)
+				jobOf(ctx).step()
+			}
+			return map[string]int{"New": 2}, nil
+		}, db)
+		if err != nil {
+			t.Fatal(err)
+		} else if j.State != JobRunning || j.User.String != user.Name || j.Args.String != `{"Path":"/a"}` {
+			t.Errorf("started job %s by %s with %s", j.State, j.User.String, j.Args.String)
+		}
+
+		j = waitJob(t, j.ID, db)
+		if j.State != JobDone || j.Done != 2 || j.Total != 2 || j.Result.String != `{"New":2}` ||
+			j.Error.Valid || !j.FinishedOn.Valid {
+			t.Errorf("finished job %s, %d/%d, result %s, error %s", j.State, j.Done, j.Total, j.Result.String,
+				j.Error.String)
+		}
+
+		lines, err := JobLog(ctx, j.ID, db)
+		if err != nil {
+			t.Fatal(err)
+		} else if len(lines) != 2 || lines[0].Line != "item 0" || lines[1].Line != "item 1" {
+			t.Errorf("stored %d log lines", len(lines))
+		}
+
+		j, err = StartJob(JobScrub, nil, user, func(ctx context.Context) (interface{}, error) {
+			return nil, errors.New("disk on fire")
+		}, db)
+		if err != nil {
+			t.Fatal(err)
+		} else if j = waitJob(t, j.ID, db); j.State != JobFailed || j.Error.String != "disk on fire" {
+			t.Errorf("failed job %s with %q", j.State, j.Error.String)
+		}
+	})
+}
+
+// One job of a kind runs at a time, and a cancelled job stops, whether
+// cancelled in this process or by another one through the database.
+func TestCancelJob(t *testing.T) {
+	forEachDB(t, func(t *testing.T, db *sql.DB) {
+		ctx := context.Background()
+		user := insertJobUser(t, db)
+
+		scan, err := StartJob(JobScan, nil, user, runUntilCancelled, db)
+		if err != nil {
+			t.Fatal(err)
+		} else if _, err := StartJob(JobScan, nil, user, runUntilCancelled, db); !errors.Is(err, ErrJobRunning) {
+			t.Errorf("started a second scan: %v", err)
+		}
+
+		scrub, err := StartJob(JobScrub, nil, user, runUntilCancelled, db)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if ok, err := CancelJob(ctx, scan.ID, db); err != nil || !ok {
+			t.Errorf("cancelled %t: %v", ok, err)
+		} else if j := waitJob(t, scan.ID, db); j.State != JobCancelled || j.Result.String != `"stopped"` {
+			t.Errorf("cancelled job %s with %s", j.State, j.Result.String)
+		}
+
+		if ok, err := CancelJob(ctx, scan.ID, db); err != nil || ok {
+			t.Errorf("cancelled a finished job: %v", err)
+		}
+
+		// Cancelled by another process, which only sets the flag.
+		if _, err := db.Exec("UPDATE jobs SET cancel=true WHERE id=$1", scrub.ID); err != nil {
+			t.Fatal(err)
+		} else if j := waitJob(t, scrub.ID, db); j.State != JobCancelled {
+			t.Errorf("cancelled job %s", j.State)
+		}
+
+		if j, err := StartJob(JobScan, nil, user, func(ctx context.Context) (interface{}, error) {
+			return nil, nil
+		}, db); err != nil {
+			t.Errorf("scan after a cancelled one: %v", err)
+		} else {
+			waitJob(t, j.ID, db)
+		}
+	})
+}
+
+// A running job which has stopped storing its progress, since its process
+// has stopped, fails and no longer prevents starting a job of its kind.
+func TestFailStaleJobs(t *testing.T) {
+	forEachDB(t, func(t *testing.T, db *sql.DB) {
+		ctx := context.Background()
+		user := insertJobUser(t, db)
+
+		var stale int
+		if err := db.QueryRow("INSERT INTO jobs (kind, args, user_id, state, created_on, updated_on) "+
+			"VALUES ($1, $2, $3, $4, $5, $5) RETURNING id", JobScan, "null", user.ID, JobRunning,
+			time.Now().Add(-2*jobStaleAfter)).Scan(&stale); err != nil {
+			t.Fatal(err)
+		}
+
+		// A job storing its progress is not stale.
+		live, err := StartJob(JobScrub, nil, user, runUntilCancelled, db)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() {
+			CancelJob(ctx, live.ID, db)
+			waitJob(t, live.ID, db)
+		}()
+
+		j, err := StartJob(JobScan, nil, user, func(ctx context.Context) (interface{}, error) {
+			return nil, nil
+		}, db)
+		if err != nil {
+			t.Fatal(err)
+		}
+		waitJob(t, j.ID, db)
+
+		if j, err := JobByID(ctx, stale, db); err != nil {
+			t.Fatal(err)
+		} else if j.State != JobFailed || !j.Error.Valid || !j.FinishedOn.Valid {
+			t.Errorf("stale job %s with %q", j.State, j.Error.String)
+		}
+
+		if n, err := FailStaleJobs(ctx, db); err != nil || n != 0 {
+			t.Errorf("failed %d jobs: %v", n, err)
+		} else if j, err := JobByID(ctx, live.ID, db); err != nil || j.State != JobRunning {
+			t.Errorf("running job: %v", err)
+		}
+	})
+}
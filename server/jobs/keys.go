@@ -0,0 +1,157 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/terotoi/koticloud/server/core"
+	"github.com/terotoi/koticloud/server/crypt"
+	"github.com/terotoi/koticloud/server/fs"
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+// RotateKeys rotates the encryption keys. If oldMasterFile is given, the data
+// keys wrapped by the master key in it are rewrapped with the current master
+// key. Unless masterOnly is true, each user with a data key wrapped by the
+// master key then gets a new data key, and the files of the user are
+// re-encrypted with it. Returns the number of re-encrypted files.
+func RotateKeys(ctx context.Context, cfg *core.Config, oldMasterFile string, masterOnly bool,
+	db *sql.DB) (int, error) {
+	if !crypt.Enabled() {
+		return 0, fmt.Errorf("encryption is not enabled, set master_key_file in the configuration")
+	}
+
+	if oldMasterFile != "" {
+		oldMaster, err := crypt.LoadMasterKey(oldMasterFile)
+		if err != nil {
+			return 0, err
+		}
+
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return 0, err
+		}
+		defer tx.Rollback()
+
+		count, err := fs.RewrapKeys(ctx, oldMaster, tx)
+		if err != nil {
+			return 0, err
+		} else if err := tx.Commit(); err != nil {
+			return 0, err
+		}
+		log.Printf("[keys] Rewrapped %d data keys with the new master key", count)
+	}
+
+	if masterOnly {
+		return 0, nil
+	}
+
+	var users []*models.User
+	if err := queries.Raw("SELECT * FROM users WHERE id IN (SELECT user_id FROM user_keys) ORDER BY id").
+		Bind(ctx, db, &users); err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, u := range users {
+		files, err := rotateUserKey(ctx, u, cfg.HomeRoot, cfg.ThumbRoot, db)
+		total += files
+		if err != nil {
+			log.Printf("[keys] %s: %s", u.Name, err)
+		}
+	}
+	return total, nil
+}
+
+// Creates a new data key for a user and re-encrypts the files, versions,
+// thumbnails and metadata of the user with it. Files stored as plaintext are
+// encrypted, except files in the blob store, which may be shared with other
+// users. The previous keys are removed if everything was re-encrypted.
+//
+// The data key is locked while it is replaced and while each node is
+// re-encrypted, so that files written with a previous key are re-encrypted
+// too, and no re-encryption replaces a file that is being written.
+func rotateUserKey(ctx context.Context, user *models.User, homeRoot, thumbRoot string, db *sql.DB) (int, error) {
+	keys, err := fs.UserKeys(ctx, user.ID, db)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, k := range keys {
+		if k.Salt.Valid {
+			log.Printf("[keys] %s: data key is protected by a passphrase, skipped", user.Name)
+			return 0, nil
+		}
+	}
+
+	var key *crypt.Key
+	if err := withKeyLock(ctx, user.ID, db, func(tx *sql.Tx) error {
+		key, err = fs.CreateUserKey(ctx, user.ID, tx)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	var nodes []*models.Node
+	if err := queries.Raw("SELECT * FROM nodes WHERE owner_id=$1 AND type='file' ORDER BY id", user.ID).
+		Bind(ctx, db, &nodes); err != nil {
+		return 0, err
+	}
+
+	log.Printf("[keys] %s: re-encrypting %d files with key version %d", user.Name, len(nodes), key.Version)
+
+	count, failed := 0, 0
+	for _, n := range nodes {
+		if err := withKeyLock(ctx, user.ID, db, func(tx *sql.Tx) error {
+			files, err := fs.ReencryptNode(ctx, n, key, homeRoot, thumbRoot, tx)
+			count += files
+			return err
+		}); err != nil {
+			log.Printf("[keys] node %d: %s", n.ID, err)
+			failed++
+		}
+	}
+
+	// Text indexed before the files were encrypted is removed.
+	if _, err := queries.Raw("DELETE FROM node_texts WHERE node_id IN (SELECT id FROM nodes WHERE owner_id=$1)",
+		user.ID).ExecContext(ctx, db); err != nil {
+		return count, err
+	}
+
+	if failed > 0 {
+		return count, fmt.Errorf("%d files failed, previous keys kept", failed)
+	}
+
+	// Files written with a previous key after they were listed above, such
+	// as versions, are re-encrypted before the previous keys are removed.
+	err = withKeyLock(ctx, user.ID, db, func(tx *sql.Tx) error {
+		nodes = nil
+		if err := queries.Raw("SELECT * FROM nodes WHERE owner_id=$1 AND type='file' ORDER BY id", user.ID).
+			Bind(ctx, tx, &nodes); err != nil {
+			return err
+		}
+
+		for _, n := range nodes {
+			files, err := fs.ReencryptNode(ctx, n, key, homeRoot, thumbRoot, tx)
+			count += files
+			if err != nil {
+				return fmt.Errorf("node %d: %w", n.ID, err)
+			}
+		}
+		return fs.DeleteUserKeysBefore(ctx, user.ID, key.Version, tx)
+	})
+	return count, err
+}
+
+// Runs fn in a transaction holding an exclusive lock on the data key of a user.
+func withKeyLock(ctx context.Context, userID int, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	return withTx(ctx, db, func(tx *sql.Tx) error {
+		if err := fs.LockDataKey(ctx, userID, true, tx); err != nil {
+			return err
+		}
+		return fn(tx)
+	})
+}
@@ -0,0 +1,211 @@
+package jobs
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/terotoi/koticloud/server/fs"
+	"github.com/terotoi/koticloud/server/util"
+	"github.com/volatiletech/null/v8"
+)
+
+// extractPhotoInfo reads the dimensions and EXIF data of an image with ImageMagick.
+func extractPhotoInfo(path string) (*fs.PhotoInfo, error) {
+	out, err := util.Exec(fmt.Sprintf(`identify -format '%%wx%%h\n%%[EXIF:*]\n' %s 2>/dev/null`,
+		util.ShellEscape(path)))
+	if err != nil {
+		return nil, err
+	}
+
+	var info fs.PhotoInfo
+	exif := map[string]string{}
+	for _, line := range strings.Split(out, "\n") {
+		if i := strings.Index(line, "="); i > 0 && strings.HasPrefix(line, "exif:") {
+			exif[line[5:i]] = strings.TrimSpace(line[i+1:])
+		} else if info.Width == 0 {
+			fmt.Sscanf(line, "%dx%d", &info.Width, &info.Height)
+		}
+	}
+
+	info.Make = exif["Make"]
+	info.Model = exif["Model"]
+	info.Lens = exif["LensModel"]
+	info.ExposureTime = exif["ExposureTime"]
+	info.FNumber = parseRational(exif["FNumber"])
+	info.FocalLength = parseRational(exif["FocalLength"])
+	info.ISO, _ = strconv.Atoi(firstNonEmpty(exif["PhotographicSensitivity"], exif["ISOSpeedRatings"]))
+	info.Orientation, _ = strconv.Atoi(exif["Orientation"])
+
+	for _, key := range []string{"DateTimeOriginal", "DateTimeDigitized", "DateTime"} {
+		if t, err := time.ParseInLocation("2006:01:02 15:04:05", exif[key], time.Local); err == nil {
+			info.TakenOn = null.TimeFrom(t)
+			break
+		}
+	}
+
+	if lat, ok := parseGPSCoordinate(exif["GPSLatitude"], exif["GPSLatitudeRef"]); ok {
+		if lon, ok := parseGPSCoordinate(exif["GPSLongitude"], exif["GPSLongitudeRef"]); ok {
+			info.Latitude = null.Float64From(lat)
+			info.Longitude = null.Float64From(lon)
+		}
+	}
+
+	if alt := exif["GPSAltitude"]; alt != "" {
+		a := parseRational(alt)
+		if exif["GPSAltitudeRef"] == "1" {
+			a = -a
+		}
+		info.Altitude = null.Float64From(a)
+	}
+
+	return &info, nil
+}
+
+// Parses an EXIF rational such as 28/10.
+func parseRational(s string) float64 {
+	var n, d float64
+	switch c, _ := fmt.Sscanf(s, "%g/%g", &n, &d); {
+	case c == 1:
+		return n
+	case c == 2 && d != 0:
+		return n / d
+	}
+	return 0
+}
+
+// Parses an EXIF GPS coordinate such as "60/1, 10/1, 3000/100" into degrees.
+// Coordinates on the southern and western hemispheres are negative.
+func parseGPSCoordinate(s, ref string) (float64, bool) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 3 {
+		return 0, false
+	}
+
+	deg := parseRational(strings.TrimSpace(parts[0])) +
+		parseRational(strings.TrimSpace(parts[1]))/60 +
+		parseRational(strings.TrimSpace(parts[2]))/3600
+	if ref == "S" || ref == "W" {
+		deg = -deg
+	}
+	return deg, true
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// Output of ffprobe -print_format json.
+type ffprobeOutput struct {
+	Format struct {
+		FormatName string            `json:"format_name"`
+		Duration   string            `json:"duration"`
+		BitRate    string            `json:"bit_rate"`
+		Tags       map[string]string `json:"tags"`
+	} `json:"format"`
+	Streams []struct {
+		CodecType    string            `json:"codec_type"`
+		CodecName    string            `json:"codec_name"`
+		Width        int               `json:"width"`
+		Height       int               `json:"height"`
+		AvgFrameRate string            `json:"avg_frame_rate"`
+		SampleRate   string            `json:"sample_rate"`
+		Channels     int               `json:"channels"`
+		BitRate      string            `json:"bit_rate"`
+		Tags         map[string]string `json:"tags"`
+	} `json:"streams"`
+}
+
+// extractMediaInfo reads the format, streams and tags of an audio or video file with ffprobe.
+func extractMediaInfo(path string) (*fs.MediaInfo, error) {
+	out, err := util.Exec(fmt.Sprintf("ffprobe -v quiet -print_format json -show_format -show_streams %s",
+		util.ShellEscape(path)))
+	if err != nil {
+		return nil, err
+	}
+
+	var probe ffprobeOutput
+	if err := json.Unmarshal([]byte(out), &probe); err != nil {
+		return nil, err
+	}
+
+	info := fs.MediaInfo{
+		Format:  probe.Format.FormatName,
+		Streams: []fs.MediaStream{},
+		Tags:    map[string]string{},
+	}
+	info.Duration, _ = strconv.ParseFloat(probe.Format.Duration, 64)
+	info.BitRate, _ = strconv.ParseInt(probe.Format.BitRate, 10, 64)
+
+	// Tag names vary in case between formats.
+	for k, v := range probe.Format.Tags {
+		info.Tags[strings.ToLower(k)] = v
+	}
+
+	for _, s := range probe.Streams {
+		stream := fs.MediaStream{
+			Type:     s.CodecType,
+			Codec:    s.CodecName,
+			Width:    s.Width,
+			Height:   s.Height,
+			Channels: s.Channels,
+			Language: s.Tags["language"],
+		}
+
+		if s.CodecType == "video" {
+			stream.FrameRate = parseRational(s.AvgFrameRate)
+		}
+		stream.SampleRate, _ = strconv.Atoi(s.SampleRate)
+		stream.BitRate, _ = strconv.ParseInt(s.BitRate, 10, 64)
+		info.Streams = append(info.Streams, stream)
+	}
+
+	return &info, nil
+}
+
+var pdfInfoLine = regexp.MustCompile(`^([A-Za-z ]+):\s*(.*)$`)
+
+// extractDocumentInfo reads the page count and document properties of a PDF file with pdfinfo.
+func extractDocumentInfo(path string) (*fs.DocumentInfo, error) {
+	out, err := util.Exec(fmt.Sprintf("pdfinfo -isodates %s 2>/dev/null", util.ShellEscape(path)))
+	if err != nil {
+		return nil, err
+	}
+
+	var info fs.DocumentInfo
+	for _, line := range strings.Split(out, "\n") {
+		m := pdfInfoLine.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		switch m[1] {
+		case "Pages":
+			info.Pages, _ = strconv.Atoi(m[2])
+		case "Title":
+			info.Title = m[2]
+		case "Author":
+			info.Author = m[2]
+		case "CreationDate":
+			for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05-07", "2006-01-02T15:04:05"} {
+				if t, err := time.Parse(layout, m[2]); err == nil {
+					info.CreatedOn = null.TimeFrom(t)
+					break
+				}
+			}
+		}
+	}
+
+	if info.Pages == 0 {
+		return nil, fmt.Errorf("no page count in pdfinfo output")
+	}
+	return &info, nil
+}
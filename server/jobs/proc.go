@@ -2,15 +2,21 @@ package jobs
 
 import (
 	"context"
+	"crypto/rand"
 	"database/sql"
+	"errors"
+	"fmt"
 	"log"
 	"os"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/terotoi/koticloud/server/core"
+	"github.com/terotoi/koticloud/server/database"
 	"github.com/terotoi/koticloud/server/fs"
 	"github.com/terotoi/koticloud/server/models"
+	"github.com/terotoi/koticloud/server/storage"
 	"github.com/terotoi/koticloud/server/util"
 	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
@@ -26,89 +32,149 @@ type NodeProcessRequest struct {
 }
 
 // NodeProcessor processes thumbnails, video durations, etc on new nodes.
+// Several processors, in one or more servers and workers, can share the
+// queue of a database.
 type NodeProcessor struct {
 	Channel   chan NodeProcessRequest
 	WaitGroup sync.WaitGroup // WaitGroup to signal end of the processor
 
 	ctx         context.Context
+	worker      string // Identifies the processor in the leases of requests
 	thumbRoot   string
 	thumbMethod string
 	tempDir     string
+	language    string // Text search configuration
 	db          *sql.DB
 }
 
+// Returns a name for the processor of this process, unique across hosts.
+func workerName() string {
+	host, _ := os.Hostname()
+	b := make([]byte, 4)
+	rand.Read(b)
+	return fmt.Sprintf("%s:%d:%x", host, os.Getpid(), b)
+}
+
 // RunNodeProc starts the node processor.
 func RunNodeProc(cfg *core.Config, db *sql.DB) *NodeProcessor {
 	np := NodeProcessor{
 		ctx:         context.Background(),
 		Channel:     make(chan NodeProcessRequest, maxReqs),
+		worker:      workerName(),
 		thumbRoot:   cfg.ThumbRoot,
 		thumbMethod: cfg.ThumbMethod,
-		tempDir:     cfg.ThumbRoot,
+		tempDir:     cfg.UploadDir,
+		language:    cfg.SearchLanguage,
 		db:          db,
 	}
 
+	// Stored files are downloaded into the temporary directory for processing.
+	if err := util.EnsureDirExists(np.tempDir); err != nil {
+		log.Printf("[process] %s", err)
+	}
+
+	// Requests queued by any process are notified on PostgreSQL. Without
+	// notifications, the queue is polled.
+	notify, stopListen, err := database.Listen(cfg.Database, procNotifyChannel)
+	if err != nil {
+		log.Printf("[process] %s", err)
+		stopListen = func() {}
+	}
+
+	// Leases are renewed until the processor stops.
+	stopped := make(chan struct{})
+	go func() {
+		tick := time.NewTicker(procHeartbeatInterval)
+		defer tick.Stop()
+
+		for {
+			select {
+			case <-tick.C:
+				if err := renewLeases(np.ctx, np.worker, np.db); err != nil {
+					log.Printf("[process] %s", err)
+				}
+			case <-stopped:
+				return
+			}
+		}
+	}()
+
 	np.WaitGroup.Add(1)
 	var wg sync.WaitGroup
 	g := make(chan struct{}, maxRunningProcs)
 
-	var takeMutex sync.Mutex
-
 	go func() {
-		log.Println("[process] file processor started.")
+		log.Printf("[process] file processor %s started.", np.worker)
+
+		// Failed requests become due for a retry without a new request.
+		poll := time.NewTicker(procPollInterval)
+		defer poll.Stop()
 
 		for {
 			g <- struct{}{} // Block until we can acquire a slot
 			wg.Add(1)
 
 			f := func() {
+				defer func() {
+					wg.Done()
+					<-g // Release a slot
+				}()
+
 				for {
-					tx, err := np.db.BeginTx(np.ctx, nil)
-					if err != nil {
-						log.Printf("[process] error: %s", err)
-						break
+					select {
+					case <-stopped:
+						return
+					default:
 					}
-					defer tx.Rollback()
-
-					takeMutex.Lock()
-
-					// Take one request, break out if failed
-					req, err := models.NodeProcessReqs().One(np.ctx, tx)
 
+					// Take one request, return if there are none due.
+					req, err := takeRequest(np.ctx, np.worker, np.db)
 					if err != nil {
-						tx.Rollback()
-						takeMutex.Unlock()
-						break
-					} else if _, err = req.Delete(np.ctx, tx); err != nil {
 						log.Printf("[process] %s", err)
-						tx.Rollback()
-						takeMutex.Unlock()
-					} else if err := tx.Commit(); err != nil {
-						tx.Rollback()
-						log.Printf("[process] %s", err)
-						takeMutex.Unlock()
+						return
+					} else if req == nil {
+						return
+					}
+
+					// Requests whose workers stopped while processing them fail
+					// once they run out of attempts, in case they stopped the workers.
+					if req.Attempts > maxProcAttempts {
+						err = fmt.Errorf("processing was interrupted, last error: %s", req.Error.String)
 					} else {
-						takeMutex.Unlock()
 						err = np.processRequest(req)
-						if err != nil {
-							log.Printf("[process] %s", err)
-						}
+					}
+					if err != nil {
+						log.Printf("[process] %s", err)
+					}
+
+					if err := finishRequest(np.ctx, req, err, np.db); err != nil {
+						log.Printf("[process] %s", err)
 					}
 				}
-				wg.Done()
-				<-g // Release a slot
 			}
 			go f()
 
-			r := <-np.Channel
+			select {
+			case r := <-np.Channel:
+				if r.Quit {
+					close(stopped)
+					stopListen()
 
-			if r.Quit {
-				log.Println("[process] file processor stopped.")
-				break
+					// Requests still being processed are left for other processors.
+					if n, err := releaseLeases(np.ctx, np.worker, np.db); err != nil {
+						log.Printf("[process] %s", err)
+					} else if n > 0 {
+						log.Printf("[process] %d requests returned into the queue", n)
+					}
+
+					log.Println("[process] file processor stopped.")
+					np.WaitGroup.Done()
+					return
+				}
+			case <-notify:
+			case <-poll.C:
 			}
 		}
-
-		np.WaitGroup.Done()
 	}()
 
 	return &np
@@ -119,68 +185,100 @@ func (np *NodeProcessor) End() {
 	np.Channel <- NodeProcessRequest{Quit: true}
 }
 
-func (np *NodeProcessor) processRequest(req *models.NodeProcessReq) error {
-	if req.RemoveUpload {
-		defer func() {
-			os.Remove(req.Path)
-		}()
+// Processes a request, storing the results as they are ready. Returns an
+// error listing the steps that failed, so that the request can be retried.
+func (np *NodeProcessor) processRequest(req *NodeProcess) error {
+	// No transaction is held while processing, since SQLite databases allow a
+	// single writer, and the results are stored as they are ready.
+	node, err := fs.NodeByID(np.ctx, req.NodeID, np.db)
+	if err != nil {
+		return err
 	}
 
-	tx, err := np.db.BeginTx(np.ctx, nil)
+	log.Printf("[process] Processing %d %s, attempt %d", node.ID, node.Name, req.Attempts)
+
+	// Text of encrypted files is not indexed.
+	key, err := fs.UserDataKey(np.ctx, node.OwnerID.Int, np.db)
 	if err != nil {
 		return err
 	}
-	defer tx.Rollback()
 
-	node, err := fs.NodeByID(np.ctx, req.NodeID, tx)
+	// The external tools need a local copy of a file in remote storage.
+	path, cleanup, err := storage.Fetch(req.Path, np.tempDir, node.Encrypted)
 	if err != nil {
 		return err
 	}
-
-	log.Printf("[process] Processing %d %s", node.ID, node.Name)
+	defer cleanup()
 
 	var updated bool
 	var duration float64
+	var failed []string
+
+	// Stored outside the transaction, so that a failure does not lose the other results.
+	media, err := np.extractInfo(node, path, np.db)
+	if err != nil {
+		log.Printf("[process] Error extracting metadata for node: %d path: %s: %s",
+			node.ID, req.Path, err.Error())
+		failed = append(failed, "metadata: "+strings.TrimSpace(err.Error()))
+	}
 
-	if util.IsVideo(node.MimeType) || util.IsAudio(node.MimeType) {
-		duration, err = queryVideoDuration(node, req.Path)
+	if media != nil && media.Duration > 0 {
+		node.Length = null.Float64From(media.Duration)
+		updated = true
+	} else if util.IsVideo(node.MimeType) || util.IsAudio(node.MimeType) {
+		duration, err = queryVideoDuration(node, path)
 
 		if err != nil {
 			log.Printf("[process] Error querying video duration for node: %d path: %s: %s",
 				node.ID, req.Path, err.Error())
+			failed = append(failed, "duration: "+strings.TrimSpace(err.Error()))
 		} else {
 			node.Length = null.Float64{Float64: duration, Valid: true}
 			updated = true
 		}
 	}
 
-	if err := generateThumbnail(node, req.RemoveUpload, req.Path, np.thumbRoot, np.tempDir, np.thumbMethod); err != nil {
+	if err := generateThumbnail(np.ctx, node, path, np.thumbRoot, np.tempDir, np.thumbMethod,
+		np.db); err != nil {
 		log.Printf("Error generating thumbnail for node: %d path: %s: error: %s",
 			node.ID, req.Path, err.Error())
+		failed = append(failed, "thumbnail: "+strings.TrimSpace(err.Error()))
 	} else {
 		updated = true
 	}
 
-	if updated {
-		_, err = node.Update(np.ctx, tx, boil.Infer())
+	if err := np.indexText(node, path, key != nil, np.db); err != nil {
+		log.Printf("[process] Error extracting text for node: %d path: %s: %s",
+			node.ID, req.Path, err.Error())
+		failed = append(failed, "text: "+strings.TrimSpace(err.Error()))
+	}
 
-		if err := tx.Commit(); err != nil {
-			log.Printf("[process] %s", err)
+	if updated {
+		if _, err := node.Update(np.ctx, np.db, boil.Whitelist(models.NodeColumns.Length,
+			models.NodeColumns.HasCustomThumb)); err != nil {
+			return err
 		}
 	}
 
-	return err
+	if len(failed) > 0 {
+		return errors.New(strings.Join(failed, "; "))
+	}
+	return nil
 }
 
 // AddNodeProcessRequest adds a request to process a node into the queue.
+// A node has at most one request, which is replaced by a new one. The file
+// is the stored file of the node, which is kept for retries.
 func AddNodeProcessRequest(ctx context.Context, procCh chan NodeProcessRequest, node *models.Node,
-	file string, removeUpload bool, db *sql.DB) error {
-	req := models.NodeProcessReq{NodeID: node.ID, Path: file, RemoveUpload: removeUpload}
-	err := req.Insert(ctx, db, boil.Infer())
-	if err == nil {
-		procCh <- NodeProcessRequest{Quit: false}
+	file string, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, "INSERT INTO node_process_reqs (node_id, path, state, updated_on) "+
+		"VALUES ($1, $2, $3, $4) ON CONFLICT (node_id) DO UPDATE SET path=excluded.path, "+
+		"state=excluded.state, attempts=0, error=NULL, run_after=NULL, "+
+		"updated_on=excluded.updated_on", node.ID, file, ProcPending, time.Now())
+	if err != nil {
+		return err
 	}
-	return err
+	return wakeProcessors(ctx, procCh, db)
 }
 
 // (Re)generate all thumbnails.
@@ -197,15 +295,133 @@ func (np *NodeProcessor) GenerateAllThumbs(ctx context.Context, onlyMissing bool
 		return err
 	}
 
+	jobOf(ctx).setTotal(len(nodes))
 	for _, n := range nodes {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		path, err := fs.PhysPath(ctx, n, homeRoot, db)
 		if err != nil {
-			log.Println(err)
+			logf(ctx, "%s", err)
 		} else {
-			AddNodeProcessRequest(ctx, np.Channel, n, path,
-				false, db)
+			AddNodeProcessRequest(ctx, np.Channel, n, path, db)
+		}
+		jobOf(ctx).step()
+	}
+
+	logf(ctx, "[thumbnails] Queued %d files for thumbnail generation", len(nodes))
+	return nil
+}
+
+// Stores the text content of a node for full-text search. Stored text is
+// removed if the file no longer contains text. The text of encrypted files
+// is not stored, since the index would reveal it.
+func (np *NodeProcessor) indexText(node *models.Node, path string, encrypted bool, tx boil.ContextExecutor) error {
+	if !util.HasText(node.MimeType) || encrypted {
+		return fs.DeleteNodeText(np.ctx, node.ID, tx)
+	}
+
+	text, err := extractText(node, path)
+	if err != nil {
+		return err
+	}
+	return fs.SetNodeText(np.ctx, node.ID, text, np.language, tx)
+}
+
+// Stores the metadata of a node as an info record of the type matching the
+// file. Returns the metadata of audio and video files, so that their
+// duration does not need to be queried again.
+func (np *NodeProcessor) extractInfo(node *models.Node, path string, db *sql.DB) (*fs.MediaInfo, error) {
+	var infoType string
+	var data interface{}
+	var media *fs.MediaInfo
+	var err error
+
+	switch {
+	case util.IsImage(node.MimeType):
+		infoType = fs.InfoPhoto
+		data, err = extractPhotoInfo(path)
+	case util.IsVideo(node.MimeType) || util.IsAudio(node.MimeType):
+		infoType = fs.InfoMedia
+		media, err = extractMediaInfo(path)
+		data = media
+	case util.IsPDF(node.MimeType):
+		infoType = fs.InfoDocument
+		data, err = extractDocumentInfo(path)
+	default:
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	// Stored in a transaction, which keeps the data key of the owner current, see fs.LockDataKey.
+	return media, withTx(np.ctx, db, func(tx *sql.Tx) error {
+		return fs.SetNodeInfo(np.ctx, node, infoType, data, tx)
+	})
+}
+
+// ExtractAllInfo queues all files with metadata for processing.
+func (np *NodeProcessor) ExtractAllInfo(ctx context.Context, onlyMissing bool, homeRoot string, db *sql.DB) error {
+	types := "'" + strings.Join(util.TypesWithMetadata(), "', '") + "'"
+	query := "SELECT * FROM nodes WHERE type='file' AND mime_type IN (" + types + ")"
+	if onlyMissing {
+		query += " AND id NOT IN (SELECT node_id FROM infos)"
+	}
+
+	var nodes []*models.Node
+	if err := queries.Raw(query).Bind(ctx, db, &nodes); err != nil {
+		return err
+	}
+
+	jobOf(ctx).setTotal(len(nodes))
+	for _, n := range nodes {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		path, err := fs.PhysPath(ctx, n, homeRoot, db)
+		if err != nil {
+			logf(ctx, "%s", err)
+		} else if err := AddNodeProcessRequest(ctx, np.Channel, n, path, db); err != nil {
+			return err
+		}
+		jobOf(ctx).step()
+	}
+
+	logf(ctx, "[meta] Queued %d files for metadata extraction", len(nodes))
+	return nil
+}
+
+// IndexAllText queues all files containing text for text extraction.
+func (np *NodeProcessor) IndexAllText(ctx context.Context, onlyMissing bool, homeRoot string, db *sql.DB) error {
+	types := "'" + strings.Join(util.TypesWithText(), "', '") + "'"
+	query := "SELECT * FROM nodes WHERE type='file' AND (mime_type LIKE 'text/%' OR mime_type IN (" + types + "))"
+	if onlyMissing {
+		query += " AND id NOT IN (SELECT node_id FROM node_texts)"
+	}
+
+	var nodes []*models.Node
+	if err := queries.Raw(query).Bind(ctx, db, &nodes); err != nil {
+		return err
+	}
+
+	jobOf(ctx).setTotal(len(nodes))
+	for _, n := range nodes {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		path, err := fs.PhysPath(ctx, n, homeRoot, db)
+		if err != nil {
+			logf(ctx, "%s", err)
+		} else if err := AddNodeProcessRequest(ctx, np.Channel, n, path, db); err != nil {
+			return err
 		}
+		jobOf(ctx).step()
 	}
 
+	logf(ctx, "[text] Queued %d files for text extraction", len(nodes))
 	return nil
 }
@@ -0,0 +1,171 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/terotoi/koticloud/server/database"
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+// States of node processing requests.
+const (
+	ProcPending = "pending" // Waiting to be processed, or to be retried after RunAfter
+	ProcRunning = "running"
+	ProcDone    = "done"
+	ProcFailed  = "failed" // Failed on every attempt, until requeued
+)
+
+// Number of attempts to process a node before the request fails.
+const maxProcAttempts = 5
+
+// Delay before retrying a failed request. The delay doubles on every attempt.
+const procRetryDelay = time.Minute
+
+// Interval of checking for requests due for a retry, and for requests
+// queued by other processes using SQLite, which has no notifications.
+const procPollInterval = 10 * time.Second
+
+// A running request is leased to its worker for this long, and the lease is
+// renewed at the heartbeat interval. Requests of workers which have stopped
+// are taken by others once their leases expire.
+const (
+	procLeaseDuration     = 2 * time.Minute
+	procHeartbeatInterval = 30 * time.Second
+)
+
+// Notification channel waking up the node processors of all processes.
+const procNotifyChannel = "node_process_reqs"
+
+// NodeProcess is a request to process a node, with the state and the error
+// of its latest attempt. A node has at most one request, which remains after
+// processing.
+type NodeProcess struct {
+	models.NodeProcessReq `boil:",bind"`
+	NodeName              null.String `boil:"node_name" json:"node_name"` // Set by FailedRequests
+}
+
+// Takes the oldest pending request that is due, or a running request whose
+// lease has expired, and leases it to a worker. Returns nil if there are none.
+// Requests locked by other processes are skipped on PostgreSQL, while SQLite
+// runs the statement alone, since it allows a single writer.
+func takeRequest(ctx context.Context, worker string, db *sql.DB) (*NodeProcess, error) {
+	lock := " FOR UPDATE SKIP LOCKED"
+	if database.DialectOf(db) == database.SQLite {
+		lock = ""
+	}
+
+	now := time.Now()
+	var reqs []*NodeProcess
+	err := queries.Raw("UPDATE node_process_reqs SET state=$1, attempts=attempts+1, worker=$2, lease_until=$3, "+
+		"updated_on=$4 WHERE id=(SELECT id FROM node_process_reqs "+
+		"WHERE (state=$5 AND (run_after IS NULL OR run_after<=$4)) OR (state=$1 AND lease_until<$4) "+
+		"ORDER BY id LIMIT 1"+lock+") RETURNING *",
+		ProcRunning, worker, now.Add(procLeaseDuration), now, ProcPending).Bind(ctx, db, &reqs)
+	if err != nil || len(reqs) == 0 {
+		return nil, err
+	}
+	return reqs[0], nil
+}
+
+// Stores the outcome of processing a request. A failed request is retried
+// later, until it runs out of attempts. The request is left alone if it has
+// been replaced or taken by another worker while processing.
+func finishRequest(ctx context.Context, req *NodeProcess, procErr error, db *sql.DB) error {
+	state := ProcDone
+	var errText null.String
+	var runAfter null.Time
+
+	if procErr != nil {
+		errText = null.StringFrom(procErr.Error())
+		if req.Attempts < maxProcAttempts {
+			state = ProcPending
+			runAfter = null.TimeFrom(time.Now().Add(procRetryDelay << (req.Attempts - 1)))
+			log.Printf("[process] node %d failed on attempt %d, retrying after %s", req.NodeID, req.Attempts,
+				runAfter.Time.Format(time.Stamp))
+		} else {
+			state = ProcFailed
+			log.Printf("[process] node %d failed after %d attempts", req.NodeID, req.Attempts)
+		}
+	}
+
+	_, err := db.ExecContext(ctx, "UPDATE node_process_reqs SET state=$2, error=$3, run_after=$4, updated_on=$5, "+
+		"lease_until=NULL WHERE id=$1 AND state=$6 AND worker=$7 AND attempts=$8",
+		req.ID, state, errText, runAfter, time.Now(), ProcRunning, req.Worker, req.Attempts)
+	return err
+}
+
+// Renews the leases of the requests a worker is processing.
+func renewLeases(ctx context.Context, worker string, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, "UPDATE node_process_reqs SET lease_until=$1 WHERE state=$2 AND worker=$3",
+		time.Now().Add(procLeaseDuration), ProcRunning, worker)
+	return err
+}
+
+// Returns the requests a worker is processing into the queue, so that other
+// workers can take them without waiting for the leases to expire. The
+// interrupted attempts are not counted.
+func releaseLeases(ctx context.Context, worker string, db *sql.DB) (int64, error) {
+	res, err := db.ExecContext(ctx, "UPDATE node_process_reqs SET state=$1, attempts=attempts-1, lease_until=NULL "+
+		"WHERE state=$2 AND worker=$3", ProcPending, ProcRunning, worker)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// Wakes up the node processors after queueing requests: the processors of
+// all processes with a notification on PostgreSQL, and the processor of this
+// process with procCh on SQLite, where the others poll.
+func wakeProcessors(ctx context.Context, procCh chan NodeProcessRequest, db *sql.DB) error {
+	if database.DialectOf(db) == database.SQLite {
+		select {
+		case procCh <- NodeProcessRequest{Quit: false}:
+		default: // The processor has wakeups pending already.
+		}
+		return nil
+	}
+	return database.Notify(ctx, procNotifyChannel, db)
+}
+
+// FailedRequests returns the requests that failed on every attempt, latest
+// failures first.
+func FailedRequests(ctx context.Context, db *sql.DB) ([]*NodeProcess, error) {
+	var reqs []*NodeProcess
+	err := queries.Raw("SELECT r.*, n.name AS node_name FROM node_process_reqs r "+
+		"JOIN nodes n ON n.id=r.node_id WHERE r.state=$1 ORDER BY r.updated_on DESC, r.id DESC", ProcFailed).
+		Bind(ctx, db, &reqs)
+	return reqs, err
+}
+
+// RequeueFailed queues the failed requests of the given nodes for processing
+// again with new attempts, or all failed requests if nodeIDs is empty.
+// Returns the number of requeued requests.
+func RequeueFailed(ctx context.Context, nodeIDs []int, procCh chan NodeProcessRequest, db *sql.DB) (int64, error) {
+	query := "UPDATE node_process_reqs SET state=$1, attempts=0, error=NULL, run_after=NULL, updated_on=$2 " +
+		"WHERE state=$3"
+	if len(nodeIDs) > 0 {
+		ids := make([]string, len(nodeIDs))
+		for i, id := range nodeIDs {
+			ids[i] = strconv.Itoa(id)
+		}
+		query += " AND node_id IN (" + strings.Join(ids, ", ") + ")"
+	}
+
+	res, err := db.ExecContext(ctx, query, ProcPending, time.Now(), ProcFailed)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := res.RowsAffected()
+	if err == nil && n > 0 {
+		err = wakeProcessors(ctx, procCh, db)
+	}
+	return n, err
+}
@@ -0,0 +1,327 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/terotoi/koticloud/server/database"
+	"github.com/terotoi/koticloud/server/migrate"
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// Returns scratch databases with the current schema: an SQLite database,
+// and the PostgreSQL database of $DATABASE if set. The schema of that
+// database is recreated, so it must be a scratch database too.
+func testDBs(t *testing.T) map[string]*sql.DB {
+	ctx := context.Background()
+	dbs := make(map[string]*sql.DB)
+	for name, config := range map[string]string{
+		"sqlite":   "sqlite:" + filepath.Join(t.TempDir(), "test.db"),
+		"postgres": os.Getenv("DATABASE"),
+	} {
+		if config == "" {
+			continue
+		}
+
+		db, err := database.Open(config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { db.Close() })
+
+		migs, err := migrate.Migrations(database.DialectOf(db))
+		if err != nil {
+			t.Fatal(err)
+		} else if _, err := migrate.Down(ctx, len(migs), db); err != nil {
+			t.Fatal(err)
+		} else if _, err := migrate.Up(ctx, db); err != nil {
+			t.Fatal(err)
+		}
+		dbs[name] = db
+	}
+	return dbs
+}
+
+// Runs a test against each of the scratch databases.
+func forEachDB(t *testing.T, test func(t *testing.T, db *sql.DB)) {
+	for name, db := range testDBs(t) {
+		t.Run(name, func(t *testing.T) { test(t, db) })
+	}
+}
+
+// Inserts count file nodes and queues them for processing.
+func queueTestNodes(t *testing.T, count int, db *sql.DB) []*models.Node {
+	ctx := context.Background()
+	var nodes []*models.Node
+	for i := 0; i < count; i++ {
+		node := &models.Node{Name: fmt.Sprintf("%d.txt", i), Type: "file", MimeType: "text/plain"}
+		if err := node.Insert(ctx, db, boil.Infer()); err != nil {
+			t.Fatal(err)
+		} else if err := AddNodeProcessRequest(ctx, nil, node, "/tmp/"+node.Name, db); err != nil {
+			t.Fatal(err)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// Returns the stored request of a node.
+func testRequest(t *testing.T, node *models.Node, db *sql.DB) *models.NodeProcessReq {
+	t.Helper()
+	req, err := models.NodeProcessReqs(qm.Where("node_id=?", node.ID)).One(context.Background(), db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return req
+}
+
+// Takes a request, failing the test if there is none.
+func mustTake(t *testing.T, worker string, db *sql.DB) *NodeProcess {
+	t.Helper()
+	req, err := takeRequest(context.Background(), worker, db)
+	if err != nil {
+		t.Fatal(err)
+	} else if req == nil {
+		t.Fatal("no request taken")
+	}
+	return req
+}
+
+// Checks that no request can be taken.
+func checkNoneDue(t *testing.T, db *sql.DB) {
+	t.Helper()
+	if req, err := takeRequest(context.Background(), "other", db); err != nil {
+		t.Fatal(err)
+	} else if req != nil {
+		t.Errorf("took the request of node %d", req.NodeID)
+	}
+}
+
+// Checks that a time is within a second of the expected one, allowing for
+// the precision of the database and the time spent by the test.
+func checkTime(t *testing.T, what string, got time.Time, want time.Time) {
+	t.Helper()
+	if d := got.Sub(want); d < -time.Second || d > time.Second {
+		t.Errorf("%s is %s, want %s", what, got, want)
+	}
+}
+
+// Requests are taken oldest first once they are due, and leased to the worker.
+func TestTakeRequest(t *testing.T) {
+	forEachDB(t, func(t *testing.T, db *sql.DB) {
+		nodes := queueTestNodes(t, 2, db)
+		if _, err := db.Exec("UPDATE node_process_reqs SET run_after=$1 WHERE node_id=$2",
+			time.Now().Add(time.Hour), nodes[0].ID); err != nil {
+			t.Fatal(err)
+		}
+
+		req := mustTake(t, "w1", db)
+		if req.NodeID != nodes[1].ID || req.State != ProcRunning || req.Attempts != 1 || req.Worker.String != "w1" {
+			t.Errorf("took node %d, state %s, attempt %d by %s", req.NodeID, req.State, req.Attempts, req.Worker.String)
+		}
+		checkTime(t, "lease", req.LeaseUntil.Time, time.Now().Add(procLeaseDuration))
+
+		// Neither the request waiting for a retry nor the leased one is due.
+		checkNoneDue(t, db)
+
+		if _, err := db.Exec("UPDATE node_process_reqs SET run_after=$1 WHERE node_id=$2",
+			time.Now().Add(-time.Second), nodes[0].ID); err != nil {
+			t.Fatal(err)
+		} else if req := mustTake(t, "w1", db); req.NodeID != nodes[0].ID {
+			t.Errorf("took node %d, want %d", req.NodeID, nodes[0].ID)
+		}
+	})
+}
+
+// The request of a worker whose lease has expired is taken by another
+// worker, and the outcome of the first worker is then ignored.
+func TestTakeRequestExpired(t *testing.T) {
+	forEachDB(t, func(t *testing.T, db *sql.DB) {
+		ctx := context.Background()
+		nodes := queueTestNodes(t, 1, db)
+		first := mustTake(t, "w1", db)
+
+		if err := renewLeases(ctx, "w1", db); err != nil {
+			t.Fatal(err)
+		}
+		checkNoneDue(t, db)
+
+		if _, err := db.Exec("UPDATE node_process_reqs SET lease_until=$1", time.Now().Add(-time.Second)); err != nil {
+			t.Fatal(err)
+		}
+
+		second := mustTake(t, "w2", db)
+		if second.ID != first.ID || second.Worker.String != "w2" || second.Attempts != 2 {
+			t.Errorf("took request %d by %s on attempt %d", second.ID, second.Worker.String, second.Attempts)
+		}
+
+		if err := finishRequest(ctx, first, nil, db); err != nil {
+			t.Fatal(err)
+		} else if req := testRequest(t, nodes[0], db); req.State != ProcRunning || req.Worker.String != "w2" {
+			t.Errorf("finished by the first worker: %s by %s", req.State, req.Worker.String)
+		}
+
+		if err := finishRequest(ctx, second, nil, db); err != nil {
+			t.Fatal(err)
+		} else if req := testRequest(t, nodes[0], db); req.State != ProcDone || req.LeaseUntil.Valid {
+			t.Errorf("finished by the second worker: %s, lease %v", req.State, req.LeaseUntil)
+		}
+	})
+}
+
+// Workers taking requests at the same time never take the same request.
+func TestTakeRequestConcurrent(t *testing.T) {
+	const count, workers = 40, 4
+	forEachDB(t, func(t *testing.T, db *sql.DB) {
+		queueTestNodes(t, count, db)
+
+		var mutex sync.Mutex
+		taken := make(map[int]string)
+		var wg sync.WaitGroup
+		for i := 0; i < workers; i++ {
+			worker := fmt.Sprintf("w%d", i)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					req, err := takeRequest(context.Background(), worker, db)
+					if err != nil {
+						t.Error(err)
+						return
+					} else if req == nil {
+						return
+					}
+
+					mutex.Lock()
+					if other, ok := taken[req.ID]; ok {
+						t.Errorf("request %d taken by %s and %s", req.ID, other, worker)
+					}
+					taken[req.ID] = worker
+					mutex.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		if len(taken) != count {
+			t.Errorf("took %d requests, want %d", len(taken), count)
+		}
+	})
+}
+
+// A failed request is retried with a doubling delay until it runs out of
+// attempts, and then waits to be requeued.
+func TestFinishRequestRetry(t *testing.T) {
+	forEachDB(t, func(t *testing.T, db *sql.DB) {
+		ctx := context.Background()
+		nodes := queueTestNodes(t, 1, db)
+		procErr := errors.New("no thumbnail")
+
+		for attempt := 1; attempt <= maxProcAttempts; attempt++ {
+			req := mustTake(t, "w1", db)
+			if req.NodeID != nodes[0].ID || req.Attempts != attempt {
+				t.Fatalf("took node %d on attempt %d, want %d", req.NodeID, req.Attempts, attempt)
+			} else if err := finishRequest(ctx, req, procErr, db); err != nil {
+				t.Fatal(err)
+			}
+
+			stored := testRequest(t, nodes[0], db)
+			if stored.Error.String != procErr.Error() || stored.LeaseUntil.Valid {
+				t.Errorf("attempt %d: error %q, lease %v", attempt, stored.Error.String, stored.LeaseUntil)
+			}
+
+			if attempt < maxProcAttempts {
+				if stored.State != ProcPending {
+					t.Errorf("attempt %d: state %s", attempt, stored.State)
+				}
+				checkTime(t, "retry", stored.RunAfter.Time, time.Now().Add(procRetryDelay<<(attempt-1)))
+				checkNoneDue(t, db)
+
+				if _, err := db.Exec("UPDATE node_process_reqs SET run_after=$1 WHERE id=$2",
+					time.Now().Add(-time.Second), stored.ID); err != nil {
+					t.Fatal(err)
+				}
+			} else if stored.State != ProcFailed {
+				t.Errorf("state %s after the last attempt", stored.State)
+			}
+		}
+		checkNoneDue(t, db)
+
+		failed, err := FailedRequests(ctx, db)
+		if err != nil {
+			t.Fatal(err)
+		} else if len(failed) != 1 || failed[0].NodeID != nodes[0].ID || failed[0].NodeName.String != nodes[0].Name {
+			t.Errorf("failed requests: %v", failed)
+		}
+
+		// Requeueing other nodes leaves the request failed.
+		if n, err := RequeueFailed(ctx, []int{nodes[0].ID + 1}, nil, db); err != nil || n != 0 {
+			t.Errorf("requeued %d requests: %v", n, err)
+		}
+
+		if n, err := RequeueFailed(ctx, []int{nodes[0].ID}, nil, db); err != nil || n != 1 {
+			t.Errorf("requeued %d requests: %v", n, err)
+		} else if req := mustTake(t, "w1", db); req.NodeID != nodes[0].ID || req.Attempts != 1 || req.Error.Valid {
+			t.Errorf("took node %d on attempt %d, error %v", req.NodeID, req.Attempts, req.Error)
+		}
+	})
+}
+
+// A request replaced while it is processed is processed again, and the
+// outcome of the replaced request is ignored.
+func TestFinishRequestReplaced(t *testing.T) {
+	forEachDB(t, func(t *testing.T, db *sql.DB) {
+		ctx := context.Background()
+		nodes := queueTestNodes(t, 1, db)
+		req := mustTake(t, "w1", db)
+
+		if err := AddNodeProcessRequest(ctx, nil, nodes[0], "/tmp/replaced", db); err != nil {
+			t.Fatal(err)
+		} else if err := finishRequest(ctx, req, nil, db); err != nil {
+			t.Fatal(err)
+		}
+
+		stored := testRequest(t, nodes[0], db)
+		if stored.State != ProcPending || stored.Attempts != 0 || stored.Path != "/tmp/replaced" {
+			t.Errorf("replaced request is %s on attempt %d for %s", stored.State, stored.Attempts, stored.Path)
+		}
+	})
+}
+
+// The requests of a stopping worker are returned into the queue without
+// counting the interrupted attempts.
+func TestReleaseLeases(t *testing.T) {
+	forEachDB(t, func(t *testing.T, db *sql.DB) {
+		ctx := context.Background()
+		nodes := queueTestNodes(t, 3, db)
+		mustTake(t, "w1", db)
+		mustTake(t, "w1", db)
+		mustTake(t, "w2", db)
+
+		if n, err := releaseLeases(ctx, "w1", db); err != nil || n != 2 {
+			t.Errorf("released %d requests: %v", n, err)
+		}
+
+		for i, node := range nodes {
+			req := testRequest(t, node, db)
+			if i < 2 && (req.State != ProcPending || req.Attempts != 0 || req.LeaseUntil.Valid) {
+				t.Errorf("released request is %s on attempt %d, lease %v", req.State, req.Attempts, req.LeaseUntil)
+			} else if i == 2 && (req.State != ProcRunning || req.Worker.String != "w2") {
+				t.Errorf("request of w2 is %s by %s", req.State, req.Worker.String)
+			}
+		}
+
+		if req := mustTake(t, "w3", db); req.NodeID != nodes[0].ID || req.Attempts != 1 {
+			t.Errorf("took node %d on attempt %d", req.NodeID, req.Attempts)
+		}
+	})
+}
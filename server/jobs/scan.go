@@ -0,0 +1,221 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/terotoi/koticloud/server/core"
+	vfs "github.com/terotoi/koticloud/server/fs"
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/terotoi/koticloud/server/mx"
+	"github.com/terotoi/koticloud/server/storage"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// Scan modes.
+const (
+	ScanModeNew     = "new"     // Only new and modified files and directories
+	ScanModeDeleted = "deleted" // Only deleted files and directories
+)
+
+// Operations of a ScanChange.
+const (
+	ScanCreate = "create"
+	ScanUpdate = "update"
+	ScanDelete = "delete"
+)
+
+// ScanOptions selects what a scan looks for and where.
+type ScanOptions struct {
+	User   string // Scan only the home directory of this user
+	Path   string // Scan only this file or directory in the home directory of User, such as "/Photos"
+	Mode   string // ScanModeNew, ScanModeDeleted, or empty for both
+	DryRun bool   // Find the changes without applying them
+}
+
+// ScanChange is a change found by a scan.
+type ScanChange struct {
+	Op    string // ScanCreate, ScanUpdate or ScanDelete
+	User  string // Owner of the home directory
+	Path  string // Path in the home directory
+	IsDir bool
+	Size  int64 // Size of the file on disk, or of the node of a deleted file
+}
+
+// ScanResult contains the numbers of new, changed and removed files and
+// directories found by a scan. The changes themselves are listed only by a
+// dry run.
+type ScanResult struct {
+	New     int
+	Changed int
+	Removed int
+	Changes []*ScanChange
+
+	mutex sync.Mutex
+}
+
+// Counts a change, and lists it if it was not applied.
+func (r *ScanResult) add(c *ScanChange, dryRun bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	switch c.Op {
+	case ScanCreate:
+		r.New++
+	case ScanUpdate:
+		r.Changed++
+	case ScanDelete:
+		r.Removed++
+	}
+
+	if dryRun {
+		r.Changes = append(r.Changes, c)
+	}
+}
+
+// Counts deleted nodes.
+func (r *ScanResult) removed(count int) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.Removed += count
+}
+
+// Counts a change found by scanPath.
+func (r *ScanResult) addPath(p *PathEntry, change int, dryRun bool) {
+	c := &ScanChange{
+		User:  p.User.Name,
+		Path:  strings.TrimPrefix(p.Path, p.RootPath),
+		IsDir: p.Info.IsDir,
+		Size:  p.Info.Size,
+	}
+
+	switch change {
+	case scanNew:
+		c.Op = ScanCreate
+	case scanChanged:
+		c.Op = ScanUpdate
+	default:
+		return
+	}
+	r.add(c, dryRun)
+}
+
+// Scan makes the nodes of the home directories match the files on disk. Nodes
+// of files and directories which no longer exist are deleted first, and then
+// nodes are created for new files and directories and updated for modified
+// files. Files and directories matching the exclusion patterns of the
+// configuration are ignored. Errors with single files are logged, and the
+// scan continues. A cancelled scan returns the changes found so far.
+func Scan(ctx context.Context, opts *ScanOptions, cfg *core.Config, np *NodeProcessor, db *sql.DB) (*ScanResult, error) {
+	res := &ScanResult{}
+	if opts.Mode != "" && opts.Mode != ScanModeNew && opts.Mode != ScanModeDeleted {
+		return res, fmt.Errorf("invalid scan mode: %s", opts.Mode)
+	} else if opts.Path != "" && opts.User == "" {
+		return res, fmt.Errorf("a user is required for scanning a path")
+	}
+
+	var mods []qm.QueryMod
+	if opts.User != "" {
+		mods = append(mods, qm.Where("name=?", opts.User))
+	}
+
+	users, err := models.Users(mods...).All(ctx, db)
+	if err != nil {
+		return res, err
+	} else if opts.User != "" && len(users) == 0 {
+		return res, fmt.Errorf("no such user: %s", opts.User)
+	}
+
+	var paths []*PathEntry
+	for _, user := range users {
+		if ctx.Err() != nil {
+			return res, ctx.Err()
+		}
+
+		p, err := scanHome(ctx, user, opts, res, cfg, db)
+		if err != nil && opts.User != "" {
+			return res, err
+		} else if err != nil {
+			logf(ctx, "[scan] %s", err)
+		}
+		paths = append(paths, p...)
+	}
+
+	scanPaths(ctx, paths, opts.DryRun, res, np, cfg, db)
+	return res, ctx.Err()
+}
+
+// Scans the home directory of a user, or the path of the options in it,
+// for deleted files and directories, and lists the paths to scan for new
+// and modified ones.
+func scanHome(ctx context.Context, user *models.User, opts *ScanOptions, res *ScanResult,
+	cfg *core.Config, db *sql.DB) ([]*PathEntry, error) {
+	// A dry run does not create the missing root of a new user.
+	if opts.DryRun && !user.RootID.Valid {
+		return nil, nil
+	}
+
+	root, err := mx.UserEnsureRootNode(ctx, user, cfg.HomeRoot, db)
+	if err != nil {
+		return nil, err
+	}
+
+	rpath := strings.TrimSuffix(path.Clean("/"+opts.Path), "/")
+	if cfg.ScanExcluded(rpath) {
+		return nil, fmt.Errorf("excluded from scans: %s", rpath)
+	}
+
+	start, err := vfs.NodeByPath(ctx, rpath, root, db)
+	if err != nil {
+		return nil, err
+	}
+
+	if start != nil && opts.Mode != ScanModeNew {
+		if err := scanDeleted(ctx, user, root, start, rpath, opts.DryRun, res, cfg, db); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.Mode == ScanModeDeleted {
+		return nil, nil
+	}
+
+	home, err := vfs.PhysPath(ctx, root, cfg.HomeRoot, db)
+	if err != nil {
+		return nil, err
+	}
+
+	// The directories above a new path are scanned too, so that their nodes are created.
+	var paths []*PathEntry
+	for dir := path.Dir(rpath); start == nil && dir != "/"; dir = path.Dir(dir) {
+		if node, err := vfs.NodeByPath(ctx, dir, root, db); err != nil {
+			return nil, err
+		} else if node != nil {
+			break
+		}
+
+		info, err := storage.Stat(home+dir, false)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, &PathEntry{Path: home + dir, Info: info, Root: root, RootPath: home})
+	}
+
+	p, err := listPaths(home+rpath, home, root, cfg)
+	if os.IsNotExist(err) && start != nil {
+		return nil, nil // Deleted
+	} else if err != nil {
+		return nil, err
+	}
+	paths = append(paths, p...)
+
+	for _, p := range paths {
+		p.User = user
+	}
+	return paths, nil
+}
@@ -3,77 +3,86 @@ package jobs
 import (
 	"context"
 	"database/sql"
-	"log"
 	"os"
 
 	"github.com/terotoi/koticloud/server/core"
 	"github.com/terotoi/koticloud/server/fs"
 	"github.com/terotoi/koticloud/server/models"
+	"github.com/terotoi/koticloud/server/storage"
 )
 
-// ScanDeletedNodes scans for deleted files or dangling links in the file store.
-func ScanDeletedNodes(ctx context.Context, user *models.User, cfg *core.Config, db *sql.DB) error {
-	nodes, err := fs.NodesAll(ctx, db)
+// Deletes the nodes of files and directories which no longer exist on disk,
+// with everything under them, from a path in the home directory of a user.
+// The node of the path itself is included, unless it is the root. rpath is
+// the path of start in the home directory. Excluded files and
+// directories are left as they are.
+func scanDeleted(ctx context.Context, user *models.User, root, start *models.Node, rpath string,
+	dryRun bool, res *ScanResult, cfg *core.Config, db *sql.DB) error {
+	nodes, err := fs.SubtreeWithPaths(ctx, start, cfg.HomeRoot, db)
 	if err != nil {
 		return err
 	}
 
-	var dirs []*models.Node
+	// Nodes come after their parents, so the paths of the parents are known.
+	paths := map[int]string{start.ID: rpath}
+	gone := make(map[int]bool)
 
-	for _, node := range nodes {
-		if fs.IsDir(node) {
+	for _, np := range nodes {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		node := &np.Node
+		path := rpath
+		if node.ID != start.ID {
+			parentPath, ok := paths[node.ParentID.Int]
+			if !ok {
+				continue // Under an excluded directory
+			}
+			path = parentPath + "/" + node.Name
+		}
+
+		c := &ScanChange{Op: ScanDelete, User: user.Name, Path: path, IsDir: fs.IsDir(node), Size: node.Size.Int64}
+		if gone[node.ParentID.Int] && node.ID != start.ID {
+			// Deleted with the parent.
+			gone[node.ID] = true
+			if dryRun {
+				res.add(c, true)
+			}
+			continue
+		} else if cfg.ScanExcluded(path) {
 			continue
 		}
 
-		path, err := fs.PhysPath(ctx, node, cfg.HomeRoot, db)
-		if err != nil {
-			log.Printf("[scan-deleted] %s", err)
+		paths[node.ID] = path
+		if node.ID == root.ID {
 			continue
 		}
 
-		if _, err = os.Stat(path); os.IsNotExist(err) {
-			log.Printf("[scan-deleted] node %d does not exist in filesystem, deleting from database.", node.ID)
+		if _, err := storage.Stat(np.Path, false); err == nil {
+			continue
+		} else if !os.IsNotExist(err) {
+			logf(ctx, "[scan] %s", err)
+			continue
+		}
 
-			if _, err = fs.Delete(ctx, node, false, user, cfg.HomeRoot, cfg.ThumbRoot, db); err != nil {
-				log.Printf("[scan-deleted] %s", err)
-			} else {
-				log.Printf("[scan-deleted] deleted a file node: %d %s", node.ID, node.Name)
+		gone[node.ID] = true
+		if dryRun {
+			res.add(c, true)
+			continue
+		}
 
-				if node.ParentID.Valid {
-					// Add unique parents
-					found := false
-					for _, dir := range dirs {
-						if node.ParentID.Int == dir.ID {
-							found = true
-							break
-						}
-					}
+		j := fs.NewJournal(cfg.HomeRoot)
+		deleted, err := fs.Delete(ctx, node, true, user, cfg.HomeRoot, cfg.ThumbRoot, j, db)
+		j.Finish(db)
 
-					if !found {
-						dir, err := fs.NodeByID(ctx, node.ParentID.Int, db)
-						if err != nil {
-							log.Printf("[scan-deleted] %s", err)
-						} else if dir != nil && !dir.ParentID.Valid {
-							// Do not delete any root directories
-							dirs = append(dirs, dir)
-						}
-					}
-				}
-			}
-		} else if err != nil {
-			log.Printf("[scan-deleted] %s", err)
-		}
+		res.removed(len(deleted))
 
-		// Delete resulting empty directories
-		for _, dir := range dirs {
-			_, err := fs.Delete(ctx, dir, false, user, cfg.HomeRoot, cfg.ThumbRoot, db)
-			if err != nil {
-				log.Printf("[scan-deleted] %s", err)
-			} else {
-				log.Printf("[scan-deleted] deleted an empty directory node: %d %s", node.ID, node.Name)
-			}
+		if err != nil {
+			logf(ctx, "[scan] %s", err)
+		} else {
+			logf(ctx, "[scan] deleted %s of %s", path, user.Name)
 		}
 	}
-
 	return nil
 }
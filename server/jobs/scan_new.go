@@ -4,17 +4,19 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"io/fs"
-	"log"
 	"path/filepath"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/terotoi/koticloud/server/core"
+	"github.com/terotoi/koticloud/server/crypt"
 	vfs "github.com/terotoi/koticloud/server/fs"
 	"github.com/terotoi/koticloud/server/models"
-	"github.com/terotoi/koticloud/server/mx"
+	"github.com/terotoi/koticloud/server/storage"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
 )
 
 // Number of concurrent scans
@@ -22,35 +24,24 @@ const maxRunningScans = 8
 
 type PathEntry struct {
 	Path     string
-	DirEntry fs.DirEntry
+	Info     *storage.FileInfo
 	Root     *models.Node
 	RootPath string
 	User     *models.User
 }
 
-func ScanAllHomes(ctx context.Context, cfg *core.Config, np *NodeProcessor, db *sql.DB) error {
-	users, err := models.Users().All(ctx, db)
-	if err != nil {
-		return err
-	}
-
-	// Collect all paths
-	var paths []*PathEntry
-	for _, user := range users {
-		p, err := listHomePaths(ctx, user, cfg, db)
-
-		if err != nil {
-			log.Printf("[scan] %s", err)
-		} else {
-			paths = append(paths, p...)
-		}
-	}
+// Scans paths for new files and directories and modified files. The
+// directories are scanned first, in the order of their paths, so that the
+// nodes of their parents exist, and then the files concurrently.
+func scanPaths(ctx context.Context, paths []*PathEntry, dryRun bool, res *ScanResult,
+	np *NodeProcessor, cfg *core.Config, db *sql.DB) {
+	jobOf(ctx).setTotal(len(paths))
 
 	// Take the directories
 	var dirs []*PathEntry
 	var files []*PathEntry
 	for _, p := range paths {
-		if p.DirEntry.IsDir() {
+		if p.Info.IsDir {
 			dirs = append(dirs, p)
 		} else {
 			files = append(files, p)
@@ -58,15 +49,22 @@ func ScanAllHomes(ctx context.Context, cfg *core.Config, np *NodeProcessor, db *
 	}
 
 	// Sort directories by path
-	sort.Slice(paths, func(i, j int) bool {
-		return paths[i].Path < paths[j].Path
+	sort.Slice(dirs, func(i, j int) bool {
+		return dirs[i].Path < dirs[j].Path
 	})
 
 	// Make the directories first
 	for _, p := range dirs {
-		if err := scanPath(ctx, p, np, cfg, db); err != nil {
-			log.Printf("[scan] %s", err)
+		if ctx.Err() != nil {
+			break
+		}
+
+		change, err := scanPath(ctx, p, dryRun, np, cfg, db)
+		if err != nil {
+			logf(ctx, "[scan] %s", err)
 		}
+		res.addPath(p, change, dryRun)
+		jobOf(ctx).step()
 	}
 
 	// Distrubute file paths to workers
@@ -74,13 +72,20 @@ func ScanAllHomes(ctx context.Context, cfg *core.Config, np *NodeProcessor, db *
 	var wg sync.WaitGroup
 
 	for _, p := range files {
+		if ctx.Err() != nil {
+			break
+		}
+
 		g <- struct{}{} // Block until we can acquire a slot
 		wg.Add(1)
 
 		f := func(p *PathEntry) {
-			if err := scanPath(ctx, p, np, cfg, db); err != nil {
-				log.Printf("[scan] %s", err)
+			change, err := scanPath(ctx, p, dryRun, np, cfg, db)
+			if err != nil {
+				logf(ctx, "[scan] %s", err)
 			}
+			res.addPath(p, change, dryRun)
+			jobOf(ctx).step()
 
 			wg.Done()
 			<-g // Release a slot
@@ -90,141 +95,221 @@ func ScanAllHomes(ctx context.Context, cfg *core.Config, np *NodeProcessor, db *
 
 	// Wait for all workers to finish
 	wg.Wait()
-
-	return nil
 }
 
-// List all paths under user's home directory
-func listHomePaths(ctx context.Context, user *models.User, cfg *core.Config, db *sql.DB) ([]*PathEntry, error) {
-	root, err := mx.UserEnsureRootNode(ctx, user, cfg.HomeRoot, db)
-	if err != nil {
-		return nil, err
-	}
-
-	rootPath, err := vfs.PhysPath(ctx, root, cfg.HomeRoot, db)
-	if err != nil {
-		return nil, err
-	}
-
-	paths, err := listPaths(rootPath, root)
-	if err != nil {
-		return nil, err
-	}
-
-	for _, p := range paths {
-		p.User = user
-	}
-
-	return paths, nil
-}
-
-// Collect all paths under a given path
-func listPaths(rootPath string, root *models.Node) ([]*PathEntry, error) {
+// Collect all paths under a directory in a home directory, except the
+// excluded ones.
+func listPaths(dir, rootPath string, root *models.Node, cfg *core.Config) ([]*PathEntry, error) {
 	var p []*PathEntry
 
-	f := func(path string, d fs.DirEntry, err error) error {
+	f := func(path string, info *storage.FileInfo, err error) error {
 		if err != nil {
 			return err
+		} else if cfg.ScanExcluded(strings.TrimPrefix(path, rootPath)) {
+			return nil
 		}
 
-		p = append(p, &PathEntry{Path: path, DirEntry: d, Root: root, RootPath: rootPath})
+		p = append(p, &PathEntry{Path: path, Info: info, Root: root, RootPath: rootPath})
 		return nil
 	}
 
-	err := filepath.WalkDir(rootPath, f)
+	err := storage.Walk(dir, f)
 	return p, err
 }
 
-// Scans a single path for a new file or directory
-func scanPath(ctx context.Context, p *PathEntry, np *NodeProcessor, cfg *core.Config, db *sql.DB) error {
+// Changes found by scanPath.
+const (
+	scanUnchanged = iota
+	scanNew
+	scanChanged
+)
+
+// Scans a single path for a new file or directory, or a modified file.
+// Returns the change found. A dry run only finds the change.
+func scanPath(ctx context.Context, p *PathEntry, dryRun bool, np *NodeProcessor, cfg *core.Config, db *sql.DB) (int, error) {
 	rpath := strings.TrimPrefix(p.Path, p.RootPath)
 
 	//log.Printf("[scan] scanning path %s", rpath)
 
 	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
-		return err
+		return scanUnchanged, err
 	}
 	defer tx.Rollback()
 
 	node, err := vfs.NodeByPath(ctx, rpath, p.Root, tx)
 	if err != nil {
-		return err
+		return scanUnchanged, err
+	}
+
+	if node != nil {
+		tx.Rollback()
+		if vfs.IsDir(node) != p.Info.IsDir {
+			logf(ctx, "file entry type mismatch: %s", rpath)
+			return scanUnchanged, nil
+		}
+
+		if changed, err := scanModified(ctx, p, node, dryRun, np, cfg, db); err != nil || !changed {
+			return scanUnchanged, err
+		}
+		return scanChanged, nil
+	} else if dryRun {
+		return scanNew, nil
+	}
+
+	name := filepath.Base(rpath)
+	parentPath := filepath.Dir(rpath)
+	parent, err := vfs.NodeByPath(ctx, parentPath, p.Root, tx)
+	if err != nil {
+		return scanUnchanged, err
 	}
 
-	if node == nil {
-		name := filepath.Base(rpath)
-		parentPath := filepath.Dir(rpath)
-		parent, err := vfs.NodeByPath(ctx, parentPath, p.Root, tx)
+	if parent == nil {
+		return scanUnchanged, fmt.Errorf("no parent found: %s", parentPath)
+	}
+
+	if p.Info.IsDir {
+		node, err := vfs.MakeDir(ctx, parent, name, p.User, cfg.HomeRoot, true, tx)
 		if err != nil {
-			return err
+			return scanUnchanged, err
 		}
 
-		if parent == nil {
-			return fmt.Errorf("no parent found: %s", parentPath)
+		path, err := vfs.PathFor(ctx, node, tx)
+		if err != nil {
+			return scanUnchanged, err
 		}
 
-		if p.DirEntry.IsDir() {
-			node, err := vfs.MakeDir(ctx, parent, name, p.User, cfg.HomeRoot, true, tx)
-			if err != nil {
-				return err
-			}
+		logf(ctx, "[scan] mkdir %s", path)
+		return scanNew, tx.Commit()
+	}
 
-			path, err := vfs.PathFor(ctx, node, tx)
-			if err != nil {
-				return err
-			}
+	mimeType, err := vfs.DetectMimeType(p.Path, false)
+	if err != nil {
+		logf(ctx, "[scan] %s", err)
+		return scanUnchanged, nil
+	}
 
-			log.Printf("[scan] mkdir %s", path)
+	hash, _, err := vfs.HashFile(p.Path, false)
+	if err != nil {
+		logf(ctx, "[scan] %s", err)
+		return scanUnchanged, nil
+	}
 
-			if err := tx.Commit(); err != nil {
-				return err
-			}
-		} else {
-			mimeType, err := vfs.DetectMimeType(p.Path)
-			if err != nil {
-				log.Println(err)
-				return nil
-			}
+	node, err = vfs.NewFile(ctx, parent, name, mimeType, p.Info.Size, p.User, nil, false, tx)
+	if err != nil {
+		return scanUnchanged, err
+	}
 
-			entry, err := p.DirEntry.Info()
-			if err != nil {
-				log.Println(err)
-				return nil
-			}
+	if err := vfs.SetNodeChecksum(ctx, node, null.StringFrom(hash), tx); err != nil {
+		return scanUnchanged, err
+	}
 
-			if node, err := vfs.NewFile(ctx, parent, name, mimeType,
-				entry.Size(), p.User, nil, false, tx); err != nil {
-				return err
-			} else {
-				path, err := vfs.PathFor(ctx, node, tx)
-				if err != nil {
-					return err
-				}
+	path, err := vfs.PathFor(ctx, node, tx)
+	if err != nil {
+		return scanUnchanged, err
+	}
 
-				log.Printf("[scan] new file %s", path)
+	logf(ctx, "[scan] new file %s", path)
 
-				if err := tx.Commit(); err != nil {
-					return err
-				}
+	if err := tx.Commit(); err != nil {
+		return scanUnchanged, err
+	}
 
-				// Add to processor
-				physPath, err := vfs.PhysPath(ctx, node, cfg.HomeRoot, db)
-				if err != nil {
-					return err
-				}
+	// Add to processor
+	physPath, err := vfs.PhysPath(ctx, node, cfg.HomeRoot, db)
+	if err != nil {
+		return scanNew, err
+	}
 
-				log.Printf("[scan] add to processor %s", physPath)
+	logf(ctx, "[scan] add to processor %s", physPath)
+	return scanNew, AddNodeProcessRequest(ctx, np.Channel, node, physPath, db)
+}
 
-				if err := AddNodeProcessRequest(ctx, np.Channel, node, physPath, false, db); err != nil {
-					return err
-				}
-			}
-		}
-	} else {
-		if vfs.IsDir(node) != p.DirEntry.IsDir() {
-			log.Printf("file entry type mismatch: %s", rpath)
+// Updates the node of a file which has been changed on disk since it was
+// stored: its size, type, modification time and checksum are updated, and the
+// file is processed again. A file with a newer modification time but the
+// same contents only gets the new time. Returns true if the file had changed.
+// Files in the blob store are not changed on disk, so they are left as they are.
+// A dry run only compares the file with the node.
+func scanModified(ctx context.Context, p *PathEntry, node *models.Node, dryRun bool, np *NodeProcessor,
+	cfg *core.Config, db *sql.DB) (bool, error) {
+	if vfs.IsDir(node) || p.Info.IsDir {
+		return false, nil
+	}
+
+	// Times are stored with microsecond precision.
+	modTime := p.Info.ModTime.Truncate(time.Microsecond)
+	size := p.Info.Size
+	if node.Encrypted {
+		size = crypt.PlainSize(size)
+	}
+	sizeChanged := !node.Size.Valid || node.Size.Int64 != size
+	if !sizeChanged && !modTime.After(node.ModifiedOn) {
+		return false, nil
+	}
+
+	physPath, err := vfs.PhysPath(ctx, node, cfg.HomeRoot, db)
+	if err != nil || physPath != p.Path {
+		return false, err
+	}
+
+	hash, _, err := vfs.HashFile(p.Path, node.Encrypted)
+	if err != nil {
+		return false, err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	checksum, err := vfs.NodeChecksum(ctx, node.ID, tx)
+	if err != nil {
+		return false, err
+	}
+
+	changed := sizeChanged || checksum.String != hash
+	if dryRun {
+		return changed, nil
+	}
+
+	node.ModifiedOn = modTime
+	cols := []string{models.NodeColumns.ModifiedOn}
+	if changed {
+		mimeType, err := vfs.DetectMimeType(p.Path, node.Encrypted)
+		if err != nil {
+			return false, err
 		}
+
+		// The duration and the thumbnail are set again by the node processor.
+		node.Size = null.Int64From(size)
+		node.MimeType = mimeType
+		node.Length = null.Float64{}
+		node.HasCustomThumb = false
+		cols = append(cols, models.NodeColumns.Size, models.NodeColumns.MimeType, models.NodeColumns.Length,
+			models.NodeColumns.HasCustomThumb)
+	}
+
+	if _, err := node.Update(ctx, tx, boil.Whitelist(cols...)); err != nil {
+		return false, err
+	} else if !changed {
+		return false, tx.Commit()
+	}
+
+	if err := vfs.SetNodeChecksum(ctx, node, null.StringFrom(hash), tx); err != nil {
+		return false, err
 	}
-	return nil
+
+	path, err := vfs.PathFor(ctx, node, tx)
+	if err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	logf(ctx, "[scan] modified file %s", path)
+	return true, AddNodeProcessRequest(ctx, np.Channel, node, physPath, db)
 }
@@ -0,0 +1,183 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/terotoi/koticloud/server/core"
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/terotoi/koticloud/server/mx"
+)
+
+// Creates files under a directory.
+func writeScanFiles(t *testing.T, dir string, files map[string]string) {
+	for name, data := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		} else if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// Runs a scan, and checks the numbers of changes found and that a dry run
+// lists them all.
+func checkScan(t *testing.T, opts *ScanOptions, cfg *core.Config, db *sql.DB, new, changed, removed int) *ScanResult {
+	t.Helper()
+	np := &NodeProcessor{Channel: make(chan NodeProcessRequest, 1)}
+	res, err := Scan(context.Background(), opts, cfg, np, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.New != new || res.Changed != changed || res.Removed != removed {
+		t.Errorf("dry run %t: %d new, %d changed, %d removed, want %d, %d, %d",
+			opts.DryRun, res.New, res.Changed, res.Removed, new, changed, removed)
+	}
+
+	if opts.DryRun && len(res.Changes) != new+changed+removed {
+		t.Errorf("dry run listed %d changes", len(res.Changes))
+	} else if !opts.DryRun && len(res.Changes) > 0 {
+		t.Errorf("listed %d applied changes", len(res.Changes))
+	}
+	return res
+}
+
+// Returns the operations and the paths of the changes found by a dry run.
+func scanChanges(res *ScanResult) []string {
+	var changes []string
+	for _, c := range res.Changes {
+		changes = append(changes, c.Op+" "+c.Path)
+	}
+	sort.Strings(changes)
+	return changes
+}
+
+// Checks the number of nodes.
+func checkNodeCount(t *testing.T, db *sql.DB, want int64) {
+	t.Helper()
+	if n, err := models.Nodes().Count(context.Background(), db); err != nil {
+		t.Fatal(err)
+	} else if n != want {
+		t.Errorf("%d nodes, want %d", n, want)
+	}
+}
+
+// A dry run finds the same changes as a scan without applying them, and
+// excluded files are neither created nor deleted.
+func TestScan(t *testing.T) {
+	forEachDB(t, func(t *testing.T, db *sql.DB) {
+		ctx := context.Background()
+		cfg := &core.Config{HomeRoot: t.TempDir(), ThumbRoot: t.TempDir(), ScanExclude: []string{"*.tmp", "@eaDir"}}
+		if err := mx.UserCreate(ctx, "alice", "pw", false, cfg.HomeRoot, db); err != nil {
+			t.Fatal(err)
+		}
+
+		home := filepath.Join(cfg.HomeRoot, "alice")
+		writeScanFiles(t, home, map[string]string{
+			"a.txt": "a", "d/b.txt": "b", "d/e/c.txt": "c",
+			"x.tmp": "x", "d/y.tmp": "y", "@eaDir/thumb.jpg": "t",
+		})
+
+		dry := &ScanOptions{DryRun: true}
+		res := checkScan(t, dry, cfg, db, 5, 0, 0)
+		want := []string{"create /a.txt", "create /d", "create /d/b.txt", "create /d/e", "create /d/e/c.txt"}
+		if got := scanChanges(res); !reflect.DeepEqual(got, want) {
+			t.Errorf("dry run found %v, want %v", got, want)
+		}
+		checkNodeCount(t, db, 1)
+
+		checkScan(t, &ScanOptions{}, cfg, db, 5, 0, 0)
+		checkNodeCount(t, db, 6)
+		checkScan(t, &ScanOptions{}, cfg, db, 0, 0, 0)
+
+		// A file with a new modification time but the same contents is unchanged.
+		later := time.Now().Add(time.Hour)
+		if err := os.Chtimes(filepath.Join(home, "d/b.txt"), later, later); err != nil {
+			t.Fatal(err)
+		}
+		checkScan(t, &ScanOptions{}, cfg, db, 0, 0, 0)
+
+		writeScanFiles(t, home, map[string]string{"a.txt": "changed"})
+		if err := os.RemoveAll(filepath.Join(home, "d/e")); err != nil {
+			t.Fatal(err)
+		}
+
+		res = checkScan(t, dry, cfg, db, 0, 1, 2)
+		want = []string{"delete /d/e", "delete /d/e/c.txt", "update /a.txt"}
+		if got := scanChanges(res); !reflect.DeepEqual(got, want) {
+			t.Errorf("dry run found %v, want %v", got, want)
+		}
+		checkNodeCount(t, db, 6)
+
+		checkScan(t, &ScanOptions{}, cfg, db, 0, 1, 2)
+		checkNodeCount(t, db, 4)
+
+		// Nodes of excluded files are kept after the files are gone.
+		cfg.ScanExclude = nil
+		checkScan(t, &ScanOptions{}, cfg, db, 4, 0, 0)
+		cfg.ScanExclude = []string{"*.tmp", "@eaDir"}
+
+		for _, name := range []string{"x.tmp", "d/y.tmp", "@eaDir"} {
+			if err := os.RemoveAll(filepath.Join(home, name)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		checkScan(t, dry, cfg, db, 0, 0, 0)
+		checkScan(t, &ScanOptions{}, cfg, db, 0, 0, 0)
+		checkNodeCount(t, db, 8)
+	})
+}
+
+// A scan of a path or of one mode only finds the changes under the path,
+// and excluded paths are refused.
+func TestScanPath(t *testing.T) {
+	forEachDB(t, func(t *testing.T, db *sql.DB) {
+		ctx := context.Background()
+		cfg := &core.Config{HomeRoot: t.TempDir(), ThumbRoot: t.TempDir(), ScanExclude: []string{"*.tmp"}}
+		if err := mx.UserCreate(ctx, "alice", "pw", false, cfg.HomeRoot, db); err != nil {
+			t.Fatal(err)
+		}
+
+		home := filepath.Join(cfg.HomeRoot, "alice")
+		writeScanFiles(t, home, map[string]string{"a.txt": "a", "d/b.txt": "b", "d/e/c.txt": "c"})
+
+		// The new directories above the path are created too.
+		res := checkScan(t, &ScanOptions{User: "alice", Path: "/d/e", DryRun: true}, cfg, db, 3, 0, 0)
+		want := []string{"create /d", "create /d/e", "create /d/e/c.txt"}
+		if got := scanChanges(res); !reflect.DeepEqual(got, want) {
+			t.Errorf("dry run found %v, want %v", got, want)
+		}
+		checkScan(t, &ScanOptions{User: "alice", Path: "/d/e"}, cfg, db, 3, 0, 0)
+		checkScan(t, &ScanOptions{User: "alice"}, cfg, db, 2, 0, 0)
+
+		if err := os.Remove(filepath.Join(home, "a.txt")); err != nil {
+			t.Fatal(err)
+		}
+		writeScanFiles(t, home, map[string]string{"f.txt": "f"})
+
+		checkScan(t, &ScanOptions{Mode: ScanModeDeleted, DryRun: true}, cfg, db, 0, 0, 1)
+		checkScan(t, &ScanOptions{Mode: ScanModeNew, DryRun: true}, cfg, db, 1, 0, 0)
+		checkScan(t, &ScanOptions{User: "alice", Path: "/d"}, cfg, db, 0, 0, 0)
+
+		np := &NodeProcessor{Channel: make(chan NodeProcessRequest, 1)}
+		for _, opts := range []*ScanOptions{
+			{User: "alice", Path: "/x.tmp"},
+			{User: "bob"},
+			{Path: "/d"},
+			{Mode: "all"},
+		} {
+			if _, err := Scan(ctx, opts, cfg, np, db); err == nil {
+				t.Errorf("scanned %+v", opts)
+			}
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"os"
+	"time"
+
+	"github.com/terotoi/koticloud/server/fs"
+)
+
+const scrubCheckInterval = time.Hour
+
+// Scrub re-hashes the contents of all files whose checksum was last verified
+// before t, or never, and flags the files whose contents no longer match.
+// Files without a checksum get one. Returns the number of checked and mismatched files.
+func Scrub(ctx context.Context, homeRoot string, t time.Time, db *sql.DB) (int, int, error) {
+	var ids []int
+	rows, err := db.QueryContext(ctx, "SELECT id FROM nodes WHERE type='file' AND "+
+		"(checksum_verified_on IS NULL OR checksum_verified_on < $1) ORDER BY checksum_verified_on NULLS FIRST, id", t)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, 0, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+
+	// Files in the blob store may be shared by many nodes.
+	hashes := map[string]string{}
+
+	checked, failed := 0, 0
+	jobOf(ctx).setTotal(len(ids))
+	for _, id := range ids {
+		select {
+		case <-ctx.Done():
+			return checked, failed, ctx.Err()
+		default:
+		}
+		jobOf(ctx).step()
+
+		node, err := fs.NodeByIDopt(ctx, id, db)
+		if err != nil {
+			return checked, failed, err
+		} else if node == nil {
+			continue
+		}
+
+		path, err := fs.PhysPath(ctx, node, homeRoot, db)
+		if err != nil {
+			return checked, failed, err
+		}
+
+		hash, ok := hashes[path]
+		if !ok {
+			hash, _, err = fs.HashFile(path, node.Encrypted)
+			if os.IsNotExist(err) {
+				// Left for the scan of deleted files.
+				continue
+			} else if err != nil {
+				logf(ctx, "[scrub] %d %s: %s", node.ID, path, err)
+				continue
+			}
+			hashes[path] = hash
+		}
+
+		ok, err = fs.VerifyNodeChecksum(ctx, node.ID, hash, db)
+		if err != nil {
+			return checked, failed, err
+		}
+
+		checked++
+		if !ok {
+			failed++
+			logf(ctx, "[scrub] Checksum mismatch: node %d (%s) %s", node.ID, node.Name, path)
+		}
+	}
+
+	return checked, failed, nil
+}
+
+// RunScrubber periodically verifies the checksums of files last verified
+// more than interval ago. Returns when ctx is cancelled.
+func RunScrubber(ctx context.Context, homeRoot string, interval time.Duration, db *sql.DB) {
+	ticker := time.NewTicker(scrubCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		checked, failed, err := Scrub(ctx, homeRoot, time.Now().Add(-interval), db)
+		if err != nil && err != context.Canceled {
+			log.Printf("[scrub] %s", err)
+		} else if checked > 0 {
+			log.Printf("[scrub] verified %d files, %d mismatches", checked, failed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package jobs
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/terotoi/koticloud/server/util"
+)
+
+// Maximum number of bytes of text stored per file for searching.
+const maxTextSize = 512 << 10
+
+// Maximum number of PDF pages to extract text from.
+const maxTextPages = 200
+
+// extractText extracts the text content of a file for full-text search.
+func extractText(node *models.Node, path string) (string, error) {
+	var text string
+	var err error
+
+	switch {
+	case util.IsText(node.MimeType):
+		text, err = readTextFile(path)
+	case util.IsPDF(node.MimeType):
+		text, err = extractTextPDF(path)
+	case util.IsOffice(node.MimeType):
+		text, err = extractTextOffice(path)
+	default:
+		return "", fmt.Errorf("cannot extract text from %s", node.MimeType)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(text) > maxTextSize {
+		text = text[:maxTextSize]
+	}
+
+	// PostgreSQL does not accept NUL characters or invalid UTF-8 in text.
+	text = strings.ReplaceAll(text, "\x00", "")
+	return strings.ToValidUTF8(text, ""), nil
+}
+
+// Reads the beginning of a text file.
+func readTextFile(path string) (string, error) {
+	fh, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer fh.Close()
+
+	data, err := io.ReadAll(io.LimitReader(fh, maxTextSize))
+	return string(data), err
+}
+
+// extractTextPDF extracts text from a PDF file with pdftotext, falling back to ghostscript.
+func extractTextPDF(path string) (string, error) {
+	src := util.ShellEscape(path)
+
+	text, err := util.Exec(fmt.Sprintf("pdftotext -q -enc UTF-8 -l %d %s -", maxTextPages, src))
+	if err == nil {
+		return text, nil
+	}
+
+	return util.Exec(fmt.Sprintf("gs -q -dSAFER -dBATCH -dNOPAUSE -dLastPage=%d "+
+		"-sDEVICE=txtwrite -o - %s", maxTextPages, src))
+}
+
+// extractTextOffice extracts text from the XML parts of an Office Open XML
+// or OpenDocument file.
+func extractTextOffice(path string) (string, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return "", err
+	}
+	defer zr.Close()
+
+	var parts []*zip.File
+	for _, f := range zr.File {
+		switch {
+		case f.Name == "word/document.xml", f.Name == "xl/sharedStrings.xml", f.Name == "content.xml":
+			parts = append(parts, f)
+		case strings.HasPrefix(f.Name, "ppt/slides/") && filepath.Ext(f.Name) == ".xml":
+			parts = append(parts, f)
+		}
+	}
+
+	// Keep slides in order.
+	sort.Slice(parts, func(i, j int) bool {
+		return len(parts[i].Name) < len(parts[j].Name) ||
+			(len(parts[i].Name) == len(parts[j].Name) && parts[i].Name < parts[j].Name)
+	})
+
+	var sb strings.Builder
+	for _, f := range parts {
+		if err := xmlText(f, &sb); err != nil {
+			return "", err
+		}
+
+		if sb.Len() >= maxTextSize {
+			break
+		}
+	}
+	return sb.String(), nil
+}
+
+// Appends the character data of an XML document to sb. Elements are separated by spaces.
+func xmlText(f *zip.File, sb *strings.Builder) error {
+	r, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	dec := xml.NewDecoder(r)
+	for sb.Len() < maxTextSize {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		switch t := tok.(type) {
+		case xml.CharData:
+			sb.Write(t)
+		case xml.EndElement:
+			if sb.Len() > 0 && !strings.HasSuffix(sb.String(), " ") {
+				sb.WriteByte(' ')
+			}
+		}
+	}
+	return nil
+}
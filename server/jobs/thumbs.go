@@ -1,13 +1,17 @@
 package jobs
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
 
 	"github.com/terotoi/koticloud/server/fs"
 	"github.com/terotoi/koticloud/server/models"
+	"github.com/terotoi/koticloud/server/storage"
 	"github.com/terotoi/koticloud/server/util"
 )
 
@@ -102,29 +106,48 @@ func generateThumbnailPDF(src, dst, tempDir, method string) error {
 	return nil
 }
 
-// generateThumbnail generates a thumbnail image for a file
-func generateThumbnail(node *models.Node, removeUpload bool, uploadFile, thumbRoot, tempDir, method string) error {
+// generateThumbnail generates a thumbnail image for a file. The thumbnail is
+// generated into a temporary file, and stored encrypted with the data key of
+// the owner of the node, if it has one.
+func generateThumbnail(ctx context.Context, node *models.Node, uploadFile, thumbRoot,
+	tempDir, method string, db *sql.DB) error {
 	if util.HasCustomThumb(node.MimeType) {
 		path := util.ShellEscape(uploadFile)
+		thumbPath := fs.ThumbPath(thumbRoot, node.ID, true)
 
-		destFile := fs.ThumbPath(thumbRoot, node.ID, true)
-		err := util.EnsureDirExists(fs.ThumbPath(thumbRoot, node.ID, false))
+		fh, err := ioutil.TempFile(tempDir, "thumb-*"+filepath.Ext(thumbPath))
 		if err != nil {
 			return err
 		}
+		fh.Close()
+		defer os.Remove(fh.Name())
 
 		if util.IsImage(node.MimeType) {
-			err = generateThumbnailImage(path, destFile, method)
+			err = generateThumbnailImage(path, fh.Name(), method)
 		} else if util.IsVideo(node.MimeType) {
-			err = generateThumbnailVideo(path, destFile, tempDir, method, node.Length.Float64)
+			err = generateThumbnailVideo(path, fh.Name(), tempDir, method, node.Length.Float64)
 		} else if util.IsPDF(node.MimeType) {
-			err = generateThumbnailPDF(path, destFile, tempDir, method)
+			err = generateThumbnailPDF(path, fh.Name(), tempDir, method)
 		}
-
 		if err != nil {
 			return err
 		}
 
+		// The key is looked up in a transaction, which keeps it current until
+		// the thumbnail is stored, see fs.LockDataKey.
+		if err := withTx(ctx, db, func(tx *sql.Tx) error {
+			key, err := fs.UserDataKey(ctx, node.OwnerID.Int, tx)
+			if err != nil {
+				return err
+			}
+			if err := storage.Store(fh.Name(), false, thumbPath, key); err != nil {
+				return err
+			}
+			return fs.SetThumbEncrypted(ctx, node, key != nil, tx)
+		}); err != nil {
+			return err
+		}
+
 		node.HasCustomThumb = true
 	}
 
@@ -0,0 +1,72 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/terotoi/koticloud/server/core"
+	"github.com/terotoi/koticloud/server/fs"
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/terotoi/koticloud/server/util"
+)
+
+const trashPurgeInterval = time.Hour
+
+// PurgeTrash permanently deletes nodes which have been in the trash longer
+// than maxAge. Returns the number of deleted nodes.
+func PurgeTrash(ctx context.Context, cfg *core.Config, maxAge time.Duration, db *sql.DB) (int, error) {
+	items, err := fs.TrashItemsBefore(ctx, time.Now().Add(-maxAge), db)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, item := range items {
+		j := fs.NewJournal(cfg.HomeRoot)
+		err := util.WithTransaction(ctx, db, func(tx *sql.Tx) error {
+			owner, err := models.FindUser(ctx, tx, item.UserID)
+			if err != nil {
+				return err
+			}
+
+			node, err := fs.NodeByID(ctx, item.NodeID, tx)
+			if err != nil {
+				return err
+			}
+
+			deleted, err := fs.Delete(ctx, node, true, owner, cfg.HomeRoot, cfg.ThumbRoot, j, tx)
+			count += len(deleted)
+			return err
+		})
+		j.Finish(db)
+
+		if err != nil {
+			log.Printf("[trash] failed to purge node %d: %s", item.NodeID, err)
+		}
+	}
+	return count, nil
+}
+
+// RunTrashPurger periodically deletes nodes which have been in the trash
+// longer than maxAge. Returns when ctx is cancelled.
+func RunTrashPurger(ctx context.Context, cfg *core.Config, maxAge time.Duration, db *sql.DB) {
+	ticker := time.NewTicker(trashPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		count, err := PurgeTrash(ctx, cfg, maxAge, db)
+		if err != nil {
+			log.Printf("[trash] %s", err)
+		} else if count > 0 {
+			log.Printf("[trash] purged %d nodes", count)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
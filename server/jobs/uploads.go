@@ -0,0 +1,33 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/terotoi/koticloud/server/uploads"
+)
+
+const uploadCleanInterval = time.Hour
+
+// RunUploadCleaner periodically removes upload sessions which have
+// not received data in maxAge. Returns when ctx is cancelled.
+func RunUploadCleaner(ctx context.Context, uploadDir string, maxAge time.Duration) {
+	ticker := time.NewTicker(uploadCleanInterval)
+	defer ticker.Stop()
+
+	for {
+		count, err := uploads.RemoveExpired(uploadDir, maxAge)
+		if err != nil {
+			log.Printf("[upload] %s", err)
+		} else if count > 0 {
+			log.Printf("[upload] removed %d expired upload sessions", count)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
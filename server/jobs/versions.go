@@ -0,0 +1,70 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/terotoi/koticloud/server/fs"
+	"github.com/terotoi/koticloud/server/models"
+)
+
+const versionPruneInterval = time.Hour
+
+// PruneOldVersions deletes versions of all nodes older than maxAge.
+// Returns the number of deleted versions.
+func PruneOldVersions(ctx context.Context, homeRoot string, maxAge time.Duration, db *sql.DB) (int, error) {
+	versions, err := fs.VersionsBefore(ctx, time.Now().Add(-maxAge), db)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, v := range versions {
+		if err := deleteVersion(ctx, v, homeRoot, db); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// Deletes a version in a transaction of its own.
+func deleteVersion(ctx context.Context, v *models.Version, homeRoot string, db *sql.DB) error {
+	j := fs.NewJournal(homeRoot)
+	defer j.Finish(db)
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fs.DeleteVersion(ctx, v, homeRoot, j, tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// RunVersionPruner periodically deletes versions older than maxAge.
+// Returns when ctx is cancelled.
+func RunVersionPruner(ctx context.Context, homeRoot string, maxAge time.Duration, db *sql.DB) {
+	ticker := time.NewTicker(versionPruneInterval)
+	defer ticker.Stop()
+
+	for {
+		count, err := PruneOldVersions(ctx, homeRoot, maxAge, db)
+		if err != nil {
+			log.Printf("[versions] %s", err)
+		} else if count > 0 {
+			log.Printf("[versions] pruned %d old versions", count)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,451 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/terotoi/koticloud/server/core"
+	vfs "github.com/terotoi/koticloud/server/fs"
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/terotoi/koticloud/server/storage"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// Interval of checking whether pending changes have settled.
+const watchTick = 250 * time.Millisecond
+
+// Changes are reconciled at the latest this long after the first change of
+// a burst, even if the burst continues.
+const watchMaxDelay = 30 * time.Second
+
+// Interval of checking for new home directories and of the partial rescans,
+// and the number of directories rescanned each time.
+const watchRescanInterval = 5 * time.Second
+const watchRescanBatch = 100
+
+// watchEvent is a change to a path in a watched directory.
+type watchEvent struct {
+	Path     string // Full path of the created, changed or removed file or directory
+	From     string // Previous path of a file or directory moved to Path, if known
+	Overflow bool   // Events were lost
+}
+
+// notifier reports changes in watched directories. Directories created in
+// or moved into a watched directory are watched with everything under them.
+type notifier interface {
+	// Add watches a directory, but not the directories under it.
+	Add(dir string) error
+
+	// Events returns the channel of the changes.
+	Events() <-chan watchEvent
+
+	// Close stops watching.
+	Close() error
+}
+
+// Keeps the nodes of the home directories in sync with changes made to the
+// files outside of the server. Changes are collected until there have been
+// none for the debounce time, and then reconciled with the nodes of their paths.
+type watcher struct {
+	cfg      *core.Config
+	np       *NodeProcessor
+	db       *sql.DB
+	debounce time.Duration
+	notifier notifier
+
+	homes   map[string]bool // Watched home directories
+	pending map[string]bool // Paths changed since the last reconciliation
+	moves   []watchEvent    // Files and directories moved since the last reconciliation
+	first   time.Time       // Time of the first pending change
+	last    time.Time       // Time of the latest pending change
+	rescan  []string        // Directories to rescan
+}
+
+// RunWatcher watches the home directories for changes made outside of the
+// server, such as files copied into them over Samba, and creates, updates,
+// moves and deletes their nodes accordingly until ctx is done. If the kernel
+// reports that changes were lost, the home directories are rescanned a few
+// directories at a time.
+func RunWatcher(ctx context.Context, cfg *core.Config, np *NodeProcessor, debounce time.Duration, db *sql.DB) error {
+	if !storage.IsLocal(cfg.HomeRoot) {
+		return fmt.Errorf("home directories in remote storage cannot be watched")
+	}
+
+	n, err := newNotifier()
+	if err != nil {
+		return err
+	}
+	defer n.Close()
+
+	w := &watcher{
+		cfg:      cfg,
+		np:       np,
+		db:       db,
+		debounce: debounce,
+		notifier: n,
+		homes:    make(map[string]bool),
+		pending:  make(map[string]bool),
+	}
+
+	w.watchHomes(ctx, false)
+	log.Printf("[watch] watching %d home directories", len(w.homes))
+
+	tick := time.NewTicker(watchTick)
+	defer tick.Stop()
+	rescanTick := time.NewTicker(watchRescanInterval)
+	defer rescanTick.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case e, ok := <-n.Events():
+			if !ok {
+				return fmt.Errorf("watching stopped")
+			}
+			w.add(e)
+
+		case now := <-tick.C:
+			if len(w.pending) > 0 && (now.Sub(w.last) >= w.debounce || now.Sub(w.first) >= watchMaxDelay) {
+				w.reconcile(ctx)
+			}
+
+		case <-rescanTick.C:
+			w.watchHomes(ctx, true)
+			w.rescanSome(ctx)
+		}
+	}
+}
+
+// Tells if a file is a temporary file written by the server, see storage.WriteAtomic.
+func isTempFile(path string) bool {
+	name := filepath.Base(path)
+	return strings.HasPrefix(name, ".") && strings.Contains(name, ".tmp-")
+}
+
+// Tells if a path is excluded from scans, see core.Config.ScanExcluded.
+func (w *watcher) excluded(path string) bool {
+	rel := strings.TrimPrefix(path, strings.TrimSuffix(w.cfg.HomeRoot, "/")+"/")
+	if i := strings.Index(rel, "/"); i >= 0 && rel != path {
+		return w.cfg.ScanExcluded(rel[i:])
+	}
+	return false
+}
+
+// Adds a change to the pending changes.
+func (w *watcher) add(e watchEvent) {
+	if e.Overflow {
+		log.Printf("[watch] changes were lost, rescanning the home directories")
+		w.rescan = w.rescan[:0]
+		for home := range w.homes {
+			w.rescan = append(w.rescan, home)
+		}
+		return
+	}
+
+	if isTempFile(e.From) || w.excluded(e.From) {
+		e.From = ""
+	}
+
+	// A file moved to an excluded path is removed.
+	if isTempFile(e.Path) || w.excluded(e.Path) {
+		if e.From == "" {
+			return
+		}
+		e.Path, e.From = e.From, ""
+	}
+
+	now := time.Now()
+	if len(w.pending) == 0 {
+		w.first = now
+	}
+	w.last = now
+
+	w.pending[e.Path] = true
+	if e.From != "" {
+		w.pending[e.From] = true
+		w.moves = append(w.moves, e)
+	}
+}
+
+// Watches the home directories of all users, which are created on demand.
+// New home directories are rescanned if rescan is true.
+func (w *watcher) watchHomes(ctx context.Context, rescan bool) {
+	roots, err := models.Nodes(qm.Where("parent_id IS NULL")).All(ctx, w.db)
+	if err != nil {
+		log.Printf("[watch] %s", err)
+		return
+	}
+
+	for _, root := range roots {
+		home, err := vfs.PhysPath(ctx, root, w.cfg.HomeRoot, w.db)
+		if err != nil {
+			log.Printf("[watch] %s", err)
+		} else if !w.homes[home] {
+			if err := w.watchTree(home); err != nil {
+				log.Printf("[watch] %s", err)
+				continue
+			}
+
+			w.homes[home] = true
+			if rescan {
+				w.rescan = append(w.rescan, home)
+			}
+		}
+	}
+}
+
+// Watches a directory and all directories under it.
+func (w *watcher) watchTree(dir string) error {
+	return filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		} else if d.IsDir() {
+			return w.notifier.Add(path)
+		}
+		return nil
+	})
+}
+
+// Rescans some of the directories waiting for a rescan. The files and
+// directories in them and their nodes are added to the pending changes,
+// and the directories under them to the directories to rescan.
+func (w *watcher) rescanSome(ctx context.Context) {
+	for i := 0; i < watchRescanBatch && len(w.rescan) > 0; i++ {
+		dir := w.rescan[len(w.rescan)-1]
+		w.rescan = w.rescan[:len(w.rescan)-1]
+
+		// Directories created while events were lost are not watched yet.
+		if err := w.notifier.Add(dir); err != nil {
+			if !os.IsNotExist(err) {
+				log.Printf("[watch] %s", err)
+			}
+			continue
+		}
+
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			log.Printf("[watch] %s", err)
+			continue
+		}
+
+		for _, e := range entries {
+			path := dir + "/" + e.Name()
+			w.add(watchEvent{Path: path})
+			if e.IsDir() {
+				w.rescan = append(w.rescan, path)
+			}
+		}
+
+		// Nodes of removed files.
+		if home, root, _, err := w.homeOf(ctx, dir); err != nil {
+			log.Printf("[watch] %s", err)
+		} else if root != nil {
+			if node, err := vfs.NodeByPath(ctx, strings.TrimPrefix(dir, home), root, w.db); err != nil {
+				log.Printf("[watch] %s", err)
+			} else if node != nil {
+				children, err := vfs.NodesByParentID(ctx, node.ID, w.db)
+				if err != nil {
+					log.Printf("[watch] %s", err)
+				}
+
+				for _, c := range children {
+					w.add(watchEvent{Path: dir + "/" + c.Name})
+				}
+			}
+		}
+	}
+}
+
+// Returns the home directory containing a path, its root node and the owner
+// of the home directory. The root is nil if the path is not in a home directory.
+func (w *watcher) homeOf(ctx context.Context, path string) (string, *models.Node, *models.User, error) {
+	rel := strings.TrimPrefix(path, strings.TrimSuffix(w.cfg.HomeRoot, "/")+"/")
+	if rel == path {
+		return "", nil, nil, nil
+	}
+
+	name := strings.SplitN(rel, "/", 2)[0]
+	home := strings.TrimSuffix(path, strings.TrimPrefix(rel, name))
+	if !w.homes[home] {
+		return "", nil, nil, nil
+	}
+
+	root, err := models.Nodes(qm.Where("parent_id IS NULL"), qm.And("name=?", name)).One(ctx, w.db)
+	if err == sql.ErrNoRows {
+		return "", nil, nil, nil
+	} else if err != nil {
+		return "", nil, nil, err
+	} else if !root.OwnerID.Valid {
+		return "", nil, nil, fmt.Errorf("home directory %s has no owner", home)
+	}
+
+	user, err := models.FindUser(ctx, w.db, root.OwnerID.Int)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	return home, root, user, nil
+}
+
+// Reconciles the pending changes: moved nodes are moved first, and then each
+// changed path is reconciled, parents before their contents.
+func (w *watcher) reconcile(ctx context.Context) {
+	for _, m := range w.moves {
+		if err := w.move(ctx, m.From, m.Path); err != nil {
+			log.Printf("[watch] %s", err)
+		}
+	}
+
+	paths := make([]string, 0, len(w.pending))
+	for path := range w.pending {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	w.moves = w.moves[:0]
+	w.pending = make(map[string]bool)
+
+	for _, path := range paths {
+		if err := w.reconcilePath(ctx, path); err != nil {
+			log.Printf("[watch] %s: %s", path, err)
+		}
+	}
+}
+
+// Moves the node of a file or directory moved within a home directory, so
+// that it keeps its identity, thumbnails and shares. The file has already
+// been moved.
+func (w *watcher) move(ctx context.Context, from, to string) error {
+	home, root, _, err := w.homeOf(ctx, from)
+	if err != nil || root == nil || !strings.HasPrefix(to, home+"/") {
+		return err
+	}
+
+	tx, err := w.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	node, err := vfs.NodeByPath(ctx, strings.TrimPrefix(from, home), root, tx)
+	if err != nil || node == nil || node.ID == root.ID {
+		return err
+	}
+
+	// The contents of files in the blob store are not in the home directory.
+	if physPath, err := vfs.PhysPath(ctx, node, w.cfg.HomeRoot, tx); err != nil || physPath != from {
+		return err
+	}
+
+	rpath := strings.TrimPrefix(to, home)
+	if existing, err := vfs.NodeByPath(ctx, rpath, root, tx); err != nil || existing != nil {
+		return err
+	}
+
+	parent, err := vfs.NodeByPath(ctx, filepath.Dir(rpath), root, tx)
+	if err != nil || parent == nil || !vfs.IsDir(parent) {
+		return err
+	}
+
+	node.ParentID = null.IntFrom(parent.ID)
+	node.Name = filepath.Base(rpath)
+	if _, err := node.Update(ctx, tx, boil.Whitelist(models.NodeColumns.ParentID, models.NodeColumns.Name)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	log.Printf("[watch] moved %s to %s", strings.TrimPrefix(from, home), rpath)
+	return nil
+}
+
+// Reconciles the node of a path with the file or directory in it: a node is
+// created for a new file or directory, with everything under a directory,
+// updated for a modified file and deleted with everything under it for a
+// removed file or directory.
+func (w *watcher) reconcilePath(ctx context.Context, path string) error {
+	home, root, user, err := w.homeOf(ctx, path)
+	if err != nil || root == nil || path == home {
+		return err
+	}
+
+	info, err := storage.Stat(path, false)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	rpath := strings.TrimPrefix(path, home)
+	node, err := vfs.NodeByPath(ctx, rpath, root, w.db)
+	if err != nil {
+		return err
+	}
+
+	if info == nil {
+		if node != nil {
+			return w.remove(ctx, node, path, user)
+		}
+		return nil
+	}
+
+	p := &PathEntry{Path: path, Info: info, Root: root, RootPath: home, User: user}
+	if node != nil || !info.IsDir {
+		_, err := scanPath(ctx, p, false, w.np, w.cfg, w.db)
+		return err
+	}
+
+	// The contents of a new directory may have been created before it was watched.
+	if err := w.watchTree(path); err != nil {
+		return err
+	}
+
+	return storage.Walk(path, func(path string, info *storage.FileInfo, err error) error {
+		if err != nil {
+			return err
+		} else if isTempFile(path) || w.cfg.ScanExcluded(strings.TrimPrefix(path, home)) {
+			return nil
+		}
+
+		_, err = scanPath(ctx, &PathEntry{Path: path, Info: info, Root: root, RootPath: home, User: user},
+			false, w.np, w.cfg, w.db)
+		return err
+	})
+}
+
+// Deletes the node of a removed file or directory with everything under it.
+func (w *watcher) remove(ctx context.Context, node *models.Node, path string, user *models.User) error {
+	// The contents of files in the blob store are not in the home directory.
+	if physPath, err := vfs.PhysPath(ctx, node, w.cfg.HomeRoot, w.db); err != nil || physPath != path {
+		return err
+	}
+
+	j := vfs.NewJournal(w.cfg.HomeRoot)
+	defer j.Finish(w.db)
+
+	tx, err := w.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	deleted, err := vfs.Delete(ctx, node, true, user, w.cfg.HomeRoot, w.cfg.ThumbRoot, j, tx)
+	if err != nil {
+		return err
+	} else if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	log.Printf("[watch] deleted %d nodes under %s", len(deleted), path)
+	return nil
+}
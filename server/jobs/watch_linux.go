@@ -0,0 +1,209 @@
+//go:build linux
+
+package jobs
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// Changes reported for watched directories.
+const inotifyMask = unix.IN_CREATE | unix.IN_CLOSE_WRITE | unix.IN_ATTRIB | unix.IN_DELETE |
+	unix.IN_MOVED_FROM | unix.IN_MOVED_TO | unix.IN_ONLYDIR | unix.IN_DONT_FOLLOW | unix.IN_EXCL_UNLINK
+
+// Size of the buffer events are read into, enough for a few hundred events.
+const inotifyBufferSize = 64 * 1024
+
+// inotify watches directories with the inotify API of Linux.
+type inotify struct {
+	fd     int
+	file   *os.File
+	events chan watchEvent
+	done   chan struct{}
+
+	mutex sync.Mutex
+	dirs  map[int]string // Watched directories by watch descriptor
+}
+
+func newNotifier() (notifier, error) {
+	fd, err := unix.InotifyInit1(unix.IN_NONBLOCK | unix.IN_CLOEXEC)
+	if err != nil {
+		return nil, os.NewSyscallError("inotify_init1", err)
+	}
+
+	n := &inotify{
+		fd: fd,
+		// A non-blocking file can be closed while it is being read.
+		file:   os.NewFile(uintptr(fd), "inotify"),
+		events: make(chan watchEvent, 1024),
+		done:   make(chan struct{}),
+		dirs:   make(map[int]string),
+	}
+
+	go n.read()
+	return n, nil
+}
+
+func (n *inotify) Add(dir string) error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	wd, err := unix.InotifyAddWatch(n.fd, dir, inotifyMask)
+	if err != nil {
+		return &os.PathError{Op: "inotify_add_watch", Path: dir, Err: err}
+	}
+
+	// A directory moved elsewhere keeps its watch descriptor.
+	n.dirs[wd] = dir
+	return nil
+}
+
+func (n *inotify) Events() <-chan watchEvent {
+	return n.events
+}
+
+func (n *inotify) Close() error {
+	close(n.done)
+	return n.file.Close()
+}
+
+// Sends an event, unless the notifier has been closed.
+func (n *inotify) send(e watchEvent) {
+	select {
+	case n.events <- e:
+	case <-n.done:
+	}
+}
+
+// Adds a directory created in or moved into a watched directory with the
+// directories under it. Errors are ignored, since the directory may already
+// have been removed. It is walked again when its node is created.
+func (n *inotify) addTree(dir string) {
+	filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err == nil && d.IsDir() {
+			n.Add(path)
+		}
+		return nil
+	})
+}
+
+// Updates the paths of the watched directories under a moved directory.
+func (n *inotify) moveTree(from, to string) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	for wd, dir := range n.dirs {
+		if dir == from || strings.HasPrefix(dir, from+"/") {
+			n.dirs[wd] = to + strings.TrimPrefix(dir, from)
+		}
+	}
+}
+
+// Stops watching the directories under a directory moved out of the watched directories.
+func (n *inotify) removeTree(dir string) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	for wd, d := range n.dirs {
+		if d == dir || strings.HasPrefix(d, dir+"/") {
+			unix.InotifyRmWatch(n.fd, uint32(wd))
+			delete(n.dirs, wd)
+		}
+	}
+}
+
+// Returns the directory of a watch descriptor.
+func (n *inotify) dir(wd int) (string, bool) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	dir, ok := n.dirs[wd]
+	return dir, ok
+}
+
+// Forgets a watch descriptor removed by the kernel.
+func (n *inotify) forget(wd int) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	delete(n.dirs, wd)
+}
+
+// Reads events until the notifier is closed, and sends them to the events
+// channel. A move within the watched directories is reported as a single
+// event if both of its halves are read at once, and otherwise as a removal
+// and a creation.
+func (n *inotify) read() {
+	defer close(n.events)
+
+	type moved struct {
+		path string
+		dir  bool
+	}
+
+	buf := make([]byte, inotifyBufferSize)
+	for {
+		count, err := n.file.Read(buf)
+		if err != nil {
+			return
+		}
+
+		movedFrom := make(map[uint32]moved)
+		for offset := 0; offset+unix.SizeofInotifyEvent <= count; {
+			e := (*unix.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			name := buf[offset+unix.SizeofInotifyEvent : offset+unix.SizeofInotifyEvent+int(e.Len)]
+			offset += unix.SizeofInotifyEvent + int(e.Len)
+
+			if e.Mask&unix.IN_Q_OVERFLOW != 0 {
+				n.send(watchEvent{Overflow: true})
+				continue
+			} else if e.Mask&unix.IN_IGNORED != 0 {
+				n.forget(int(e.Wd))
+				continue
+			}
+
+			dir, ok := n.dir(int(e.Wd))
+			if !ok || e.Len == 0 {
+				continue
+			}
+
+			path := dir + "/" + string(bytes.TrimRight(name, "\x00"))
+			isDir := e.Mask&unix.IN_ISDIR != 0
+
+			switch {
+			case e.Mask&unix.IN_MOVED_FROM != 0:
+				movedFrom[e.Cookie] = moved{path: path, dir: isDir}
+				continue
+
+			case e.Mask&unix.IN_MOVED_TO != 0:
+				if from, ok := movedFrom[e.Cookie]; ok {
+					delete(movedFrom, e.Cookie)
+					if isDir {
+						n.moveTree(from.path, path)
+					}
+					n.send(watchEvent{Path: path, From: from.path})
+					continue
+				} else if isDir {
+					n.addTree(path)
+				}
+
+			case e.Mask&unix.IN_CREATE != 0 && isDir:
+				n.addTree(path)
+			}
+
+			n.send(watchEvent{Path: path})
+		}
+
+		// Moved out of the watched directories.
+		for _, from := range movedFrom {
+			if from.dir {
+				n.removeTree(from.path)
+			}
+			n.send(watchEvent{Path: from.path})
+		}
+	}
+}
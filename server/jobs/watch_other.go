@@ -0,0 +1,9 @@
+//go:build !linux
+
+package jobs
+
+import "fmt"
+
+func newNotifier() (notifier, error) {
+	return nil, fmt.Errorf("watching home directories is only supported on Linux")
+}
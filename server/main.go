@@ -6,14 +6,17 @@ import (
 	"flag"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
 	"github.com/terotoi/koticloud/server/core"
+	"github.com/terotoi/koticloud/server/database"
+	"github.com/terotoi/koticloud/server/fs"
 	"github.com/terotoi/koticloud/server/jobs"
-
-	_ "github.com/lib/pq" // For PostgreSQL driver
 )
 
 // Connect to database and wait for it to become live.
@@ -21,7 +24,7 @@ func connectDB(config string) (*sql.DB, error) {
 	const initialWait = 2
 	const maxWait = 15
 
-	db, err := sql.Open("postgres", config)
+	db, err := database.Open(config)
 	if err != nil {
 		return nil, err
 	}
@@ -55,12 +58,47 @@ func main() {
 	args := flag.Args()
 	cmd := args[0]
 
+	if err := setupStorage(cfg); err != nil {
+		log.Println(err)
+		return
+	}
+
 	db, err := connectDB(cfg.Database)
 	if err != nil {
 		log.Println(err)
 		return
 	}
 
+	// The schema is migrated before anything else accesses the database. The
+	// tree is repaired before the migrations enforcing its invariants.
+	if cmd == "migrate" {
+		if err := runMigrate(args[1:], db); err != nil {
+			log.Println(err)
+		}
+		return
+	} else if cmd == "repair-tree" {
+		rep, err := fs.RepairTree(context.Background(), cfg.HomeRoot, db)
+		if err != nil {
+			log.Println(err)
+			return
+		}
+		log.Printf("Repaired %d cycles, %d duplicate names and %d duplicate progress rows",
+			rep.Cycles, rep.Duplicates, rep.Progress)
+		return
+	}
+
+	if cfg.AutoMigrate {
+		if err := applyMigrations(db); err != nil {
+			log.Println(err)
+			return
+		}
+	}
+
+	if err := setupEncryption(cfg, db); err != nil {
+		log.Println(err)
+		return
+	}
+
 	if cfg.InitialUser != "" && cfg.InitialPW != "" {
 		if err := createInitialUser(cfg.InitialUser, cfg.InitialPW, cfg.HomeRoot, db); err != nil {
 			log.Println(err)
@@ -69,6 +107,11 @@ func main() {
 	}
 
 	if cmd == "serve" {
+		if err := recoverJournal(cfg, db); err != nil {
+			log.Println(err)
+			return
+		}
+
 		r := chi.NewRouter()
 		r.Use(middleware.RequestID)
 		r.Use(middleware.RealIP)
@@ -76,8 +119,35 @@ func main() {
 		r.Use(middleware.Recoverer)
 		r.Use(middleware.Timeout(60 * time.Second))
 
+		if _, err := jobs.FailStaleJobs(context.Background(), db); err != nil {
+			log.Println(err)
+			return
+		}
+
 		np := jobs.RunNodeProc(cfg, db)
 
+		cleanCtx, stopCleaner := context.WithCancel(context.Background())
+		go jobs.RunUploadCleaner(cleanCtx, cfg.UploadDir, time.Duration(cfg.UploadMaxAge)*time.Hour)
+		if cfg.TrashRetention > 0 {
+			go jobs.RunTrashPurger(cleanCtx, cfg, time.Duration(cfg.TrashRetention)*24*time.Hour, db)
+		}
+		if cfg.VersionsMaxAge > 0 {
+			go jobs.RunVersionPruner(cleanCtx, cfg.HomeRoot, cfg.VersionsMaxAgeDuration(), db)
+		}
+		if cfg.ScrubInterval > 0 {
+			go jobs.RunScrubber(cleanCtx, cfg.HomeRoot, cfg.ScrubIntervalDuration(), db)
+		}
+		if cfg.BlobStore {
+			go jobs.RunBlobCollector(cleanCtx, cfg.HomeRoot, cfg.BlobGCMaxAgeDuration(), db)
+		}
+		if cfg.WatchHomes {
+			go func() {
+				if err := jobs.RunWatcher(cleanCtx, cfg, np, cfg.WatchDebounceDuration(), db); err != nil {
+					log.Printf("[watch] %s", err)
+				}
+			}()
+		}
+
 		setupRoutes(r, cfg, np, db)
 
 		addr := cfg.ListenAddress
@@ -85,16 +155,85 @@ func main() {
 		if err := http.ListenAndServe(addr, r); err != nil {
 			log.Println(err)
 		}
+		stopCleaner()
 		if np != nil {
 			np.End()
 			np.WaitGroup.Wait()
 		}
+	} else if cmd == "worker" {
+		// Processes the nodes queued by the servers until stopped.
+		np := jobs.RunNodeProc(cfg, db)
+
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+		<-sig
+
+		np.End()
+		np.WaitGroup.Wait()
+
 	} else if cmd == "scan" {
+		flags := flag.NewFlagSet(cmd, flag.ExitOnError)
+		opts := &jobs.ScanOptions{}
+		flags.StringVar(&opts.User, "user", "", "scan only the home directory of this user")
+		flags.StringVar(&opts.Path, "path", "", "scan only this file or directory in the home directory of the user")
+		onlyNew := flags.Bool("new", false, "scan only for new and modified files")
+		onlyDeleted := flags.Bool("deleted", false, "scan only for deleted files")
+		flags.BoolVar(&opts.DryRun, "dry-run", false, "list the changes without applying them")
+		flags.Parse(args[1:])
+
+		if *onlyNew && !*onlyDeleted {
+			opts.Mode = jobs.ScanModeNew
+		} else if *onlyDeleted && !*onlyNew {
+			opts.Mode = jobs.ScanModeDeleted
+		}
+
 		np := jobs.RunNodeProc(cfg, db)
-		err = jobs.ScanAllHomes(context.Background(), cfg, np, db)
+		var res *jobs.ScanResult
+		if res, err = jobs.Scan(context.Background(), opts, cfg, np, db); err == nil {
+			for _, c := range res.Changes {
+				log.Printf("%s %s:%s", c.Op, c.User, c.Path)
+			}
+			log.Printf("Found %d new, %d changed and %d deleted files and directories",
+				res.New, res.Changed, res.Removed)
+		}
 		np.End()
 		np.WaitGroup.Wait()
 
+	} else if cmd == "scrub" {
+		var checked, failed int
+		checked, failed, err = jobs.Scrub(context.Background(), cfg.HomeRoot, time.Now(), db)
+		log.Printf("Verified %d files, %d mismatches", checked, failed)
+
+	} else if cmd == "migrate-blobs" {
+		var files, versions int
+		files, versions, err = jobs.MigrateToBlobs(context.Background(), cfg.HomeRoot, db)
+		log.Printf("Migrated %d files and %d versions into the blob store", files, versions)
+		if err == nil && !cfg.BlobStore {
+			log.Printf("Set \"blob_store\": true in the configuration to store new files as blobs")
+		}
+
+	} else if cmd == "gc-blobs" {
+		var count int
+		count, err = jobs.CollectBlobs(context.Background(), cfg.HomeRoot, cfg.BlobGCMaxAgeDuration(), db)
+		log.Printf("Removed %d blobs", count)
+
+	} else if cmd == "rotate-keys" {
+		flags := flag.NewFlagSet(cmd, flag.ExitOnError)
+		oldMaster := flags.String("old-master", "", "file containing the previous master key")
+		masterOnly := flags.Bool("master-only", false, "only rewrap the data keys with the current master key")
+		flags.Parse(args[1:])
+		var files int
+		files, err = jobs.RotateKeys(context.Background(), cfg, *oldMaster, *masterOnly, db)
+		log.Printf("Re-encrypted %d files", files)
+
+	} else if cmd == "convert-db" {
+		flags := flag.NewFlagSet(cmd, flag.ExitOnError)
+		to := flags.String("to", "", "database configuration string of the destination database")
+		flags.Parse(args[1:])
+		var rows int64
+		rows, err = jobs.ConvertDB(context.Background(), *to, cfg.SearchLanguage, db)
+		log.Printf("Copied %d rows", rows)
+
 	} else {
 		log.Printf("Unknown command: %s", cmd)
 	}
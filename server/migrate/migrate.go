@@ -0,0 +1,321 @@
+// Package migrate keeps the database schema up to date with versioned
+// migrations, which are built into the server binary.
+//
+// Each migration is a pair of SQL files in the directory of the dialect of
+// the database under migrations, named NNNN_name.up.sql and
+// NNNN_name.down.sql, where NNNN is the version. Both dialects have the same
+// versions. The applied versions are recorded in the schema_migrations
+// table. A migration and its record are applied in a single transaction.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"log"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/terotoi/koticloud/server/database"
+	"github.com/volatiletech/null/v8"
+)
+
+//go:embed migrations/postgres/*.sql migrations/sqlite/*.sql
+var files embed.FS
+
+// Key of the advisory lock held while migrating PostgreSQL databases, so
+// that servers started at the same time do not apply the same migrations.
+// SQLite databases are used by a single server.
+const lockKey = 0x6b6f7469
+
+// Version of the migration creating the schema of a database made before
+// migrations were versioned.
+const baselineVersion = 1
+
+var fileRe = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+var createTable = map[database.Dialect]string{
+	database.Postgres: `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version integer PRIMARY KEY,
+    name character varying NOT NULL,
+    applied_on timestamp with time zone DEFAULT now() NOT NULL
+)`,
+	database.SQLite: `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version integer PRIMARY KEY,
+    name text NOT NULL,
+    applied_on timestamp DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')) NOT NULL
+)`,
+}
+
+// Queries telling if the schema_migrations table has rows, and if the nodes table exists.
+var baselineQuery = map[database.Dialect]string{
+	database.Postgres: "SELECT EXISTS(SELECT 1 FROM schema_migrations), to_regclass('public.nodes') IS NOT NULL",
+	database.SQLite: "SELECT EXISTS(SELECT 1 FROM schema_migrations), " +
+		"EXISTS(SELECT 1 FROM sqlite_master WHERE type='table' AND name='nodes')",
+}
+
+// Migration is a versioned change to the schema.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string // Empty if the migration cannot be reverted
+}
+
+// State tells if a migration has been applied.
+type State struct {
+	*Migration
+	AppliedOn null.Time
+}
+
+// Applied tells if the migration has been applied.
+func (s *State) Applied() bool {
+	return s.AppliedOn.Valid
+}
+
+// Migrations returns the migrations of a dialect built into the binary,
+// ordered by version.
+func Migrations(dialect database.Dialect) ([]*Migration, error) {
+	migrationDir := "migrations/" + string(dialect)
+	entries, err := files.ReadDir(migrationDir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, e := range entries {
+		m := fileRe.FindStringSubmatch(e.Name())
+		if m == nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", e.Name())
+		}
+
+		version, _ := strconv.Atoi(m[1])
+		mig := byVersion[version]
+		if mig == nil {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		} else if mig.Name != m[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, mig.Name, m[2])
+		}
+
+		data, err := files.ReadFile(migrationDir + "/" + e.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		if m[3] == "up" {
+			mig.Up = string(data)
+		} else {
+			mig.Down = string(data)
+		}
+	}
+
+	migs := make([]*Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.Up == "" {
+			return nil, fmt.Errorf("migration %d (%s) has no up file", mig.Version, mig.Name)
+		}
+		migs = append(migs, mig)
+	}
+
+	sort.Slice(migs, func(i, k int) bool { return migs[i].Version < migs[k].Version })
+	return migs, nil
+}
+
+// Find returns the migration of a dialect with the given name.
+func Find(dialect database.Dialect, name string) (*Migration, error) {
+	migs, err := Migrations(dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range migs {
+		if m.Name == name {
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf("no migration named %s", name)
+}
+
+// Status returns the state of each migration, ordered by version.
+func Status(ctx context.Context, db *sql.DB) ([]*State, error) {
+	var states []*State
+	err := withLock(ctx, db, func(conn *sql.Conn) error {
+		var err error
+		states, err = loadStates(ctx, database.DialectOf(db), conn)
+		return err
+	})
+	return states, err
+}
+
+// Up applies all pending migrations. Returns the number of applied migrations.
+func Up(ctx context.Context, db *sql.DB) (int, error) {
+	return UpTo(ctx, math.MaxInt32, db)
+}
+
+// UpTo applies the pending migrations up to and including the given
+// version. Returns the number of applied migrations.
+func UpTo(ctx context.Context, version int, db *sql.DB) (int, error) {
+	count := 0
+	err := withLock(ctx, db, func(conn *sql.Conn) error {
+		states, err := loadStates(ctx, database.DialectOf(db), conn)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range states {
+			if s.Version > version {
+				break
+			} else if s.Applied() {
+				continue
+			}
+
+			log.Printf("[migrate] applying %04d %s", s.Version, s.Name)
+			if err := apply(ctx, s.Up, "INSERT INTO schema_migrations (version, name) VALUES ($1, $2)",
+				s.Migration, conn); err != nil {
+				return fmt.Errorf("migration %04d %s: %s", s.Version, s.Name, err)
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Down reverts the given number of the latest applied migrations. Returns
+// the number of reverted migrations.
+func Down(ctx context.Context, steps int, db *sql.DB) (int, error) {
+	count := 0
+	err := withLock(ctx, db, func(conn *sql.Conn) error {
+		states, err := loadStates(ctx, database.DialectOf(db), conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(states) - 1; i >= 0 && count < steps; i-- {
+			s := states[i]
+			if !s.Applied() {
+				continue
+			} else if s.Down == "" {
+				return fmt.Errorf("migration %04d %s cannot be reverted", s.Version, s.Name)
+			}
+
+			log.Printf("[migrate] reverting %04d %s", s.Version, s.Name)
+			if err := apply(ctx, s.Down, "DELETE FROM schema_migrations WHERE version=$1 AND name=$2",
+				s.Migration, conn); err != nil {
+				return fmt.Errorf("migration %04d %s: %s", s.Version, s.Name, err)
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Runs f on a connection holding the migration lock. The schema_migrations
+// table is created first if it does not exist.
+func withLock(ctx context.Context, db *sql.DB, f func(conn *sql.Conn) error) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	dialect := database.DialectOf(db)
+	if dialect == database.Postgres {
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+			return err
+		}
+		defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockKey)
+	}
+
+	if _, err := conn.ExecContext(ctx, createTable[dialect]); err != nil {
+		return err
+	}
+
+	if err := adoptBaseline(ctx, dialect, conn); err != nil {
+		return err
+	}
+	return f(conn)
+}
+
+// Records the baseline migration as applied in a database created from the
+// schema dump before migrations were versioned.
+func adoptBaseline(ctx context.Context, dialect database.Dialect, conn *sql.Conn) error {
+	var versioned, exists bool
+	if err := conn.QueryRowContext(ctx, baselineQuery[dialect]).Scan(&versioned, &exists); err != nil {
+		return err
+	} else if versioned || !exists {
+		return nil
+	}
+
+	log.Printf("[migrate] existing database, recording migration %04d as applied", baselineVersion)
+	_, err := conn.ExecContext(ctx, "INSERT INTO schema_migrations (version, name) VALUES ($1, 'baseline')",
+		baselineVersion)
+	return err
+}
+
+// Returns the state of each migration. Versions applied to the database but
+// unknown to this binary are an error, since the schema is then newer.
+func loadStates(ctx context.Context, dialect database.Dialect, conn *sql.Conn) ([]*State, error) {
+	migs, err := Migrations(dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.QueryContext(ctx, "SELECT version, applied_on FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedOn time.Time
+		if err := rows.Scan(&version, &appliedOn); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedOn
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	states := make([]*State, len(migs))
+	for i, m := range migs {
+		states[i] = &State{Migration: m}
+		if t, ok := applied[m.Version]; ok {
+			states[i].AppliedOn = null.TimeFrom(t)
+			delete(applied, m.Version)
+		}
+	}
+
+	for version := range applied {
+		return nil, fmt.Errorf("migration %04d is applied to the database but unknown to this version of koticloud",
+			version)
+	}
+	return states, nil
+}
+
+// Runs the statements of a migration and updates schema_migrations with
+// record in a transaction.
+func apply(ctx context.Context, stmts, record string, m *Migration, conn *sql.Conn) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, stmts); err != nil {
+		return err
+	} else if _, err := tx.ExecContext(ctx, record, m.Version, m.Name); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
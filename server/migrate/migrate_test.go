@@ -0,0 +1,238 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/terotoi/koticloud/server/database"
+)
+
+// Returns the databases to migrate: a scratch SQLite database, and the
+// PostgreSQL database of $DATABASE if set. The schema of that database is
+// dropped, so it must be a scratch database too.
+func testDBs(t *testing.T) map[string]*sql.DB {
+	dbs := make(map[string]*sql.DB)
+	for name, config := range map[string]string{
+		"sqlite":   "sqlite:" + filepath.Join(t.TempDir(), "test.db"),
+		"postgres": os.Getenv("DATABASE"),
+	} {
+		if config == "" {
+			continue
+		}
+
+		db, err := database.Open(config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { db.Close() })
+		dbs[name] = db
+	}
+	return dbs
+}
+
+func TestMigrations(t *testing.T) {
+	pg, err := Migrations(database.Postgres)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlite, err := Migrations(database.SQLite)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pg) != len(sqlite) {
+		t.Fatalf("%d PostgreSQL and %d SQLite migrations", len(pg), len(sqlite))
+	}
+
+	for i, m := range pg {
+		if m.Version != i+1 {
+			t.Errorf("migration %04d %s: expected version %d", m.Version, m.Name, i+1)
+		} else if sqlite[i].Version != m.Version || sqlite[i].Name != m.Name {
+			t.Errorf("migration %04d %s is %04d %s for SQLite", m.Version, m.Name, sqlite[i].Version, sqlite[i].Name)
+		} else if m.Down == "" || sqlite[i].Down == "" {
+			t.Errorf("migration %04d %s has no down file", m.Version, m.Name)
+		}
+	}
+}
+
+// A new database is migrated to the schema of the models, reverted to an
+// empty database and migrated again.
+func TestUpDown(t *testing.T) {
+	ctx := context.Background()
+	migs, err := Migrations(database.SQLite)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, db := range testDBs(t) {
+		t.Run(name, func(t *testing.T) {
+			// Earlier runs against PostgreSQL may have left the schema behind.
+			if _, err := Down(ctx, len(migs), db); err != nil {
+				t.Fatal(err)
+			}
+
+			for i := 0; i < 2; i++ {
+				if n, err := Up(ctx, db); err != nil {
+					t.Fatal(err)
+				} else if n != len(migs) {
+					t.Errorf("applied %d migrations, want %d", n, len(migs))
+				}
+
+				if err := CheckModels(ctx, db); err != nil {
+					t.Error(err)
+				}
+
+				if n, err := Up(ctx, db); err != nil || n != 0 {
+					t.Errorf("second Up applied %d migrations: %v", n, err)
+				}
+
+				if n, err := Down(ctx, len(migs), db); err != nil {
+					t.Fatal(err)
+				} else if n != len(migs) {
+					t.Errorf("reverted %d migrations, want %d", n, len(migs))
+				}
+			}
+		})
+	}
+}
+
+// A database created from the baseline schema before migrations were
+// versioned is adopted, and its data is carried through the migrations.
+func TestUpBaseline(t *testing.T) {
+	ctx := context.Background()
+	for name, db := range testDBs(t) {
+		t.Run(name, func(t *testing.T) {
+			migs, err := Migrations(database.DialectOf(db))
+			if err != nil {
+				t.Fatal(err)
+			} else if _, err := Down(ctx, len(migs), db); err != nil {
+				t.Fatal(err)
+			} else if _, err := db.ExecContext(ctx, "DROP TABLE schema_migrations"); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, q := range []string{
+				migs[0].Up,
+				"INSERT INTO users (id, name) VALUES (1, 'alice')",
+				"INSERT INTO nodes (id, name, type, mime_type, owner_id) VALUES (1, 'alice', 'directory', 'inode/directory', 1)",
+				"INSERT INTO nodes (id, name, type, mime_type, owner_id, parent_id) VALUES " +
+					"(2, 'Photos', 'directory', 'inode/directory', 1, 1), (3, 'a.jpg', 'file', 'image/jpeg', 1, 2)",
+				"UPDATE users SET root_id=1",
+				`INSERT INTO infos (node_id, user_id, type, data) VALUES (3, 1, 'photo', '{"taken_on":"2021-05-01T10:00:00Z"}')`,
+			} {
+				if _, err := db.ExecContext(ctx, q); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if n, err := Up(ctx, db); err != nil {
+				t.Fatal(err)
+			} else if n != len(migs)-1 {
+				t.Errorf("applied %d migrations, want %d", n, len(migs)-1)
+			}
+
+			var path string
+			var takenOn time.Time
+			if err := db.QueryRowContext(ctx, "SELECT path FROM nodes WHERE id=3").Scan(&path); err != nil {
+				t.Fatal(err)
+			} else if path != "/alice/Photos/a.jpg" {
+				t.Errorf("path = %s", path)
+			}
+
+			if err := db.QueryRowContext(ctx, "SELECT taken_on FROM infos WHERE node_id=3").Scan(&takenOn); err != nil {
+				t.Fatal(err)
+			} else if !takenOn.Equal(time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC)) {
+				t.Errorf("taken_on = %s", takenOn)
+			}
+
+			// The tree invariants are enforced.
+			if _, err := db.ExecContext(ctx, "UPDATE nodes SET parent_id=3 WHERE id=2"); err == nil {
+				t.Error("moved a directory under itself")
+			}
+			if _, err := db.ExecContext(ctx, "INSERT INTO nodes (name, type, mime_type, parent_id) "+
+				"VALUES ('Photos', 'directory', 'inode/directory', 1)"); !database.IsUniqueViolation(err) {
+				t.Errorf("inserting a duplicate name: %v", err)
+			}
+
+			if _, err := Down(ctx, len(migs), db); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestUpTo(t *testing.T) {
+	ctx := context.Background()
+	for name, db := range testDBs(t) {
+		t.Run(name, func(t *testing.T) {
+			migs, err := Migrations(database.DialectOf(db))
+			if err != nil {
+				t.Fatal(err)
+			} else if _, err := Down(ctx, len(migs), db); err != nil {
+				t.Fatal(err)
+			}
+
+			paths, err := Find(database.DialectOf(db), "node_paths")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if n, err := UpTo(ctx, paths.Version-1, db); err != nil || n != paths.Version-1 {
+				t.Errorf("UpTo applied %d migrations: %v", n, err)
+			}
+
+			if n, err := Up(ctx, db); err != nil || n != len(migs)-paths.Version+1 {
+				t.Errorf("Up applied %d migrations: %v", n, err)
+			}
+
+			if _, err := Down(ctx, len(migs), db); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// Columns added to or removed from the database without regenerating the
+// models are detected.
+func TestCheckModels(t *testing.T) {
+	ctx := context.Background()
+	for name, db := range testDBs(t) {
+		t.Run(name, func(t *testing.T) {
+			migs, err := Migrations(database.DialectOf(db))
+			if err != nil {
+				t.Fatal(err)
+			} else if _, err := Down(ctx, len(migs), db); err != nil {
+				t.Fatal(err)
+			} else if _, err := Up(ctx, db); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, c := range []struct{ q, col string }{
+				{"ALTER TABLE journal ADD COLUMN extra text", "journal.extra"},
+				{"ALTER TABLE journal DROP COLUMN extra", ""},
+				{"ALTER TABLE journal DROP COLUMN created_on", "journal.created_on"},
+			} {
+				if _, err := db.ExecContext(ctx, c.q); err != nil {
+					t.Fatal(err)
+				}
+
+				err := CheckModels(ctx, db)
+				if c.col == "" && err != nil {
+					t.Errorf("%s: %s", c.q, err)
+				} else if c.col != "" && (err == nil || !strings.Contains(err.Error(), c.col)) {
+					t.Errorf("%s: %v, want an error for %s", c.q, err, c.col)
+				}
+			}
+
+			if _, err := Down(ctx, len(migs), db); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,8 @@
+--
+-- Removes the baseline schema of koticloud with all data.
+--
+
+DROP TABLE IF EXISTS public.progress, public.node_process_reqs, public.infos, public.nodes,
+    public.users CASCADE;
+
+DROP TYPE IF EXISTS public.node_type;
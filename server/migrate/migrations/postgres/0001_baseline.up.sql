@@ -1,21 +1,7 @@
 --
--- PostgreSQL database dump
+-- Baseline schema of koticloud, from a PostgreSQL database dump.
 --
 
--- Dumped from database version 11.12 (Debian 11.12-0+deb10u1)
--- Dumped by pg_dump version 11.12 (Debian 11.12-0+deb10u1)
-
-SET statement_timeout = 0;
-SET lock_timeout = 0;
-SET idle_in_transaction_session_timeout = 0;
-SET client_encoding = 'UTF8';
-SET standard_conforming_strings = on;
-SELECT pg_catalog.set_config('search_path', '', false);
-SET check_function_bodies = false;
-SET xmloption = content;
-SET client_min_messages = warning;
-SET row_security = off;
-
 --
 -- Name: node_type; Type: TYPE; Schema: public; Owner: -
 --
@@ -26,10 +12,6 @@ CREATE TYPE public.node_type AS ENUM (
 );
 
 
-SET default_tablespace = '';
-
-SET default_with_oids = false;
-
 --
 -- Name: infos; Type: TABLE; Schema: public; Owner: -
 --
@@ -344,9 +326,3 @@ ALTER TABLE ONLY public.progress
 
 ALTER TABLE ONLY public.users
     ADD CONSTRAINT users_root_id_fkey FOREIGN KEY (root_id) REFERENCES public.nodes(id) ON UPDATE CASCADE ON DELETE SET NULL;
-
-
---
--- PostgreSQL database dump complete
---
-
@@ -0,0 +1,8 @@
+--
+-- Removes the trash table and the trash directories of users. The trashed
+-- nodes are kept.
+--
+
+DROP TABLE IF EXISTS public.trash;
+
+ALTER TABLE public.users DROP COLUMN IF EXISTS trash_id;
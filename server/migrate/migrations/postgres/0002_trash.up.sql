@@ -0,0 +1,59 @@
+--
+-- Deleted nodes are moved into the trash directory of their owner, and the
+-- trash table remembers where they came from.
+--
+
+--
+-- Name: trash; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.trash (
+    node_id integer NOT NULL,
+    user_id integer NOT NULL,
+    original_parent_id integer,
+    original_name character varying NOT NULL,
+    deleted_on timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+ALTER TABLE public.users ADD COLUMN trash_id integer;
+
+
+--
+-- Name: trash trash_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.trash
+    ADD CONSTRAINT trash_pkey PRIMARY KEY (node_id);
+
+
+--
+-- Name: trash trash_node_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.trash
+    ADD CONSTRAINT trash_node_id_fkey FOREIGN KEY (node_id) REFERENCES public.nodes(id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+
+--
+-- Name: trash trash_original_parent_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.trash
+    ADD CONSTRAINT trash_original_parent_id_fkey FOREIGN KEY (original_parent_id) REFERENCES public.nodes(id) ON UPDATE CASCADE ON DELETE SET NULL;
+
+
+--
+-- Name: trash trash_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.trash
+    ADD CONSTRAINT trash_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+
+--
+-- Name: users users_trash_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.users
+    ADD CONSTRAINT users_trash_id_fkey FOREIGN KEY (trash_id) REFERENCES public.nodes(id) ON UPDATE CASCADE ON DELETE SET NULL;
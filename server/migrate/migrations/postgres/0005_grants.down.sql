@@ -0,0 +1,7 @@
+--
+-- Removes the access grants and the groups.
+--
+
+DROP TABLE IF EXISTS public.grants;
+DROP TABLE IF EXISTS public.group_members;
+DROP TABLE IF EXISTS public.groups;
@@ -0,0 +1,5 @@
+--
+-- Removes the storage quotas of users.
+--
+
+ALTER TABLE public.users DROP COLUMN IF EXISTS quota;
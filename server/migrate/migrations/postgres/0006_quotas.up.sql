@@ -0,0 +1,6 @@
+--
+-- Storage quotas of users, in bytes. Users without a quota have unlimited
+-- storage.
+--
+
+ALTER TABLE public.users ADD COLUMN quota bigint;
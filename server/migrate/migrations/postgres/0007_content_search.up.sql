@@ -0,0 +1,36 @@
+--
+-- Text extracted from the contents of files for content search.
+--
+
+--
+-- Name: node_texts; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.node_texts (
+    node_id integer NOT NULL,
+    content text NOT NULL,
+    tsv tsvector NOT NULL
+);
+
+
+--
+-- Name: node_texts node_texts_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.node_texts
+    ADD CONSTRAINT node_texts_pkey PRIMARY KEY (node_id);
+
+
+--
+-- Name: node_texts_tsv_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX node_texts_tsv_idx ON public.node_texts USING gin (tsv);
+
+
+--
+-- Name: node_texts node_texts_node_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.node_texts
+    ADD CONSTRAINT node_texts_node_id_fkey FOREIGN KEY (node_id) REFERENCES public.nodes(id) ON UPDATE CASCADE ON DELETE CASCADE;
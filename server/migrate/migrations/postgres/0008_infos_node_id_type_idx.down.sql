@@ -0,0 +1,5 @@
+--
+-- Removes the index of info records by node and type.
+--
+
+DROP INDEX IF EXISTS public.infos_node_id_type_idx;
@@ -0,0 +1,9 @@
+--
+-- Info records are looked up by their node and type.
+--
+
+--
+-- Name: infos_node_id_type_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX infos_node_id_type_idx ON public.infos USING btree (node_id, type);
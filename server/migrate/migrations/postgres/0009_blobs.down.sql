@@ -0,0 +1,12 @@
+--
+-- Removes the blob store. The contents of files stored as blobs are not
+-- moved back into the directory tree.
+--
+
+DROP TRIGGER IF EXISTS nodes_blob_refs ON public.nodes;
+DROP TRIGGER IF EXISTS versions_blob_refs ON public.versions;
+DROP FUNCTION IF EXISTS public.update_blob_refs();
+
+ALTER TABLE public.nodes DROP COLUMN IF EXISTS blob;
+ALTER TABLE public.versions DROP COLUMN IF EXISTS blob;
+DROP TABLE IF EXISTS public.blobs;
@@ -0,0 +1,92 @@
+--
+-- Contents of files stored once by their SHA-256 hash. Nodes and versions
+-- refer to their contents in the blob store, and triggers keep the number of
+-- references of each blob.
+--
+
+--
+-- Name: update_blob_refs(); Type: FUNCTION; Schema: public; Owner: -
+--
+
+CREATE FUNCTION public.update_blob_refs() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') AND OLD.blob IS NOT NULL THEN
+        UPDATE public.blobs SET refs = refs - 1 WHERE hash = OLD.blob;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') AND NEW.blob IS NOT NULL THEN
+        UPDATE public.blobs SET refs = refs + 1 WHERE hash = NEW.blob;
+    END IF;
+    RETURN NULL;
+END;
+$$;
+
+
+--
+-- Name: blobs; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.blobs (
+    hash character(64) NOT NULL,
+    size bigint NOT NULL,
+    refs integer DEFAULT 0 NOT NULL,
+    created_on timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+ALTER TABLE public.nodes ADD COLUMN blob character(64);
+
+ALTER TABLE public.versions ADD COLUMN blob character(64);
+
+
+--
+-- Name: blobs blobs_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.blobs
+    ADD CONSTRAINT blobs_pkey PRIMARY KEY (hash);
+
+
+--
+-- Name: nodes_blob_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX nodes_blob_idx ON public.nodes USING btree (blob);
+
+
+--
+-- Name: versions_blob_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX versions_blob_idx ON public.versions USING btree (blob);
+
+
+--
+-- Name: nodes nodes_blob_refs; Type: TRIGGER; Schema: public; Owner: -
+--
+
+CREATE TRIGGER nodes_blob_refs AFTER INSERT OR DELETE OR UPDATE OF blob ON public.nodes FOR EACH ROW EXECUTE PROCEDURE public.update_blob_refs();
+
+
+--
+-- Name: versions versions_blob_refs; Type: TRIGGER; Schema: public; Owner: -
+--
+
+CREATE TRIGGER versions_blob_refs AFTER INSERT OR DELETE OR UPDATE OF blob ON public.versions FOR EACH ROW EXECUTE PROCEDURE public.update_blob_refs();
+
+
+--
+-- Name: nodes nodes_blob_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.nodes
+    ADD CONSTRAINT nodes_blob_fkey FOREIGN KEY (blob) REFERENCES public.blobs(hash);
+
+
+--
+-- Name: versions versions_blob_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.versions
+    ADD CONSTRAINT versions_blob_fkey FOREIGN KEY (blob) REFERENCES public.blobs(hash);
@@ -0,0 +1,11 @@
+--
+-- Removes the checksums of files and versions.
+--
+
+DROP INDEX IF EXISTS public.nodes_checksum_verified_on_idx;
+
+ALTER TABLE public.nodes DROP COLUMN IF EXISTS checksum;
+ALTER TABLE public.nodes DROP COLUMN IF EXISTS checksum_verified_on;
+ALTER TABLE public.nodes DROP COLUMN IF EXISTS checksum_failed;
+
+ALTER TABLE public.versions DROP COLUMN IF EXISTS checksum;
@@ -0,0 +1,17 @@
+--
+-- Checksums of the contents of files and versions, and when the contents of
+-- each file were last verified against its checksum.
+--
+
+ALTER TABLE public.nodes ADD COLUMN checksum character(64);
+ALTER TABLE public.nodes ADD COLUMN checksum_verified_on timestamp with time zone;
+ALTER TABLE public.nodes ADD COLUMN checksum_failed boolean DEFAULT false NOT NULL;
+
+ALTER TABLE public.versions ADD COLUMN checksum character(64);
+
+
+--
+-- Name: nodes_checksum_verified_on_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX nodes_checksum_verified_on_idx ON public.nodes USING btree (checksum_verified_on) WHERE (type = 'file'::public.node_type);
@@ -0,0 +1,38 @@
+--
+-- Data keys of users, wrapped with the master key, for encryption at rest.
+-- The capture time of a photo is kept in plaintext beside its info record,
+-- so that photos can be sorted by it also when the records are encrypted.
+--
+
+--
+-- Name: user_keys; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.user_keys (
+    user_id integer NOT NULL,
+    version integer NOT NULL,
+    wrapped_key bytea NOT NULL,
+    salt bytea,
+    created_on timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+--
+-- Name: user_keys user_keys_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.user_keys
+    ADD CONSTRAINT user_keys_pkey PRIMARY KEY (user_id, version);
+
+
+--
+-- Name: user_keys user_keys_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.user_keys
+    ADD CONSTRAINT user_keys_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+
+ALTER TABLE public.infos ADD COLUMN taken_on timestamp with time zone;
+
+UPDATE public.infos SET taken_on=(data->>'taken_on')::timestamp with time zone WHERE type='photo';
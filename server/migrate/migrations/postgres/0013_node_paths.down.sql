@@ -0,0 +1,9 @@
+--
+-- Removes the stored paths of nodes and the triggers maintaining them.
+--
+
+DROP TRIGGER IF EXISTS nodes_path ON public.nodes;
+DROP TRIGGER IF EXISTS nodes_subtree_paths ON public.nodes;
+DROP FUNCTION IF EXISTS public.update_node_path(), public.update_subtree_paths();
+
+ALTER TABLE public.nodes DROP COLUMN IF EXISTS path;
@@ -0,0 +1,71 @@
+--
+-- The path of each node from its root, kept up to date by triggers, so that
+-- paths and subtrees are resolved with single queries. The statements may be
+-- run again on a migrated database, as "koticloud repair-tree" does.
+--
+
+ALTER TABLE public.nodes ADD COLUMN IF NOT EXISTS path character varying COLLATE pg_catalog."C";
+
+WITH RECURSIVE t AS (
+    SELECT id, '/' || name AS path FROM public.nodes WHERE parent_id IS NULL
+    UNION ALL SELECT n.id, t.path || '/' || n.name FROM public.nodes n JOIN t ON n.parent_id = t.id
+)
+UPDATE public.nodes SET path = t.path FROM t WHERE nodes.id = t.id AND nodes.path IS DISTINCT FROM t.path;
+
+ALTER TABLE public.nodes ALTER COLUMN path SET NOT NULL;
+
+
+--
+-- Name: update_node_path(); Type: FUNCTION; Schema: public; Owner: -
+--
+
+CREATE OR REPLACE FUNCTION public.update_node_path() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    IF NEW.parent_id IS NULL THEN
+        NEW.path := '/' || NEW.name;
+    ELSE
+        SELECT path || '/' || NEW.name INTO NEW.path FROM public.nodes WHERE id = NEW.parent_id;
+    END IF;
+    RETURN NEW;
+END;
+$$;
+
+
+--
+-- Name: update_subtree_paths(); Type: FUNCTION; Schema: public; Owner: -
+--
+
+CREATE OR REPLACE FUNCTION public.update_subtree_paths() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    UPDATE public.nodes SET path = NEW.path || substr(path, length(OLD.path) + 1)
+        WHERE path > OLD.path || '/' AND path < OLD.path || '0';
+    RETURN NULL;
+END;
+$$;
+
+
+--
+-- Name: nodes_path_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX IF NOT EXISTS nodes_path_idx ON public.nodes USING btree (path);
+
+
+--
+-- Name: nodes nodes_path; Type: TRIGGER; Schema: public; Owner: -
+--
+
+DROP TRIGGER IF EXISTS nodes_path ON public.nodes;
+CREATE TRIGGER nodes_path BEFORE INSERT OR UPDATE OF name, parent_id ON public.nodes FOR EACH ROW EXECUTE PROCEDURE public.update_node_path();
+
+
+--
+-- Name: nodes nodes_subtree_paths; Type: TRIGGER; Schema: public; Owner: -
+--
+
+DROP TRIGGER IF EXISTS nodes_subtree_paths ON public.nodes;
+CREATE TRIGGER nodes_subtree_paths AFTER UPDATE OF name, parent_id ON public.nodes FOR EACH ROW WHEN (((old.path)::text IS DISTINCT FROM (new.path)::text)) EXECUTE PROCEDURE public.update_subtree_paths();
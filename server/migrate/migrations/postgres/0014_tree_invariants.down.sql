@@ -0,0 +1,21 @@
+--
+-- Removes the constraints enforcing the invariants of the node tree. Nodes
+-- may be moved under themselves afterwards.
+--
+
+ALTER TABLE public.nodes DROP CONSTRAINT IF EXISTS nodes_parent_id_name_key;
+ALTER TABLE public.progress DROP CONSTRAINT IF EXISTS progress_user_id_node_id_key;
+DROP INDEX IF EXISTS public.nodes_root_name_key;
+
+CREATE OR REPLACE FUNCTION public.update_node_path() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    IF NEW.parent_id IS NULL THEN
+        NEW.path := '/' || NEW.name;
+    ELSE
+        SELECT path || '/' || NEW.name INTO NEW.path FROM public.nodes WHERE id = NEW.parent_id;
+    END IF;
+    RETURN NEW;
+END;
+$$;
@@ -0,0 +1,66 @@
+--
+-- Invariants of the node tree: names are unique within a directory and among
+-- the roots, a node cannot be moved under itself, and each user has at most
+-- one progress row per node. A database violating them is repaired with
+-- "koticloud repair-tree", which runs these statements again.
+--
+-- The row of the new parent of a moved node is locked before its path is
+-- checked, so that moves of the parent wait, and a concurrent move creating a
+-- cycle either sees the committed path or deadlocks and fails.
+--
+
+--
+-- Name: update_node_path(); Type: FUNCTION; Schema: public; Owner: -
+--
+
+CREATE OR REPLACE FUNCTION public.update_node_path() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+DECLARE
+    parent_path character varying;
+BEGIN
+    IF NEW.parent_id IS NULL THEN
+        NEW.path := '/' || NEW.name;
+        RETURN NEW;
+    END IF;
+
+    IF TG_OP = 'INSERT' OR NEW.parent_id = OLD.parent_id THEN
+        SELECT path INTO parent_path FROM public.nodes WHERE id = NEW.parent_id;
+    ELSE
+        SELECT path INTO parent_path FROM public.nodes WHERE id = NEW.parent_id FOR UPDATE;
+        IF NEW.parent_id = NEW.id OR parent_path = OLD.path OR
+                left(parent_path, length(OLD.path) + 1) = OLD.path || '/' THEN
+            RAISE EXCEPTION 'node % cannot be moved under itself', NEW.id USING ERRCODE = 'check_violation';
+        END IF;
+    END IF;
+
+    NEW.path := parent_path || '/' || NEW.name;
+    RETURN NEW;
+END;
+$$;
+
+
+--
+-- Name: nodes nodes_parent_id_name_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.nodes DROP CONSTRAINT IF EXISTS nodes_parent_id_name_key;
+ALTER TABLE ONLY public.nodes
+    ADD CONSTRAINT nodes_parent_id_name_key UNIQUE (parent_id, name);
+
+
+--
+-- Name: progress progress_user_id_node_id_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.progress DROP CONSTRAINT IF EXISTS progress_user_id_node_id_key;
+ALTER TABLE ONLY public.progress
+    ADD CONSTRAINT progress_user_id_node_id_key UNIQUE (user_id, node_id);
+
+
+--
+-- Name: nodes_root_name_key; Type: INDEX; Schema: public; Owner: -
+--
+
+DROP INDEX IF EXISTS public.nodes_root_name_key;
+CREATE UNIQUE INDEX nodes_root_name_key ON public.nodes USING btree (name) WHERE (parent_id IS NULL);
@@ -0,0 +1,6 @@
+--
+-- Removes the tables of background jobs with their log lines.
+--
+
+DROP TABLE IF EXISTS public.job_logs;
+DROP TABLE IF EXISTS public.jobs;
@@ -0,0 +1,31 @@
+--
+-- Background jobs started by administrators, such as scans, with their
+-- progress, results and log lines.
+--
+
+CREATE TABLE public.jobs (
+    id serial PRIMARY KEY,
+    kind character varying NOT NULL,
+    args text,
+    user_id integer REFERENCES public.users (id) ON UPDATE CASCADE ON DELETE SET NULL,
+    state character varying DEFAULT 'running' NOT NULL,
+    done bigint DEFAULT 0 NOT NULL,
+    total bigint DEFAULT 0 NOT NULL,
+    result text,
+    error text,
+    cancel boolean DEFAULT false NOT NULL,
+    created_on timestamp with time zone DEFAULT now() NOT NULL,
+    updated_on timestamp with time zone DEFAULT now() NOT NULL,
+    finished_on timestamp with time zone
+);
+
+CREATE INDEX jobs_state_idx ON public.jobs USING btree (state);
+
+CREATE TABLE public.job_logs (
+    id serial PRIMARY KEY,
+    job_id integer NOT NULL REFERENCES public.jobs (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    logged_on timestamp with time zone DEFAULT now() NOT NULL,
+    line text NOT NULL
+);
+
+CREATE INDEX job_logs_job_id_idx ON public.job_logs USING btree (job_id, id);
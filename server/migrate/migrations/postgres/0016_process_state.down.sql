@@ -0,0 +1,15 @@
+--
+-- Removes the processing state of nodes, and the requests already processed.
+--
+
+DELETE FROM public.node_process_reqs WHERE state<>'pending';
+
+DROP INDEX IF EXISTS public.node_process_reqs_state_idx;
+DROP INDEX IF EXISTS public.node_process_reqs_node_id_idx;
+
+ALTER TABLE public.node_process_reqs
+    DROP COLUMN IF EXISTS state,
+    DROP COLUMN IF EXISTS attempts,
+    DROP COLUMN IF EXISTS error,
+    DROP COLUMN IF EXISTS run_after,
+    DROP COLUMN IF EXISTS updated_on;
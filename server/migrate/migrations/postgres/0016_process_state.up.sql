@@ -0,0 +1,18 @@
+--
+-- Processing state of nodes. Requests to process a node are kept after
+-- processing, one per node, with the outcome of the latest attempt. Failed
+-- requests are retried after run_after until they run out of attempts.
+--
+
+DELETE FROM public.node_process_reqs
+    WHERE id NOT IN (SELECT max(id) FROM public.node_process_reqs GROUP BY node_id);
+
+ALTER TABLE public.node_process_reqs
+    ADD COLUMN state character varying DEFAULT 'pending' NOT NULL,
+    ADD COLUMN attempts integer DEFAULT 0 NOT NULL,
+    ADD COLUMN error text,
+    ADD COLUMN run_after timestamp with time zone,
+    ADD COLUMN updated_on timestamp with time zone;
+
+CREATE UNIQUE INDEX node_process_reqs_node_id_idx ON public.node_process_reqs USING btree (node_id);
+CREATE INDEX node_process_reqs_state_idx ON public.node_process_reqs USING btree (state, id);
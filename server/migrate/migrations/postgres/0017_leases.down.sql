@@ -0,0 +1,12 @@
+--
+-- Removes the leases of node processing requests and the index allowing one
+-- running job of a kind.
+--
+
+DROP INDEX IF EXISTS public.jobs_running_kind_idx;
+
+UPDATE public.node_process_reqs SET state='pending' WHERE state='running';
+
+ALTER TABLE public.node_process_reqs
+    DROP COLUMN IF EXISTS worker,
+    DROP COLUMN IF EXISTS lease_until;
@@ -0,0 +1,17 @@
+--
+-- Leases of node processing requests, so that several processes can share
+-- the queue. A running request is leased to a worker until lease_until, and
+-- the worker renews its leases while it runs. Requests whose lease has
+-- expired are taken by other workers.
+--
+-- Only one job of a kind runs at a time, also across servers.
+--
+
+ALTER TABLE public.node_process_reqs
+    ADD COLUMN worker character varying,
+    ADD COLUMN lease_until timestamp with time zone;
+
+UPDATE public.jobs SET state='failed', error='interrupted by a restart of the server', finished_on=now()
+    WHERE state='running' AND id NOT IN (SELECT max(id) FROM public.jobs WHERE state='running' GROUP BY kind);
+
+CREATE UNIQUE INDEX jobs_running_kind_idx ON public.jobs USING btree (kind) WHERE state='running';
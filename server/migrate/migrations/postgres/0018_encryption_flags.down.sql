@@ -0,0 +1,8 @@
+--
+-- Removes the encryption flags of files, custom thumbnails and versions.
+--
+
+ALTER TABLE public.nodes DROP COLUMN IF EXISTS encrypted;
+ALTER TABLE public.nodes DROP COLUMN IF EXISTS thumb_encrypted;
+
+ALTER TABLE public.versions DROP COLUMN IF EXISTS encrypted;
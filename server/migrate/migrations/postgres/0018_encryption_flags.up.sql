@@ -0,0 +1,9 @@
+--
+-- Whether the stored contents of files, custom thumbnails and versions are
+-- encrypted, so that they are never told apart by reading them.
+--
+
+ALTER TABLE public.nodes ADD COLUMN encrypted boolean DEFAULT false NOT NULL;
+ALTER TABLE public.nodes ADD COLUMN thumb_encrypted boolean DEFAULT false NOT NULL;
+
+ALTER TABLE public.versions ADD COLUMN encrypted boolean DEFAULT false NOT NULL;
@@ -0,0 +1,10 @@
+--
+-- Removes the baseline schema of koticloud with all data.
+--
+
+DROP TABLE IF EXISTS progress;
+DROP TABLE IF EXISTS node_process_reqs;
+DROP TABLE IF EXISTS infos;
+UPDATE users SET root_id = NULL;
+DROP TABLE IF EXISTS nodes;
+DROP TABLE IF EXISTS users;
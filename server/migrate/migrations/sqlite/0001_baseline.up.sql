@@ -0,0 +1,50 @@
+--
+-- Baseline schema of koticloud for SQLite, matching the PostgreSQL baseline.
+--
+-- Times are stored as text in UTC, in the format written by the driver, so
+-- that they compare in order.
+--
+
+CREATE TABLE users (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    name text NOT NULL UNIQUE,
+    password text,
+    admin boolean DEFAULT false NOT NULL,
+    root_id integer REFERENCES nodes (id) ON UPDATE CASCADE ON DELETE SET NULL
+);
+
+CREATE TABLE nodes (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    name text NOT NULL,
+    size bigint,
+    type text NOT NULL CHECK (type IN ('file', 'directory')),
+    mime_type text NOT NULL,
+    owner_id integer REFERENCES users (id) ON UPDATE CASCADE ON DELETE SET NULL,
+    parent_id integer REFERENCES nodes (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    modified_on timestamp DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')) NOT NULL,
+    has_custom_thumb boolean DEFAULT false NOT NULL,
+    length double precision
+);
+
+CREATE TABLE infos (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    node_id integer NOT NULL REFERENCES nodes (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    user_id integer NOT NULL REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    type text NOT NULL,
+    data text DEFAULT '{}' NOT NULL
+);
+
+CREATE TABLE node_process_reqs (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    node_id integer NOT NULL REFERENCES nodes (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    path text NOT NULL,
+    remove_upload boolean DEFAULT false NOT NULL
+);
+
+CREATE TABLE progress (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    node_id integer NOT NULL REFERENCES nodes (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    user_id integer NOT NULL REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    progress real,
+    volume real
+);
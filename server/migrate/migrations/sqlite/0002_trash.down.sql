@@ -0,0 +1,8 @@
+--
+-- Removes the trash table and the trash directories of users. The trashed
+-- nodes are kept.
+--
+
+DROP TABLE IF EXISTS trash;
+
+ALTER TABLE users DROP COLUMN trash_id;
@@ -0,0 +1,14 @@
+--
+-- Deleted nodes are moved into the trash directory of their owner, and the
+-- trash table remembers where they came from.
+--
+
+CREATE TABLE trash (
+    node_id integer PRIMARY KEY REFERENCES nodes (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    user_id integer NOT NULL REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    original_parent_id integer REFERENCES nodes (id) ON UPDATE CASCADE ON DELETE SET NULL,
+    original_name text NOT NULL,
+    deleted_on timestamp DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')) NOT NULL
+);
+
+ALTER TABLE users ADD COLUMN trash_id integer REFERENCES nodes (id) ON UPDATE CASCADE ON DELETE SET NULL;
@@ -0,0 +1,5 @@
+--
+-- Removes the versions of files.
+--
+
+DROP TABLE IF EXISTS versions;
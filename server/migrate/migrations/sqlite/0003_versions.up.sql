@@ -0,0 +1,15 @@
+--
+-- Previous contents of updated files.
+--
+
+CREATE TABLE versions (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    node_id integer NOT NULL REFERENCES nodes (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    version integer NOT NULL,
+    mime_type text NOT NULL,
+    size bigint NOT NULL,
+    modified_on timestamp NOT NULL,
+    created_on timestamp DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')) NOT NULL,
+    user_id integer REFERENCES users (id) ON UPDATE CASCADE ON DELETE SET NULL,
+    UNIQUE (node_id, version)
+);
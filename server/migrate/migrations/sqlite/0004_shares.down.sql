@@ -0,0 +1,5 @@
+--
+-- Removes the share links.
+--
+
+DROP TABLE IF EXISTS shares;
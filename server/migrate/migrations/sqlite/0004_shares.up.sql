@@ -0,0 +1,16 @@
+--
+-- Public links to nodes, optionally protected by a password, an expiry time
+-- and a limit of downloads.
+--
+
+CREATE TABLE shares (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    slug text NOT NULL UNIQUE,
+    node_id integer NOT NULL REFERENCES nodes (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    user_id integer NOT NULL REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    password text,
+    expires_on timestamp,
+    max_downloads integer,
+    downloads integer DEFAULT 0 NOT NULL,
+    created_on timestamp DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')) NOT NULL
+);
@@ -0,0 +1,7 @@
+--
+-- Removes the access grants and the groups.
+--
+
+DROP TABLE IF EXISTS grants;
+DROP TABLE IF EXISTS group_members;
+DROP TABLE IF EXISTS groups;
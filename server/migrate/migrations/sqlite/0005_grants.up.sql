@@ -0,0 +1,26 @@
+--
+-- Access to nodes granted to other users and to groups of users.
+--
+
+CREATE TABLE groups (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    name text NOT NULL UNIQUE
+);
+
+CREATE TABLE group_members (
+    group_id integer NOT NULL REFERENCES groups (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    user_id integer NOT NULL REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    PRIMARY KEY (group_id, user_id)
+);
+
+CREATE TABLE grants (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    node_id integer NOT NULL REFERENCES nodes (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    user_id integer REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    group_id integer REFERENCES groups (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    write boolean DEFAULT false NOT NULL,
+    created_on timestamp DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')) NOT NULL,
+    CHECK ((user_id IS NULL) <> (group_id IS NULL)),
+    UNIQUE (node_id, group_id),
+    UNIQUE (node_id, user_id)
+);
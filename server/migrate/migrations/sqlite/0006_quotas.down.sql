@@ -0,0 +1,5 @@
+--
+-- Removes the storage quotas of users.
+--
+
+ALTER TABLE users DROP COLUMN quota;
@@ -0,0 +1,5 @@
+--
+-- Removes the extracted texts of files.
+--
+
+DROP TABLE IF EXISTS node_texts;
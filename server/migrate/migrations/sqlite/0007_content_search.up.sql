@@ -0,0 +1,9 @@
+--
+-- Text extracted from the contents of files for content search. Search uses
+-- the stored content without an index.
+--
+
+CREATE TABLE node_texts (
+    node_id integer PRIMARY KEY REFERENCES nodes (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    content text NOT NULL
+);
@@ -0,0 +1,5 @@
+--
+-- Info records are looked up by their node and type.
+--
+
+CREATE INDEX infos_node_id_type_idx ON infos (node_id, type);
@@ -0,0 +1,18 @@
+--
+-- Removes the blob store. The contents of files stored as blobs are not
+-- moved back into the directory tree.
+--
+
+DROP TRIGGER IF EXISTS nodes_blob_refs_insert;
+DROP TRIGGER IF EXISTS nodes_blob_refs_update;
+DROP TRIGGER IF EXISTS nodes_blob_refs_delete;
+DROP TRIGGER IF EXISTS versions_blob_refs_insert;
+DROP TRIGGER IF EXISTS versions_blob_refs_update;
+DROP TRIGGER IF EXISTS versions_blob_refs_delete;
+
+DROP INDEX IF EXISTS nodes_blob_idx;
+DROP INDEX IF EXISTS versions_blob_idx;
+
+ALTER TABLE nodes DROP COLUMN blob;
+ALTER TABLE versions DROP COLUMN blob;
+DROP TABLE IF EXISTS blobs;
@@ -0,0 +1,50 @@
+--
+-- Contents of files stored once by their SHA-256 hash. Nodes and versions
+-- refer to their contents in the blob store, and triggers keep the number of
+-- references of each blob.
+--
+
+CREATE TABLE blobs (
+    hash text PRIMARY KEY,
+    size bigint NOT NULL,
+    refs integer DEFAULT 0 NOT NULL,
+    created_on timestamp DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')) NOT NULL
+);
+
+ALTER TABLE nodes ADD COLUMN blob text REFERENCES blobs (hash);
+ALTER TABLE versions ADD COLUMN blob text REFERENCES blobs (hash);
+
+CREATE INDEX nodes_blob_idx ON nodes (blob);
+CREATE INDEX versions_blob_idx ON versions (blob);
+
+CREATE TRIGGER nodes_blob_refs_insert AFTER INSERT ON nodes WHEN NEW.blob IS NOT NULL
+BEGIN
+    UPDATE blobs SET refs = refs + 1 WHERE hash = NEW.blob;
+END;
+
+CREATE TRIGGER nodes_blob_refs_update AFTER UPDATE OF blob ON nodes WHEN NEW.blob IS NOT OLD.blob
+BEGIN
+    UPDATE blobs SET refs = refs - 1 WHERE hash = OLD.blob;
+    UPDATE blobs SET refs = refs + 1 WHERE hash = NEW.blob;
+END;
+
+CREATE TRIGGER nodes_blob_refs_delete AFTER DELETE ON nodes WHEN OLD.blob IS NOT NULL
+BEGIN
+    UPDATE blobs SET refs = refs - 1 WHERE hash = OLD.blob;
+END;
+
+CREATE TRIGGER versions_blob_refs_insert AFTER INSERT ON versions WHEN NEW.blob IS NOT NULL
+BEGIN
+    UPDATE blobs SET refs = refs + 1 WHERE hash = NEW.blob;
+END;
+
+CREATE TRIGGER versions_blob_refs_update AFTER UPDATE OF blob ON versions WHEN NEW.blob IS NOT OLD.blob
+BEGIN
+    UPDATE blobs SET refs = refs - 1 WHERE hash = OLD.blob;
+    UPDATE blobs SET refs = refs + 1 WHERE hash = NEW.blob;
+END;
+
+CREATE TRIGGER versions_blob_refs_delete AFTER DELETE ON versions WHEN OLD.blob IS NOT NULL
+BEGIN
+    UPDATE blobs SET refs = refs - 1 WHERE hash = OLD.blob;
+END;
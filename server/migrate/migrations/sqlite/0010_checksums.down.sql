@@ -0,0 +1,11 @@
+--
+-- Removes the checksums of files and versions.
+--
+
+DROP INDEX IF EXISTS nodes_checksum_verified_on_idx;
+
+ALTER TABLE nodes DROP COLUMN checksum;
+ALTER TABLE nodes DROP COLUMN checksum_verified_on;
+ALTER TABLE nodes DROP COLUMN checksum_failed;
+
+ALTER TABLE versions DROP COLUMN checksum;
@@ -0,0 +1,12 @@
+--
+-- Checksums of the contents of files and versions, and when the contents of
+-- each file were last verified against its checksum.
+--
+
+ALTER TABLE nodes ADD COLUMN checksum text;
+ALTER TABLE nodes ADD COLUMN checksum_verified_on timestamp;
+ALTER TABLE nodes ADD COLUMN checksum_failed boolean DEFAULT false NOT NULL;
+
+ALTER TABLE versions ADD COLUMN checksum text;
+
+CREATE INDEX nodes_checksum_verified_on_idx ON nodes (checksum_verified_on) WHERE type = 'file';
@@ -0,0 +1,8 @@
+--
+-- Removes the data keys of users and the plaintext capture times of photos.
+-- Encrypted files cannot be read afterwards.
+--
+
+DROP TABLE IF EXISTS user_keys;
+
+ALTER TABLE infos DROP COLUMN taken_on;
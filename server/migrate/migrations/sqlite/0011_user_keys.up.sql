@@ -0,0 +1,19 @@
+--
+-- Data keys of users, wrapped with the master key, for encryption at rest.
+-- The capture time of a photo is kept in plaintext beside its info record,
+-- so that photos can be sorted by it also when the records are encrypted.
+--
+
+CREATE TABLE user_keys (
+    user_id integer NOT NULL REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    version integer NOT NULL,
+    wrapped_key blob NOT NULL,
+    salt blob,
+    created_on timestamp DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')) NOT NULL,
+    PRIMARY KEY (user_id, version)
+);
+
+ALTER TABLE infos ADD COLUMN taken_on timestamp;
+
+UPDATE infos SET taken_on=strftime('%Y-%m-%d %H:%M:%f+00:00', json_extract(CAST(data AS text), '$.taken_on'))
+    WHERE type='photo' AND json_valid(CAST(data AS text));
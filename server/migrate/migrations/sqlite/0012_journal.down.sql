@@ -0,0 +1,5 @@
+--
+-- Removes the markers of the file journal.
+--
+
+DROP TABLE IF EXISTS journal;
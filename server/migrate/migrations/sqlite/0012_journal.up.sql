@@ -0,0 +1,10 @@
+--
+-- Markers of the file operations of committed transactions. The journal
+-- entries of a transaction are completed if its marker exists, and undone
+-- otherwise.
+--
+
+CREATE TABLE journal (
+    id text PRIMARY KEY,
+    created_on timestamp DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')) NOT NULL
+);
@@ -0,0 +1,9 @@
+--
+-- Removes the stored paths of nodes and the triggers maintaining them.
+--
+
+DROP TRIGGER IF EXISTS nodes_path;
+DROP TRIGGER IF EXISTS nodes_subtree_paths;
+DROP INDEX IF EXISTS nodes_path_idx;
+
+ALTER TABLE nodes DROP COLUMN path;
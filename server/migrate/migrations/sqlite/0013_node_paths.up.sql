@@ -0,0 +1,30 @@
+--
+-- The path of each node from its root, kept up to date by triggers, so that
+-- paths and subtrees are resolved with single queries. The path of a node is
+-- set after it has been inserted, since SQLite triggers cannot change the
+-- inserted row.
+--
+
+ALTER TABLE nodes ADD COLUMN path text DEFAULT '' NOT NULL;
+
+WITH RECURSIVE t(id, path) AS (
+    SELECT id, '/' || name FROM nodes WHERE parent_id IS NULL
+    UNION ALL SELECT n.id, t.path || '/' || n.name FROM nodes n JOIN t ON n.parent_id = t.id
+)
+UPDATE nodes SET path = (SELECT t.path FROM t WHERE t.id = nodes.id);
+
+CREATE INDEX nodes_path_idx ON nodes (path);
+
+CREATE TRIGGER nodes_path AFTER INSERT ON nodes
+BEGIN
+    UPDATE nodes SET path = COALESCE((SELECT p.path FROM nodes p WHERE p.id = NEW.parent_id), '') || '/' || NEW.name
+        WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER nodes_subtree_paths AFTER UPDATE OF name, parent_id ON nodes
+    WHEN NEW.name IS NOT OLD.name OR NEW.parent_id IS NOT OLD.parent_id
+BEGIN
+    UPDATE nodes SET path = COALESCE((SELECT p.path FROM nodes p WHERE p.id = NEW.parent_id), '') ||
+            '/' || NEW.name || substr(path, length(OLD.path) + 1)
+        WHERE id = NEW.id OR (path > OLD.path || '/' AND path < OLD.path || '0');
+END;
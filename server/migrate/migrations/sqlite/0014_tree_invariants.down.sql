@@ -0,0 +1,9 @@
+--
+-- Removes the constraints enforcing the invariants of the node tree. Nodes
+-- may be moved under themselves afterwards.
+--
+
+DROP TRIGGER IF EXISTS nodes_cycle;
+DROP INDEX IF EXISTS nodes_parent_id_name_key;
+DROP INDEX IF EXISTS nodes_root_name_key;
+DROP INDEX IF EXISTS progress_user_id_node_id_key;
@@ -0,0 +1,17 @@
+--
+-- Invariants of the node tree: names are unique within a directory and among
+-- the roots, a node cannot be moved under itself, and each user has at most
+-- one progress row per node.
+--
+
+CREATE TRIGGER nodes_cycle BEFORE UPDATE OF parent_id ON nodes
+    WHEN NEW.parent_id IS NOT OLD.parent_id AND (NEW.parent_id = NEW.id OR EXISTS (
+        SELECT 1 FROM nodes p WHERE p.id = NEW.parent_id AND
+            (p.path = OLD.path OR substr(p.path, 1, length(OLD.path) + 1) = OLD.path || '/')))
+BEGIN
+    SELECT RAISE(ABORT, 'node cannot be moved under itself');
+END;
+
+CREATE UNIQUE INDEX nodes_parent_id_name_key ON nodes (parent_id, name);
+CREATE UNIQUE INDEX nodes_root_name_key ON nodes (name) WHERE parent_id IS NULL;
+CREATE UNIQUE INDEX progress_user_id_node_id_key ON progress (user_id, node_id);
@@ -0,0 +1,31 @@
+--
+-- Background jobs started by administrators, such as scans, with their
+-- progress, results and log lines.
+--
+
+CREATE TABLE jobs (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    kind text NOT NULL,
+    args text,
+    user_id integer REFERENCES users (id) ON UPDATE CASCADE ON DELETE SET NULL,
+    state text DEFAULT 'running' NOT NULL,
+    done bigint DEFAULT 0 NOT NULL,
+    total bigint DEFAULT 0 NOT NULL,
+    result text,
+    error text,
+    cancel boolean DEFAULT false NOT NULL,
+    created_on timestamp DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')) NOT NULL,
+    updated_on timestamp DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')) NOT NULL,
+    finished_on timestamp
+);
+
+CREATE INDEX jobs_state_idx ON jobs (state);
+
+CREATE TABLE job_logs (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    job_id integer NOT NULL REFERENCES jobs (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    logged_on timestamp DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')) NOT NULL,
+    line text NOT NULL
+);
+
+CREATE INDEX job_logs_job_id_idx ON job_logs (job_id, id);
@@ -0,0 +1,14 @@
+--
+-- Removes the processing state of nodes, and the requests already processed.
+--
+
+DELETE FROM node_process_reqs WHERE state<>'pending';
+
+DROP INDEX IF EXISTS node_process_reqs_state_idx;
+DROP INDEX IF EXISTS node_process_reqs_node_id_idx;
+
+ALTER TABLE node_process_reqs DROP COLUMN state;
+ALTER TABLE node_process_reqs DROP COLUMN attempts;
+ALTER TABLE node_process_reqs DROP COLUMN error;
+ALTER TABLE node_process_reqs DROP COLUMN run_after;
+ALTER TABLE node_process_reqs DROP COLUMN updated_on;
@@ -0,0 +1,17 @@
+--
+-- Processing state of nodes. Requests to process a node are kept after
+-- processing, one per node, with the outcome of the latest attempt. Failed
+-- requests are retried after run_after until they run out of attempts.
+--
+
+DELETE FROM node_process_reqs
+    WHERE id NOT IN (SELECT max(id) FROM node_process_reqs GROUP BY node_id);
+
+ALTER TABLE node_process_reqs ADD COLUMN state text DEFAULT 'pending' NOT NULL;
+ALTER TABLE node_process_reqs ADD COLUMN attempts integer DEFAULT 0 NOT NULL;
+ALTER TABLE node_process_reqs ADD COLUMN error text;
+ALTER TABLE node_process_reqs ADD COLUMN run_after timestamp;
+ALTER TABLE node_process_reqs ADD COLUMN updated_on timestamp;
+
+CREATE UNIQUE INDEX node_process_reqs_node_id_idx ON node_process_reqs (node_id);
+CREATE INDEX node_process_reqs_state_idx ON node_process_reqs (state, id);
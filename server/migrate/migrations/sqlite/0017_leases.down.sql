@@ -0,0 +1,11 @@
+--
+-- Removes the leases of node processing requests and the index allowing one
+-- running job of a kind.
+--
+
+DROP INDEX IF EXISTS jobs_running_kind_idx;
+
+UPDATE node_process_reqs SET state='pending' WHERE state='running';
+
+ALTER TABLE node_process_reqs DROP COLUMN worker;
+ALTER TABLE node_process_reqs DROP COLUMN lease_until;
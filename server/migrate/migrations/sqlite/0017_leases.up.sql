@@ -0,0 +1,16 @@
+--
+-- Leases of node processing requests, so that several processes can share
+-- the queue. A running request is leased to a worker until lease_until, and
+-- the worker renews its leases while it runs. Requests whose lease has
+-- expired are taken by other workers.
+--
+-- Only one job of a kind runs at a time, also across servers.
+--
+
+ALTER TABLE node_process_reqs ADD COLUMN worker text;
+ALTER TABLE node_process_reqs ADD COLUMN lease_until timestamp;
+
+UPDATE jobs SET state='failed', error='interrupted by a restart of the server', finished_on=strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')
+    WHERE state='running' AND id NOT IN (SELECT max(id) FROM jobs WHERE state='running' GROUP BY kind);
+
+CREATE UNIQUE INDEX jobs_running_kind_idx ON jobs (kind) WHERE state='running';
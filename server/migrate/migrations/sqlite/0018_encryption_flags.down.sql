@@ -0,0 +1,8 @@
+--
+-- Removes the encryption flags of files, custom thumbnails and versions.
+--
+
+ALTER TABLE nodes DROP COLUMN encrypted;
+ALTER TABLE nodes DROP COLUMN thumb_encrypted;
+
+ALTER TABLE versions DROP COLUMN encrypted;
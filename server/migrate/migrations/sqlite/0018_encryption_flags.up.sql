@@ -0,0 +1,9 @@
+--
+-- Whether the stored contents of files, custom thumbnails and versions are
+-- encrypted, so that they are never told apart by reading them.
+--
+
+ALTER TABLE nodes ADD COLUMN encrypted boolean DEFAULT false NOT NULL;
+ALTER TABLE nodes ADD COLUMN thumb_encrypted boolean DEFAULT false NOT NULL;
+
+ALTER TABLE versions ADD COLUMN encrypted boolean DEFAULT false NOT NULL;
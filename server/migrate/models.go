@@ -0,0 +1,102 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/terotoi/koticloud/server/database"
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+// Columns of the generated models by table.
+var modelColumns = map[string]interface{}{
+	models.TableNames.Blobs:           models.BlobColumns,
+	models.TableNames.Grants:          models.GrantColumns,
+	models.TableNames.Groups:          models.GroupColumns,
+	models.TableNames.Infos:           models.InfoColumns,
+	models.TableNames.JobLogs:         models.JobLogColumns,
+	models.TableNames.Jobs:            models.JobColumns,
+	models.TableNames.Journal:         models.JournalColumns,
+	models.TableNames.NodeProcessReqs: models.NodeProcessReqColumns,
+	models.TableNames.NodeTexts:       models.NodeTextColumns,
+	models.TableNames.Nodes:           models.NodeColumns,
+	models.TableNames.Progress:        models.ProgressColumns,
+	models.TableNames.Shares:          models.ShareColumns,
+	models.TableNames.Trash:           models.TrashColumns,
+	models.TableNames.UserKeys:        models.UserKeyColumns,
+	models.TableNames.Users:           models.UserColumns,
+	models.TableNames.Versions:        models.VersionColumns,
+}
+
+// Tables and columns left out of the models by the blacklist of
+// sqlboiler.toml, and join tables, which are modeled as relationships.
+var unmodeled = map[string]bool{
+	"schema_migrations": true,
+	"group_members":     true,
+	"nodes.path":        true,
+	"node_texts.tsv":    true,
+}
+
+// Queries selecting the columns of all tables.
+var columnsQuery = map[database.Dialect]string{
+	database.Postgres: "SELECT table_name, column_name FROM information_schema.columns WHERE table_schema='public'",
+	database.SQLite: "SELECT m.name AS table_name, c.name AS column_name " +
+		"FROM sqlite_master m JOIN pragma_table_info(m.name) c WHERE m.type='table' AND m.name NOT LIKE 'sqlite_%'",
+}
+
+// CheckModels verifies that the generated models match the schema created by
+// the migrations: every table and column of the models exists in the
+// database, and every table and column of the database has a model, unless
+// it is left out of the models on purpose.
+func CheckModels(ctx context.Context, db *sql.DB) error {
+	var rows []struct {
+		Table  string `boil:"table_name"`
+		Column string `boil:"column_name"`
+	}
+	if err := queries.Raw(columnsQuery[database.DialectOf(db)]).Bind(ctx, db, &rows); err != nil {
+		return err
+	}
+
+	modeled := make(map[string]bool)
+	for table, cols := range modelColumns {
+		v := reflect.ValueOf(cols)
+		for i := 0; i < v.NumField(); i++ {
+			modeled[table+"."+v.Field(i).String()] = true
+		}
+	}
+
+	var unknown []string
+	for _, r := range rows {
+		col := r.Table + "." + r.Column
+		if modeled[col] {
+			delete(modeled, col)
+		} else if !unmodeled[r.Table] && !unmodeled[col] {
+			unknown = append(unknown, col)
+		}
+	}
+
+	var errs []string
+	if len(modeled) > 0 {
+		var missing []string
+		for col := range modeled {
+			missing = append(missing, col)
+		}
+		sort.Strings(missing)
+		errs = append(errs, "columns of the models missing from the database: "+strings.Join(missing, ", "))
+	}
+
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		errs = append(errs, "columns of the database missing from the models: "+strings.Join(unknown, ", "))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,1530 @@
+// Code generated by SQLBoiler 4.14.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// Blob is an object representing the database table.
+type Blob struct {
+	Hash      string    `boil:"hash" json:"hash" toml:"hash" yaml:"hash"`
+	Size      int64     `boil:"size" json:"size" toml:"size" yaml:"size"`
+	Refs      int       `boil:"refs" json:"refs" toml:"refs" yaml:"refs"`
+	CreatedOn time.Time `boil:"created_on" json:"created_on" toml:"created_on" yaml:"created_on"`
+
+	R *blobR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L blobL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var BlobColumns = struct {
+	Hash      string
+	Size      string
+	Refs      string
+	CreatedOn string
+}{
+	Hash:      "hash",
+	Size:      "size",
+	Refs:      "refs",
+	CreatedOn: "created_on",
+}
+
+var BlobTableColumns = struct {
+	Hash      string
+	Size      string
+	Refs      string
+	CreatedOn string
+}{
+	Hash:      "blobs.hash",
+	Size:      "blobs.size",
+	Refs:      "blobs.refs",
+	CreatedOn: "blobs.created_on",
+}
+
+// Generated where
+
+type whereHelperstring struct{ field string }
+
+func (w whereHelperstring) EQ(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperstring) NEQ(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperstring) LT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperstring) LTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperstring) GT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperstring) GTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperstring) IN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelperstring) NIN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+type whereHelperint64 struct{ field string }
+
+func (w whereHelperint64) EQ(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperint64) NEQ(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperint64) LT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperint64) LTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperint64) GT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperint64) GTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperint64) IN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelperint64) NIN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+type whereHelperint struct{ field string }
+
+func (w whereHelperint) EQ(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperint) NEQ(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperint) LT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperint) LTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperint) GT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperint) GTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperint) IN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelperint) NIN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+type whereHelpertime_Time struct{ field string }
+
+func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.EQ, x)
+}
+func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+}
+func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+var BlobWhere = struct {
+	Hash      whereHelperstring
+	Size      whereHelperint64
+	Refs      whereHelperint
+	CreatedOn whereHelpertime_Time
+}{
+	Hash:      whereHelperstring{field: "\"blobs\".\"hash\""},
+	Size:      whereHelperint64{field: "\"blobs\".\"size\""},
+	Refs:      whereHelperint{field: "\"blobs\".\"refs\""},
+	CreatedOn: whereHelpertime_Time{field: "\"blobs\".\"created_on\""},
+}
+
+// BlobRels is where relationship names are stored.
+var BlobRels = struct {
+	Nodes    string
+	Versions string
+}{
+	Nodes:    "Nodes",
+	Versions: "Versions",
+}
+
+// blobR is where relationships are stored.
+type blobR struct {
+	Nodes    NodeSlice    `boil:"Nodes" json:"Nodes" toml:"Nodes" yaml:"Nodes"`
+	Versions VersionSlice `boil:"Versions" json:"Versions" toml:"Versions" yaml:"Versions"`
+}
+
+// NewStruct creates a new relationship struct
+func (*blobR) NewStruct() *blobR {
+	return &blobR{}
+}
+
+func (r *blobR) GetNodes() NodeSlice {
+	if r == nil {
+		return nil
+	}
+	return r.Nodes
+}
+
+func (r *blobR) GetVersions() VersionSlice {
+	if r == nil {
+		return nil
+	}
+	return r.Versions
+}
+
+// blobL is where Load methods for each relationship are stored.
+type blobL struct{}
+
+var (
+	blobAllColumns            = []string{"hash", "size", "refs", "created_on"}
+	blobColumnsWithoutDefault = []string{"hash", "size"}
+	blobColumnsWithDefault    = []string{"refs", "created_on"}
+	blobPrimaryKeyColumns     = []string{"hash"}
+	blobGeneratedColumns      = []string{}
+)
+
+type (
+	// BlobSlice is an alias for a slice of pointers to Blob.
+	// This should almost always be used instead of []Blob.
+	BlobSlice []*Blob
+	// BlobHook is the signature for custom Blob hook methods
+	BlobHook func(context.Context, boil.ContextExecutor, *Blob) error
+
+	blobQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	blobType                 = reflect.TypeOf(&Blob{})
+	blobMapping              = queries.MakeStructMapping(blobType)
+	blobPrimaryKeyMapping, _ = queries.BindMapping(blobType, blobMapping, blobPrimaryKeyColumns)
+	blobInsertCacheMut       sync.RWMutex
+	blobInsertCache          = make(map[string]insertCache)
+	blobUpdateCacheMut       sync.RWMutex
+	blobUpdateCache          = make(map[string]updateCache)
+	blobUpsertCacheMut       sync.RWMutex
+	blobUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var blobAfterSelectHooks []BlobHook
+
+var blobBeforeInsertHooks []BlobHook
+var blobAfterInsertHooks []BlobHook
+
+var blobBeforeUpdateHooks []BlobHook
+var blobAfterUpdateHooks []BlobHook
+
+var blobBeforeDeleteHooks []BlobHook
+var blobAfterDeleteHooks []BlobHook
+
+var blobBeforeUpsertHooks []BlobHook
+var blobAfterUpsertHooks []BlobHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *Blob) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range blobAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *Blob) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range blobBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *Blob) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range blobAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *Blob) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range blobBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *Blob) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range blobAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *Blob) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range blobBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *Blob) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range blobAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *Blob) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range blobBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *Blob) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range blobAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddBlobHook registers your hook function for all future operations.
+func AddBlobHook(hookPoint boil.HookPoint, blobHook BlobHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		blobAfterSelectHooks = append(blobAfterSelectHooks, blobHook)
+	case boil.BeforeInsertHook:
+		blobBeforeInsertHooks = append(blobBeforeInsertHooks, blobHook)
+	case boil.AfterInsertHook:
+		blobAfterInsertHooks = append(blobAfterInsertHooks, blobHook)
+	case boil.BeforeUpdateHook:
+		blobBeforeUpdateHooks = append(blobBeforeUpdateHooks, blobHook)
+	case boil.AfterUpdateHook:
+		blobAfterUpdateHooks = append(blobAfterUpdateHooks, blobHook)
+	case boil.BeforeDeleteHook:
+		blobBeforeDeleteHooks = append(blobBeforeDeleteHooks, blobHook)
+	case boil.AfterDeleteHook:
+		blobAfterDeleteHooks = append(blobAfterDeleteHooks, blobHook)
+	case boil.BeforeUpsertHook:
+		blobBeforeUpsertHooks = append(blobBeforeUpsertHooks, blobHook)
+	case boil.AfterUpsertHook:
+		blobAfterUpsertHooks = append(blobAfterUpsertHooks, blobHook)
+	}
+}
+
+// One returns a single blob record from the query.
+func (q blobQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Blob, error) {
+	o := &Blob{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for blobs")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all Blob records from the query.
+func (q blobQuery) All(ctx context.Context, exec boil.ContextExecutor) (BlobSlice, error) {
+	var o []*Blob
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to Blob slice")
+	}
+
+	if len(blobAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all Blob records in the query.
+func (q blobQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count blobs rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q blobQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if blobs exists")
+	}
+
+	return count > 0, nil
+}
+
+// Nodes retrieves all the node's Nodes with an executor.
+func (o *Blob) Nodes(mods ...qm.QueryMod) nodeQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"nodes\".\"blob\"=?", o.Hash),
+	)
+
+	return Nodes(queryMods...)
+}
+
+// Versions retrieves all the version's Versions with an executor.
+func (o *Blob) Versions(mods ...qm.QueryMod) versionQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"versions\".\"blob\"=?", o.Hash),
+	)
+
+	return Versions(queryMods...)
+}
+
+// LoadNodes allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (blobL) LoadNodes(ctx context.Context, e boil.ContextExecutor, singular bool, maybeBlob interface{}, mods queries.Applicator) error {
+	var slice []*Blob
+	var object *Blob
+
+	if singular {
+		var ok bool
+		object, ok = maybeBlob.(*Blob)
+		if !ok {
+			object = new(Blob)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeBlob)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeBlob))
+			}
+		}
+	} else {
+		s, ok := maybeBlob.(*[]*Blob)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeBlob)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeBlob))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &blobR{}
+		}
+		args = append(args, object.Hash)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &blobR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.Hash) {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.Hash)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`nodes`),
+		qm.WhereIn(`nodes.blob in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load nodes")
+	}
+
+	var resultSlice []*Node
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice nodes")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on nodes")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for nodes")
+	}
+
+	if len(nodeAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Nodes = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &nodeR{}
+			}
+			foreign.R.NodeBlob = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.Hash, foreign.Blob) {
+				local.R.Nodes = append(local.R.Nodes, foreign)
+				if foreign.R == nil {
+					foreign.R = &nodeR{}
+				}
+				foreign.R.NodeBlob = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadVersions allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (blobL) LoadVersions(ctx context.Context, e boil.ContextExecutor, singular bool, maybeBlob interface{}, mods queries.Applicator) error {
+	var slice []*Blob
+	var object *Blob
+
+	if singular {
+		var ok bool
+		object, ok = maybeBlob.(*Blob)
+		if !ok {
+			object = new(Blob)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeBlob)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeBlob))
+			}
+		}
+	} else {
+		s, ok := maybeBlob.(*[]*Blob)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeBlob)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeBlob))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &blobR{}
+		}
+		args = append(args, object.Hash)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &blobR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.Hash) {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.Hash)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`versions`),
+		qm.WhereIn(`versions.blob in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load versions")
+	}
+
+	var resultSlice []*Version
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice versions")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on versions")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for versions")
+	}
+
+	if len(versionAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Versions = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &versionR{}
+			}
+			foreign.R.VersionBlob = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.Hash, foreign.Blob) {
+				local.R.Versions = append(local.R.Versions, foreign)
+				if foreign.R == nil {
+					foreign.R = &versionR{}
+				}
+				foreign.R.VersionBlob = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// AddNodes adds the given related objects to the existing relationships
+// of the blob, optionally inserting them as new records.
+// Appends related to o.R.Nodes.
+// Sets related.R.NodeBlob appropriately.
+func (o *Blob) AddNodes(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Node) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.Blob, o.Hash)
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"nodes\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"blob"}),
+				strmangle.WhereClause("\"", "\"", 2, nodePrimaryKeyColumns),
+			)
+			values := []interface{}{o.Hash, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.Blob, o.Hash)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &blobR{
+			Nodes: related,
+		}
+	} else {
+		o.R.Nodes = append(o.R.Nodes, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &nodeR{
+				NodeBlob: o,
+			}
+		} else {
+			rel.R.NodeBlob = o
+		}
+	}
+	return nil
+}
+
+// SetNodes removes all previously related items of the
+// blob replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.NodeBlob's Nodes accordingly.
+// Replaces o.R.Nodes with related.
+// Sets related.R.NodeBlob's Nodes accordingly.
+func (o *Blob) SetNodes(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Node) error {
+	query := "update \"nodes\" set \"blob\" = null where \"blob\" = $1"
+	values := []interface{}{o.Hash}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.Nodes {
+			queries.SetScanner(&rel.Blob, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.NodeBlob = nil
+		}
+		o.R.Nodes = nil
+	}
+
+	return o.AddNodes(ctx, exec, insert, related...)
+}
+
+// RemoveNodes relationships from objects passed in.
+// Removes related items from R.Nodes (uses pointer comparison, removal does not keep order)
+// Sets related.R.NodeBlob.
+func (o *Blob) RemoveNodes(ctx context.Context, exec boil.ContextExecutor, related ...*Node) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.Blob, nil)
+		if rel.R != nil {
+			rel.R.NodeBlob = nil
+		}
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("blob")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.Nodes {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.Nodes)
+			if ln > 1 && i < ln-1 {
+				o.R.Nodes[i] = o.R.Nodes[ln-1]
+			}
+			o.R.Nodes = o.R.Nodes[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+// AddVersions adds the given related objects to the existing relationships
+// of the blob, optionally inserting them as new records.
+// Appends related to o.R.Versions.
+// Sets related.R.VersionBlob appropriately.
+func (o *Blob) AddVersions(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Version) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.Blob, o.Hash)
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"versions\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"blob"}),
+				strmangle.WhereClause("\"", "\"", 2, versionPrimaryKeyColumns),
+			)
+			values := []interface{}{o.Hash, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.Blob, o.Hash)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &blobR{
+			Versions: related,
+		}
+	} else {
+		o.R.Versions = append(o.R.Versions, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &versionR{
+				VersionBlob: o,
+			}
+		} else {
+			rel.R.VersionBlob = o
+		}
+	}
+	return nil
+}
+
+// SetVersions removes all previously related items of the
+// blob replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.VersionBlob's Versions accordingly.
+// Replaces o.R.Versions with related.
+// Sets related.R.VersionBlob's Versions accordingly.
+func (o *Blob) SetVersions(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Version) error {
+	query := "update \"versions\" set \"blob\" = null where \"blob\" = $1"
+	values := []interface{}{o.Hash}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.Versions {
+			queries.SetScanner(&rel.Blob, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.VersionBlob = nil
+		}
+		o.R.Versions = nil
+	}
+
+	return o.AddVersions(ctx, exec, insert, related...)
+}
+
+// RemoveVersions relationships from objects passed in.
+// Removes related items from R.Versions (uses pointer comparison, removal does not keep order)
+// Sets related.R.VersionBlob.
+func (o *Blob) RemoveVersions(ctx context.Context, exec boil.ContextExecutor, related ...*Version) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.Blob, nil)
+		if rel.R != nil {
+			rel.R.VersionBlob = nil
+		}
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("blob")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.Versions {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.Versions)
+			if ln > 1 && i < ln-1 {
+				o.R.Versions[i] = o.R.Versions[ln-1]
+			}
+			o.R.Versions = o.R.Versions[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+// Blobs retrieves all the records using an executor.
+func Blobs(mods ...qm.QueryMod) blobQuery {
+	mods = append(mods, qm.From("\"blobs\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"blobs\".*"})
+	}
+
+	return blobQuery{q}
+}
+
+// FindBlob retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindBlob(ctx context.Context, exec boil.ContextExecutor, hash string, selectCols ...string) (*Blob, error) {
+	blobObj := &Blob{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"blobs\" where \"hash\"=$1", sel,
+	)
+
+	q := queries.Raw(query, hash)
+
+	err := q.Bind(ctx, exec, blobObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from blobs")
+	}
+
+	if err = blobObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return blobObj, err
+	}
+
+	return blobObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *Blob) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no blobs provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(blobColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	blobInsertCacheMut.RLock()
+	cache, cached := blobInsertCache[key]
+	blobInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			blobAllColumns,
+			blobColumnsWithDefault,
+			blobColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(blobType, blobMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(blobType, blobMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"blobs\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"blobs\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into blobs")
+	}
+
+	if !cached {
+		blobInsertCacheMut.Lock()
+		blobInsertCache[key] = cache
+		blobInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the Blob.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *Blob) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	blobUpdateCacheMut.RLock()
+	cache, cached := blobUpdateCache[key]
+	blobUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			blobAllColumns,
+			blobPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update blobs, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"blobs\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, blobPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(blobType, blobMapping, append(wl, blobPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update blobs row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for blobs")
+	}
+
+	if !cached {
+		blobUpdateCacheMut.Lock()
+		blobUpdateCache[key] = cache
+		blobUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q blobQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for blobs")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for blobs")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o BlobSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), blobPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"blobs\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, blobPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in blob slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all blob")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *Blob) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no blobs provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(blobColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	blobUpsertCacheMut.RLock()
+	cache, cached := blobUpsertCache[key]
+	blobUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			blobAllColumns,
+			blobColumnsWithDefault,
+			blobColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			blobAllColumns,
+			blobPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert blobs, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(blobPrimaryKeyColumns))
+			copy(conflict, blobPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"blobs\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(blobType, blobMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(blobType, blobMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert blobs")
+	}
+
+	if !cached {
+		blobUpsertCacheMut.Lock()
+		blobUpsertCache[key] = cache
+		blobUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single Blob record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *Blob) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no Blob provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), blobPrimaryKeyMapping)
+	sql := "DELETE FROM \"blobs\" WHERE \"hash\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from blobs")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for blobs")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q blobQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no blobQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from blobs")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for blobs")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o BlobSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(blobBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), blobPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"blobs\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, blobPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from blob slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for blobs")
+	}
+
+	if len(blobAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *Blob) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindBlob(ctx, exec, o.Hash)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *BlobSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := BlobSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), blobPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"blobs\".* FROM \"blobs\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, blobPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in BlobSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// BlobExists checks if the Blob row exists.
+func BlobExists(ctx context.Context, exec boil.ContextExecutor, hash string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"blobs\" where \"hash\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, hash)
+	}
+	row := exec.QueryRowContext(ctx, sql, hash)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if blobs exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the Blob row exists.
+func (o *Blob) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return BlobExists(ctx, exec, o.Hash)
+}
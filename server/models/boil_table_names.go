@@ -1,18 +1,42 @@
-// Code generated by SQLBoiler 4.6.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// Code generated by SQLBoiler 4.14.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
 // This file is meant to be re-generated in place and/or deleted at any time.
 
 package models
 
 var TableNames = struct {
+	Blobs           string
+	Grants          string
+	GroupMembers    string
+	Groups          string
 	Infos           string
+	JobLogs         string
+	Jobs            string
+	Journal         string
 	NodeProcessReqs string
+	NodeTexts       string
 	Nodes           string
 	Progress        string
+	Shares          string
+	Trash           string
+	UserKeys        string
 	Users           string
+	Versions        string
 }{
+	Blobs:           "blobs",
+	Grants:          "grants",
+	GroupMembers:    "group_members",
+	Groups:          "groups",
 	Infos:           "infos",
+	JobLogs:         "job_logs",
+	Jobs:            "jobs",
+	Journal:         "journal",
 	NodeProcessReqs: "node_process_reqs",
+	NodeTexts:       "node_texts",
 	Nodes:           "nodes",
 	Progress:        "progress",
+	Shares:          "shares",
+	Trash:           "trash",
+	UserKeys:        "user_keys",
 	Users:           "users",
+	Versions:        "versions",
 }
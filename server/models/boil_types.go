@@ -1,4 +1,4 @@
-// Code generated by SQLBoiler 4.6.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// Code generated by SQLBoiler 4.14.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
 // This file is meant to be re-generated in place and/or deleted at any time.
 
 package models
@@ -51,8 +51,15 @@ func makeCacheKey(cols boil.Columns, nzDefaults []string) string {
 	return str
 }
 
-// Enum values for node_type
+// Enum values for NodeType
 const (
-	NodeTypeFile      = "file"
-	NodeTypeDirectory = "directory"
+	NodeTypeFile      string = "file"
+	NodeTypeDirectory string = "directory"
 )
+
+func AllNodeType() []string {
+	return []string{
+		NodeTypeFile,
+		NodeTypeDirectory,
+	}
+}
@@ -1,4 +1,4 @@
-// Code generated by SQLBoiler 4.6.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// Code generated by SQLBoiler 4.14.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
 // This file is meant to be re-generated in place and/or deleted at any time.
 
 package models
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -24,92 +25,51 @@ import (
 
 // Info is an object representing the database table.
 type Info struct {
-	ID     int        `boil:"id" json:"id" toml:"id" yaml:"id"`
-	NodeID int        `boil:"node_id" json:"node_id" toml:"node_id" yaml:"node_id"`
-	UserID int        `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
-	Type   string     `boil:"type" json:"type" toml:"type" yaml:"type"`
-	Data   types.JSON `boil:"data" json:"data" toml:"data" yaml:"data"`
+	ID      int        `boil:"id" json:"id" toml:"id" yaml:"id"`
+	NodeID  int        `boil:"node_id" json:"node_id" toml:"node_id" yaml:"node_id"`
+	UserID  int        `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	Type    string     `boil:"type" json:"type" toml:"type" yaml:"type"`
+	Data    types.JSON `boil:"data" json:"data" toml:"data" yaml:"data"`
+	TakenOn null.Time  `boil:"taken_on" json:"taken_on,omitempty" toml:"taken_on" yaml:"taken_on,omitempty"`
 
 	R *infoR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L infoL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var InfoColumns = struct {
-	ID     string
-	NodeID string
-	UserID string
-	Type   string
-	Data   string
+	ID      string
+	NodeID  string
+	UserID  string
+	Type    string
+	Data    string
+	TakenOn string
 }{
-	ID:     "id",
-	NodeID: "node_id",
-	UserID: "user_id",
-	Type:   "type",
-	Data:   "data",
+	ID:      "id",
+	NodeID:  "node_id",
+	UserID:  "user_id",
+	Type:    "type",
+	Data:    "data",
+	TakenOn: "taken_on",
 }
 
 var InfoTableColumns = struct {
-	ID     string
-	NodeID string
-	UserID string
-	Type   string
-	Data   string
+	ID      string
+	NodeID  string
+	UserID  string
+	Type    string
+	Data    string
+	TakenOn string
 }{
-	ID:     "infos.id",
-	NodeID: "infos.node_id",
-	UserID: "infos.user_id",
-	Type:   "infos.type",
-	Data:   "infos.data",
+	ID:      "infos.id",
+	NodeID:  "infos.node_id",
+	UserID:  "infos.user_id",
+	Type:    "infos.type",
+	Data:    "infos.data",
+	TakenOn: "infos.taken_on",
 }
 
 // Generated where
 
-type whereHelperint struct{ field string }
-
-func (w whereHelperint) EQ(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperint) NEQ(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperint) LT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperint) LTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperint) GT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperint) GTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperint) IN(slice []int) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperint) NIN(slice []int) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
-type whereHelperstring struct{ field string }
-
-func (w whereHelperstring) EQ(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperstring) NEQ(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperstring) LT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperstring) LTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperstring) GT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperstring) GTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperstring) IN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperstring) NIN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
 type whereHelpertypes_JSON struct{ field string }
 
 func (w whereHelpertypes_JSON) EQ(x types.JSON) qm.QueryMod {
@@ -131,18 +91,44 @@ func (w whereHelpertypes_JSON) GTE(x types.JSON) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
 
+type whereHelpernull_Time struct{ field string }
+
+func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
 var InfoWhere = struct {
-	ID     whereHelperint
-	NodeID whereHelperint
-	UserID whereHelperint
-	Type   whereHelperstring
-	Data   whereHelpertypes_JSON
+	ID      whereHelperint
+	NodeID  whereHelperint
+	UserID  whereHelperint
+	Type    whereHelperstring
+	Data    whereHelpertypes_JSON
+	TakenOn whereHelpernull_Time
 }{
-	ID:     whereHelperint{field: "\"infos\".\"id\""},
-	NodeID: whereHelperint{field: "\"infos\".\"node_id\""},
-	UserID: whereHelperint{field: "\"infos\".\"user_id\""},
-	Type:   whereHelperstring{field: "\"infos\".\"type\""},
-	Data:   whereHelpertypes_JSON{field: "\"infos\".\"data\""},
+	ID:      whereHelperint{field: "\"infos\".\"id\""},
+	NodeID:  whereHelperint{field: "\"infos\".\"node_id\""},
+	UserID:  whereHelperint{field: "\"infos\".\"user_id\""},
+	Type:    whereHelperstring{field: "\"infos\".\"type\""},
+	Data:    whereHelpertypes_JSON{field: "\"infos\".\"data\""},
+	TakenOn: whereHelpernull_Time{field: "\"infos\".\"taken_on\""},
 }
 
 // InfoRels is where relationship names are stored.
@@ -165,14 +151,29 @@ func (*infoR) NewStruct() *infoR {
 	return &infoR{}
 }
 
+func (r *infoR) GetNode() *Node {
+	if r == nil {
+		return nil
+	}
+	return r.Node
+}
+
+func (r *infoR) GetUser() *User {
+	if r == nil {
+		return nil
+	}
+	return r.User
+}
+
 // infoL is where Load methods for each relationship are stored.
 type infoL struct{}
 
 var (
-	infoAllColumns            = []string{"id", "node_id", "user_id", "type", "data"}
+	infoAllColumns            = []string{"id", "node_id", "user_id", "type", "data", "taken_on"}
 	infoColumnsWithoutDefault = []string{"node_id", "user_id", "type"}
-	infoColumnsWithDefault    = []string{"id", "data"}
+	infoColumnsWithDefault    = []string{"id", "data", "taken_on"}
 	infoPrimaryKeyColumns     = []string{"id"}
+	infoGeneratedColumns      = []string{}
 )
 
 type (
@@ -208,24 +209,27 @@ var (
 	_ = qmhelper.Where
 )
 
-var infoBeforeInsertHooks []InfoHook
-var infoBeforeUpdateHooks []InfoHook
-var infoBeforeDeleteHooks []InfoHook
-var infoBeforeUpsertHooks []InfoHook
+var infoAfterSelectHooks []InfoHook
 
+var infoBeforeInsertHooks []InfoHook
 var infoAfterInsertHooks []InfoHook
-var infoAfterSelectHooks []InfoHook
+
+var infoBeforeUpdateHooks []InfoHook
 var infoAfterUpdateHooks []InfoHook
+
+var infoBeforeDeleteHooks []InfoHook
 var infoAfterDeleteHooks []InfoHook
+
+var infoBeforeUpsertHooks []InfoHook
 var infoAfterUpsertHooks []InfoHook
 
-// doBeforeInsertHooks executes all "before insert" hooks.
-func (o *Info) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *Info) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range infoBeforeInsertHooks {
+	for _, hook := range infoAfterSelectHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -234,13 +238,13 @@ func (o *Info) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecuto
 	return nil
 }
 
-// doBeforeUpdateHooks executes all "before Update" hooks.
-func (o *Info) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *Info) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range infoBeforeUpdateHooks {
+	for _, hook := range infoBeforeInsertHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -249,13 +253,13 @@ func (o *Info) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecuto
 	return nil
 }
 
-// doBeforeDeleteHooks executes all "before Delete" hooks.
-func (o *Info) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *Info) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range infoBeforeDeleteHooks {
+	for _, hook := range infoAfterInsertHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -264,13 +268,13 @@ func (o *Info) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecuto
 	return nil
 }
 
-// doBeforeUpsertHooks executes all "before Upsert" hooks.
-func (o *Info) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *Info) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range infoBeforeUpsertHooks {
+	for _, hook := range infoBeforeUpdateHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -279,13 +283,13 @@ func (o *Info) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecuto
 	return nil
 }
 
-// doAfterInsertHooks executes all "after Insert" hooks.
-func (o *Info) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *Info) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range infoAfterInsertHooks {
+	for _, hook := range infoAfterUpdateHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -294,13 +298,13 @@ func (o *Info) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor
 	return nil
 }
 
-// doAfterSelectHooks executes all "after Select" hooks.
-func (o *Info) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *Info) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range infoAfterSelectHooks {
+	for _, hook := range infoBeforeDeleteHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -309,13 +313,13 @@ func (o *Info) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor
 	return nil
 }
 
-// doAfterUpdateHooks executes all "after Update" hooks.
-func (o *Info) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *Info) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range infoAfterUpdateHooks {
+	for _, hook := range infoAfterDeleteHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -324,13 +328,13 @@ func (o *Info) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor
 	return nil
 }
 
-// doAfterDeleteHooks executes all "after Delete" hooks.
-func (o *Info) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *Info) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range infoAfterDeleteHooks {
+	for _, hook := range infoBeforeUpsertHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -357,22 +361,22 @@ func (o *Info) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor
 // AddInfoHook registers your hook function for all future operations.
 func AddInfoHook(hookPoint boil.HookPoint, infoHook InfoHook) {
 	switch hookPoint {
+	case boil.AfterSelectHook:
+		infoAfterSelectHooks = append(infoAfterSelectHooks, infoHook)
 	case boil.BeforeInsertHook:
 		infoBeforeInsertHooks = append(infoBeforeInsertHooks, infoHook)
-	case boil.BeforeUpdateHook:
-		infoBeforeUpdateHooks = append(infoBeforeUpdateHooks, infoHook)
-	case boil.BeforeDeleteHook:
-		infoBeforeDeleteHooks = append(infoBeforeDeleteHooks, infoHook)
-	case boil.BeforeUpsertHook:
-		infoBeforeUpsertHooks = append(infoBeforeUpsertHooks, infoHook)
 	case boil.AfterInsertHook:
 		infoAfterInsertHooks = append(infoAfterInsertHooks, infoHook)
-	case boil.AfterSelectHook:
-		infoAfterSelectHooks = append(infoAfterSelectHooks, infoHook)
+	case boil.BeforeUpdateHook:
+		infoBeforeUpdateHooks = append(infoBeforeUpdateHooks, infoHook)
 	case boil.AfterUpdateHook:
 		infoAfterUpdateHooks = append(infoAfterUpdateHooks, infoHook)
+	case boil.BeforeDeleteHook:
+		infoBeforeDeleteHooks = append(infoBeforeDeleteHooks, infoHook)
 	case boil.AfterDeleteHook:
 		infoAfterDeleteHooks = append(infoAfterDeleteHooks, infoHook)
+	case boil.BeforeUpsertHook:
+		infoBeforeUpsertHooks = append(infoBeforeUpsertHooks, infoHook)
 	case boil.AfterUpsertHook:
 		infoAfterUpsertHooks = append(infoAfterUpsertHooks, infoHook)
 	}
@@ -386,7 +390,7 @@ func (q infoQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Info, e
 
 	err := q.Bind(ctx, exec, o)
 	if err != nil {
-		if errors.Cause(err) == sql.ErrNoRows {
+		if errors.Is(err, sql.ErrNoRows) {
 			return nil, sql.ErrNoRows
 		}
 		return nil, errors.Wrap(err, "models: failed to execute a one query for infos")
@@ -458,10 +462,7 @@ func (o *Info) Node(mods ...qm.QueryMod) nodeQuery {
 
 	queryMods = append(queryMods, mods...)
 
-	query := Nodes(queryMods...)
-	queries.SetFrom(query.Query, "\"nodes\"")
-
-	return query
+	return Nodes(queryMods...)
 }
 
 // User pointed to by the foreign key.
@@ -472,10 +473,7 @@ func (o *Info) User(mods ...qm.QueryMod) userQuery {
 
 	queryMods = append(queryMods, mods...)
 
-	query := Users(queryMods...)
-	queries.SetFrom(query.Query, "\"users\"")
-
-	return query
+	return Users(queryMods...)
 }
 
 // LoadNode allows an eager lookup of values, cached into the
@@ -485,9 +483,25 @@ func (infoL) LoadNode(ctx context.Context, e boil.ContextExecutor, singular bool
 	var object *Info
 
 	if singular {
-		object = maybeInfo.(*Info)
+		var ok bool
+		object, ok = maybeInfo.(*Info)
+		if !ok {
+			object = new(Info)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeInfo)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeInfo))
+			}
+		}
 	} else {
-		slice = *maybeInfo.(*[]*Info)
+		s, ok := maybeInfo.(*[]*Info)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeInfo)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeInfo))
+			}
+		}
 	}
 
 	args := make([]interface{}, 0, 1)
@@ -544,7 +558,7 @@ func (infoL) LoadNode(ctx context.Context, e boil.ContextExecutor, singular bool
 		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for nodes")
 	}
 
-	if len(infoAfterSelectHooks) != 0 {
+	if len(nodeAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
@@ -589,9 +603,25 @@ func (infoL) LoadUser(ctx context.Context, e boil.ContextExecutor, singular bool
 	var object *Info
 
 	if singular {
-		object = maybeInfo.(*Info)
+		var ok bool
+		object, ok = maybeInfo.(*Info)
+		if !ok {
+			object = new(Info)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeInfo)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeInfo))
+			}
+		}
 	} else {
-		slice = *maybeInfo.(*[]*Info)
+		s, ok := maybeInfo.(*[]*Info)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeInfo)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeInfo))
+			}
+		}
 	}
 
 	args := make([]interface{}, 0, 1)
@@ -648,7 +678,7 @@ func (infoL) LoadUser(ctx context.Context, e boil.ContextExecutor, singular bool
 		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
 	}
 
-	if len(infoAfterSelectHooks) != 0 {
+	if len(userAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
@@ -783,7 +813,12 @@ func (o *Info) SetUser(ctx context.Context, exec boil.ContextExecutor, insert bo
 // Infos retrieves all the records using an executor.
 func Infos(mods ...qm.QueryMod) infoQuery {
 	mods = append(mods, qm.From("\"infos\""))
-	return infoQuery{NewQuery(mods...)}
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"infos\".*"})
+	}
+
+	return infoQuery{q}
 }
 
 // FindInfo retrieves a single record by ID with an executor.
@@ -803,7 +838,7 @@ func FindInfo(ctx context.Context, exec boil.ContextExecutor, iD int, selectCols
 
 	err := q.Bind(ctx, exec, infoObj)
 	if err != nil {
-		if errors.Cause(err) == sql.ErrNoRows {
+		if errors.Is(err, sql.ErrNoRows) {
 			return nil, sql.ErrNoRows
 		}
 		return nil, errors.Wrap(err, "models: unable to select from infos")
@@ -1077,6 +1112,7 @@ func (o *Info) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnCo
 			infoColumnsWithoutDefault,
 			nzDefaults,
 		)
+
 		update := updateColumns.UpdateColumnSet(
 			infoAllColumns,
 			infoPrimaryKeyColumns,
@@ -1119,7 +1155,7 @@ func (o *Info) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnCo
 	}
 	if len(cache.retMapping) != 0 {
 		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
-		if err == sql.ErrNoRows {
+		if errors.Is(err, sql.ErrNoRows) {
 			err = nil // Postgres doesn't return anything when there's no update
 		}
 	} else {
@@ -1304,3 +1340,8 @@ func InfoExists(ctx context.Context, exec boil.ContextExecutor, iD int) (bool, e
 
 	return exists, nil
 }
+
+// Exists checks if the Info row exists.
+func (o *Info) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return InfoExists(ctx, exec, o.ID)
+}
@@ -1,4 +1,4 @@
-// Code generated by SQLBoiler 4.6.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// Code generated by SQLBoiler 4.14.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
 // This file is meant to be re-generated in place and/or deleted at any time.
 
 package models
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -23,10 +24,17 @@ import (
 
 // NodeProcessReq is an object representing the database table.
 type NodeProcessReq struct {
-	ID           int    `boil:"id" json:"id" toml:"id" yaml:"id"`
-	NodeID       int    `boil:"node_id" json:"node_id" toml:"node_id" yaml:"node_id"`
-	Path         string `boil:"path" json:"path" toml:"path" yaml:"path"`
-	RemoveUpload bool   `boil:"remove_upload" json:"remove_upload" toml:"remove_upload" yaml:"remove_upload"`
+	ID           int         `boil:"id" json:"id" toml:"id" yaml:"id"`
+	NodeID       int         `boil:"node_id" json:"node_id" toml:"node_id" yaml:"node_id"`
+	Path         string      `boil:"path" json:"path" toml:"path" yaml:"path"`
+	RemoveUpload bool        `boil:"remove_upload" json:"remove_upload" toml:"remove_upload" yaml:"remove_upload"`
+	State        string      `boil:"state" json:"state" toml:"state" yaml:"state"`
+	Attempts     int         `boil:"attempts" json:"attempts" toml:"attempts" yaml:"attempts"`
+	Error        null.String `boil:"error" json:"error,omitempty" toml:"error" yaml:"error,omitempty"`
+	RunAfter     null.Time   `boil:"run_after" json:"run_after,omitempty" toml:"run_after" yaml:"run_after,omitempty"`
+	UpdatedOn    null.Time   `boil:"updated_on" json:"updated_on,omitempty" toml:"updated_on" yaml:"updated_on,omitempty"`
+	Worker       null.String `boil:"worker" json:"worker,omitempty" toml:"worker" yaml:"worker,omitempty"`
+	LeaseUntil   null.Time   `boil:"lease_until" json:"lease_until,omitempty" toml:"lease_until" yaml:"lease_until,omitempty"`
 
 	R *nodeProcessReqR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L nodeProcessReqL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -37,11 +45,25 @@ var NodeProcessReqColumns = struct {
 	NodeID       string
 	Path         string
 	RemoveUpload string
+	State        string
+	Attempts     string
+	Error        string
+	RunAfter     string
+	UpdatedOn    string
+	Worker       string
+	LeaseUntil   string
 }{
 	ID:           "id",
 	NodeID:       "node_id",
 	Path:         "path",
 	RemoveUpload: "remove_upload",
+	State:        "state",
+	Attempts:     "attempts",
+	Error:        "error",
+	RunAfter:     "run_after",
+	UpdatedOn:    "updated_on",
+	Worker:       "worker",
+	LeaseUntil:   "lease_until",
 }
 
 var NodeProcessReqTableColumns = struct {
@@ -49,34 +71,53 @@ var NodeProcessReqTableColumns = struct {
 	NodeID       string
 	Path         string
 	RemoveUpload string
+	State        string
+	Attempts     string
+	Error        string
+	RunAfter     string
+	UpdatedOn    string
+	Worker       string
+	LeaseUntil   string
 }{
 	ID:           "node_process_reqs.id",
 	NodeID:       "node_process_reqs.node_id",
 	Path:         "node_process_reqs.path",
 	RemoveUpload: "node_process_reqs.remove_upload",
+	State:        "node_process_reqs.state",
+	Attempts:     "node_process_reqs.attempts",
+	Error:        "node_process_reqs.error",
+	RunAfter:     "node_process_reqs.run_after",
+	UpdatedOn:    "node_process_reqs.updated_on",
+	Worker:       "node_process_reqs.worker",
+	LeaseUntil:   "node_process_reqs.lease_until",
 }
 
 // Generated where
 
-type whereHelperbool struct{ field string }
-
-func (w whereHelperbool) EQ(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperbool) NEQ(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperbool) LT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperbool) LTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperbool) GT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperbool) GTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-
 var NodeProcessReqWhere = struct {
 	ID           whereHelperint
 	NodeID       whereHelperint
 	Path         whereHelperstring
 	RemoveUpload whereHelperbool
+	State        whereHelperstring
+	Attempts     whereHelperint
+	Error        whereHelpernull_String
+	RunAfter     whereHelpernull_Time
+	UpdatedOn    whereHelpernull_Time
+	Worker       whereHelpernull_String
+	LeaseUntil   whereHelpernull_Time
 }{
 	ID:           whereHelperint{field: "\"node_process_reqs\".\"id\""},
 	NodeID:       whereHelperint{field: "\"node_process_reqs\".\"node_id\""},
 	Path:         whereHelperstring{field: "\"node_process_reqs\".\"path\""},
 	RemoveUpload: whereHelperbool{field: "\"node_process_reqs\".\"remove_upload\""},
+	State:        whereHelperstring{field: "\"node_process_reqs\".\"state\""},
+	Attempts:     whereHelperint{field: "\"node_process_reqs\".\"attempts\""},
+	Error:        whereHelpernull_String{field: "\"node_process_reqs\".\"error\""},
+	RunAfter:     whereHelpernull_Time{field: "\"node_process_reqs\".\"run_after\""},
+	UpdatedOn:    whereHelpernull_Time{field: "\"node_process_reqs\".\"updated_on\""},
+	Worker:       whereHelpernull_String{field: "\"node_process_reqs\".\"worker\""},
+	LeaseUntil:   whereHelpernull_Time{field: "\"node_process_reqs\".\"lease_until\""},
 }
 
 // NodeProcessReqRels is where relationship names are stored.
@@ -96,14 +137,22 @@ func (*nodeProcessReqR) NewStruct() *nodeProcessReqR {
 	return &nodeProcessReqR{}
 }
 
+func (r *nodeProcessReqR) GetNode() *Node {
+	if r == nil {
+		return nil
+	}
+	return r.Node
+}
+
 // nodeProcessReqL is where Load methods for each relationship are stored.
 type nodeProcessReqL struct{}
 
 var (
-	nodeProcessReqAllColumns            = []string{"id", "node_id", "path", "remove_upload"}
+	nodeProcessReqAllColumns            = []string{"id", "node_id", "path", "remove_upload", "state", "attempts", "error", "run_after", "updated_on", "worker", "lease_until"}
 	nodeProcessReqColumnsWithoutDefault = []string{"node_id", "path"}
-	nodeProcessReqColumnsWithDefault    = []string{"id", "remove_upload"}
+	nodeProcessReqColumnsWithDefault    = []string{"id", "remove_upload", "state", "attempts", "error", "run_after", "updated_on", "worker", "lease_until"}
 	nodeProcessReqPrimaryKeyColumns     = []string{"id"}
+	nodeProcessReqGeneratedColumns      = []string{}
 )
 
 type (
@@ -139,24 +188,27 @@ var (
 	_ = qmhelper.Where
 )
 
-var nodeProcessReqBeforeInsertHooks []NodeProcessReqHook
-var nodeProcessReqBeforeUpdateHooks []NodeProcessReqHook
-var nodeProcessReqBeforeDeleteHooks []NodeProcessReqHook
-var nodeProcessReqBeforeUpsertHooks []NodeProcessReqHook
+var nodeProcessReqAfterSelectHooks []NodeProcessReqHook
 
+var nodeProcessReqBeforeInsertHooks []NodeProcessReqHook
 var nodeProcessReqAfterInsertHooks []NodeProcessReqHook
-var nodeProcessReqAfterSelectHooks []NodeProcessReqHook
+
+var nodeProcessReqBeforeUpdateHooks []NodeProcessReqHook
 var nodeProcessReqAfterUpdateHooks []NodeProcessReqHook
+
+var nodeProcessReqBeforeDeleteHooks []NodeProcessReqHook
 var nodeProcessReqAfterDeleteHooks []NodeProcessReqHook
+
+var nodeProcessReqBeforeUpsertHooks []NodeProcessReqHook
 var nodeProcessReqAfterUpsertHooks []NodeProcessReqHook
 
-// doBeforeInsertHooks executes all "before insert" hooks.
-func (o *NodeProcessReq) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *NodeProcessReq) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range nodeProcessReqBeforeInsertHooks {
+	for _, hook := range nodeProcessReqAfterSelectHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -165,13 +217,13 @@ func (o *NodeProcessReq) doBeforeInsertHooks(ctx context.Context, exec boil.Cont
 	return nil
 }
 
-// doBeforeUpdateHooks executes all "before Update" hooks.
-func (o *NodeProcessReq) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *NodeProcessReq) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range nodeProcessReqBeforeUpdateHooks {
+	for _, hook := range nodeProcessReqBeforeInsertHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -180,13 +232,13 @@ func (o *NodeProcessReq) doBeforeUpdateHooks(ctx context.Context, exec boil.Cont
 	return nil
 }
 
-// doBeforeDeleteHooks executes all "before Delete" hooks.
-func (o *NodeProcessReq) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *NodeProcessReq) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range nodeProcessReqBeforeDeleteHooks {
+	for _, hook := range nodeProcessReqAfterInsertHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -195,13 +247,13 @@ func (o *NodeProcessReq) doBeforeDeleteHooks(ctx context.Context, exec boil.Cont
 	return nil
 }
 
-// doBeforeUpsertHooks executes all "before Upsert" hooks.
-func (o *NodeProcessReq) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *NodeProcessReq) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range nodeProcessReqBeforeUpsertHooks {
+	for _, hook := range nodeProcessReqBeforeUpdateHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -210,13 +262,13 @@ func (o *NodeProcessReq) doBeforeUpsertHooks(ctx context.Context, exec boil.Cont
 	return nil
 }
 
-// doAfterInsertHooks executes all "after Insert" hooks.
-func (o *NodeProcessReq) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *NodeProcessReq) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range nodeProcessReqAfterInsertHooks {
+	for _, hook := range nodeProcessReqAfterUpdateHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -225,13 +277,13 @@ func (o *NodeProcessReq) doAfterInsertHooks(ctx context.Context, exec boil.Conte
 	return nil
 }
 
-// doAfterSelectHooks executes all "after Select" hooks.
-func (o *NodeProcessReq) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *NodeProcessReq) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range nodeProcessReqAfterSelectHooks {
+	for _, hook := range nodeProcessReqBeforeDeleteHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -240,13 +292,13 @@ func (o *NodeProcessReq) doAfterSelectHooks(ctx context.Context, exec boil.Conte
 	return nil
 }
 
-// doAfterUpdateHooks executes all "after Update" hooks.
-func (o *NodeProcessReq) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *NodeProcessReq) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range nodeProcessReqAfterUpdateHooks {
+	for _, hook := range nodeProcessReqAfterDeleteHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -255,13 +307,13 @@ func (o *NodeProcessReq) doAfterUpdateHooks(ctx context.Context, exec boil.Conte
 	return nil
 }
 
-// doAfterDeleteHooks executes all "after Delete" hooks.
-func (o *NodeProcessReq) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *NodeProcessReq) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range nodeProcessReqAfterDeleteHooks {
+	for _, hook := range nodeProcessReqBeforeUpsertHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -288,22 +340,22 @@ func (o *NodeProcessReq) doAfterUpsertHooks(ctx context.Context, exec boil.Conte
 // AddNodeProcessReqHook registers your hook function for all future operations.
 func AddNodeProcessReqHook(hookPoint boil.HookPoint, nodeProcessReqHook NodeProcessReqHook) {
 	switch hookPoint {
+	case boil.AfterSelectHook:
+		nodeProcessReqAfterSelectHooks = append(nodeProcessReqAfterSelectHooks, nodeProcessReqHook)
 	case boil.BeforeInsertHook:
 		nodeProcessReqBeforeInsertHooks = append(nodeProcessReqBeforeInsertHooks, nodeProcessReqHook)
-	case boil.BeforeUpdateHook:
-		nodeProcessReqBeforeUpdateHooks = append(nodeProcessReqBeforeUpdateHooks, nodeProcessReqHook)
-	case boil.BeforeDeleteHook:
-		nodeProcessReqBeforeDeleteHooks = append(nodeProcessReqBeforeDeleteHooks, nodeProcessReqHook)
-	case boil.BeforeUpsertHook:
-		nodeProcessReqBeforeUpsertHooks = append(nodeProcessReqBeforeUpsertHooks, nodeProcessReqHook)
 	case boil.AfterInsertHook:
 		nodeProcessReqAfterInsertHooks = append(nodeProcessReqAfterInsertHooks, nodeProcessReqHook)
-	case boil.AfterSelectHook:
-		nodeProcessReqAfterSelectHooks = append(nodeProcessReqAfterSelectHooks, nodeProcessReqHook)
+	case boil.BeforeUpdateHook:
+		nodeProcessReqBeforeUpdateHooks = append(nodeProcessReqBeforeUpdateHooks, nodeProcessReqHook)
 	case boil.AfterUpdateHook:
 		nodeProcessReqAfterUpdateHooks = append(nodeProcessReqAfterUpdateHooks, nodeProcessReqHook)
+	case boil.BeforeDeleteHook:
+		nodeProcessReqBeforeDeleteHooks = append(nodeProcessReqBeforeDeleteHooks, nodeProcessReqHook)
 	case boil.AfterDeleteHook:
 		nodeProcessReqAfterDeleteHooks = append(nodeProcessReqAfterDeleteHooks, nodeProcessReqHook)
+	case boil.BeforeUpsertHook:
+		nodeProcessReqBeforeUpsertHooks = append(nodeProcessReqBeforeUpsertHooks, nodeProcessReqHook)
 	case boil.AfterUpsertHook:
 		nodeProcessReqAfterUpsertHooks = append(nodeProcessReqAfterUpsertHooks, nodeProcessReqHook)
 	}
@@ -317,7 +369,7 @@ func (q nodeProcessReqQuery) One(ctx context.Context, exec boil.ContextExecutor)
 
 	err := q.Bind(ctx, exec, o)
 	if err != nil {
-		if errors.Cause(err) == sql.ErrNoRows {
+		if errors.Is(err, sql.ErrNoRows) {
 			return nil, sql.ErrNoRows
 		}
 		return nil, errors.Wrap(err, "models: failed to execute a one query for node_process_reqs")
@@ -389,10 +441,7 @@ func (o *NodeProcessReq) Node(mods ...qm.QueryMod) nodeQuery {
 
 	queryMods = append(queryMods, mods...)
 
-	query := Nodes(queryMods...)
-	queries.SetFrom(query.Query, "\"nodes\"")
-
-	return query
+	return Nodes(queryMods...)
 }
 
 // LoadNode allows an eager lookup of values, cached into the
@@ -402,9 +451,25 @@ func (nodeProcessReqL) LoadNode(ctx context.Context, e boil.ContextExecutor, sin
 	var object *NodeProcessReq
 
 	if singular {
-		object = maybeNodeProcessReq.(*NodeProcessReq)
+		var ok bool
+		object, ok = maybeNodeProcessReq.(*NodeProcessReq)
+		if !ok {
+			object = new(NodeProcessReq)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeNodeProcessReq)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeNodeProcessReq))
+			}
+		}
 	} else {
-		slice = *maybeNodeProcessReq.(*[]*NodeProcessReq)
+		s, ok := maybeNodeProcessReq.(*[]*NodeProcessReq)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeNodeProcessReq)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeNodeProcessReq))
+			}
+		}
 	}
 
 	args := make([]interface{}, 0, 1)
@@ -461,7 +526,7 @@ func (nodeProcessReqL) LoadNode(ctx context.Context, e boil.ContextExecutor, sin
 		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for nodes")
 	}
 
-	if len(nodeProcessReqAfterSelectHooks) != 0 {
+	if len(nodeAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
@@ -479,7 +544,7 @@ func (nodeProcessReqL) LoadNode(ctx context.Context, e boil.ContextExecutor, sin
 		if foreign.R == nil {
 			foreign.R = &nodeR{}
 		}
-		foreign.R.NodeProcessReqs = append(foreign.R.NodeProcessReqs, object)
+		foreign.R.NodeProcessReq = object
 		return nil
 	}
 
@@ -490,7 +555,7 @@ func (nodeProcessReqL) LoadNode(ctx context.Context, e boil.ContextExecutor, sin
 				if foreign.R == nil {
 					foreign.R = &nodeR{}
 				}
-				foreign.R.NodeProcessReqs = append(foreign.R.NodeProcessReqs, local)
+				foreign.R.NodeProcessReq = local
 				break
 			}
 		}
@@ -501,7 +566,7 @@ func (nodeProcessReqL) LoadNode(ctx context.Context, e boil.ContextExecutor, sin
 
 // SetNode of the nodeProcessReq to the related item.
 // Sets o.R.Node to related.
-// Adds o to related.R.NodeProcessReqs.
+// Adds o to related.R.NodeProcessReq.
 func (o *NodeProcessReq) SetNode(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Node) error {
 	var err error
 	if insert {
@@ -537,10 +602,10 @@ func (o *NodeProcessReq) SetNode(ctx context.Context, exec boil.ContextExecutor,
 
 	if related.R == nil {
 		related.R = &nodeR{
-			NodeProcessReqs: NodeProcessReqSlice{o},
+			NodeProcessReq: o,
 		}
 	} else {
-		related.R.NodeProcessReqs = append(related.R.NodeProcessReqs, o)
+		related.R.NodeProcessReq = o
 	}
 
 	return nil
@@ -549,7 +614,12 @@ func (o *NodeProcessReq) SetNode(ctx context.Context, exec boil.ContextExecutor,
 // NodeProcessReqs retrieves all the records using an executor.
 func NodeProcessReqs(mods ...qm.QueryMod) nodeProcessReqQuery {
 	mods = append(mods, qm.From("\"node_process_reqs\""))
-	return nodeProcessReqQuery{NewQuery(mods...)}
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"node_process_reqs\".*"})
+	}
+
+	return nodeProcessReqQuery{q}
 }
 
 // FindNodeProcessReq retrieves a single record by ID with an executor.
@@ -569,7 +639,7 @@ func FindNodeProcessReq(ctx context.Context, exec boil.ContextExecutor, iD int,
 
 	err := q.Bind(ctx, exec, nodeProcessReqObj)
 	if err != nil {
-		if errors.Cause(err) == sql.ErrNoRows {
+		if errors.Is(err, sql.ErrNoRows) {
 			return nil, sql.ErrNoRows
 		}
 		return nil, errors.Wrap(err, "models: unable to select from node_process_reqs")
@@ -843,6 +913,7 @@ func (o *NodeProcessReq) Upsert(ctx context.Context, exec boil.ContextExecutor,
 			nodeProcessReqColumnsWithoutDefault,
 			nzDefaults,
 		)
+
 		update := updateColumns.UpdateColumnSet(
 			nodeProcessReqAllColumns,
 			nodeProcessReqPrimaryKeyColumns,
@@ -885,7 +956,7 @@ func (o *NodeProcessReq) Upsert(ctx context.Context, exec boil.ContextExecutor,
 	}
 	if len(cache.retMapping) != 0 {
 		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
-		if err == sql.ErrNoRows {
+		if errors.Is(err, sql.ErrNoRows) {
 			err = nil // Postgres doesn't return anything when there's no update
 		}
 	} else {
@@ -1070,3 +1141,8 @@ func NodeProcessReqExists(ctx context.Context, exec boil.ContextExecutor, iD int
 
 	return exists, nil
 }
+
+// Exists checks if the NodeProcessReq row exists.
+func (o *NodeProcessReq) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return NodeProcessReqExists(ctx, exec, o.ID)
+}
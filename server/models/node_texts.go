@@ -0,0 +1,1084 @@
+// Code generated by SQLBoiler 4.14.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// NodeText is an object representing the database table.
+type NodeText struct {
+	NodeID  int    `boil:"node_id" json:"node_id" toml:"node_id" yaml:"node_id"`
+	Content string `boil:"content" json:"content" toml:"content" yaml:"content"`
+
+	R *nodeTextR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L nodeTextL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var NodeTextColumns = struct {
+	NodeID  string
+	Content string
+}{
+	NodeID:  "node_id",
+	Content: "content",
+}
+
+var NodeTextTableColumns = struct {
+	NodeID  string
+	Content string
+}{
+	NodeID:  "node_texts.node_id",
+	Content: "node_texts.content",
+}
+
+// Generated where
+
+var NodeTextWhere = struct {
+	NodeID  whereHelperint
+	Content whereHelperstring
+}{
+	NodeID:  whereHelperint{field: "\"node_texts\".\"node_id\""},
+	Content: whereHelperstring{field: "\"node_texts\".\"content\""},
+}
+
+// NodeTextRels is where relationship names are stored.
+var NodeTextRels = struct {
+	Node string
+}{
+	Node: "Node",
+}
+
+// nodeTextR is where relationships are stored.
+type nodeTextR struct {
+	Node *Node `boil:"Node" json:"Node" toml:"Node" yaml:"Node"`
+}
+
+// NewStruct creates a new relationship struct
+func (*nodeTextR) NewStruct() *nodeTextR {
+	return &nodeTextR{}
+}
+
+func (r *nodeTextR) GetNode() *Node {
+	if r == nil {
+		return nil
+	}
+	return r.Node
+}
+
+// nodeTextL is where Load methods for each relationship are stored.
+type nodeTextL struct{}
+
+var (
+	nodeTextAllColumns            = []string{"node_id", "content"}
+	nodeTextColumnsWithoutDefault = []string{"node_id", "content"}
+	nodeTextColumnsWithDefault    = []string{}
+	nodeTextPrimaryKeyColumns     = []string{"node_id"}
+	nodeTextGeneratedColumns      = []string{}
+)
+
+type (
+	// NodeTextSlice is an alias for a slice of pointers to NodeText.
+	// This should almost always be used instead of []NodeText.
+	NodeTextSlice []*NodeText
+	// NodeTextHook is the signature for custom NodeText hook methods
+	NodeTextHook func(context.Context, boil.ContextExecutor, *NodeText) error
+
+	nodeTextQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	nodeTextType                 = reflect.TypeOf(&NodeText{})
+	nodeTextMapping              = queries.MakeStructMapping(nodeTextType)
+	nodeTextPrimaryKeyMapping, _ = queries.BindMapping(nodeTextType, nodeTextMapping, nodeTextPrimaryKeyColumns)
+	nodeTextInsertCacheMut       sync.RWMutex
+	nodeTextInsertCache          = make(map[string]insertCache)
+	nodeTextUpdateCacheMut       sync.RWMutex
+	nodeTextUpdateCache          = make(map[string]updateCache)
+	nodeTextUpsertCacheMut       sync.RWMutex
+	nodeTextUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var nodeTextAfterSelectHooks []NodeTextHook
+
+var nodeTextBeforeInsertHooks []NodeTextHook
+var nodeTextAfterInsertHooks []NodeTextHook
+
+var nodeTextBeforeUpdateHooks []NodeTextHook
+var nodeTextAfterUpdateHooks []NodeTextHook
+
+var nodeTextBeforeDeleteHooks []NodeTextHook
+var nodeTextAfterDeleteHooks []NodeTextHook
+
+var nodeTextBeforeUpsertHooks []NodeTextHook
+var nodeTextAfterUpsertHooks []NodeTextHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *NodeText) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range nodeTextAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *NodeText) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range nodeTextBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *NodeText) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range nodeTextAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *NodeText) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range nodeTextBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *NodeText) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range nodeTextAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *NodeText) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range nodeTextBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *NodeText) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range nodeTextAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *NodeText) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range nodeTextBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *NodeText) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range nodeTextAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddNodeTextHook registers your hook function for all future operations.
+func AddNodeTextHook(hookPoint boil.HookPoint, nodeTextHook NodeTextHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		nodeTextAfterSelectHooks = append(nodeTextAfterSelectHooks, nodeTextHook)
+	case boil.BeforeInsertHook:
+		nodeTextBeforeInsertHooks = append(nodeTextBeforeInsertHooks, nodeTextHook)
+	case boil.AfterInsertHook:
+		nodeTextAfterInsertHooks = append(nodeTextAfterInsertHooks, nodeTextHook)
+	case boil.BeforeUpdateHook:
+		nodeTextBeforeUpdateHooks = append(nodeTextBeforeUpdateHooks, nodeTextHook)
+	case boil.AfterUpdateHook:
+		nodeTextAfterUpdateHooks = append(nodeTextAfterUpdateHooks, nodeTextHook)
+	case boil.BeforeDeleteHook:
+		nodeTextBeforeDeleteHooks = append(nodeTextBeforeDeleteHooks, nodeTextHook)
+	case boil.AfterDeleteHook:
+		nodeTextAfterDeleteHooks = append(nodeTextAfterDeleteHooks, nodeTextHook)
+	case boil.BeforeUpsertHook:
+		nodeTextBeforeUpsertHooks = append(nodeTextBeforeUpsertHooks, nodeTextHook)
+	case boil.AfterUpsertHook:
+		nodeTextAfterUpsertHooks = append(nodeTextAfterUpsertHooks, nodeTextHook)
+	}
+}
+
+// One returns a single nodeText record from the query.
+func (q nodeTextQuery) One(ctx context.Context, exec boil.ContextExecutor) (*NodeText, error) {
+	o := &NodeText{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for node_texts")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all NodeText records from the query.
+func (q nodeTextQuery) All(ctx context.Context, exec boil.ContextExecutor) (NodeTextSlice, error) {
+	var o []*NodeText
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to NodeText slice")
+	}
+
+	if len(nodeTextAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all NodeText records in the query.
+func (q nodeTextQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count node_texts rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q nodeTextQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if node_texts exists")
+	}
+
+	return count > 0, nil
+}
+
+// Node pointed to by the foreign key.
+func (o *NodeText) Node(mods ...qm.QueryMod) nodeQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.NodeID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Nodes(queryMods...)
+}
+
+// LoadNode allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (nodeTextL) LoadNode(ctx context.Context, e boil.ContextExecutor, singular bool, maybeNodeText interface{}, mods queries.Applicator) error {
+	var slice []*NodeText
+	var object *NodeText
+
+	if singular {
+		var ok bool
+		object, ok = maybeNodeText.(*NodeText)
+		if !ok {
+			object = new(NodeText)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeNodeText)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeNodeText))
+			}
+		}
+	} else {
+		s, ok := maybeNodeText.(*[]*NodeText)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeNodeText)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeNodeText))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &nodeTextR{}
+		}
+		args = append(args, object.NodeID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &nodeTextR{}
+			}
+
+			for _, a := range args {
+				if a == obj.NodeID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.NodeID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`nodes`),
+		qm.WhereIn(`nodes.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Node")
+	}
+
+	var resultSlice []*Node
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Node")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for nodes")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for nodes")
+	}
+
+	if len(nodeAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Node = foreign
+		if foreign.R == nil {
+			foreign.R = &nodeR{}
+		}
+		foreign.R.NodeText = object
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.NodeID == foreign.ID {
+				local.R.Node = foreign
+				if foreign.R == nil {
+					foreign.R = &nodeR{}
+				}
+				foreign.R.NodeText = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetNode of the nodeText to the related item.
+// Sets o.R.Node to related.
+// Adds o to related.R.NodeText.
+func (o *NodeText) SetNode(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Node) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"node_texts\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"node_id"}),
+		strmangle.WhereClause("\"", "\"", 2, nodeTextPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.NodeID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.NodeID = related.ID
+	if o.R == nil {
+		o.R = &nodeTextR{
+			Node: related,
+		}
+	} else {
+		o.R.Node = related
+	}
+
+	if related.R == nil {
+		related.R = &nodeR{
+			NodeText: o,
+		}
+	} else {
+		related.R.NodeText = o
+	}
+
+	return nil
+}
+
+// NodeTexts retrieves all the records using an executor.
+func NodeTexts(mods ...qm.QueryMod) nodeTextQuery {
+	mods = append(mods, qm.From("\"node_texts\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"node_texts\".*"})
+	}
+
+	return nodeTextQuery{q}
+}
+
+// FindNodeText retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindNodeText(ctx context.Context, exec boil.ContextExecutor, nodeID int, selectCols ...string) (*NodeText, error) {
+	nodeTextObj := &NodeText{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"node_texts\" where \"node_id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, nodeID)
+
+	err := q.Bind(ctx, exec, nodeTextObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from node_texts")
+	}
+
+	if err = nodeTextObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return nodeTextObj, err
+	}
+
+	return nodeTextObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *NodeText) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no node_texts provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(nodeTextColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	nodeTextInsertCacheMut.RLock()
+	cache, cached := nodeTextInsertCache[key]
+	nodeTextInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			nodeTextAllColumns,
+			nodeTextColumnsWithDefault,
+			nodeTextColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(nodeTextType, nodeTextMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(nodeTextType, nodeTextMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"node_texts\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"node_texts\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into node_texts")
+	}
+
+	if !cached {
+		nodeTextInsertCacheMut.Lock()
+		nodeTextInsertCache[key] = cache
+		nodeTextInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the NodeText.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *NodeText) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	nodeTextUpdateCacheMut.RLock()
+	cache, cached := nodeTextUpdateCache[key]
+	nodeTextUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			nodeTextAllColumns,
+			nodeTextPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update node_texts, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"node_texts\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, nodeTextPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(nodeTextType, nodeTextMapping, append(wl, nodeTextPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update node_texts row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for node_texts")
+	}
+
+	if !cached {
+		nodeTextUpdateCacheMut.Lock()
+		nodeTextUpdateCache[key] = cache
+		nodeTextUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q nodeTextQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for node_texts")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for node_texts")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o NodeTextSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), nodeTextPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"node_texts\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, nodeTextPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in nodeText slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all nodeText")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *NodeText) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no node_texts provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(nodeTextColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	nodeTextUpsertCacheMut.RLock()
+	cache, cached := nodeTextUpsertCache[key]
+	nodeTextUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			nodeTextAllColumns,
+			nodeTextColumnsWithDefault,
+			nodeTextColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			nodeTextAllColumns,
+			nodeTextPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert node_texts, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(nodeTextPrimaryKeyColumns))
+			copy(conflict, nodeTextPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"node_texts\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(nodeTextType, nodeTextMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(nodeTextType, nodeTextMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert node_texts")
+	}
+
+	if !cached {
+		nodeTextUpsertCacheMut.Lock()
+		nodeTextUpsertCache[key] = cache
+		nodeTextUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single NodeText record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *NodeText) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no NodeText provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), nodeTextPrimaryKeyMapping)
+	sql := "DELETE FROM \"node_texts\" WHERE \"node_id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from node_texts")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for node_texts")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q nodeTextQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no nodeTextQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from node_texts")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for node_texts")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o NodeTextSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(nodeTextBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), nodeTextPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"node_texts\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, nodeTextPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from nodeText slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for node_texts")
+	}
+
+	if len(nodeTextAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *NodeText) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindNodeText(ctx, exec, o.NodeID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *NodeTextSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := NodeTextSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), nodeTextPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"node_texts\".* FROM \"node_texts\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, nodeTextPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in NodeTextSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// NodeTextExists checks if the NodeText row exists.
+func NodeTextExists(ctx context.Context, exec boil.ContextExecutor, nodeID int) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"node_texts\" where \"node_id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, nodeID)
+	}
+	row := exec.QueryRowContext(ctx, sql, nodeID)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if node_texts exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the NodeText row exists.
+func (o *NodeText) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return NodeTextExists(ctx, exec, o.NodeID)
+}
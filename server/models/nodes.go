@@ -1,4 +1,4 @@
-// Code generated by SQLBoiler 4.6.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// Code generated by SQLBoiler 4.14.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
 // This file is meant to be re-generated in place and/or deleted at any time.
 
 package models
@@ -24,67 +24,97 @@ import (
 
 // Node is an object representing the database table.
 type Node struct {
-	ID             int          `boil:"id" json:"id" toml:"id" yaml:"id"`
-	Name           string       `boil:"name" json:"name" toml:"name" yaml:"name"`
-	Size           null.Int64   `boil:"size" json:"size,omitempty" toml:"size" yaml:"size,omitempty"`
-	Type           string       `boil:"type" json:"type" toml:"type" yaml:"type"`
-	MimeType       string       `boil:"mime_type" json:"mime_type" toml:"mime_type" yaml:"mime_type"`
-	OwnerID        null.Int     `boil:"owner_id" json:"owner_id,omitempty" toml:"owner_id" yaml:"owner_id,omitempty"`
-	ParentID       null.Int     `boil:"parent_id" json:"parent_id,omitempty" toml:"parent_id" yaml:"parent_id,omitempty"`
-	ModifiedOn     time.Time    `boil:"modified_on" json:"modified_on" toml:"modified_on" yaml:"modified_on"`
-	HasCustomThumb bool         `boil:"has_custom_thumb" json:"has_custom_thumb" toml:"has_custom_thumb" yaml:"has_custom_thumb"`
-	Length         null.Float64 `boil:"length" json:"length,omitempty" toml:"length" yaml:"length,omitempty"`
+	ID                 int          `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Name               string       `boil:"name" json:"name" toml:"name" yaml:"name"`
+	Size               null.Int64   `boil:"size" json:"size,omitempty" toml:"size" yaml:"size,omitempty"`
+	Type               string       `boil:"type" json:"type" toml:"type" yaml:"type"`
+	MimeType           string       `boil:"mime_type" json:"mime_type" toml:"mime_type" yaml:"mime_type"`
+	OwnerID            null.Int     `boil:"owner_id" json:"owner_id,omitempty" toml:"owner_id" yaml:"owner_id,omitempty"`
+	ParentID           null.Int     `boil:"parent_id" json:"parent_id,omitempty" toml:"parent_id" yaml:"parent_id,omitempty"`
+	ModifiedOn         time.Time    `boil:"modified_on" json:"modified_on" toml:"modified_on" yaml:"modified_on"`
+	HasCustomThumb     bool         `boil:"has_custom_thumb" json:"has_custom_thumb" toml:"has_custom_thumb" yaml:"has_custom_thumb"`
+	Length             null.Float64 `boil:"length" json:"length,omitempty" toml:"length" yaml:"length,omitempty"`
+	Blob               null.String  `boil:"blob" json:"-" toml:"-" yaml:"-"`
+	Checksum           null.String  `boil:"checksum" json:"checksum,omitempty" toml:"checksum" yaml:"checksum,omitempty"`
+	ChecksumVerifiedOn null.Time    `boil:"checksum_verified_on" json:"checksum_verified_on,omitempty" toml:"checksum_verified_on" yaml:"checksum_verified_on,omitempty"`
+	ChecksumFailed     bool         `boil:"checksum_failed" json:"checksum_failed" toml:"checksum_failed" yaml:"checksum_failed"`
+	Encrypted          bool         `boil:"encrypted" json:"encrypted" toml:"encrypted" yaml:"encrypted"`
+	ThumbEncrypted     bool         `boil:"thumb_encrypted" json:"thumb_encrypted" toml:"thumb_encrypted" yaml:"thumb_encrypted"`
 
 	R *nodeR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L nodeL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var NodeColumns = struct {
-	ID             string
-	Name           string
-	Size           string
-	Type           string
-	MimeType       string
-	OwnerID        string
-	ParentID       string
-	ModifiedOn     string
-	HasCustomThumb string
-	Length         string
+	ID                 string
+	Name               string
+	Size               string
+	Type               string
+	MimeType           string
+	OwnerID            string
+	ParentID           string
+	ModifiedOn         string
+	HasCustomThumb     string
+	Length             string
+	Blob               string
+	Checksum           string
+	ChecksumVerifiedOn string
+	ChecksumFailed     string
+	Encrypted          string
+	ThumbEncrypted     string
 }{
-	ID:             "id",
-	Name:           "name",
-	Size:           "size",
-	Type:           "type",
-	MimeType:       "mime_type",
-	OwnerID:        "owner_id",
-	ParentID:       "parent_id",
-	ModifiedOn:     "modified_on",
-	HasCustomThumb: "has_custom_thumb",
-	Length:         "length",
+	ID:                 "id",
+	Name:               "name",
+	Size:               "size",
+	Type:               "type",
+	MimeType:           "mime_type",
+	OwnerID:            "owner_id",
+	ParentID:           "parent_id",
+	ModifiedOn:         "modified_on",
+	HasCustomThumb:     "has_custom_thumb",
+	Length:             "length",
+	Blob:               "blob",
+	Checksum:           "checksum",
+	ChecksumVerifiedOn: "checksum_verified_on",
+	ChecksumFailed:     "checksum_failed",
+	Encrypted:          "encrypted",
+	ThumbEncrypted:     "thumb_encrypted",
 }
 
 var NodeTableColumns = struct {
-	ID             string
-	Name           string
-	Size           string
-	Type           string
-	MimeType       string
-	OwnerID        string
-	ParentID       string
-	ModifiedOn     string
-	HasCustomThumb string
-	Length         string
+	ID                 string
+	Name               string
+	Size               string
+	Type               string
+	MimeType           string
+	OwnerID            string
+	ParentID           string
+	ModifiedOn         string
+	HasCustomThumb     string
+	Length             string
+	Blob               string
+	Checksum           string
+	ChecksumVerifiedOn string
+	ChecksumFailed     string
+	Encrypted          string
+	ThumbEncrypted     string
 }{
-	ID:             "nodes.id",
-	Name:           "nodes.name",
-	Size:           "nodes.size",
-	Type:           "nodes.type",
-	MimeType:       "nodes.mime_type",
-	OwnerID:        "nodes.owner_id",
-	ParentID:       "nodes.parent_id",
-	ModifiedOn:     "nodes.modified_on",
-	HasCustomThumb: "nodes.has_custom_thumb",
-	Length:         "nodes.length",
+	ID:                 "nodes.id",
+	Name:               "nodes.name",
+	Size:               "nodes.size",
+	Type:               "nodes.type",
+	MimeType:           "nodes.mime_type",
+	OwnerID:            "nodes.owner_id",
+	ParentID:           "nodes.parent_id",
+	ModifiedOn:         "nodes.modified_on",
+	HasCustomThumb:     "nodes.has_custom_thumb",
+	Length:             "nodes.length",
+	Blob:               "nodes.blob",
+	Checksum:           "nodes.checksum",
+	ChecksumVerifiedOn: "nodes.checksum_verified_on",
+	ChecksumFailed:     "nodes.checksum_failed",
+	Encrypted:          "nodes.encrypted",
+	ThumbEncrypted:     "nodes.thumb_encrypted",
 }
 
 // Generated where
@@ -97,8 +127,6 @@ func (w whereHelpernull_Int64) EQ(x null.Int64) qm.QueryMod {
 func (w whereHelpernull_Int64) NEQ(x null.Int64) qm.QueryMod {
 	return qmhelper.WhereNullEQ(w.field, true, x)
 }
-func (w whereHelpernull_Int64) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_Int64) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 func (w whereHelpernull_Int64) LT(x null.Int64) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LT, x)
 }
@@ -111,50 +139,23 @@ func (w whereHelpernull_Int64) GT(x null.Int64) qm.QueryMod {
 func (w whereHelpernull_Int64) GTE(x null.Int64) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
-
-type whereHelpernull_Int struct{ field string }
-
-func (w whereHelpernull_Int) EQ(x null.Int) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpernull_Int) NEQ(x null.Int) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpernull_Int) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_Int) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
-func (w whereHelpernull_Int) LT(x null.Int) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpernull_Int) LTE(x null.Int) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpernull_Int) GT(x null.Int) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
+func (w whereHelpernull_Int64) IN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
 }
-func (w whereHelpernull_Int) GTE(x null.Int) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
+func (w whereHelpernull_Int64) NIN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
 }
 
-type whereHelpertime_Time struct{ field string }
-
-func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.EQ, x)
-}
-func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.NEQ, x)
-}
-func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
+func (w whereHelpernull_Int64) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Int64) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
 type whereHelpernull_Float64 struct{ field string }
 
@@ -164,8 +165,6 @@ func (w whereHelpernull_Float64) EQ(x null.Float64) qm.QueryMod {
 func (w whereHelpernull_Float64) NEQ(x null.Float64) qm.QueryMod {
 	return qmhelper.WhereNullEQ(w.field, true, x)
 }
-func (w whereHelpernull_Float64) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_Float64) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 func (w whereHelpernull_Float64) LT(x null.Float64) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LT, x)
 }
@@ -178,59 +177,112 @@ func (w whereHelpernull_Float64) GT(x null.Float64) qm.QueryMod {
 func (w whereHelpernull_Float64) GTE(x null.Float64) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
+func (w whereHelpernull_Float64) IN(slice []float64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_Float64) NIN(slice []float64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+func (w whereHelpernull_Float64) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Float64) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
 var NodeWhere = struct {
-	ID             whereHelperint
-	Name           whereHelperstring
-	Size           whereHelpernull_Int64
-	Type           whereHelperstring
-	MimeType       whereHelperstring
-	OwnerID        whereHelpernull_Int
-	ParentID       whereHelpernull_Int
-	ModifiedOn     whereHelpertime_Time
-	HasCustomThumb whereHelperbool
-	Length         whereHelpernull_Float64
+	ID                 whereHelperint
+	Name               whereHelperstring
+	Size               whereHelpernull_Int64
+	Type               whereHelperstring
+	MimeType           whereHelperstring
+	OwnerID            whereHelpernull_Int
+	ParentID           whereHelpernull_Int
+	ModifiedOn         whereHelpertime_Time
+	HasCustomThumb     whereHelperbool
+	Length             whereHelpernull_Float64
+	Blob               whereHelpernull_String
+	Checksum           whereHelpernull_String
+	ChecksumVerifiedOn whereHelpernull_Time
+	ChecksumFailed     whereHelperbool
+	Encrypted          whereHelperbool
+	ThumbEncrypted     whereHelperbool
 }{
-	ID:             whereHelperint{field: "\"nodes\".\"id\""},
-	Name:           whereHelperstring{field: "\"nodes\".\"name\""},
-	Size:           whereHelpernull_Int64{field: "\"nodes\".\"size\""},
-	Type:           whereHelperstring{field: "\"nodes\".\"type\""},
-	MimeType:       whereHelperstring{field: "\"nodes\".\"mime_type\""},
-	OwnerID:        whereHelpernull_Int{field: "\"nodes\".\"owner_id\""},
-	ParentID:       whereHelpernull_Int{field: "\"nodes\".\"parent_id\""},
-	ModifiedOn:     whereHelpertime_Time{field: "\"nodes\".\"modified_on\""},
-	HasCustomThumb: whereHelperbool{field: "\"nodes\".\"has_custom_thumb\""},
-	Length:         whereHelpernull_Float64{field: "\"nodes\".\"length\""},
+	ID:                 whereHelperint{field: "\"nodes\".\"id\""},
+	Name:               whereHelperstring{field: "\"nodes\".\"name\""},
+	Size:               whereHelpernull_Int64{field: "\"nodes\".\"size\""},
+	Type:               whereHelperstring{field: "\"nodes\".\"type\""},
+	MimeType:           whereHelperstring{field: "\"nodes\".\"mime_type\""},
+	OwnerID:            whereHelpernull_Int{field: "\"nodes\".\"owner_id\""},
+	ParentID:           whereHelpernull_Int{field: "\"nodes\".\"parent_id\""},
+	ModifiedOn:         whereHelpertime_Time{field: "\"nodes\".\"modified_on\""},
+	HasCustomThumb:     whereHelperbool{field: "\"nodes\".\"has_custom_thumb\""},
+	Length:             whereHelpernull_Float64{field: "\"nodes\".\"length\""},
+	Blob:               whereHelpernull_String{field: "\"nodes\".\"blob\""},
+	Checksum:           whereHelpernull_String{field: "\"nodes\".\"checksum\""},
+	ChecksumVerifiedOn: whereHelpernull_Time{field: "\"nodes\".\"checksum_verified_on\""},
+	ChecksumFailed:     whereHelperbool{field: "\"nodes\".\"checksum_failed\""},
+	Encrypted:          whereHelperbool{field: "\"nodes\".\"encrypted\""},
+	ThumbEncrypted:     whereHelperbool{field: "\"nodes\".\"thumb_encrypted\""},
 }
 
 // NodeRels is where relationship names are stored.
 var NodeRels = struct {
-	Owner           string
-	Parent          string
-	Infos           string
-	NodeProcessReqs string
-	ParentNodes     string
-	Progresses      string
-	RootUsers       string
+	Owner                 string
+	Parent                string
+	NodeBlob              string
+	NodeProcessReq        string
+	NodeText              string
+	Trash                 string
+	Grants                string
+	Infos                 string
+	ParentNodes           string
+	Progresses            string
+	Shares                string
+	OriginalParentTrashes string
+	RootUsers             string
+	TrashUsers            string
+	Versions              string
 }{
-	Owner:           "Owner",
-	Parent:          "Parent",
-	Infos:           "Infos",
-	NodeProcessReqs: "NodeProcessReqs",
-	ParentNodes:     "ParentNodes",
-	Progresses:      "Progresses",
-	RootUsers:       "RootUsers",
+	Owner:                 "Owner",
+	Parent:                "Parent",
+	NodeBlob:              "NodeBlob",
+	NodeProcessReq:        "NodeProcessReq",
+	NodeText:              "NodeText",
+	Trash:                 "Trash",
+	Grants:                "Grants",
+	Infos:                 "Infos",
+	ParentNodes:           "ParentNodes",
+	Progresses:            "Progresses",
+	Shares:                "Shares",
+	OriginalParentTrashes: "OriginalParentTrashes",
+	RootUsers:             "RootUsers",
+	TrashUsers:            "TrashUsers",
+	Versions:              "Versions",
 }
 
 // nodeR is where relationships are stored.
 type nodeR struct {
-	Owner           *User               `boil:"Owner" json:"Owner" toml:"Owner" yaml:"Owner"`
-	Parent          *Node               `boil:"Parent" json:"Parent" toml:"Parent" yaml:"Parent"`
-	Infos           InfoSlice           `boil:"Infos" json:"Infos" toml:"Infos" yaml:"Infos"`
-	NodeProcessReqs NodeProcessReqSlice `boil:"NodeProcessReqs" json:"NodeProcessReqs" toml:"NodeProcessReqs" yaml:"NodeProcessReqs"`
-	ParentNodes     NodeSlice           `boil:"ParentNodes" json:"ParentNodes" toml:"ParentNodes" yaml:"ParentNodes"`
-	Progresses      ProgressSlice       `boil:"Progresses" json:"Progresses" toml:"Progresses" yaml:"Progresses"`
-	RootUsers       UserSlice           `boil:"RootUsers" json:"RootUsers" toml:"RootUsers" yaml:"RootUsers"`
+	Owner                 *User           `boil:"Owner" json:"Owner" toml:"Owner" yaml:"Owner"`
+	Parent                *Node           `boil:"Parent" json:"Parent" toml:"Parent" yaml:"Parent"`
+	NodeBlob              *Blob           `boil:"NodeBlob" json:"NodeBlob" toml:"NodeBlob" yaml:"NodeBlob"`
+	NodeProcessReq        *NodeProcessReq `boil:"NodeProcessReq" json:"NodeProcessReq" toml:"NodeProcessReq" yaml:"NodeProcessReq"`
+	NodeText              *NodeText       `boil:"NodeText" json:"NodeText" toml:"NodeText" yaml:"NodeText"`
+	Trash                 *Trash          `boil:"Trash" json:"Trash" toml:"Trash" yaml:"Trash"`
+	Grants                GrantSlice      `boil:"Grants" json:"Grants" toml:"Grants" yaml:"Grants"`
+	Infos                 InfoSlice       `boil:"Infos" json:"Infos" toml:"Infos" yaml:"Infos"`
+	ParentNodes           NodeSlice       `boil:"ParentNodes" json:"ParentNodes" toml:"ParentNodes" yaml:"ParentNodes"`
+	Progresses            ProgressSlice   `boil:"Progresses" json:"Progresses" toml:"Progresses" yaml:"Progresses"`
+	Shares                ShareSlice      `boil:"Shares" json:"Shares" toml:"Shares" yaml:"Shares"`
+	OriginalParentTrashes TrashSlice      `boil:"OriginalParentTrashes" json:"OriginalParentTrashes" toml:"OriginalParentTrashes" yaml:"OriginalParentTrashes"`
+	RootUsers             UserSlice       `boil:"RootUsers" json:"RootUsers" toml:"RootUsers" yaml:"RootUsers"`
+	TrashUsers            UserSlice       `boil:"TrashUsers" json:"TrashUsers" toml:"TrashUsers" yaml:"TrashUsers"`
+	Versions              VersionSlice    `boil:"Versions" json:"Versions" toml:"Versions" yaml:"Versions"`
 }
 
 // NewStruct creates a new relationship struct
@@ -238,14 +290,120 @@ func (*nodeR) NewStruct() *nodeR {
 	return &nodeR{}
 }
 
+func (r *nodeR) GetOwner() *User {
+	if r == nil {
+		return nil
+	}
+	return r.Owner
+}
+
+func (r *nodeR) GetParent() *Node {
+	if r == nil {
+		return nil
+	}
+	return r.Parent
+}
+
+func (r *nodeR) GetNodeBlob() *Blob {
+	if r == nil {
+		return nil
+	}
+	return r.NodeBlob
+}
+
+func (r *nodeR) GetNodeProcessReq() *NodeProcessReq {
+	if r == nil {
+		return nil
+	}
+	return r.NodeProcessReq
+}
+
+func (r *nodeR) GetNodeText() *NodeText {
+	if r == nil {
+		return nil
+	}
+	return r.NodeText
+}
+
+func (r *nodeR) GetTrash() *Trash {
+	if r == nil {
+		return nil
+	}
+	return r.Trash
+}
+
+func (r *nodeR) GetGrants() GrantSlice {
+	if r == nil {
+		return nil
+	}
+	return r.Grants
+}
+
+func (r *nodeR) GetInfos() InfoSlice {
+	if r == nil {
+		return nil
+	}
+	return r.Infos
+}
+
+func (r *nodeR) GetParentNodes() NodeSlice {
+	if r == nil {
+		return nil
+	}
+	return r.ParentNodes
+}
+
+func (r *nodeR) GetProgresses() ProgressSlice {
+	if r == nil {
+		return nil
+	}
+	return r.Progresses
+}
+
+func (r *nodeR) GetShares() ShareSlice {
+	if r == nil {
+		return nil
+	}
+	return r.Shares
+}
+
+func (r *nodeR) GetOriginalParentTrashes() TrashSlice {
+	if r == nil {
+		return nil
+	}
+	return r.OriginalParentTrashes
+}
+
+func (r *nodeR) GetRootUsers() UserSlice {
+	if r == nil {
+		return nil
+	}
+	return r.RootUsers
+}
+
+func (r *nodeR) GetTrashUsers() UserSlice {
+	if r == nil {
+		return nil
+	}
+	return r.TrashUsers
+}
+
+func (r *nodeR) GetVersions() VersionSlice {
+	if r == nil {
+		return nil
+	}
+	return r.Versions
+}
+
 // nodeL is where Load methods for each relationship are stored.
 type nodeL struct{}
 
 var (
-	nodeAllColumns            = []string{"id", "name", "size", "type", "mime_type", "owner_id", "parent_id", "modified_on", "has_custom_thumb", "length"}
-	nodeColumnsWithoutDefault = []string{"name", "size", "type", "mime_type", "owner_id", "parent_id", "length"}
-	nodeColumnsWithDefault    = []string{"id", "modified_on", "has_custom_thumb"}
+	nodeAllColumns            = []string{"id", "name", "size", "type", "mime_type", "owner_id", "parent_id", "modified_on", "has_custom_thumb", "length", "blob", "checksum", "checksum_verified_on", "checksum_failed", "encrypted", "thumb_encrypted"}
+	nodeColumnsWithoutDefault = []string{"name", "type", "mime_type"}
+	nodeColumnsWithDefault    = []string{"id", "size", "owner_id", "parent_id", "modified_on", "has_custom_thumb", "length", "blob", "checksum", "checksum_verified_on", "checksum_failed", "encrypted", "thumb_encrypted"}
 	nodePrimaryKeyColumns     = []string{"id"}
+	nodeGeneratedColumns      = []string{}
 )
 
 type (
@@ -281,24 +439,27 @@ var (
 	_ = qmhelper.Where
 )
 
-var nodeBeforeInsertHooks []NodeHook
-var nodeBeforeUpdateHooks []NodeHook
-var nodeBeforeDeleteHooks []NodeHook
-var nodeBeforeUpsertHooks []NodeHook
+var nodeAfterSelectHooks []NodeHook
 
+var nodeBeforeInsertHooks []NodeHook
 var nodeAfterInsertHooks []NodeHook
-var nodeAfterSelectHooks []NodeHook
+
+var nodeBeforeUpdateHooks []NodeHook
 var nodeAfterUpdateHooks []NodeHook
+
+var nodeBeforeDeleteHooks []NodeHook
 var nodeAfterDeleteHooks []NodeHook
+
+var nodeBeforeUpsertHooks []NodeHook
 var nodeAfterUpsertHooks []NodeHook
 
-// doBeforeInsertHooks executes all "before insert" hooks.
-func (o *Node) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *Node) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range nodeBeforeInsertHooks {
+	for _, hook := range nodeAfterSelectHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -307,13 +468,13 @@ func (o *Node) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecuto
 	return nil
 }
 
-// doBeforeUpdateHooks executes all "before Update" hooks.
-func (o *Node) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *Node) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range nodeBeforeUpdateHooks {
+	for _, hook := range nodeBeforeInsertHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -322,13 +483,13 @@ func (o *Node) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecuto
 	return nil
 }
 
-// doBeforeDeleteHooks executes all "before Delete" hooks.
-func (o *Node) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *Node) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range nodeBeforeDeleteHooks {
+	for _, hook := range nodeAfterInsertHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -337,13 +498,13 @@ func (o *Node) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecuto
 	return nil
 }
 
-// doBeforeUpsertHooks executes all "before Upsert" hooks.
-func (o *Node) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *Node) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range nodeBeforeUpsertHooks {
+	for _, hook := range nodeBeforeUpdateHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -352,13 +513,13 @@ func (o *Node) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecuto
 	return nil
 }
 
-// doAfterInsertHooks executes all "after Insert" hooks.
-func (o *Node) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *Node) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range nodeAfterInsertHooks {
+	for _, hook := range nodeAfterUpdateHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -367,13 +528,13 @@ func (o *Node) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor
 	return nil
 }
 
-// doAfterSelectHooks executes all "after Select" hooks.
-func (o *Node) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *Node) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range nodeAfterSelectHooks {
+	for _, hook := range nodeBeforeDeleteHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -382,13 +543,13 @@ func (o *Node) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor
 	return nil
 }
 
-// doAfterUpdateHooks executes all "after Update" hooks.
-func (o *Node) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *Node) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range nodeAfterUpdateHooks {
+	for _, hook := range nodeAfterDeleteHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -397,13 +558,13 @@ func (o *Node) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor
 	return nil
 }
 
-// doAfterDeleteHooks executes all "after Delete" hooks.
-func (o *Node) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *Node) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range nodeAfterDeleteHooks {
+	for _, hook := range nodeBeforeUpsertHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -430,22 +591,22 @@ func (o *Node) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor
 // AddNodeHook registers your hook function for all future operations.
 func AddNodeHook(hookPoint boil.HookPoint, nodeHook NodeHook) {
 	switch hookPoint {
+	case boil.AfterSelectHook:
+		nodeAfterSelectHooks = append(nodeAfterSelectHooks, nodeHook)
 	case boil.BeforeInsertHook:
 		nodeBeforeInsertHooks = append(nodeBeforeInsertHooks, nodeHook)
-	case boil.BeforeUpdateHook:
-		nodeBeforeUpdateHooks = append(nodeBeforeUpdateHooks, nodeHook)
-	case boil.BeforeDeleteHook:
-		nodeBeforeDeleteHooks = append(nodeBeforeDeleteHooks, nodeHook)
-	case boil.BeforeUpsertHook:
-		nodeBeforeUpsertHooks = append(nodeBeforeUpsertHooks, nodeHook)
 	case boil.AfterInsertHook:
 		nodeAfterInsertHooks = append(nodeAfterInsertHooks, nodeHook)
-	case boil.AfterSelectHook:
-		nodeAfterSelectHooks = append(nodeAfterSelectHooks, nodeHook)
+	case boil.BeforeUpdateHook:
+		nodeBeforeUpdateHooks = append(nodeBeforeUpdateHooks, nodeHook)
 	case boil.AfterUpdateHook:
 		nodeAfterUpdateHooks = append(nodeAfterUpdateHooks, nodeHook)
+	case boil.BeforeDeleteHook:
+		nodeBeforeDeleteHooks = append(nodeBeforeDeleteHooks, nodeHook)
 	case boil.AfterDeleteHook:
 		nodeAfterDeleteHooks = append(nodeAfterDeleteHooks, nodeHook)
+	case boil.BeforeUpsertHook:
+		nodeBeforeUpsertHooks = append(nodeBeforeUpsertHooks, nodeHook)
 	case boil.AfterUpsertHook:
 		nodeAfterUpsertHooks = append(nodeAfterUpsertHooks, nodeHook)
 	}
@@ -459,7 +620,7 @@ func (q nodeQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Node, e
 
 	err := q.Bind(ctx, exec, o)
 	if err != nil {
-		if errors.Cause(err) == sql.ErrNoRows {
+		if errors.Is(err, sql.ErrNoRows) {
 			return nil, sql.ErrNoRows
 		}
 		return nil, errors.Wrap(err, "models: failed to execute a one query for nodes")
@@ -531,10 +692,7 @@ func (o *Node) Owner(mods ...qm.QueryMod) userQuery {
 
 	queryMods = append(queryMods, mods...)
 
-	query := Users(queryMods...)
-	queries.SetFrom(query.Query, "\"users\"")
-
-	return query
+	return Users(queryMods...)
 }
 
 // Parent pointed to by the foreign key.
@@ -545,52 +703,79 @@ func (o *Node) Parent(mods ...qm.QueryMod) nodeQuery {
 
 	queryMods = append(queryMods, mods...)
 
-	query := Nodes(queryMods...)
-	queries.SetFrom(query.Query, "\"nodes\"")
+	return Nodes(queryMods...)
+}
+
+// NodeBlob pointed to by the foreign key.
+func (o *Node) NodeBlob(mods ...qm.QueryMod) blobQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"hash\" = ?", o.Blob),
+	}
 
-	return query
+	queryMods = append(queryMods, mods...)
+
+	return Blobs(queryMods...)
 }
 
-// Infos retrieves all the info's Infos with an executor.
-func (o *Node) Infos(mods ...qm.QueryMod) infoQuery {
-	var queryMods []qm.QueryMod
-	if len(mods) != 0 {
-		queryMods = append(queryMods, mods...)
+// NodeProcessReq pointed to by the foreign key.
+func (o *Node) NodeProcessReq(mods ...qm.QueryMod) nodeProcessReqQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"node_id\" = ?", o.ID),
 	}
 
-	queryMods = append(queryMods,
-		qm.Where("\"infos\".\"node_id\"=?", o.ID),
-	)
+	queryMods = append(queryMods, mods...)
 
-	query := Infos(queryMods...)
-	queries.SetFrom(query.Query, "\"infos\"")
+	return NodeProcessReqs(queryMods...)
+}
+
+// NodeText pointed to by the foreign key.
+func (o *Node) NodeText(mods ...qm.QueryMod) nodeTextQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"node_id\" = ?", o.ID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return NodeTexts(queryMods...)
+}
 
-	if len(queries.GetSelect(query.Query)) == 0 {
-		queries.SetSelect(query.Query, []string{"\"infos\".*"})
+// Trash pointed to by the foreign key.
+func (o *Node) Trash(mods ...qm.QueryMod) trashQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"node_id\" = ?", o.ID),
 	}
 
-	return query
+	queryMods = append(queryMods, mods...)
+
+	return Trashes(queryMods...)
 }
 
-// NodeProcessReqs retrieves all the node_process_req's NodeProcessReqs with an executor.
-func (o *Node) NodeProcessReqs(mods ...qm.QueryMod) nodeProcessReqQuery {
+// Grants retrieves all the grant's Grants with an executor.
+func (o *Node) Grants(mods ...qm.QueryMod) grantQuery {
 	var queryMods []qm.QueryMod
 	if len(mods) != 0 {
 		queryMods = append(queryMods, mods...)
 	}
 
 	queryMods = append(queryMods,
-		qm.Where("\"node_process_reqs\".\"node_id\"=?", o.ID),
+		qm.Where("\"grants\".\"node_id\"=?", o.ID),
 	)
 
-	query := NodeProcessReqs(queryMods...)
-	queries.SetFrom(query.Query, "\"node_process_reqs\"")
+	return Grants(queryMods...)
+}
 
-	if len(queries.GetSelect(query.Query)) == 0 {
-		queries.SetSelect(query.Query, []string{"\"node_process_reqs\".*"})
+// Infos retrieves all the info's Infos with an executor.
+func (o *Node) Infos(mods ...qm.QueryMod) infoQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
 	}
 
-	return query
+	queryMods = append(queryMods,
+		qm.Where("\"infos\".\"node_id\"=?", o.ID),
+	)
+
+	return Infos(queryMods...)
 }
 
 // ParentNodes retrieves all the node's Nodes with an executor via parent_id column.
@@ -604,14 +789,7 @@ func (o *Node) ParentNodes(mods ...qm.QueryMod) nodeQuery {
 		qm.Where("\"nodes\".\"parent_id\"=?", o.ID),
 	)
 
-	query := Nodes(queryMods...)
-	queries.SetFrom(query.Query, "\"nodes\"")
-
-	if len(queries.GetSelect(query.Query)) == 0 {
-		queries.SetSelect(query.Query, []string{"\"nodes\".*"})
-	}
-
-	return query
+	return Nodes(queryMods...)
 }
 
 // Progresses retrieves all the progress's Progresses with an executor.
@@ -625,14 +803,35 @@ func (o *Node) Progresses(mods ...qm.QueryMod) progressQuery {
 		qm.Where("\"progress\".\"node_id\"=?", o.ID),
 	)
 
-	query := Progresses(queryMods...)
-	queries.SetFrom(query.Query, "\"progress\"")
+	return Progresses(queryMods...)
+}
+
+// Shares retrieves all the share's Shares with an executor.
+func (o *Node) Shares(mods ...qm.QueryMod) shareQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"shares\".\"node_id\"=?", o.ID),
+	)
+
+	return Shares(queryMods...)
+}
 
-	if len(queries.GetSelect(query.Query)) == 0 {
-		queries.SetSelect(query.Query, []string{"\"progress\".*"})
+// OriginalParentTrashes retrieves all the trash's Trashes with an executor via original_parent_id column.
+func (o *Node) OriginalParentTrashes(mods ...qm.QueryMod) trashQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
 	}
 
-	return query
+	queryMods = append(queryMods,
+		qm.Where("\"trash\".\"original_parent_id\"=?", o.ID),
+	)
+
+	return Trashes(queryMods...)
 }
 
 // RootUsers retrieves all the user's Users with an executor via root_id column.
@@ -646,14 +845,35 @@ func (o *Node) RootUsers(mods ...qm.QueryMod) userQuery {
 		qm.Where("\"users\".\"root_id\"=?", o.ID),
 	)
 
-	query := Users(queryMods...)
-	queries.SetFrom(query.Query, "\"users\"")
+	return Users(queryMods...)
+}
+
+// TrashUsers retrieves all the user's Users with an executor via trash_id column.
+func (o *Node) TrashUsers(mods ...qm.QueryMod) userQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"users\".\"trash_id\"=?", o.ID),
+	)
+
+	return Users(queryMods...)
+}
 
-	if len(queries.GetSelect(query.Query)) == 0 {
-		queries.SetSelect(query.Query, []string{"\"users\".*"})
+// Versions retrieves all the version's Versions with an executor.
+func (o *Node) Versions(mods ...qm.QueryMod) versionQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
 	}
 
-	return query
+	queryMods = append(queryMods,
+		qm.Where("\"versions\".\"node_id\"=?", o.ID),
+	)
+
+	return Versions(queryMods...)
 }
 
 // LoadOwner allows an eager lookup of values, cached into the
@@ -663,9 +883,25 @@ func (nodeL) LoadOwner(ctx context.Context, e boil.ContextExecutor, singular boo
 	var object *Node
 
 	if singular {
-		object = maybeNode.(*Node)
+		var ok bool
+		object, ok = maybeNode.(*Node)
+		if !ok {
+			object = new(Node)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeNode)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeNode))
+			}
+		}
 	} else {
-		slice = *maybeNode.(*[]*Node)
+		s, ok := maybeNode.(*[]*Node)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeNode)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeNode))
+			}
+		}
 	}
 
 	args := make([]interface{}, 0, 1)
@@ -726,7 +962,7 @@ func (nodeL) LoadOwner(ctx context.Context, e boil.ContextExecutor, singular boo
 		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
 	}
 
-	if len(nodeAfterSelectHooks) != 0 {
+	if len(userAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
@@ -771,9 +1007,25 @@ func (nodeL) LoadParent(ctx context.Context, e boil.ContextExecutor, singular bo
 	var object *Node
 
 	if singular {
-		object = maybeNode.(*Node)
+		var ok bool
+		object, ok = maybeNode.(*Node)
+		if !ok {
+			object = new(Node)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeNode)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeNode))
+			}
+		}
 	} else {
-		slice = *maybeNode.(*[]*Node)
+		s, ok := maybeNode.(*[]*Node)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeNode)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeNode))
+			}
+		}
 	}
 
 	args := make([]interface{}, 0, 1)
@@ -872,16 +1124,32 @@ func (nodeL) LoadParent(ctx context.Context, e boil.ContextExecutor, singular bo
 	return nil
 }
 
-// LoadInfos allows an eager lookup of values, cached into the
-// loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (nodeL) LoadInfos(ctx context.Context, e boil.ContextExecutor, singular bool, maybeNode interface{}, mods queries.Applicator) error {
+// LoadNodeBlob allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (nodeL) LoadNodeBlob(ctx context.Context, e boil.ContextExecutor, singular bool, maybeNode interface{}, mods queries.Applicator) error {
 	var slice []*Node
 	var object *Node
 
 	if singular {
-		object = maybeNode.(*Node)
+		var ok bool
+		object, ok = maybeNode.(*Node)
+		if !ok {
+			object = new(Node)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeNode)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeNode))
+			}
+		}
 	} else {
-		slice = *maybeNode.(*[]*Node)
+		s, ok := maybeNode.(*[]*Node)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeNode)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeNode))
+			}
+		}
 	}
 
 	args := make([]interface{}, 0, 1)
@@ -889,7 +1157,10 @@ func (nodeL) LoadInfos(ctx context.Context, e boil.ContextExecutor, singular boo
 		if object.R == nil {
 			object.R = &nodeR{}
 		}
-		args = append(args, object.ID)
+		if !queries.IsNil(object.Blob) {
+			args = append(args, object.Blob)
+		}
+
 	} else {
 	Outer:
 		for _, obj := range slice {
@@ -898,12 +1169,15 @@ func (nodeL) LoadInfos(ctx context.Context, e boil.ContextExecutor, singular boo
 			}
 
 			for _, a := range args {
-				if a == obj.ID {
+				if queries.Equal(a, obj.Blob) {
 					continue Outer
 				}
 			}
 
-			args = append(args, obj.ID)
+			if !queries.IsNil(obj.Blob) {
+				args = append(args, obj.Blob)
+			}
+
 		}
 	}
 
@@ -912,8 +1186,8 @@ func (nodeL) LoadInfos(ctx context.Context, e boil.ContextExecutor, singular boo
 	}
 
 	query := NewQuery(
-		qm.From(`infos`),
-		qm.WhereIn(`infos.node_id in ?`, args...),
+		qm.From(`blobs`),
+		qm.WhereIn(`blobs.hash in ?`, args...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -921,47 +1195,51 @@ func (nodeL) LoadInfos(ctx context.Context, e boil.ContextExecutor, singular boo
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load infos")
+		return errors.Wrap(err, "failed to eager load Blob")
 	}
 
-	var resultSlice []*Info
+	var resultSlice []*Blob
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice infos")
+		return errors.Wrap(err, "failed to bind eager loaded slice Blob")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on infos")
+		return errors.Wrap(err, "failed to close results of eager load for blobs")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for infos")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for blobs")
 	}
 
-	if len(infoAfterSelectHooks) != 0 {
+	if len(blobAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
 			}
 		}
 	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
 	if singular {
-		object.R.Infos = resultSlice
-		for _, foreign := range resultSlice {
-			if foreign.R == nil {
-				foreign.R = &infoR{}
-			}
-			foreign.R.Node = object
+		foreign := resultSlice[0]
+		object.R.NodeBlob = foreign
+		if foreign.R == nil {
+			foreign.R = &blobR{}
 		}
+		foreign.R.Nodes = append(foreign.R.Nodes, object)
 		return nil
 	}
 
-	for _, foreign := range resultSlice {
-		for _, local := range slice {
-			if local.ID == foreign.NodeID {
-				local.R.Infos = append(local.R.Infos, foreign)
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.Blob, foreign.Hash) {
+				local.R.NodeBlob = foreign
 				if foreign.R == nil {
-					foreign.R = &infoR{}
+					foreign.R = &blobR{}
 				}
-				foreign.R.Node = local
+				foreign.R.Nodes = append(foreign.R.Nodes, local)
 				break
 			}
 		}
@@ -970,16 +1248,32 @@ func (nodeL) LoadInfos(ctx context.Context, e boil.ContextExecutor, singular boo
 	return nil
 }
 
-// LoadNodeProcessReqs allows an eager lookup of values, cached into the
-// loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (nodeL) LoadNodeProcessReqs(ctx context.Context, e boil.ContextExecutor, singular bool, maybeNode interface{}, mods queries.Applicator) error {
+// LoadNodeProcessReq allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-1 relationship.
+func (nodeL) LoadNodeProcessReq(ctx context.Context, e boil.ContextExecutor, singular bool, maybeNode interface{}, mods queries.Applicator) error {
 	var slice []*Node
 	var object *Node
 
 	if singular {
-		object = maybeNode.(*Node)
+		var ok bool
+		object, ok = maybeNode.(*Node)
+		if !ok {
+			object = new(Node)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeNode)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeNode))
+			}
+		}
 	} else {
-		slice = *maybeNode.(*[]*Node)
+		s, ok := maybeNode.(*[]*Node)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeNode)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeNode))
+			}
+		}
 	}
 
 	args := make([]interface{}, 0, 1)
@@ -1019,16 +1313,16 @@ func (nodeL) LoadNodeProcessReqs(ctx context.Context, e boil.ContextExecutor, si
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load node_process_reqs")
+		return errors.Wrap(err, "failed to eager load NodeProcessReq")
 	}
 
 	var resultSlice []*NodeProcessReq
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice node_process_reqs")
+		return errors.Wrap(err, "failed to bind eager loaded slice NodeProcessReq")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on node_process_reqs")
+		return errors.Wrap(err, "failed to close results of eager load for node_process_reqs")
 	}
 	if err = results.Err(); err != nil {
 		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for node_process_reqs")
@@ -1041,21 +1335,24 @@ func (nodeL) LoadNodeProcessReqs(ctx context.Context, e boil.ContextExecutor, si
 			}
 		}
 	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
 	if singular {
-		object.R.NodeProcessReqs = resultSlice
-		for _, foreign := range resultSlice {
-			if foreign.R == nil {
-				foreign.R = &nodeProcessReqR{}
-			}
-			foreign.R.Node = object
+		foreign := resultSlice[0]
+		object.R.NodeProcessReq = foreign
+		if foreign.R == nil {
+			foreign.R = &nodeProcessReqR{}
 		}
-		return nil
+		foreign.R.Node = object
 	}
 
-	for _, foreign := range resultSlice {
-		for _, local := range slice {
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
 			if local.ID == foreign.NodeID {
-				local.R.NodeProcessReqs = append(local.R.NodeProcessReqs, foreign)
+				local.R.NodeProcessReq = foreign
 				if foreign.R == nil {
 					foreign.R = &nodeProcessReqR{}
 				}
@@ -1068,16 +1365,32 @@ func (nodeL) LoadNodeProcessReqs(ctx context.Context, e boil.ContextExecutor, si
 	return nil
 }
 
-// LoadParentNodes allows an eager lookup of values, cached into the
-// loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (nodeL) LoadParentNodes(ctx context.Context, e boil.ContextExecutor, singular bool, maybeNode interface{}, mods queries.Applicator) error {
+// LoadNodeText allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-1 relationship.
+func (nodeL) LoadNodeText(ctx context.Context, e boil.ContextExecutor, singular bool, maybeNode interface{}, mods queries.Applicator) error {
 	var slice []*Node
 	var object *Node
 
 	if singular {
-		object = maybeNode.(*Node)
+		var ok bool
+		object, ok = maybeNode.(*Node)
+		if !ok {
+			object = new(Node)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeNode)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeNode))
+			}
+		}
 	} else {
-		slice = *maybeNode.(*[]*Node)
+		s, ok := maybeNode.(*[]*Node)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeNode)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeNode))
+			}
+		}
 	}
 
 	args := make([]interface{}, 0, 1)
@@ -1094,7 +1407,7 @@ func (nodeL) LoadParentNodes(ctx context.Context, e boil.ContextExecutor, singul
 			}
 
 			for _, a := range args {
-				if queries.Equal(a, obj.ID) {
+				if a == obj.ID {
 					continue Outer
 				}
 			}
@@ -1108,8 +1421,8 @@ func (nodeL) LoadParentNodes(ctx context.Context, e boil.ContextExecutor, singul
 	}
 
 	query := NewQuery(
-		qm.From(`nodes`),
-		qm.WhereIn(`nodes.parent_id in ?`, args...),
+		qm.From(`node_texts`),
+		qm.WhereIn(`node_texts.node_id in ?`, args...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -1117,47 +1430,50 @@ func (nodeL) LoadParentNodes(ctx context.Context, e boil.ContextExecutor, singul
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load nodes")
+		return errors.Wrap(err, "failed to eager load NodeText")
 	}
 
-	var resultSlice []*Node
+	var resultSlice []*NodeText
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice nodes")
+		return errors.Wrap(err, "failed to bind eager loaded slice NodeText")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on nodes")
+		return errors.Wrap(err, "failed to close results of eager load for node_texts")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for nodes")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for node_texts")
 	}
 
-	if len(nodeAfterSelectHooks) != 0 {
+	if len(nodeTextAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
 			}
 		}
 	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
 	if singular {
-		object.R.ParentNodes = resultSlice
-		for _, foreign := range resultSlice {
-			if foreign.R == nil {
-				foreign.R = &nodeR{}
-			}
-			foreign.R.Parent = object
+		foreign := resultSlice[0]
+		object.R.NodeText = foreign
+		if foreign.R == nil {
+			foreign.R = &nodeTextR{}
 		}
-		return nil
+		foreign.R.Node = object
 	}
 
-	for _, foreign := range resultSlice {
-		for _, local := range slice {
-			if queries.Equal(local.ID, foreign.ParentID) {
-				local.R.ParentNodes = append(local.R.ParentNodes, foreign)
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.ID == foreign.NodeID {
+				local.R.NodeText = foreign
 				if foreign.R == nil {
-					foreign.R = &nodeR{}
+					foreign.R = &nodeTextR{}
 				}
-				foreign.R.Parent = local
+				foreign.R.Node = local
 				break
 			}
 		}
@@ -1166,16 +1482,32 @@ func (nodeL) LoadParentNodes(ctx context.Context, e boil.ContextExecutor, singul
 	return nil
 }
 
-// LoadProgresses allows an eager lookup of values, cached into the
-// loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (nodeL) LoadProgresses(ctx context.Context, e boil.ContextExecutor, singular bool, maybeNode interface{}, mods queries.Applicator) error {
+// LoadTrash allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-1 relationship.
+func (nodeL) LoadTrash(ctx context.Context, e boil.ContextExecutor, singular bool, maybeNode interface{}, mods queries.Applicator) error {
 	var slice []*Node
 	var object *Node
 
 	if singular {
-		object = maybeNode.(*Node)
+		var ok bool
+		object, ok = maybeNode.(*Node)
+		if !ok {
+			object = new(Node)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeNode)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeNode))
+			}
+		}
 	} else {
-		slice = *maybeNode.(*[]*Node)
+		s, ok := maybeNode.(*[]*Node)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeNode)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeNode))
+			}
+		}
 	}
 
 	args := make([]interface{}, 0, 1)
@@ -1206,8 +1538,8 @@ func (nodeL) LoadProgresses(ctx context.Context, e boil.ContextExecutor, singula
 	}
 
 	query := NewQuery(
-		qm.From(`progress`),
-		qm.WhereIn(`progress.node_id in ?`, args...),
+		qm.From(`trash`),
+		qm.WhereIn(`trash.node_id in ?`, args...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -1215,45 +1547,48 @@ func (nodeL) LoadProgresses(ctx context.Context, e boil.ContextExecutor, singula
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load progress")
+		return errors.Wrap(err, "failed to eager load Trash")
 	}
 
-	var resultSlice []*Progress
+	var resultSlice []*Trash
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice progress")
+		return errors.Wrap(err, "failed to bind eager loaded slice Trash")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on progress")
+		return errors.Wrap(err, "failed to close results of eager load for trash")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for progress")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for trash")
 	}
 
-	if len(progressAfterSelectHooks) != 0 {
+	if len(trashAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
 			}
 		}
 	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
 	if singular {
-		object.R.Progresses = resultSlice
-		for _, foreign := range resultSlice {
-			if foreign.R == nil {
-				foreign.R = &progressR{}
-			}
-			foreign.R.Node = object
+		foreign := resultSlice[0]
+		object.R.Trash = foreign
+		if foreign.R == nil {
+			foreign.R = &trashR{}
 		}
-		return nil
+		foreign.R.Node = object
 	}
 
-	for _, foreign := range resultSlice {
-		for _, local := range slice {
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
 			if local.ID == foreign.NodeID {
-				local.R.Progresses = append(local.R.Progresses, foreign)
+				local.R.Trash = foreign
 				if foreign.R == nil {
-					foreign.R = &progressR{}
+					foreign.R = &trashR{}
 				}
 				foreign.R.Node = local
 				break
@@ -1264,16 +1599,32 @@ func (nodeL) LoadProgresses(ctx context.Context, e boil.ContextExecutor, singula
 	return nil
 }
 
-// LoadRootUsers allows an eager lookup of values, cached into the
+// LoadGrants allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (nodeL) LoadRootUsers(ctx context.Context, e boil.ContextExecutor, singular bool, maybeNode interface{}, mods queries.Applicator) error {
+func (nodeL) LoadGrants(ctx context.Context, e boil.ContextExecutor, singular bool, maybeNode interface{}, mods queries.Applicator) error {
 	var slice []*Node
 	var object *Node
 
 	if singular {
-		object = maybeNode.(*Node)
+		var ok bool
+		object, ok = maybeNode.(*Node)
+		if !ok {
+			object = new(Node)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeNode)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeNode))
+			}
+		}
 	} else {
-		slice = *maybeNode.(*[]*Node)
+		s, ok := maybeNode.(*[]*Node)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeNode)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeNode))
+			}
+		}
 	}
 
 	args := make([]interface{}, 0, 1)
@@ -1290,7 +1641,7 @@ func (nodeL) LoadRootUsers(ctx context.Context, e boil.ContextExecutor, singular
 			}
 
 			for _, a := range args {
-				if queries.Equal(a, obj.ID) {
+				if a == obj.ID {
 					continue Outer
 				}
 			}
@@ -1304,8 +1655,8 @@ func (nodeL) LoadRootUsers(ctx context.Context, e boil.ContextExecutor, singular
 	}
 
 	query := NewQuery(
-		qm.From(`users`),
-		qm.WhereIn(`users.root_id in ?`, args...),
+		qm.From(`grants`),
+		qm.WhereIn(`grants.node_id in ?`, args...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -1313,22 +1664,22 @@ func (nodeL) LoadRootUsers(ctx context.Context, e boil.ContextExecutor, singular
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load users")
+		return errors.Wrap(err, "failed to eager load grants")
 	}
 
-	var resultSlice []*User
+	var resultSlice []*Grant
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice users")
+		return errors.Wrap(err, "failed to bind eager loaded slice grants")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on users")
+		return errors.Wrap(err, "failed to close results in eager load on grants")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for grants")
 	}
 
-	if len(userAfterSelectHooks) != 0 {
+	if len(grantAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
@@ -1336,24 +1687,24 @@ func (nodeL) LoadRootUsers(ctx context.Context, e boil.ContextExecutor, singular
 		}
 	}
 	if singular {
-		object.R.RootUsers = resultSlice
+		object.R.Grants = resultSlice
 		for _, foreign := range resultSlice {
 			if foreign.R == nil {
-				foreign.R = &userR{}
+				foreign.R = &grantR{}
 			}
-			foreign.R.Root = object
+			foreign.R.Node = object
 		}
 		return nil
 	}
 
 	for _, foreign := range resultSlice {
 		for _, local := range slice {
-			if queries.Equal(local.ID, foreign.RootID) {
-				local.R.RootUsers = append(local.R.RootUsers, foreign)
+			if local.ID == foreign.NodeID {
+				local.R.Grants = append(local.R.Grants, foreign)
 				if foreign.R == nil {
-					foreign.R = &userR{}
+					foreign.R = &grantR{}
 				}
-				foreign.R.Root = local
+				foreign.R.Node = local
 				break
 			}
 		}
@@ -1362,68 +1713,980 @@ func (nodeL) LoadRootUsers(ctx context.Context, e boil.ContextExecutor, singular
 	return nil
 }
 
-// SetOwner of the node to the related item.
-// Sets o.R.Owner to related.
-// Adds o to related.R.OwnerNodes.
-func (o *Node) SetOwner(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
-	var err error
-	if insert {
-		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
-			return errors.Wrap(err, "failed to insert into foreign table")
-		}
-	}
-
-	updateQuery := fmt.Sprintf(
-		"UPDATE \"nodes\" SET %s WHERE %s",
-		strmangle.SetParamNames("\"", "\"", 1, []string{"owner_id"}),
-		strmangle.WhereClause("\"", "\"", 2, nodePrimaryKeyColumns),
-	)
-	values := []interface{}{related.ID, o.ID}
-
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, updateQuery)
-		fmt.Fprintln(writer, values)
-	}
-	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
-		return errors.Wrap(err, "failed to update local table")
-	}
+// LoadInfos allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (nodeL) LoadInfos(ctx context.Context, e boil.ContextExecutor, singular bool, maybeNode interface{}, mods queries.Applicator) error {
+	var slice []*Node
+	var object *Node
 
-	queries.Assign(&o.OwnerID, related.ID)
-	if o.R == nil {
-		o.R = &nodeR{
-			Owner: related,
+	if singular {
+		var ok bool
+		object, ok = maybeNode.(*Node)
+		if !ok {
+			object = new(Node)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeNode)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeNode))
+			}
 		}
 	} else {
-		o.R.Owner = related
+		s, ok := maybeNode.(*[]*Node)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeNode)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeNode))
+			}
+		}
 	}
 
-	if related.R == nil {
-		related.R = &userR{
-			OwnerNodes: NodeSlice{o},
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &nodeR{}
 		}
+		args = append(args, object.ID)
 	} else {
-		related.R.OwnerNodes = append(related.R.OwnerNodes, o)
-	}
-
-	return nil
-}
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &nodeR{}
+			}
 
-// RemoveOwner relationship.
-// Sets o.R.Owner to nil.
-// Removes o from all passed in related items' relationships struct (Optional).
-func (o *Node) RemoveOwner(ctx context.Context, exec boil.ContextExecutor, related *User) error {
-	var err error
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
 
-	queries.SetScanner(&o.OwnerID, nil)
-	if _, err = o.Update(ctx, exec, boil.Whitelist("owner_id")); err != nil {
-		return errors.Wrap(err, "failed to update local table")
+			args = append(args, obj.ID)
+		}
 	}
 
-	if o.R != nil {
-		o.R.Owner = nil
-	}
-	if related == nil || related.R == nil {
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`infos`),
+		qm.WhereIn(`infos.node_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load infos")
+	}
+
+	var resultSlice []*Info
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice infos")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on infos")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for infos")
+	}
+
+	if len(infoAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Infos = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &infoR{}
+			}
+			foreign.R.Node = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.NodeID {
+				local.R.Infos = append(local.R.Infos, foreign)
+				if foreign.R == nil {
+					foreign.R = &infoR{}
+				}
+				foreign.R.Node = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadParentNodes allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (nodeL) LoadParentNodes(ctx context.Context, e boil.ContextExecutor, singular bool, maybeNode interface{}, mods queries.Applicator) error {
+	var slice []*Node
+	var object *Node
+
+	if singular {
+		var ok bool
+		object, ok = maybeNode.(*Node)
+		if !ok {
+			object = new(Node)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeNode)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeNode))
+			}
+		}
+	} else {
+		s, ok := maybeNode.(*[]*Node)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeNode)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeNode))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &nodeR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &nodeR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.ID) {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`nodes`),
+		qm.WhereIn(`nodes.parent_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load nodes")
+	}
+
+	var resultSlice []*Node
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice nodes")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on nodes")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for nodes")
+	}
+
+	if len(nodeAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.ParentNodes = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &nodeR{}
+			}
+			foreign.R.Parent = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.ParentID) {
+				local.R.ParentNodes = append(local.R.ParentNodes, foreign)
+				if foreign.R == nil {
+					foreign.R = &nodeR{}
+				}
+				foreign.R.Parent = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadProgresses allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (nodeL) LoadProgresses(ctx context.Context, e boil.ContextExecutor, singular bool, maybeNode interface{}, mods queries.Applicator) error {
+	var slice []*Node
+	var object *Node
+
+	if singular {
+		var ok bool
+		object, ok = maybeNode.(*Node)
+		if !ok {
+			object = new(Node)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeNode)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeNode))
+			}
+		}
+	} else {
+		s, ok := maybeNode.(*[]*Node)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeNode)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeNode))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &nodeR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &nodeR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`progress`),
+		qm.WhereIn(`progress.node_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load progress")
+	}
+
+	var resultSlice []*Progress
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice progress")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on progress")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for progress")
+	}
+
+	if len(progressAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Progresses = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &progressR{}
+			}
+			foreign.R.Node = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.NodeID {
+				local.R.Progresses = append(local.R.Progresses, foreign)
+				if foreign.R == nil {
+					foreign.R = &progressR{}
+				}
+				foreign.R.Node = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadShares allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (nodeL) LoadShares(ctx context.Context, e boil.ContextExecutor, singular bool, maybeNode interface{}, mods queries.Applicator) error {
+	var slice []*Node
+	var object *Node
+
+	if singular {
+		var ok bool
+		object, ok = maybeNode.(*Node)
+		if !ok {
+			object = new(Node)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeNode)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeNode))
+			}
+		}
+	} else {
+		s, ok := maybeNode.(*[]*Node)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeNode)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeNode))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &nodeR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &nodeR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`shares`),
+		qm.WhereIn(`shares.node_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load shares")
+	}
+
+	var resultSlice []*Share
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice shares")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on shares")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for shares")
+	}
+
+	if len(shareAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Shares = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &shareR{}
+			}
+			foreign.R.Node = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.NodeID {
+				local.R.Shares = append(local.R.Shares, foreign)
+				if foreign.R == nil {
+					foreign.R = &shareR{}
+				}
+				foreign.R.Node = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadOriginalParentTrashes allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (nodeL) LoadOriginalParentTrashes(ctx context.Context, e boil.ContextExecutor, singular bool, maybeNode interface{}, mods queries.Applicator) error {
+	var slice []*Node
+	var object *Node
+
+	if singular {
+		var ok bool
+		object, ok = maybeNode.(*Node)
+		if !ok {
+			object = new(Node)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeNode)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeNode))
+			}
+		}
+	} else {
+		s, ok := maybeNode.(*[]*Node)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeNode)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeNode))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &nodeR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &nodeR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.ID) {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`trash`),
+		qm.WhereIn(`trash.original_parent_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load trash")
+	}
+
+	var resultSlice []*Trash
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice trash")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on trash")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for trash")
+	}
+
+	if len(trashAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.OriginalParentTrashes = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &trashR{}
+			}
+			foreign.R.OriginalParent = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.OriginalParentID) {
+				local.R.OriginalParentTrashes = append(local.R.OriginalParentTrashes, foreign)
+				if foreign.R == nil {
+					foreign.R = &trashR{}
+				}
+				foreign.R.OriginalParent = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadRootUsers allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (nodeL) LoadRootUsers(ctx context.Context, e boil.ContextExecutor, singular bool, maybeNode interface{}, mods queries.Applicator) error {
+	var slice []*Node
+	var object *Node
+
+	if singular {
+		var ok bool
+		object, ok = maybeNode.(*Node)
+		if !ok {
+			object = new(Node)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeNode)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeNode))
+			}
+		}
+	} else {
+		s, ok := maybeNode.(*[]*Node)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeNode)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeNode))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &nodeR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &nodeR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.ID) {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`users`),
+		qm.WhereIn(`users.root_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load users")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice users")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on users")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
+	}
+
+	if len(userAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.RootUsers = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &userR{}
+			}
+			foreign.R.Root = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.RootID) {
+				local.R.RootUsers = append(local.R.RootUsers, foreign)
+				if foreign.R == nil {
+					foreign.R = &userR{}
+				}
+				foreign.R.Root = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadTrashUsers allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (nodeL) LoadTrashUsers(ctx context.Context, e boil.ContextExecutor, singular bool, maybeNode interface{}, mods queries.Applicator) error {
+	var slice []*Node
+	var object *Node
+
+	if singular {
+		var ok bool
+		object, ok = maybeNode.(*Node)
+		if !ok {
+			object = new(Node)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeNode)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeNode))
+			}
+		}
+	} else {
+		s, ok := maybeNode.(*[]*Node)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeNode)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeNode))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &nodeR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &nodeR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.ID) {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`users`),
+		qm.WhereIn(`users.trash_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load users")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice users")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on users")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
+	}
+
+	if len(userAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.TrashUsers = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &userR{}
+			}
+			foreign.R.Trash = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.TrashID) {
+				local.R.TrashUsers = append(local.R.TrashUsers, foreign)
+				if foreign.R == nil {
+					foreign.R = &userR{}
+				}
+				foreign.R.Trash = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadVersions allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (nodeL) LoadVersions(ctx context.Context, e boil.ContextExecutor, singular bool, maybeNode interface{}, mods queries.Applicator) error {
+	var slice []*Node
+	var object *Node
+
+	if singular {
+		var ok bool
+		object, ok = maybeNode.(*Node)
+		if !ok {
+			object = new(Node)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeNode)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeNode))
+			}
+		}
+	} else {
+		s, ok := maybeNode.(*[]*Node)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeNode)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeNode))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &nodeR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &nodeR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`versions`),
+		qm.WhereIn(`versions.node_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load versions")
+	}
+
+	var resultSlice []*Version
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice versions")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on versions")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for versions")
+	}
+
+	if len(versionAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Versions = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &versionR{}
+			}
+			foreign.R.Node = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.NodeID {
+				local.R.Versions = append(local.R.Versions, foreign)
+				if foreign.R == nil {
+					foreign.R = &versionR{}
+				}
+				foreign.R.Node = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetOwner of the node to the related item.
+// Sets o.R.Owner to related.
+// Adds o to related.R.OwnerNodes.
+func (o *Node) SetOwner(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"nodes\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"owner_id"}),
+		strmangle.WhereClause("\"", "\"", 2, nodePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.OwnerID, related.ID)
+	if o.R == nil {
+		o.R = &nodeR{
+			Owner: related,
+		}
+	} else {
+		o.R.Owner = related
+	}
+
+	if related.R == nil {
+		related.R = &userR{
+			OwnerNodes: NodeSlice{o},
+		}
+	} else {
+		related.R.OwnerNodes = append(related.R.OwnerNodes, o)
+	}
+
+	return nil
+}
+
+// RemoveOwner relationship.
+// Sets o.R.Owner to nil.
+// Removes o from all passed in related items' relationships struct.
+func (o *Node) RemoveOwner(ctx context.Context, exec boil.ContextExecutor, related *User) error {
+	var err error
+
+	queries.SetScanner(&o.OwnerID, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("owner_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.Owner = nil
+	}
+	if related == nil || related.R == nil {
 		return nil
 	}
 
@@ -1432,101 +2695,564 @@ func (o *Node) RemoveOwner(ctx context.Context, exec boil.ContextExecutor, relat
 			continue
 		}
 
-		ln := len(related.R.OwnerNodes)
-		if ln > 1 && i < ln-1 {
-			related.R.OwnerNodes[i] = related.R.OwnerNodes[ln-1]
+		ln := len(related.R.OwnerNodes)
+		if ln > 1 && i < ln-1 {
+			related.R.OwnerNodes[i] = related.R.OwnerNodes[ln-1]
+		}
+		related.R.OwnerNodes = related.R.OwnerNodes[:ln-1]
+		break
+	}
+	return nil
+}
+
+// SetParent of the node to the related item.
+// Sets o.R.Parent to related.
+// Adds o to related.R.ParentNodes.
+func (o *Node) SetParent(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Node) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"nodes\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"parent_id"}),
+		strmangle.WhereClause("\"", "\"", 2, nodePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.ParentID, related.ID)
+	if o.R == nil {
+		o.R = &nodeR{
+			Parent: related,
+		}
+	} else {
+		o.R.Parent = related
+	}
+
+	if related.R == nil {
+		related.R = &nodeR{
+			ParentNodes: NodeSlice{o},
+		}
+	} else {
+		related.R.ParentNodes = append(related.R.ParentNodes, o)
+	}
+
+	return nil
+}
+
+// RemoveParent relationship.
+// Sets o.R.Parent to nil.
+// Removes o from all passed in related items' relationships struct.
+func (o *Node) RemoveParent(ctx context.Context, exec boil.ContextExecutor, related *Node) error {
+	var err error
+
+	queries.SetScanner(&o.ParentID, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("parent_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.Parent = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.ParentNodes {
+		if queries.Equal(o.ParentID, ri.ParentID) {
+			continue
+		}
+
+		ln := len(related.R.ParentNodes)
+		if ln > 1 && i < ln-1 {
+			related.R.ParentNodes[i] = related.R.ParentNodes[ln-1]
+		}
+		related.R.ParentNodes = related.R.ParentNodes[:ln-1]
+		break
+	}
+	return nil
+}
+
+// SetNodeBlob of the node to the related item.
+// Sets o.R.NodeBlob to related.
+// Adds o to related.R.Nodes.
+func (o *Node) SetNodeBlob(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Blob) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"nodes\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"blob"}),
+		strmangle.WhereClause("\"", "\"", 2, nodePrimaryKeyColumns),
+	)
+	values := []interface{}{related.Hash, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.Blob, related.Hash)
+	if o.R == nil {
+		o.R = &nodeR{
+			NodeBlob: related,
+		}
+	} else {
+		o.R.NodeBlob = related
+	}
+
+	if related.R == nil {
+		related.R = &blobR{
+			Nodes: NodeSlice{o},
+		}
+	} else {
+		related.R.Nodes = append(related.R.Nodes, o)
+	}
+
+	return nil
+}
+
+// RemoveNodeBlob relationship.
+// Sets o.R.NodeBlob to nil.
+// Removes o from all passed in related items' relationships struct.
+func (o *Node) RemoveNodeBlob(ctx context.Context, exec boil.ContextExecutor, related *Blob) error {
+	var err error
+
+	queries.SetScanner(&o.Blob, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("blob")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.NodeBlob = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.Nodes {
+		if queries.Equal(o.Blob, ri.Blob) {
+			continue
+		}
+
+		ln := len(related.R.Nodes)
+		if ln > 1 && i < ln-1 {
+			related.R.Nodes[i] = related.R.Nodes[ln-1]
+		}
+		related.R.Nodes = related.R.Nodes[:ln-1]
+		break
+	}
+	return nil
+}
+
+// SetNodeProcessReq of the node to the related item.
+// Sets o.R.NodeProcessReq to related.
+// Adds o to related.R.Node.
+func (o *Node) SetNodeProcessReq(ctx context.Context, exec boil.ContextExecutor, insert bool, related *NodeProcessReq) error {
+	var err error
+
+	if insert {
+		related.NodeID = o.ID
+
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	} else {
+		updateQuery := fmt.Sprintf(
+			"UPDATE \"node_process_reqs\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, []string{"node_id"}),
+			strmangle.WhereClause("\"", "\"", 2, nodeProcessReqPrimaryKeyColumns),
+		)
+		values := []interface{}{o.ID, related.ID}
+
+		if boil.IsDebug(ctx) {
+			writer := boil.DebugWriterFrom(ctx)
+			fmt.Fprintln(writer, updateQuery)
+			fmt.Fprintln(writer, values)
+		}
+		if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+			return errors.Wrap(err, "failed to update foreign table")
+		}
+
+		related.NodeID = o.ID
+	}
+
+	if o.R == nil {
+		o.R = &nodeR{
+			NodeProcessReq: related,
+		}
+	} else {
+		o.R.NodeProcessReq = related
+	}
+
+	if related.R == nil {
+		related.R = &nodeProcessReqR{
+			Node: o,
+		}
+	} else {
+		related.R.Node = o
+	}
+	return nil
+}
+
+// SetNodeText of the node to the related item.
+// Sets o.R.NodeText to related.
+// Adds o to related.R.Node.
+func (o *Node) SetNodeText(ctx context.Context, exec boil.ContextExecutor, insert bool, related *NodeText) error {
+	var err error
+
+	if insert {
+		related.NodeID = o.ID
+
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	} else {
+		updateQuery := fmt.Sprintf(
+			"UPDATE \"node_texts\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, []string{"node_id"}),
+			strmangle.WhereClause("\"", "\"", 2, nodeTextPrimaryKeyColumns),
+		)
+		values := []interface{}{o.ID, related.NodeID}
+
+		if boil.IsDebug(ctx) {
+			writer := boil.DebugWriterFrom(ctx)
+			fmt.Fprintln(writer, updateQuery)
+			fmt.Fprintln(writer, values)
+		}
+		if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+			return errors.Wrap(err, "failed to update foreign table")
+		}
+
+		related.NodeID = o.ID
+	}
+
+	if o.R == nil {
+		o.R = &nodeR{
+			NodeText: related,
 		}
-		related.R.OwnerNodes = related.R.OwnerNodes[:ln-1]
-		break
+	} else {
+		o.R.NodeText = related
+	}
+
+	if related.R == nil {
+		related.R = &nodeTextR{
+			Node: o,
+		}
+	} else {
+		related.R.Node = o
 	}
 	return nil
 }
 
-// SetParent of the node to the related item.
-// Sets o.R.Parent to related.
-// Adds o to related.R.ParentNodes.
-func (o *Node) SetParent(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Node) error {
+// SetTrash of the node to the related item.
+// Sets o.R.Trash to related.
+// Adds o to related.R.Node.
+func (o *Node) SetTrash(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Trash) error {
 	var err error
+
 	if insert {
+		related.NodeID = o.ID
+
 		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
 			return errors.Wrap(err, "failed to insert into foreign table")
 		}
+	} else {
+		updateQuery := fmt.Sprintf(
+			"UPDATE \"trash\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, []string{"node_id"}),
+			strmangle.WhereClause("\"", "\"", 2, trashPrimaryKeyColumns),
+		)
+		values := []interface{}{o.ID, related.NodeID}
+
+		if boil.IsDebug(ctx) {
+			writer := boil.DebugWriterFrom(ctx)
+			fmt.Fprintln(writer, updateQuery)
+			fmt.Fprintln(writer, values)
+		}
+		if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+			return errors.Wrap(err, "failed to update foreign table")
+		}
+
+		related.NodeID = o.ID
 	}
 
-	updateQuery := fmt.Sprintf(
-		"UPDATE \"nodes\" SET %s WHERE %s",
-		strmangle.SetParamNames("\"", "\"", 1, []string{"parent_id"}),
-		strmangle.WhereClause("\"", "\"", 2, nodePrimaryKeyColumns),
-	)
-	values := []interface{}{related.ID, o.ID}
+	if o.R == nil {
+		o.R = &nodeR{
+			Trash: related,
+		}
+	} else {
+		o.R.Trash = related
+	}
 
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, updateQuery)
-		fmt.Fprintln(writer, values)
+	if related.R == nil {
+		related.R = &trashR{
+			Node: o,
+		}
+	} else {
+		related.R.Node = o
 	}
-	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
-		return errors.Wrap(err, "failed to update local table")
+	return nil
+}
+
+// AddGrants adds the given related objects to the existing relationships
+// of the node, optionally inserting them as new records.
+// Appends related to o.R.Grants.
+// Sets related.R.Node appropriately.
+func (o *Node) AddGrants(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Grant) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.NodeID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"grants\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"node_id"}),
+				strmangle.WhereClause("\"", "\"", 2, grantPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.NodeID = o.ID
+		}
 	}
 
-	queries.Assign(&o.ParentID, related.ID)
 	if o.R == nil {
 		o.R = &nodeR{
-			Parent: related,
+			Grants: related,
 		}
 	} else {
-		o.R.Parent = related
+		o.R.Grants = append(o.R.Grants, related...)
 	}
 
-	if related.R == nil {
-		related.R = &nodeR{
-			ParentNodes: NodeSlice{o},
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &grantR{
+				Node: o,
+			}
+		} else {
+			rel.R.Node = o
+		}
+	}
+	return nil
+}
+
+// AddInfos adds the given related objects to the existing relationships
+// of the node, optionally inserting them as new records.
+// Appends related to o.R.Infos.
+// Sets related.R.Node appropriately.
+func (o *Node) AddInfos(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Info) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.NodeID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"infos\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"node_id"}),
+				strmangle.WhereClause("\"", "\"", 2, infoPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.NodeID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &nodeR{
+			Infos: related,
 		}
 	} else {
-		related.R.ParentNodes = append(related.R.ParentNodes, o)
+		o.R.Infos = append(o.R.Infos, related...)
 	}
 
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &infoR{
+				Node: o,
+			}
+		} else {
+			rel.R.Node = o
+		}
+	}
 	return nil
 }
 
-// RemoveParent relationship.
-// Sets o.R.Parent to nil.
-// Removes o from all passed in related items' relationships struct (Optional).
-func (o *Node) RemoveParent(ctx context.Context, exec boil.ContextExecutor, related *Node) error {
+// AddParentNodes adds the given related objects to the existing relationships
+// of the node, optionally inserting them as new records.
+// Appends related to o.R.ParentNodes.
+// Sets related.R.Parent appropriately.
+func (o *Node) AddParentNodes(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Node) error {
 	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.ParentID, o.ID)
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"nodes\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"parent_id"}),
+				strmangle.WhereClause("\"", "\"", 2, nodePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
 
-	queries.SetScanner(&o.ParentID, nil)
-	if _, err = o.Update(ctx, exec, boil.Whitelist("parent_id")); err != nil {
-		return errors.Wrap(err, "failed to update local table")
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.ParentID, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &nodeR{
+			ParentNodes: related,
+		}
+	} else {
+		o.R.ParentNodes = append(o.R.ParentNodes, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &nodeR{
+				Parent: o,
+			}
+		} else {
+			rel.R.Parent = o
+		}
+	}
+	return nil
+}
+
+// SetParentNodes removes all previously related items of the
+// node replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.Parent's ParentNodes accordingly.
+// Replaces o.R.ParentNodes with related.
+// Sets related.R.Parent's ParentNodes accordingly.
+func (o *Node) SetParentNodes(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Node) error {
+	query := "update \"nodes\" set \"parent_id\" = null where \"parent_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
 	}
 
 	if o.R != nil {
-		o.R.Parent = nil
+		for _, rel := range o.R.ParentNodes {
+			queries.SetScanner(&rel.ParentID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.Parent = nil
+		}
+		o.R.ParentNodes = nil
 	}
-	if related == nil || related.R == nil {
+
+	return o.AddParentNodes(ctx, exec, insert, related...)
+}
+
+// RemoveParentNodes relationships from objects passed in.
+// Removes related items from R.ParentNodes (uses pointer comparison, removal does not keep order)
+// Sets related.R.Parent.
+func (o *Node) RemoveParentNodes(ctx context.Context, exec boil.ContextExecutor, related ...*Node) error {
+	if len(related) == 0 {
 		return nil
 	}
 
-	for i, ri := range related.R.ParentNodes {
-		if queries.Equal(o.ParentID, ri.ParentID) {
-			continue
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.ParentID, nil)
+		if rel.R != nil {
+			rel.R.Parent = nil
+		}
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("parent_id")); err != nil {
+			return err
 		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.ParentNodes {
+			if rel != ri {
+				continue
+			}
 
-		ln := len(related.R.ParentNodes)
-		if ln > 1 && i < ln-1 {
-			related.R.ParentNodes[i] = related.R.ParentNodes[ln-1]
+			ln := len(o.R.ParentNodes)
+			if ln > 1 && i < ln-1 {
+				o.R.ParentNodes[i] = o.R.ParentNodes[ln-1]
+			}
+			o.R.ParentNodes = o.R.ParentNodes[:ln-1]
+			break
 		}
-		related.R.ParentNodes = related.R.ParentNodes[:ln-1]
-		break
 	}
+
 	return nil
 }
 
-// AddInfos adds the given related objects to the existing relationships
+// AddProgresses adds the given related objects to the existing relationships
 // of the node, optionally inserting them as new records.
-// Appends related to o.R.Infos.
+// Appends related to o.R.Progresses.
 // Sets related.R.Node appropriately.
-func (o *Node) AddInfos(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Info) error {
+func (o *Node) AddProgresses(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Progress) error {
 	var err error
 	for _, rel := range related {
 		if insert {
@@ -1536,9 +3262,9 @@ func (o *Node) AddInfos(ctx context.Context, exec boil.ContextExecutor, insert b
 			}
 		} else {
 			updateQuery := fmt.Sprintf(
-				"UPDATE \"infos\" SET %s WHERE %s",
+				"UPDATE \"progress\" SET %s WHERE %s",
 				strmangle.SetParamNames("\"", "\"", 1, []string{"node_id"}),
-				strmangle.WhereClause("\"", "\"", 2, infoPrimaryKeyColumns),
+				strmangle.WhereClause("\"", "\"", 2, progressPrimaryKeyColumns),
 			)
 			values := []interface{}{o.ID, rel.ID}
 
@@ -1557,15 +3283,15 @@ func (o *Node) AddInfos(ctx context.Context, exec boil.ContextExecutor, insert b
 
 	if o.R == nil {
 		o.R = &nodeR{
-			Infos: related,
+			Progresses: related,
 		}
 	} else {
-		o.R.Infos = append(o.R.Infos, related...)
+		o.R.Progresses = append(o.R.Progresses, related...)
 	}
 
 	for _, rel := range related {
 		if rel.R == nil {
-			rel.R = &infoR{
+			rel.R = &progressR{
 				Node: o,
 			}
 		} else {
@@ -1575,11 +3301,11 @@ func (o *Node) AddInfos(ctx context.Context, exec boil.ContextExecutor, insert b
 	return nil
 }
 
-// AddNodeProcessReqs adds the given related objects to the existing relationships
+// AddShares adds the given related objects to the existing relationships
 // of the node, optionally inserting them as new records.
-// Appends related to o.R.NodeProcessReqs.
+// Appends related to o.R.Shares.
 // Sets related.R.Node appropriately.
-func (o *Node) AddNodeProcessReqs(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*NodeProcessReq) error {
+func (o *Node) AddShares(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Share) error {
 	var err error
 	for _, rel := range related {
 		if insert {
@@ -1589,9 +3315,9 @@ func (o *Node) AddNodeProcessReqs(ctx context.Context, exec boil.ContextExecutor
 			}
 		} else {
 			updateQuery := fmt.Sprintf(
-				"UPDATE \"node_process_reqs\" SET %s WHERE %s",
+				"UPDATE \"shares\" SET %s WHERE %s",
 				strmangle.SetParamNames("\"", "\"", 1, []string{"node_id"}),
-				strmangle.WhereClause("\"", "\"", 2, nodeProcessReqPrimaryKeyColumns),
+				strmangle.WhereClause("\"", "\"", 2, sharePrimaryKeyColumns),
 			)
 			values := []interface{}{o.ID, rel.ID}
 
@@ -1610,15 +3336,15 @@ func (o *Node) AddNodeProcessReqs(ctx context.Context, exec boil.ContextExecutor
 
 	if o.R == nil {
 		o.R = &nodeR{
-			NodeProcessReqs: related,
+			Shares: related,
 		}
 	} else {
-		o.R.NodeProcessReqs = append(o.R.NodeProcessReqs, related...)
+		o.R.Shares = append(o.R.Shares, related...)
 	}
 
 	for _, rel := range related {
 		if rel.R == nil {
-			rel.R = &nodeProcessReqR{
+			rel.R = &shareR{
 				Node: o,
 			}
 		} else {
@@ -1628,25 +3354,25 @@ func (o *Node) AddNodeProcessReqs(ctx context.Context, exec boil.ContextExecutor
 	return nil
 }
 
-// AddParentNodes adds the given related objects to the existing relationships
+// AddOriginalParentTrashes adds the given related objects to the existing relationships
 // of the node, optionally inserting them as new records.
-// Appends related to o.R.ParentNodes.
-// Sets related.R.Parent appropriately.
-func (o *Node) AddParentNodes(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Node) error {
+// Appends related to o.R.OriginalParentTrashes.
+// Sets related.R.OriginalParent appropriately.
+func (o *Node) AddOriginalParentTrashes(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Trash) error {
 	var err error
 	for _, rel := range related {
 		if insert {
-			queries.Assign(&rel.ParentID, o.ID)
+			queries.Assign(&rel.OriginalParentID, o.ID)
 			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
 				return errors.Wrap(err, "failed to insert into foreign table")
 			}
 		} else {
 			updateQuery := fmt.Sprintf(
-				"UPDATE \"nodes\" SET %s WHERE %s",
-				strmangle.SetParamNames("\"", "\"", 1, []string{"parent_id"}),
-				strmangle.WhereClause("\"", "\"", 2, nodePrimaryKeyColumns),
+				"UPDATE \"trash\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"original_parent_id"}),
+				strmangle.WhereClause("\"", "\"", 2, trashPrimaryKeyColumns),
 			)
-			values := []interface{}{o.ID, rel.ID}
+			values := []interface{}{o.ID, rel.NodeID}
 
 			if boil.IsDebug(ctx) {
 				writer := boil.DebugWriterFrom(ctx)
@@ -1657,38 +3383,38 @@ func (o *Node) AddParentNodes(ctx context.Context, exec boil.ContextExecutor, in
 				return errors.Wrap(err, "failed to update foreign table")
 			}
 
-			queries.Assign(&rel.ParentID, o.ID)
+			queries.Assign(&rel.OriginalParentID, o.ID)
 		}
 	}
 
 	if o.R == nil {
 		o.R = &nodeR{
-			ParentNodes: related,
+			OriginalParentTrashes: related,
 		}
 	} else {
-		o.R.ParentNodes = append(o.R.ParentNodes, related...)
+		o.R.OriginalParentTrashes = append(o.R.OriginalParentTrashes, related...)
 	}
 
 	for _, rel := range related {
 		if rel.R == nil {
-			rel.R = &nodeR{
-				Parent: o,
+			rel.R = &trashR{
+				OriginalParent: o,
 			}
 		} else {
-			rel.R.Parent = o
+			rel.R.OriginalParent = o
 		}
 	}
 	return nil
 }
 
-// SetParentNodes removes all previously related items of the
+// SetOriginalParentTrashes removes all previously related items of the
 // node replacing them completely with the passed
 // in related items, optionally inserting them as new records.
-// Sets o.R.Parent's ParentNodes accordingly.
-// Replaces o.R.ParentNodes with related.
-// Sets related.R.Parent's ParentNodes accordingly.
-func (o *Node) SetParentNodes(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Node) error {
-	query := "update \"nodes\" set \"parent_id\" = null where \"parent_id\" = $1"
+// Sets o.R.OriginalParent's OriginalParentTrashes accordingly.
+// Replaces o.R.OriginalParentTrashes with related.
+// Sets related.R.OriginalParent's OriginalParentTrashes accordingly.
+func (o *Node) SetOriginalParentTrashes(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Trash) error {
+	query := "update \"trash\" set \"original_parent_id\" = null where \"original_parent_id\" = $1"
 	values := []interface{}{o.ID}
 	if boil.IsDebug(ctx) {
 		writer := boil.DebugWriterFrom(ctx)
@@ -1701,35 +3427,35 @@ func (o *Node) SetParentNodes(ctx context.Context, exec boil.ContextExecutor, in
 	}
 
 	if o.R != nil {
-		for _, rel := range o.R.ParentNodes {
-			queries.SetScanner(&rel.ParentID, nil)
+		for _, rel := range o.R.OriginalParentTrashes {
+			queries.SetScanner(&rel.OriginalParentID, nil)
 			if rel.R == nil {
 				continue
 			}
 
-			rel.R.Parent = nil
+			rel.R.OriginalParent = nil
 		}
-
-		o.R.ParentNodes = nil
+		o.R.OriginalParentTrashes = nil
 	}
-	return o.AddParentNodes(ctx, exec, insert, related...)
+
+	return o.AddOriginalParentTrashes(ctx, exec, insert, related...)
 }
 
-// RemoveParentNodes relationships from objects passed in.
-// Removes related items from R.ParentNodes (uses pointer comparison, removal does not keep order)
-// Sets related.R.Parent.
-func (o *Node) RemoveParentNodes(ctx context.Context, exec boil.ContextExecutor, related ...*Node) error {
+// RemoveOriginalParentTrashes relationships from objects passed in.
+// Removes related items from R.OriginalParentTrashes (uses pointer comparison, removal does not keep order)
+// Sets related.R.OriginalParent.
+func (o *Node) RemoveOriginalParentTrashes(ctx context.Context, exec boil.ContextExecutor, related ...*Trash) error {
 	if len(related) == 0 {
 		return nil
 	}
 
 	var err error
 	for _, rel := range related {
-		queries.SetScanner(&rel.ParentID, nil)
+		queries.SetScanner(&rel.OriginalParentID, nil)
 		if rel.R != nil {
-			rel.R.Parent = nil
+			rel.R.OriginalParent = nil
 		}
-		if _, err = rel.Update(ctx, exec, boil.Whitelist("parent_id")); err != nil {
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("original_parent_id")); err != nil {
 			return err
 		}
 	}
@@ -1738,16 +3464,16 @@ func (o *Node) RemoveParentNodes(ctx context.Context, exec boil.ContextExecutor,
 	}
 
 	for _, rel := range related {
-		for i, ri := range o.R.ParentNodes {
+		for i, ri := range o.R.OriginalParentTrashes {
 			if rel != ri {
 				continue
 			}
 
-			ln := len(o.R.ParentNodes)
+			ln := len(o.R.OriginalParentTrashes)
 			if ln > 1 && i < ln-1 {
-				o.R.ParentNodes[i] = o.R.ParentNodes[ln-1]
+				o.R.OriginalParentTrashes[i] = o.R.OriginalParentTrashes[ln-1]
 			}
-			o.R.ParentNodes = o.R.ParentNodes[:ln-1]
+			o.R.OriginalParentTrashes = o.R.OriginalParentTrashes[:ln-1]
 			break
 		}
 	}
@@ -1755,23 +3481,23 @@ func (o *Node) RemoveParentNodes(ctx context.Context, exec boil.ContextExecutor,
 	return nil
 }
 
-// AddProgresses adds the given related objects to the existing relationships
+// AddRootUsers adds the given related objects to the existing relationships
 // of the node, optionally inserting them as new records.
-// Appends related to o.R.Progresses.
-// Sets related.R.Node appropriately.
-func (o *Node) AddProgresses(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Progress) error {
+// Appends related to o.R.RootUsers.
+// Sets related.R.Root appropriately.
+func (o *Node) AddRootUsers(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*User) error {
 	var err error
 	for _, rel := range related {
 		if insert {
-			rel.NodeID = o.ID
+			queries.Assign(&rel.RootID, o.ID)
 			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
 				return errors.Wrap(err, "failed to insert into foreign table")
 			}
 		} else {
 			updateQuery := fmt.Sprintf(
-				"UPDATE \"progress\" SET %s WHERE %s",
-				strmangle.SetParamNames("\"", "\"", 1, []string{"node_id"}),
-				strmangle.WhereClause("\"", "\"", 2, progressPrimaryKeyColumns),
+				"UPDATE \"users\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"root_id"}),
+				strmangle.WhereClause("\"", "\"", 2, userPrimaryKeyColumns),
 			)
 			values := []interface{}{o.ID, rel.ID}
 
@@ -1784,46 +3510,120 @@ func (o *Node) AddProgresses(ctx context.Context, exec boil.ContextExecutor, ins
 				return errors.Wrap(err, "failed to update foreign table")
 			}
 
-			rel.NodeID = o.ID
+			queries.Assign(&rel.RootID, o.ID)
 		}
 	}
 
 	if o.R == nil {
 		o.R = &nodeR{
-			Progresses: related,
+			RootUsers: related,
 		}
 	} else {
-		o.R.Progresses = append(o.R.Progresses, related...)
+		o.R.RootUsers = append(o.R.RootUsers, related...)
 	}
 
 	for _, rel := range related {
 		if rel.R == nil {
-			rel.R = &progressR{
-				Node: o,
+			rel.R = &userR{
+				Root: o,
 			}
 		} else {
-			rel.R.Node = o
+			rel.R.Root = o
 		}
 	}
 	return nil
 }
 
-// AddRootUsers adds the given related objects to the existing relationships
+// SetRootUsers removes all previously related items of the
+// node replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.Root's RootUsers accordingly.
+// Replaces o.R.RootUsers with related.
+// Sets related.R.Root's RootUsers accordingly.
+func (o *Node) SetRootUsers(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*User) error {
+	query := "update \"users\" set \"root_id\" = null where \"root_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.RootUsers {
+			queries.SetScanner(&rel.RootID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.Root = nil
+		}
+		o.R.RootUsers = nil
+	}
+
+	return o.AddRootUsers(ctx, exec, insert, related...)
+}
+
+// RemoveRootUsers relationships from objects passed in.
+// Removes related items from R.RootUsers (uses pointer comparison, removal does not keep order)
+// Sets related.R.Root.
+func (o *Node) RemoveRootUsers(ctx context.Context, exec boil.ContextExecutor, related ...*User) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.RootID, nil)
+		if rel.R != nil {
+			rel.R.Root = nil
+		}
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("root_id")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.RootUsers {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.RootUsers)
+			if ln > 1 && i < ln-1 {
+				o.R.RootUsers[i] = o.R.RootUsers[ln-1]
+			}
+			o.R.RootUsers = o.R.RootUsers[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+// AddTrashUsers adds the given related objects to the existing relationships
 // of the node, optionally inserting them as new records.
-// Appends related to o.R.RootUsers.
-// Sets related.R.Root appropriately.
-func (o *Node) AddRootUsers(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*User) error {
+// Appends related to o.R.TrashUsers.
+// Sets related.R.Trash appropriately.
+func (o *Node) AddTrashUsers(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*User) error {
 	var err error
 	for _, rel := range related {
 		if insert {
-			queries.Assign(&rel.RootID, o.ID)
+			queries.Assign(&rel.TrashID, o.ID)
 			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
 				return errors.Wrap(err, "failed to insert into foreign table")
 			}
 		} else {
 			updateQuery := fmt.Sprintf(
 				"UPDATE \"users\" SET %s WHERE %s",
-				strmangle.SetParamNames("\"", "\"", 1, []string{"root_id"}),
+				strmangle.SetParamNames("\"", "\"", 1, []string{"trash_id"}),
 				strmangle.WhereClause("\"", "\"", 2, userPrimaryKeyColumns),
 			)
 			values := []interface{}{o.ID, rel.ID}
@@ -1837,38 +3637,38 @@ func (o *Node) AddRootUsers(ctx context.Context, exec boil.ContextExecutor, inse
 				return errors.Wrap(err, "failed to update foreign table")
 			}
 
-			queries.Assign(&rel.RootID, o.ID)
+			queries.Assign(&rel.TrashID, o.ID)
 		}
 	}
 
 	if o.R == nil {
 		o.R = &nodeR{
-			RootUsers: related,
+			TrashUsers: related,
 		}
 	} else {
-		o.R.RootUsers = append(o.R.RootUsers, related...)
+		o.R.TrashUsers = append(o.R.TrashUsers, related...)
 	}
 
 	for _, rel := range related {
 		if rel.R == nil {
 			rel.R = &userR{
-				Root: o,
+				Trash: o,
 			}
 		} else {
-			rel.R.Root = o
+			rel.R.Trash = o
 		}
 	}
 	return nil
 }
 
-// SetRootUsers removes all previously related items of the
+// SetTrashUsers removes all previously related items of the
 // node replacing them completely with the passed
 // in related items, optionally inserting them as new records.
-// Sets o.R.Root's RootUsers accordingly.
-// Replaces o.R.RootUsers with related.
-// Sets related.R.Root's RootUsers accordingly.
-func (o *Node) SetRootUsers(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*User) error {
-	query := "update \"users\" set \"root_id\" = null where \"root_id\" = $1"
+// Sets o.R.Trash's TrashUsers accordingly.
+// Replaces o.R.TrashUsers with related.
+// Sets related.R.Trash's TrashUsers accordingly.
+func (o *Node) SetTrashUsers(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*User) error {
+	query := "update \"users\" set \"trash_id\" = null where \"trash_id\" = $1"
 	values := []interface{}{o.ID}
 	if boil.IsDebug(ctx) {
 		writer := boil.DebugWriterFrom(ctx)
@@ -1881,35 +3681,35 @@ func (o *Node) SetRootUsers(ctx context.Context, exec boil.ContextExecutor, inse
 	}
 
 	if o.R != nil {
-		for _, rel := range o.R.RootUsers {
-			queries.SetScanner(&rel.RootID, nil)
+		for _, rel := range o.R.TrashUsers {
+			queries.SetScanner(&rel.TrashID, nil)
 			if rel.R == nil {
 				continue
 			}
 
-			rel.R.Root = nil
+			rel.R.Trash = nil
 		}
-
-		o.R.RootUsers = nil
+		o.R.TrashUsers = nil
 	}
-	return o.AddRootUsers(ctx, exec, insert, related...)
+
+	return o.AddTrashUsers(ctx, exec, insert, related...)
 }
 
-// RemoveRootUsers relationships from objects passed in.
-// Removes related items from R.RootUsers (uses pointer comparison, removal does not keep order)
-// Sets related.R.Root.
-func (o *Node) RemoveRootUsers(ctx context.Context, exec boil.ContextExecutor, related ...*User) error {
+// RemoveTrashUsers relationships from objects passed in.
+// Removes related items from R.TrashUsers (uses pointer comparison, removal does not keep order)
+// Sets related.R.Trash.
+func (o *Node) RemoveTrashUsers(ctx context.Context, exec boil.ContextExecutor, related ...*User) error {
 	if len(related) == 0 {
 		return nil
 	}
 
 	var err error
 	for _, rel := range related {
-		queries.SetScanner(&rel.RootID, nil)
+		queries.SetScanner(&rel.TrashID, nil)
 		if rel.R != nil {
-			rel.R.Root = nil
+			rel.R.Trash = nil
 		}
-		if _, err = rel.Update(ctx, exec, boil.Whitelist("root_id")); err != nil {
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("trash_id")); err != nil {
 			return err
 		}
 	}
@@ -1918,16 +3718,16 @@ func (o *Node) RemoveRootUsers(ctx context.Context, exec boil.ContextExecutor, r
 	}
 
 	for _, rel := range related {
-		for i, ri := range o.R.RootUsers {
+		for i, ri := range o.R.TrashUsers {
 			if rel != ri {
 				continue
 			}
 
-			ln := len(o.R.RootUsers)
+			ln := len(o.R.TrashUsers)
 			if ln > 1 && i < ln-1 {
-				o.R.RootUsers[i] = o.R.RootUsers[ln-1]
+				o.R.TrashUsers[i] = o.R.TrashUsers[ln-1]
 			}
-			o.R.RootUsers = o.R.RootUsers[:ln-1]
+			o.R.TrashUsers = o.R.TrashUsers[:ln-1]
 			break
 		}
 	}
@@ -1935,10 +3735,68 @@ func (o *Node) RemoveRootUsers(ctx context.Context, exec boil.ContextExecutor, r
 	return nil
 }
 
+// AddVersions adds the given related objects to the existing relationships
+// of the node, optionally inserting them as new records.
+// Appends related to o.R.Versions.
+// Sets related.R.Node appropriately.
+func (o *Node) AddVersions(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Version) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.NodeID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"versions\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"node_id"}),
+				strmangle.WhereClause("\"", "\"", 2, versionPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.NodeID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &nodeR{
+			Versions: related,
+		}
+	} else {
+		o.R.Versions = append(o.R.Versions, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &versionR{
+				Node: o,
+			}
+		} else {
+			rel.R.Node = o
+		}
+	}
+	return nil
+}
+
 // Nodes retrieves all the records using an executor.
 func Nodes(mods ...qm.QueryMod) nodeQuery {
 	mods = append(mods, qm.From("\"nodes\""))
-	return nodeQuery{NewQuery(mods...)}
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"nodes\".*"})
+	}
+
+	return nodeQuery{q}
 }
 
 // FindNode retrieves a single record by ID with an executor.
@@ -1958,7 +3816,7 @@ func FindNode(ctx context.Context, exec boil.ContextExecutor, iD int, selectCols
 
 	err := q.Bind(ctx, exec, nodeObj)
 	if err != nil {
-		if errors.Cause(err) == sql.ErrNoRows {
+		if errors.Is(err, sql.ErrNoRows) {
 			return nil, sql.ErrNoRows
 		}
 		return nil, errors.Wrap(err, "models: unable to select from nodes")
@@ -2232,6 +4090,7 @@ func (o *Node) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnCo
 			nodeColumnsWithoutDefault,
 			nzDefaults,
 		)
+
 		update := updateColumns.UpdateColumnSet(
 			nodeAllColumns,
 			nodePrimaryKeyColumns,
@@ -2274,7 +4133,7 @@ func (o *Node) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnCo
 	}
 	if len(cache.retMapping) != 0 {
 		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
-		if err == sql.ErrNoRows {
+		if errors.Is(err, sql.ErrNoRows) {
 			err = nil // Postgres doesn't return anything when there's no update
 		}
 	} else {
@@ -2459,3 +4318,8 @@ func NodeExists(ctx context.Context, exec boil.ContextExecutor, iD int) (bool, e
 
 	return exists, nil
 }
+
+// Exists checks if the Node row exists.
+func (o *Node) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return NodeExists(ctx, exec, o.ID)
+}
@@ -1,4 +1,4 @@
-// Code generated by SQLBoiler 4.6.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// Code generated by SQLBoiler 4.14.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
 // This file is meant to be re-generated in place and/or deleted at any time.
 
 package models
@@ -72,8 +72,6 @@ func (w whereHelpernull_Float32) EQ(x null.Float32) qm.QueryMod {
 func (w whereHelpernull_Float32) NEQ(x null.Float32) qm.QueryMod {
 	return qmhelper.WhereNullEQ(w.field, true, x)
 }
-func (w whereHelpernull_Float32) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_Float32) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 func (w whereHelpernull_Float32) LT(x null.Float32) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LT, x)
 }
@@ -86,6 +84,23 @@ func (w whereHelpernull_Float32) GT(x null.Float32) qm.QueryMod {
 func (w whereHelpernull_Float32) GTE(x null.Float32) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
+func (w whereHelpernull_Float32) IN(slice []float32) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_Float32) NIN(slice []float32) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+func (w whereHelpernull_Float32) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Float32) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
 var ProgressWhere = struct {
 	ID       whereHelperint
@@ -121,14 +136,29 @@ func (*progressR) NewStruct() *progressR {
 	return &progressR{}
 }
 
+func (r *progressR) GetNode() *Node {
+	if r == nil {
+		return nil
+	}
+	return r.Node
+}
+
+func (r *progressR) GetUser() *User {
+	if r == nil {
+		return nil
+	}
+	return r.User
+}
+
 // progressL is where Load methods for each relationship are stored.
 type progressL struct{}
 
 var (
 	progressAllColumns            = []string{"id", "node_id", "user_id", "progress", "volume"}
-	progressColumnsWithoutDefault = []string{"node_id", "user_id", "progress", "volume"}
-	progressColumnsWithDefault    = []string{"id"}
+	progressColumnsWithoutDefault = []string{"node_id", "user_id"}
+	progressColumnsWithDefault    = []string{"id", "progress", "volume"}
 	progressPrimaryKeyColumns     = []string{"id"}
+	progressGeneratedColumns      = []string{}
 )
 
 type (
@@ -164,24 +194,27 @@ var (
 	_ = qmhelper.Where
 )
 
-var progressBeforeInsertHooks []ProgressHook
-var progressBeforeUpdateHooks []ProgressHook
-var progressBeforeDeleteHooks []ProgressHook
-var progressBeforeUpsertHooks []ProgressHook
+var progressAfterSelectHooks []ProgressHook
 
+var progressBeforeInsertHooks []ProgressHook
 var progressAfterInsertHooks []ProgressHook
-var progressAfterSelectHooks []ProgressHook
+
+var progressBeforeUpdateHooks []ProgressHook
 var progressAfterUpdateHooks []ProgressHook
+
+var progressBeforeDeleteHooks []ProgressHook
 var progressAfterDeleteHooks []ProgressHook
+
+var progressBeforeUpsertHooks []ProgressHook
 var progressAfterUpsertHooks []ProgressHook
 
-// doBeforeInsertHooks executes all "before insert" hooks.
-func (o *Progress) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *Progress) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range progressBeforeInsertHooks {
+	for _, hook := range progressAfterSelectHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -190,13 +223,13 @@ func (o *Progress) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExe
 	return nil
 }
 
-// doBeforeUpdateHooks executes all "before Update" hooks.
-func (o *Progress) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *Progress) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range progressBeforeUpdateHooks {
+	for _, hook := range progressBeforeInsertHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -205,13 +238,13 @@ func (o *Progress) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExe
 	return nil
 }
 
-// doBeforeDeleteHooks executes all "before Delete" hooks.
-func (o *Progress) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *Progress) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range progressBeforeDeleteHooks {
+	for _, hook := range progressAfterInsertHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -220,13 +253,13 @@ func (o *Progress) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExe
 	return nil
 }
 
-// doBeforeUpsertHooks executes all "before Upsert" hooks.
-func (o *Progress) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *Progress) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range progressBeforeUpsertHooks {
+	for _, hook := range progressBeforeUpdateHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -235,13 +268,13 @@ func (o *Progress) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExe
 	return nil
 }
 
-// doAfterInsertHooks executes all "after Insert" hooks.
-func (o *Progress) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *Progress) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range progressAfterInsertHooks {
+	for _, hook := range progressAfterUpdateHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -250,13 +283,13 @@ func (o *Progress) doAfterInsertHooks(ctx context.Context, exec boil.ContextExec
 	return nil
 }
 
-// doAfterSelectHooks executes all "after Select" hooks.
-func (o *Progress) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *Progress) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range progressAfterSelectHooks {
+	for _, hook := range progressBeforeDeleteHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -265,13 +298,13 @@ func (o *Progress) doAfterSelectHooks(ctx context.Context, exec boil.ContextExec
 	return nil
 }
 
-// doAfterUpdateHooks executes all "after Update" hooks.
-func (o *Progress) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *Progress) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range progressAfterUpdateHooks {
+	for _, hook := range progressAfterDeleteHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -280,13 +313,13 @@ func (o *Progress) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExec
 	return nil
 }
 
-// doAfterDeleteHooks executes all "after Delete" hooks.
-func (o *Progress) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *Progress) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range progressAfterDeleteHooks {
+	for _, hook := range progressBeforeUpsertHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -313,22 +346,22 @@ func (o *Progress) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExec
 // AddProgressHook registers your hook function for all future operations.
 func AddProgressHook(hookPoint boil.HookPoint, progressHook ProgressHook) {
 	switch hookPoint {
+	case boil.AfterSelectHook:
+		progressAfterSelectHooks = append(progressAfterSelectHooks, progressHook)
 	case boil.BeforeInsertHook:
 		progressBeforeInsertHooks = append(progressBeforeInsertHooks, progressHook)
-	case boil.BeforeUpdateHook:
-		progressBeforeUpdateHooks = append(progressBeforeUpdateHooks, progressHook)
-	case boil.BeforeDeleteHook:
-		progressBeforeDeleteHooks = append(progressBeforeDeleteHooks, progressHook)
-	case boil.BeforeUpsertHook:
-		progressBeforeUpsertHooks = append(progressBeforeUpsertHooks, progressHook)
 	case boil.AfterInsertHook:
 		progressAfterInsertHooks = append(progressAfterInsertHooks, progressHook)
-	case boil.AfterSelectHook:
-		progressAfterSelectHooks = append(progressAfterSelectHooks, progressHook)
+	case boil.BeforeUpdateHook:
+		progressBeforeUpdateHooks = append(progressBeforeUpdateHooks, progressHook)
 	case boil.AfterUpdateHook:
 		progressAfterUpdateHooks = append(progressAfterUpdateHooks, progressHook)
+	case boil.BeforeDeleteHook:
+		progressBeforeDeleteHooks = append(progressBeforeDeleteHooks, progressHook)
 	case boil.AfterDeleteHook:
 		progressAfterDeleteHooks = append(progressAfterDeleteHooks, progressHook)
+	case boil.BeforeUpsertHook:
+		progressBeforeUpsertHooks = append(progressBeforeUpsertHooks, progressHook)
 	case boil.AfterUpsertHook:
 		progressAfterUpsertHooks = append(progressAfterUpsertHooks, progressHook)
 	}
@@ -342,7 +375,7 @@ func (q progressQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Pro
 
 	err := q.Bind(ctx, exec, o)
 	if err != nil {
-		if errors.Cause(err) == sql.ErrNoRows {
+		if errors.Is(err, sql.ErrNoRows) {
 			return nil, sql.ErrNoRows
 		}
 		return nil, errors.Wrap(err, "models: failed to execute a one query for progress")
@@ -414,10 +447,7 @@ func (o *Progress) Node(mods ...qm.QueryMod) nodeQuery {
 
 	queryMods = append(queryMods, mods...)
 
-	query := Nodes(queryMods...)
-	queries.SetFrom(query.Query, "\"nodes\"")
-
-	return query
+	return Nodes(queryMods...)
 }
 
 // User pointed to by the foreign key.
@@ -428,10 +458,7 @@ func (o *Progress) User(mods ...qm.QueryMod) userQuery {
 
 	queryMods = append(queryMods, mods...)
 
-	query := Users(queryMods...)
-	queries.SetFrom(query.Query, "\"users\"")
-
-	return query
+	return Users(queryMods...)
 }
 
 // LoadNode allows an eager lookup of values, cached into the
@@ -441,9 +468,25 @@ func (progressL) LoadNode(ctx context.Context, e boil.ContextExecutor, singular
 	var object *Progress
 
 	if singular {
-		object = maybeProgress.(*Progress)
+		var ok bool
+		object, ok = maybeProgress.(*Progress)
+		if !ok {
+			object = new(Progress)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeProgress)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeProgress))
+			}
+		}
 	} else {
-		slice = *maybeProgress.(*[]*Progress)
+		s, ok := maybeProgress.(*[]*Progress)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeProgress)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeProgress))
+			}
+		}
 	}
 
 	args := make([]interface{}, 0, 1)
@@ -500,7 +543,7 @@ func (progressL) LoadNode(ctx context.Context, e boil.ContextExecutor, singular
 		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for nodes")
 	}
 
-	if len(progressAfterSelectHooks) != 0 {
+	if len(nodeAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
@@ -545,9 +588,25 @@ func (progressL) LoadUser(ctx context.Context, e boil.ContextExecutor, singular
 	var object *Progress
 
 	if singular {
-		object = maybeProgress.(*Progress)
+		var ok bool
+		object, ok = maybeProgress.(*Progress)
+		if !ok {
+			object = new(Progress)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeProgress)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeProgress))
+			}
+		}
 	} else {
-		slice = *maybeProgress.(*[]*Progress)
+		s, ok := maybeProgress.(*[]*Progress)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeProgress)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeProgress))
+			}
+		}
 	}
 
 	args := make([]interface{}, 0, 1)
@@ -604,7 +663,7 @@ func (progressL) LoadUser(ctx context.Context, e boil.ContextExecutor, singular
 		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
 	}
 
-	if len(progressAfterSelectHooks) != 0 {
+	if len(userAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
@@ -739,7 +798,12 @@ func (o *Progress) SetUser(ctx context.Context, exec boil.ContextExecutor, inser
 // Progresses retrieves all the records using an executor.
 func Progresses(mods ...qm.QueryMod) progressQuery {
 	mods = append(mods, qm.From("\"progress\""))
-	return progressQuery{NewQuery(mods...)}
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"progress\".*"})
+	}
+
+	return progressQuery{q}
 }
 
 // FindProgress retrieves a single record by ID with an executor.
@@ -759,7 +823,7 @@ func FindProgress(ctx context.Context, exec boil.ContextExecutor, iD int, select
 
 	err := q.Bind(ctx, exec, progressObj)
 	if err != nil {
-		if errors.Cause(err) == sql.ErrNoRows {
+		if errors.Is(err, sql.ErrNoRows) {
 			return nil, sql.ErrNoRows
 		}
 		return nil, errors.Wrap(err, "models: unable to select from progress")
@@ -1033,6 +1097,7 @@ func (o *Progress) Upsert(ctx context.Context, exec boil.ContextExecutor, update
 			progressColumnsWithoutDefault,
 			nzDefaults,
 		)
+
 		update := updateColumns.UpdateColumnSet(
 			progressAllColumns,
 			progressPrimaryKeyColumns,
@@ -1075,7 +1140,7 @@ func (o *Progress) Upsert(ctx context.Context, exec boil.ContextExecutor, update
 	}
 	if len(cache.retMapping) != 0 {
 		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
-		if err == sql.ErrNoRows {
+		if errors.Is(err, sql.ErrNoRows) {
 			err = nil // Postgres doesn't return anything when there's no update
 		}
 	} else {
@@ -1260,3 +1325,8 @@ func ProgressExists(ctx context.Context, exec boil.ContextExecutor, iD int) (boo
 
 	return exists, nil
 }
+
+// Exists checks if the Progress row exists.
+func (o *Progress) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return ProgressExists(ctx, exec, o.ID)
+}
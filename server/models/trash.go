@@ -0,0 +1,1519 @@
+// Code generated by SQLBoiler 4.14.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// Trash is an object representing the database table.
+type Trash struct {
+	NodeID           int       `boil:"node_id" json:"node_id" toml:"node_id" yaml:"node_id"`
+	UserID           int       `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	OriginalParentID null.Int  `boil:"original_parent_id" json:"original_parent_id,omitempty" toml:"original_parent_id" yaml:"original_parent_id,omitempty"`
+	OriginalName     string    `boil:"original_name" json:"original_name" toml:"original_name" yaml:"original_name"`
+	DeletedOn        time.Time `boil:"deleted_on" json:"deleted_on" toml:"deleted_on" yaml:"deleted_on"`
+
+	R *trashR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L trashL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var TrashColumns = struct {
+	NodeID           string
+	UserID           string
+	OriginalParentID string
+	OriginalName     string
+	DeletedOn        string
+}{
+	NodeID:           "node_id",
+	UserID:           "user_id",
+	OriginalParentID: "original_parent_id",
+	OriginalName:     "original_name",
+	DeletedOn:        "deleted_on",
+}
+
+var TrashTableColumns = struct {
+	NodeID           string
+	UserID           string
+	OriginalParentID string
+	OriginalName     string
+	DeletedOn        string
+}{
+	NodeID:           "trash.node_id",
+	UserID:           "trash.user_id",
+	OriginalParentID: "trash.original_parent_id",
+	OriginalName:     "trash.original_name",
+	DeletedOn:        "trash.deleted_on",
+}
+
+// Generated where
+
+var TrashWhere = struct {
+	NodeID           whereHelperint
+	UserID           whereHelperint
+	OriginalParentID whereHelpernull_Int
+	OriginalName     whereHelperstring
+	DeletedOn        whereHelpertime_Time
+}{
+	NodeID:           whereHelperint{field: "\"trash\".\"node_id\""},
+	UserID:           whereHelperint{field: "\"trash\".\"user_id\""},
+	OriginalParentID: whereHelpernull_Int{field: "\"trash\".\"original_parent_id\""},
+	OriginalName:     whereHelperstring{field: "\"trash\".\"original_name\""},
+	DeletedOn:        whereHelpertime_Time{field: "\"trash\".\"deleted_on\""},
+}
+
+// TrashRels is where relationship names are stored.
+var TrashRels = struct {
+	Node           string
+	OriginalParent string
+	User           string
+}{
+	Node:           "Node",
+	OriginalParent: "OriginalParent",
+	User:           "User",
+}
+
+// trashR is where relationships are stored.
+type trashR struct {
+	Node           *Node `boil:"Node" json:"Node" toml:"Node" yaml:"Node"`
+	OriginalParent *Node `boil:"OriginalParent" json:"OriginalParent" toml:"OriginalParent" yaml:"OriginalParent"`
+	User           *User `boil:"User" json:"User" toml:"User" yaml:"User"`
+}
+
+// NewStruct creates a new relationship struct
+func (*trashR) NewStruct() *trashR {
+	return &trashR{}
+}
+
+func (r *trashR) GetNode() *Node {
+	if r == nil {
+		return nil
+	}
+	return r.Node
+}
+
+func (r *trashR) GetOriginalParent() *Node {
+	if r == nil {
+		return nil
+	}
+	return r.OriginalParent
+}
+
+func (r *trashR) GetUser() *User {
+	if r == nil {
+		return nil
+	}
+	return r.User
+}
+
+// trashL is where Load methods for each relationship are stored.
+type trashL struct{}
+
+var (
+	trashAllColumns            = []string{"node_id", "user_id", "original_parent_id", "original_name", "deleted_on"}
+	trashColumnsWithoutDefault = []string{"node_id", "user_id", "original_name"}
+	trashColumnsWithDefault    = []string{"original_parent_id", "deleted_on"}
+	trashPrimaryKeyColumns     = []string{"node_id"}
+	trashGeneratedColumns      = []string{}
+)
+
+type (
+	// TrashSlice is an alias for a slice of pointers to Trash.
+	// This should almost always be used instead of []Trash.
+	TrashSlice []*Trash
+	// TrashHook is the signature for custom Trash hook methods
+	TrashHook func(context.Context, boil.ContextExecutor, *Trash) error
+
+	trashQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	trashType                 = reflect.TypeOf(&Trash{})
+	trashMapping              = queries.MakeStructMapping(trashType)
+	trashPrimaryKeyMapping, _ = queries.BindMapping(trashType, trashMapping, trashPrimaryKeyColumns)
+	trashInsertCacheMut       sync.RWMutex
+	trashInsertCache          = make(map[string]insertCache)
+	trashUpdateCacheMut       sync.RWMutex
+	trashUpdateCache          = make(map[string]updateCache)
+	trashUpsertCacheMut       sync.RWMutex
+	trashUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var trashAfterSelectHooks []TrashHook
+
+var trashBeforeInsertHooks []TrashHook
+var trashAfterInsertHooks []TrashHook
+
+var trashBeforeUpdateHooks []TrashHook
+var trashAfterUpdateHooks []TrashHook
+
+var trashBeforeDeleteHooks []TrashHook
+var trashAfterDeleteHooks []TrashHook
+
+var trashBeforeUpsertHooks []TrashHook
+var trashAfterUpsertHooks []TrashHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *Trash) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range trashAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *Trash) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range trashBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *Trash) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range trashAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *Trash) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range trashBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *Trash) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range trashAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *Trash) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range trashBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *Trash) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range trashAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *Trash) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range trashBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *Trash) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range trashAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddTrashHook registers your hook function for all future operations.
+func AddTrashHook(hookPoint boil.HookPoint, trashHook TrashHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		trashAfterSelectHooks = append(trashAfterSelectHooks, trashHook)
+	case boil.BeforeInsertHook:
+		trashBeforeInsertHooks = append(trashBeforeInsertHooks, trashHook)
+	case boil.AfterInsertHook:
+		trashAfterInsertHooks = append(trashAfterInsertHooks, trashHook)
+	case boil.BeforeUpdateHook:
+		trashBeforeUpdateHooks = append(trashBeforeUpdateHooks, trashHook)
+	case boil.AfterUpdateHook:
+		trashAfterUpdateHooks = append(trashAfterUpdateHooks, trashHook)
+	case boil.BeforeDeleteHook:
+		trashBeforeDeleteHooks = append(trashBeforeDeleteHooks, trashHook)
+	case boil.AfterDeleteHook:
+		trashAfterDeleteHooks = append(trashAfterDeleteHooks, trashHook)
+	case boil.BeforeUpsertHook:
+		trashBeforeUpsertHooks = append(trashBeforeUpsertHooks, trashHook)
+	case boil.AfterUpsertHook:
+		trashAfterUpsertHooks = append(trashAfterUpsertHooks, trashHook)
+	}
+}
+
+// One returns a single trash record from the query.
+func (q trashQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Trash, error) {
+	o := &Trash{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for trash")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all Trash records from the query.
+func (q trashQuery) All(ctx context.Context, exec boil.ContextExecutor) (TrashSlice, error) {
+	var o []*Trash
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to Trash slice")
+	}
+
+	if len(trashAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all Trash records in the query.
+func (q trashQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count trash rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q trashQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if trash exists")
+	}
+
+	return count > 0, nil
+}
+
+// Node pointed to by the foreign key.
+func (o *Trash) Node(mods ...qm.QueryMod) nodeQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.NodeID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Nodes(queryMods...)
+}
+
+// OriginalParent pointed to by the foreign key.
+func (o *Trash) OriginalParent(mods ...qm.QueryMod) nodeQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.OriginalParentID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Nodes(queryMods...)
+}
+
+// User pointed to by the foreign key.
+func (o *Trash) User(mods ...qm.QueryMod) userQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.UserID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Users(queryMods...)
+}
+
+// LoadNode allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (trashL) LoadNode(ctx context.Context, e boil.ContextExecutor, singular bool, maybeTrash interface{}, mods queries.Applicator) error {
+	var slice []*Trash
+	var object *Trash
+
+	if singular {
+		var ok bool
+		object, ok = maybeTrash.(*Trash)
+		if !ok {
+			object = new(Trash)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeTrash)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeTrash))
+			}
+		}
+	} else {
+		s, ok := maybeTrash.(*[]*Trash)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeTrash)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeTrash))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &trashR{}
+		}
+		args = append(args, object.NodeID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &trashR{}
+			}
+
+			for _, a := range args {
+				if a == obj.NodeID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.NodeID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`nodes`),
+		qm.WhereIn(`nodes.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Node")
+	}
+
+	var resultSlice []*Node
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Node")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for nodes")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for nodes")
+	}
+
+	if len(nodeAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Node = foreign
+		if foreign.R == nil {
+			foreign.R = &nodeR{}
+		}
+		foreign.R.Trash = object
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.NodeID == foreign.ID {
+				local.R.Node = foreign
+				if foreign.R == nil {
+					foreign.R = &nodeR{}
+				}
+				foreign.R.Trash = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadOriginalParent allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (trashL) LoadOriginalParent(ctx context.Context, e boil.ContextExecutor, singular bool, maybeTrash interface{}, mods queries.Applicator) error {
+	var slice []*Trash
+	var object *Trash
+
+	if singular {
+		var ok bool
+		object, ok = maybeTrash.(*Trash)
+		if !ok {
+			object = new(Trash)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeTrash)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeTrash))
+			}
+		}
+	} else {
+		s, ok := maybeTrash.(*[]*Trash)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeTrash)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeTrash))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &trashR{}
+		}
+		if !queries.IsNil(object.OriginalParentID) {
+			args = append(args, object.OriginalParentID)
+		}
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &trashR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.OriginalParentID) {
+					continue Outer
+				}
+			}
+
+			if !queries.IsNil(obj.OriginalParentID) {
+				args = append(args, obj.OriginalParentID)
+			}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`nodes`),
+		qm.WhereIn(`nodes.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Node")
+	}
+
+	var resultSlice []*Node
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Node")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for nodes")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for nodes")
+	}
+
+	if len(nodeAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.OriginalParent = foreign
+		if foreign.R == nil {
+			foreign.R = &nodeR{}
+		}
+		foreign.R.OriginalParentTrashes = append(foreign.R.OriginalParentTrashes, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.OriginalParentID, foreign.ID) {
+				local.R.OriginalParent = foreign
+				if foreign.R == nil {
+					foreign.R = &nodeR{}
+				}
+				foreign.R.OriginalParentTrashes = append(foreign.R.OriginalParentTrashes, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadUser allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (trashL) LoadUser(ctx context.Context, e boil.ContextExecutor, singular bool, maybeTrash interface{}, mods queries.Applicator) error {
+	var slice []*Trash
+	var object *Trash
+
+	if singular {
+		var ok bool
+		object, ok = maybeTrash.(*Trash)
+		if !ok {
+			object = new(Trash)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeTrash)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeTrash))
+			}
+		}
+	} else {
+		s, ok := maybeTrash.(*[]*Trash)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeTrash)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeTrash))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &trashR{}
+		}
+		args = append(args, object.UserID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &trashR{}
+			}
+
+			for _, a := range args {
+				if a == obj.UserID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.UserID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`users`),
+		qm.WhereIn(`users.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for users")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
+	}
+
+	if len(userAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.User = foreign
+		if foreign.R == nil {
+			foreign.R = &userR{}
+		}
+		foreign.R.Trashes = append(foreign.R.Trashes, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.UserID == foreign.ID {
+				local.R.User = foreign
+				if foreign.R == nil {
+					foreign.R = &userR{}
+				}
+				foreign.R.Trashes = append(foreign.R.Trashes, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetNode of the trash to the related item.
+// Sets o.R.Node to related.
+// Adds o to related.R.Trash.
+func (o *Trash) SetNode(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Node) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"trash\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"node_id"}),
+		strmangle.WhereClause("\"", "\"", 2, trashPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.NodeID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.NodeID = related.ID
+	if o.R == nil {
+		o.R = &trashR{
+			Node: related,
+		}
+	} else {
+		o.R.Node = related
+	}
+
+	if related.R == nil {
+		related.R = &nodeR{
+			Trash: o,
+		}
+	} else {
+		related.R.Trash = o
+	}
+
+	return nil
+}
+
+// SetOriginalParent of the trash to the related item.
+// Sets o.R.OriginalParent to related.
+// Adds o to related.R.OriginalParentTrashes.
+func (o *Trash) SetOriginalParent(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Node) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"trash\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"original_parent_id"}),
+		strmangle.WhereClause("\"", "\"", 2, trashPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.NodeID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.OriginalParentID, related.ID)
+	if o.R == nil {
+		o.R = &trashR{
+			OriginalParent: related,
+		}
+	} else {
+		o.R.OriginalParent = related
+	}
+
+	if related.R == nil {
+		related.R = &nodeR{
+			OriginalParentTrashes: TrashSlice{o},
+		}
+	} else {
+		related.R.OriginalParentTrashes = append(related.R.OriginalParentTrashes, o)
+	}
+
+	return nil
+}
+
+// RemoveOriginalParent relationship.
+// Sets o.R.OriginalParent to nil.
+// Removes o from all passed in related items' relationships struct.
+func (o *Trash) RemoveOriginalParent(ctx context.Context, exec boil.ContextExecutor, related *Node) error {
+	var err error
+
+	queries.SetScanner(&o.OriginalParentID, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("original_parent_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.OriginalParent = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.OriginalParentTrashes {
+		if queries.Equal(o.OriginalParentID, ri.OriginalParentID) {
+			continue
+		}
+
+		ln := len(related.R.OriginalParentTrashes)
+		if ln > 1 && i < ln-1 {
+			related.R.OriginalParentTrashes[i] = related.R.OriginalParentTrashes[ln-1]
+		}
+		related.R.OriginalParentTrashes = related.R.OriginalParentTrashes[:ln-1]
+		break
+	}
+	return nil
+}
+
+// SetUser of the trash to the related item.
+// Sets o.R.User to related.
+// Adds o to related.R.Trashes.
+func (o *Trash) SetUser(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"trash\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+		strmangle.WhereClause("\"", "\"", 2, trashPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.NodeID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.UserID = related.ID
+	if o.R == nil {
+		o.R = &trashR{
+			User: related,
+		}
+	} else {
+		o.R.User = related
+	}
+
+	if related.R == nil {
+		related.R = &userR{
+			Trashes: TrashSlice{o},
+		}
+	} else {
+		related.R.Trashes = append(related.R.Trashes, o)
+	}
+
+	return nil
+}
+
+// Trashes retrieves all the records using an executor.
+func Trashes(mods ...qm.QueryMod) trashQuery {
+	mods = append(mods, qm.From("\"trash\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"trash\".*"})
+	}
+
+	return trashQuery{q}
+}
+
+// FindTrash retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindTrash(ctx context.Context, exec boil.ContextExecutor, nodeID int, selectCols ...string) (*Trash, error) {
+	trashObj := &Trash{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"trash\" where \"node_id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, nodeID)
+
+	err := q.Bind(ctx, exec, trashObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from trash")
+	}
+
+	if err = trashObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return trashObj, err
+	}
+
+	return trashObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *Trash) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no trash provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(trashColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	trashInsertCacheMut.RLock()
+	cache, cached := trashInsertCache[key]
+	trashInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			trashAllColumns,
+			trashColumnsWithDefault,
+			trashColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(trashType, trashMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(trashType, trashMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"trash\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"trash\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into trash")
+	}
+
+	if !cached {
+		trashInsertCacheMut.Lock()
+		trashInsertCache[key] = cache
+		trashInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the Trash.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *Trash) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	trashUpdateCacheMut.RLock()
+	cache, cached := trashUpdateCache[key]
+	trashUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			trashAllColumns,
+			trashPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update trash, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"trash\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, trashPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(trashType, trashMapping, append(wl, trashPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update trash row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for trash")
+	}
+
+	if !cached {
+		trashUpdateCacheMut.Lock()
+		trashUpdateCache[key] = cache
+		trashUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q trashQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for trash")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for trash")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o TrashSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), trashPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"trash\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, trashPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in trash slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all trash")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *Trash) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no trash provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(trashColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	trashUpsertCacheMut.RLock()
+	cache, cached := trashUpsertCache[key]
+	trashUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			trashAllColumns,
+			trashColumnsWithDefault,
+			trashColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			trashAllColumns,
+			trashPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert trash, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(trashPrimaryKeyColumns))
+			copy(conflict, trashPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"trash\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(trashType, trashMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(trashType, trashMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert trash")
+	}
+
+	if !cached {
+		trashUpsertCacheMut.Lock()
+		trashUpsertCache[key] = cache
+		trashUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single Trash record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *Trash) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no Trash provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), trashPrimaryKeyMapping)
+	sql := "DELETE FROM \"trash\" WHERE \"node_id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from trash")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for trash")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q trashQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no trashQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from trash")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for trash")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o TrashSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(trashBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), trashPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"trash\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, trashPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from trash slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for trash")
+	}
+
+	if len(trashAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *Trash) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindTrash(ctx, exec, o.NodeID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *TrashSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := TrashSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), trashPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"trash\".* FROM \"trash\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, trashPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in TrashSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// TrashExists checks if the Trash row exists.
+func TrashExists(ctx context.Context, exec boil.ContextExecutor, nodeID int) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"trash\" where \"node_id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, nodeID)
+	}
+	row := exec.QueryRowContext(ctx, sql, nodeID)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if trash exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the Trash row exists.
+func (o *Trash) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return TrashExists(ctx, exec, o.NodeID)
+}
@@ -1,4 +1,4 @@
-// Code generated by SQLBoiler 4.6.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// Code generated by SQLBoiler 4.14.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
 // This file is meant to be re-generated in place and/or deleted at any time.
 
 package models
@@ -26,9 +26,11 @@ import (
 type User struct {
 	ID       int         `boil:"id" json:"id" toml:"id" yaml:"id"`
 	Name     string      `boil:"name" json:"name" toml:"name" yaml:"name"`
-	Password null.String `boil:"password" json:"password,omitempty" toml:"password" yaml:"password,omitempty"`
+	Password null.String `boil:"password" json:"-" toml:"-" yaml:"-"`
 	Admin    bool        `boil:"admin" json:"admin" toml:"admin" yaml:"admin"`
 	RootID   null.Int    `boil:"root_id" json:"root_id,omitempty" toml:"root_id" yaml:"root_id,omitempty"`
+	TrashID  null.Int    `boil:"trash_id" json:"trash_id,omitempty" toml:"trash_id" yaml:"trash_id,omitempty"`
+	Quota    null.Int64  `boil:"quota" json:"quota,omitempty" toml:"quota" yaml:"quota,omitempty"`
 
 	R *userR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L userL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -40,12 +42,16 @@ var UserColumns = struct {
 	Password string
 	Admin    string
 	RootID   string
+	TrashID  string
+	Quota    string
 }{
 	ID:       "id",
 	Name:     "name",
 	Password: "password",
 	Admin:    "admin",
 	RootID:   "root_id",
+	TrashID:  "trash_id",
+	Quota:    "quota",
 }
 
 var UserTableColumns = struct {
@@ -54,72 +60,81 @@ var UserTableColumns = struct {
 	Password string
 	Admin    string
 	RootID   string
+	TrashID  string
+	Quota    string
 }{
 	ID:       "users.id",
 	Name:     "users.name",
 	Password: "users.password",
 	Admin:    "users.admin",
 	RootID:   "users.root_id",
+	TrashID:  "users.trash_id",
+	Quota:    "users.quota",
 }
 
 // Generated where
 
-type whereHelpernull_String struct{ field string }
-
-func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpernull_String) NEQ(x null.String) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
-func (w whereHelpernull_String) LT(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpernull_String) LTE(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpernull_String) GT(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
 var UserWhere = struct {
 	ID       whereHelperint
 	Name     whereHelperstring
 	Password whereHelpernull_String
 	Admin    whereHelperbool
 	RootID   whereHelpernull_Int
+	TrashID  whereHelpernull_Int
+	Quota    whereHelpernull_Int64
 }{
 	ID:       whereHelperint{field: "\"users\".\"id\""},
 	Name:     whereHelperstring{field: "\"users\".\"name\""},
 	Password: whereHelpernull_String{field: "\"users\".\"password\""},
 	Admin:    whereHelperbool{field: "\"users\".\"admin\""},
 	RootID:   whereHelpernull_Int{field: "\"users\".\"root_id\""},
+	TrashID:  whereHelpernull_Int{field: "\"users\".\"trash_id\""},
+	Quota:    whereHelpernull_Int64{field: "\"users\".\"quota\""},
 }
 
 // UserRels is where relationship names are stored.
 var UserRels = struct {
 	Root       string
+	Trash      string
+	Grants     string
+	Groups     string
 	Infos      string
+	Jobs       string
 	OwnerNodes string
 	Progresses string
+	Shares     string
+	Trashes    string
+	UserKeys   string
+	Versions   string
 }{
 	Root:       "Root",
+	Trash:      "Trash",
+	Grants:     "Grants",
+	Groups:     "Groups",
 	Infos:      "Infos",
+	Jobs:       "Jobs",
 	OwnerNodes: "OwnerNodes",
 	Progresses: "Progresses",
+	Shares:     "Shares",
+	Trashes:    "Trashes",
+	UserKeys:   "UserKeys",
+	Versions:   "Versions",
 }
 
 // userR is where relationships are stored.
 type userR struct {
 	Root       *Node         `boil:"Root" json:"Root" toml:"Root" yaml:"Root"`
+	Trash      *Node         `boil:"Trash" json:"Trash" toml:"Trash" yaml:"Trash"`
+	Grants     GrantSlice    `boil:"Grants" json:"Grants" toml:"Grants" yaml:"Grants"`
+	Groups     GroupSlice    `boil:"Groups" json:"Groups" toml:"Groups" yaml:"Groups"`
 	Infos      InfoSlice     `boil:"Infos" json:"Infos" toml:"Infos" yaml:"Infos"`
+	Jobs       JobSlice      `boil:"Jobs" json:"Jobs" toml:"Jobs" yaml:"Jobs"`
 	OwnerNodes NodeSlice     `boil:"OwnerNodes" json:"OwnerNodes" toml:"OwnerNodes" yaml:"OwnerNodes"`
 	Progresses ProgressSlice `boil:"Progresses" json:"Progresses" toml:"Progresses" yaml:"Progresses"`
+	Shares     ShareSlice    `boil:"Shares" json:"Shares" toml:"Shares" yaml:"Shares"`
+	Trashes    TrashSlice    `boil:"Trashes" json:"Trashes" toml:"Trashes" yaml:"Trashes"`
+	UserKeys   UserKeySlice  `boil:"UserKeys" json:"UserKeys" toml:"UserKeys" yaml:"UserKeys"`
+	Versions   VersionSlice  `boil:"Versions" json:"Versions" toml:"Versions" yaml:"Versions"`
 }
 
 // NewStruct creates a new relationship struct
@@ -127,14 +142,99 @@ func (*userR) NewStruct() *userR {
 	return &userR{}
 }
 
+func (r *userR) GetRoot() *Node {
+	if r == nil {
+		return nil
+	}
+	return r.Root
+}
+
+func (r *userR) GetTrash() *Node {
+	if r == nil {
+		return nil
+	}
+	return r.Trash
+}
+
+func (r *userR) GetGrants() GrantSlice {
+	if r == nil {
+		return nil
+	}
+	return r.Grants
+}
+
+func (r *userR) GetGroups() GroupSlice {
+	if r == nil {
+		return nil
+	}
+	return r.Groups
+}
+
+func (r *userR) GetInfos() InfoSlice {
+	if r == nil {
+		return nil
+	}
+	return r.Infos
+}
+
+func (r *userR) GetJobs() JobSlice {
+	if r == nil {
+		return nil
+	}
+	return r.Jobs
+}
+
+func (r *userR) GetOwnerNodes() NodeSlice {
+	if r == nil {
+		return nil
+	}
+	return r.OwnerNodes
+}
+
+func (r *userR) GetProgresses() ProgressSlice {
+	if r == nil {
+		return nil
+	}
+	return r.Progresses
+}
+
+func (r *userR) GetShares() ShareSlice {
+	if r == nil {
+		return nil
+	}
+	return r.Shares
+}
+
+func (r *userR) GetTrashes() TrashSlice {
+	if r == nil {
+		return nil
+	}
+	return r.Trashes
+}
+
+func (r *userR) GetUserKeys() UserKeySlice {
+	if r == nil {
+		return nil
+	}
+	return r.UserKeys
+}
+
+func (r *userR) GetVersions() VersionSlice {
+	if r == nil {
+		return nil
+	}
+	return r.Versions
+}
+
 // userL is where Load methods for each relationship are stored.
 type userL struct{}
 
 var (
-	userAllColumns            = []string{"id", "name", "password", "admin", "root_id"}
-	userColumnsWithoutDefault = []string{"name", "password", "root_id"}
-	userColumnsWithDefault    = []string{"id", "admin"}
+	userAllColumns            = []string{"id", "name", "password", "admin", "root_id", "trash_id", "quota"}
+	userColumnsWithoutDefault = []string{"name"}
+	userColumnsWithDefault    = []string{"id", "password", "admin", "root_id", "trash_id", "quota"}
 	userPrimaryKeyColumns     = []string{"id"}
+	userGeneratedColumns      = []string{}
 )
 
 type (
@@ -170,24 +270,27 @@ var (
 	_ = qmhelper.Where
 )
 
-var userBeforeInsertHooks []UserHook
-var userBeforeUpdateHooks []UserHook
-var userBeforeDeleteHooks []UserHook
-var userBeforeUpsertHooks []UserHook
+var userAfterSelectHooks []UserHook
 
+var userBeforeInsertHooks []UserHook
 var userAfterInsertHooks []UserHook
-var userAfterSelectHooks []UserHook
+
+var userBeforeUpdateHooks []UserHook
 var userAfterUpdateHooks []UserHook
+
+var userBeforeDeleteHooks []UserHook
 var userAfterDeleteHooks []UserHook
+
+var userBeforeUpsertHooks []UserHook
 var userAfterUpsertHooks []UserHook
 
-// doBeforeInsertHooks executes all "before insert" hooks.
-func (o *User) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *User) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range userBeforeInsertHooks {
+	for _, hook := range userAfterSelectHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -196,13 +299,13 @@ func (o *User) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecuto
 	return nil
 }
 
-// doBeforeUpdateHooks executes all "before Update" hooks.
-func (o *User) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *User) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range userBeforeUpdateHooks {
+	for _, hook := range userBeforeInsertHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -211,13 +314,13 @@ func (o *User) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecuto
 	return nil
 }
 
-// doBeforeDeleteHooks executes all "before Delete" hooks.
-func (o *User) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *User) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range userBeforeDeleteHooks {
+	for _, hook := range userAfterInsertHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -226,13 +329,13 @@ func (o *User) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecuto
 	return nil
 }
 
-// doBeforeUpsertHooks executes all "before Upsert" hooks.
-func (o *User) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *User) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range userBeforeUpsertHooks {
+	for _, hook := range userBeforeUpdateHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -241,13 +344,13 @@ func (o *User) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecuto
 	return nil
 }
 
-// doAfterInsertHooks executes all "after Insert" hooks.
-func (o *User) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *User) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range userAfterInsertHooks {
+	for _, hook := range userAfterUpdateHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -256,13 +359,13 @@ func (o *User) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor
 	return nil
 }
 
-// doAfterSelectHooks executes all "after Select" hooks.
-func (o *User) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *User) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range userAfterSelectHooks {
+	for _, hook := range userBeforeDeleteHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -271,13 +374,13 @@ func (o *User) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor
 	return nil
 }
 
-// doAfterUpdateHooks executes all "after Update" hooks.
-func (o *User) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *User) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range userAfterUpdateHooks {
+	for _, hook := range userAfterDeleteHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -286,13 +389,13 @@ func (o *User) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor
 	return nil
 }
 
-// doAfterDeleteHooks executes all "after Delete" hooks.
-func (o *User) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *User) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
 	if boil.HooksAreSkipped(ctx) {
 		return nil
 	}
 
-	for _, hook := range userAfterDeleteHooks {
+	for _, hook := range userBeforeUpsertHooks {
 		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
@@ -319,22 +422,22 @@ func (o *User) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor
 // AddUserHook registers your hook function for all future operations.
 func AddUserHook(hookPoint boil.HookPoint, userHook UserHook) {
 	switch hookPoint {
+	case boil.AfterSelectHook:
+		userAfterSelectHooks = append(userAfterSelectHooks, userHook)
 	case boil.BeforeInsertHook:
 		userBeforeInsertHooks = append(userBeforeInsertHooks, userHook)
-	case boil.BeforeUpdateHook:
-		userBeforeUpdateHooks = append(userBeforeUpdateHooks, userHook)
-	case boil.BeforeDeleteHook:
-		userBeforeDeleteHooks = append(userBeforeDeleteHooks, userHook)
-	case boil.BeforeUpsertHook:
-		userBeforeUpsertHooks = append(userBeforeUpsertHooks, userHook)
 	case boil.AfterInsertHook:
 		userAfterInsertHooks = append(userAfterInsertHooks, userHook)
-	case boil.AfterSelectHook:
-		userAfterSelectHooks = append(userAfterSelectHooks, userHook)
+	case boil.BeforeUpdateHook:
+		userBeforeUpdateHooks = append(userBeforeUpdateHooks, userHook)
 	case boil.AfterUpdateHook:
 		userAfterUpdateHooks = append(userAfterUpdateHooks, userHook)
+	case boil.BeforeDeleteHook:
+		userBeforeDeleteHooks = append(userBeforeDeleteHooks, userHook)
 	case boil.AfterDeleteHook:
 		userAfterDeleteHooks = append(userAfterDeleteHooks, userHook)
+	case boil.BeforeUpsertHook:
+		userBeforeUpsertHooks = append(userBeforeUpsertHooks, userHook)
 	case boil.AfterUpsertHook:
 		userAfterUpsertHooks = append(userAfterUpsertHooks, userHook)
 	}
@@ -348,7 +451,7 @@ func (q userQuery) One(ctx context.Context, exec boil.ContextExecutor) (*User, e
 
 	err := q.Bind(ctx, exec, o)
 	if err != nil {
-		if errors.Cause(err) == sql.ErrNoRows {
+		if errors.Is(err, sql.ErrNoRows) {
 			return nil, sql.ErrNoRows
 		}
 		return nil, errors.Wrap(err, "models: failed to execute a one query for users")
@@ -420,10 +523,47 @@ func (o *User) Root(mods ...qm.QueryMod) nodeQuery {
 
 	queryMods = append(queryMods, mods...)
 
-	query := Nodes(queryMods...)
-	queries.SetFrom(query.Query, "\"nodes\"")
+	return Nodes(queryMods...)
+}
+
+// Trash pointed to by the foreign key.
+func (o *User) Trash(mods ...qm.QueryMod) nodeQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.TrashID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Nodes(queryMods...)
+}
+
+// Grants retrieves all the grant's Grants with an executor.
+func (o *User) Grants(mods ...qm.QueryMod) grantQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"grants\".\"user_id\"=?", o.ID),
+	)
+
+	return Grants(queryMods...)
+}
+
+// Groups retrieves all the group's Groups with an executor.
+func (o *User) Groups(mods ...qm.QueryMod) groupQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
 
-	return query
+	queryMods = append(queryMods,
+		qm.InnerJoin("\"group_members\" on \"groups\".\"id\" = \"group_members\".\"group_id\""),
+		qm.Where("\"group_members\".\"user_id\"=?", o.ID),
+	)
+
+	return Groups(queryMods...)
 }
 
 // Infos retrieves all the info's Infos with an executor.
@@ -437,14 +577,21 @@ func (o *User) Infos(mods ...qm.QueryMod) infoQuery {
 		qm.Where("\"infos\".\"user_id\"=?", o.ID),
 	)
 
-	query := Infos(queryMods...)
-	queries.SetFrom(query.Query, "\"infos\"")
+	return Infos(queryMods...)
+}
 
-	if len(queries.GetSelect(query.Query)) == 0 {
-		queries.SetSelect(query.Query, []string{"\"infos\".*"})
+// Jobs retrieves all the job's Jobs with an executor.
+func (o *User) Jobs(mods ...qm.QueryMod) jobQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
 	}
 
-	return query
+	queryMods = append(queryMods,
+		qm.Where("\"jobs\".\"user_id\"=?", o.ID),
+	)
+
+	return Jobs(queryMods...)
 }
 
 // OwnerNodes retrieves all the node's Nodes with an executor via owner_id column.
@@ -458,14 +605,7 @@ func (o *User) OwnerNodes(mods ...qm.QueryMod) nodeQuery {
 		qm.Where("\"nodes\".\"owner_id\"=?", o.ID),
 	)
 
-	query := Nodes(queryMods...)
-	queries.SetFrom(query.Query, "\"nodes\"")
-
-	if len(queries.GetSelect(query.Query)) == 0 {
-		queries.SetSelect(query.Query, []string{"\"nodes\".*"})
-	}
-
-	return query
+	return Nodes(queryMods...)
 }
 
 // Progresses retrieves all the progress's Progresses with an executor.
@@ -479,14 +619,63 @@ func (o *User) Progresses(mods ...qm.QueryMod) progressQuery {
 		qm.Where("\"progress\".\"user_id\"=?", o.ID),
 	)
 
-	query := Progresses(queryMods...)
-	queries.SetFrom(query.Query, "\"progress\"")
+	return Progresses(queryMods...)
+}
+
+// Shares retrieves all the share's Shares with an executor.
+func (o *User) Shares(mods ...qm.QueryMod) shareQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"shares\".\"user_id\"=?", o.ID),
+	)
+
+	return Shares(queryMods...)
+}
+
+// Trashes retrieves all the trash's Trashes with an executor.
+func (o *User) Trashes(mods ...qm.QueryMod) trashQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"trash\".\"user_id\"=?", o.ID),
+	)
+
+	return Trashes(queryMods...)
+}
+
+// UserKeys retrieves all the user_key's UserKeys with an executor.
+func (o *User) UserKeys(mods ...qm.QueryMod) userKeyQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"user_keys\".\"user_id\"=?", o.ID),
+	)
+
+	return UserKeys(queryMods...)
+}
 
-	if len(queries.GetSelect(query.Query)) == 0 {
-		queries.SetSelect(query.Query, []string{"\"progress\".*"})
+// Versions retrieves all the version's Versions with an executor.
+func (o *User) Versions(mods ...qm.QueryMod) versionQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
 	}
 
-	return query
+	queryMods = append(queryMods,
+		qm.Where("\"versions\".\"user_id\"=?", o.ID),
+	)
+
+	return Versions(queryMods...)
 }
 
 // LoadRoot allows an eager lookup of values, cached into the
@@ -496,9 +685,25 @@ func (userL) LoadRoot(ctx context.Context, e boil.ContextExecutor, singular bool
 	var object *User
 
 	if singular {
-		object = maybeUser.(*User)
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
 	} else {
-		slice = *maybeUser.(*[]*User)
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
 	}
 
 	args := make([]interface{}, 0, 1)
@@ -559,7 +764,7 @@ func (userL) LoadRoot(ctx context.Context, e boil.ContextExecutor, singular bool
 		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for nodes")
 	}
 
-	if len(userAfterSelectHooks) != 0 {
+	if len(nodeAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
@@ -597,16 +802,32 @@ func (userL) LoadRoot(ctx context.Context, e boil.ContextExecutor, singular bool
 	return nil
 }
 
-// LoadInfos allows an eager lookup of values, cached into the
-// loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (userL) LoadInfos(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+// LoadTrash allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (userL) LoadTrash(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
 	var slice []*User
 	var object *User
 
 	if singular {
-		object = maybeUser.(*User)
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
 	} else {
-		slice = *maybeUser.(*[]*User)
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
 	}
 
 	args := make([]interface{}, 0, 1)
@@ -614,7 +835,10 @@ func (userL) LoadInfos(ctx context.Context, e boil.ContextExecutor, singular boo
 		if object.R == nil {
 			object.R = &userR{}
 		}
-		args = append(args, object.ID)
+		if !queries.IsNil(object.TrashID) {
+			args = append(args, object.TrashID)
+		}
+
 	} else {
 	Outer:
 		for _, obj := range slice {
@@ -623,12 +847,15 @@ func (userL) LoadInfos(ctx context.Context, e boil.ContextExecutor, singular boo
 			}
 
 			for _, a := range args {
-				if a == obj.ID {
+				if queries.Equal(a, obj.TrashID) {
 					continue Outer
 				}
 			}
 
-			args = append(args, obj.ID)
+			if !queries.IsNil(obj.TrashID) {
+				args = append(args, obj.TrashID)
+			}
+
 		}
 	}
 
@@ -637,8 +864,8 @@ func (userL) LoadInfos(ctx context.Context, e boil.ContextExecutor, singular boo
 	}
 
 	query := NewQuery(
-		qm.From(`infos`),
-		qm.WhereIn(`infos.user_id in ?`, args...),
+		qm.From(`nodes`),
+		qm.WhereIn(`nodes.id in ?`, args...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -646,47 +873,51 @@ func (userL) LoadInfos(ctx context.Context, e boil.ContextExecutor, singular boo
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load infos")
+		return errors.Wrap(err, "failed to eager load Node")
 	}
 
-	var resultSlice []*Info
+	var resultSlice []*Node
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice infos")
+		return errors.Wrap(err, "failed to bind eager loaded slice Node")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on infos")
+		return errors.Wrap(err, "failed to close results of eager load for nodes")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for infos")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for nodes")
 	}
 
-	if len(infoAfterSelectHooks) != 0 {
+	if len(nodeAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
 			}
 		}
 	}
-	if singular {
-		object.R.Infos = resultSlice
-		for _, foreign := range resultSlice {
-			if foreign.R == nil {
-				foreign.R = &infoR{}
-			}
-			foreign.R.User = object
-		}
+
+	if len(resultSlice) == 0 {
 		return nil
 	}
 
-	for _, foreign := range resultSlice {
-		for _, local := range slice {
-			if local.ID == foreign.UserID {
-				local.R.Infos = append(local.R.Infos, foreign)
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Trash = foreign
+		if foreign.R == nil {
+			foreign.R = &nodeR{}
+		}
+		foreign.R.TrashUsers = append(foreign.R.TrashUsers, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.TrashID, foreign.ID) {
+				local.R.Trash = foreign
 				if foreign.R == nil {
-					foreign.R = &infoR{}
+					foreign.R = &nodeR{}
 				}
-				foreign.R.User = local
+				foreign.R.TrashUsers = append(foreign.R.TrashUsers, local)
 				break
 			}
 		}
@@ -695,16 +926,32 @@ func (userL) LoadInfos(ctx context.Context, e boil.ContextExecutor, singular boo
 	return nil
 }
 
-// LoadOwnerNodes allows an eager lookup of values, cached into the
+// LoadGrants allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (userL) LoadOwnerNodes(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+func (userL) LoadGrants(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
 	var slice []*User
 	var object *User
 
 	if singular {
-		object = maybeUser.(*User)
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
 	} else {
-		slice = *maybeUser.(*[]*User)
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
 	}
 
 	args := make([]interface{}, 0, 1)
@@ -735,8 +982,8 @@ func (userL) LoadOwnerNodes(ctx context.Context, e boil.ContextExecutor, singula
 	}
 
 	query := NewQuery(
-		qm.From(`nodes`),
-		qm.WhereIn(`nodes.owner_id in ?`, args...),
+		qm.From(`grants`),
+		qm.WhereIn(`grants.user_id in ?`, args...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -744,22 +991,22 @@ func (userL) LoadOwnerNodes(ctx context.Context, e boil.ContextExecutor, singula
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load nodes")
+		return errors.Wrap(err, "failed to eager load grants")
 	}
 
-	var resultSlice []*Node
+	var resultSlice []*Grant
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice nodes")
+		return errors.Wrap(err, "failed to bind eager loaded slice grants")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on nodes")
+		return errors.Wrap(err, "failed to close results in eager load on grants")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for nodes")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for grants")
 	}
 
-	if len(nodeAfterSelectHooks) != 0 {
+	if len(grantAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
@@ -767,24 +1014,24 @@ func (userL) LoadOwnerNodes(ctx context.Context, e boil.ContextExecutor, singula
 		}
 	}
 	if singular {
-		object.R.OwnerNodes = resultSlice
+		object.R.Grants = resultSlice
 		for _, foreign := range resultSlice {
 			if foreign.R == nil {
-				foreign.R = &nodeR{}
+				foreign.R = &grantR{}
 			}
-			foreign.R.Owner = object
+			foreign.R.User = object
 		}
 		return nil
 	}
 
 	for _, foreign := range resultSlice {
 		for _, local := range slice {
-			if queries.Equal(local.ID, foreign.OwnerID) {
-				local.R.OwnerNodes = append(local.R.OwnerNodes, foreign)
+			if queries.Equal(local.ID, foreign.UserID) {
+				local.R.Grants = append(local.R.Grants, foreign)
 				if foreign.R == nil {
-					foreign.R = &nodeR{}
+					foreign.R = &grantR{}
 				}
-				foreign.R.Owner = local
+				foreign.R.User = local
 				break
 			}
 		}
@@ -793,16 +1040,32 @@ func (userL) LoadOwnerNodes(ctx context.Context, e boil.ContextExecutor, singula
 	return nil
 }
 
-// LoadProgresses allows an eager lookup of values, cached into the
+// LoadGroups allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (userL) LoadProgresses(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+func (userL) LoadGroups(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
 	var slice []*User
 	var object *User
 
 	if singular {
-		object = maybeUser.(*User)
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
 	} else {
-		slice = *maybeUser.(*[]*User)
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
 	}
 
 	args := make([]interface{}, 0, 1)
@@ -833,8 +1096,10 @@ func (userL) LoadProgresses(ctx context.Context, e boil.ContextExecutor, singula
 	}
 
 	query := NewQuery(
-		qm.From(`progress`),
-		qm.WhereIn(`progress.user_id in ?`, args...),
+		qm.Select("\"groups\".\"id\", \"groups\".\"name\", \"a\".\"user_id\""),
+		qm.From("\"groups\""),
+		qm.InnerJoin("\"group_members\" as \"a\" on \"groups\".\"id\" = \"a\".\"group_id\""),
+		qm.WhereIn("\"a\".\"user_id\" in ?", args...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -842,22 +1107,36 @@ func (userL) LoadProgresses(ctx context.Context, e boil.ContextExecutor, singula
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load progress")
+		return errors.Wrap(err, "failed to eager load groups")
 	}
 
-	var resultSlice []*Progress
-	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice progress")
+	var resultSlice []*Group
+
+	var localJoinCols []int
+	for results.Next() {
+		one := new(Group)
+		var localJoinCol int
+
+		err = results.Scan(&one.ID, &one.Name, &localJoinCol)
+		if err != nil {
+			return errors.Wrap(err, "failed to scan eager loaded results for groups")
+		}
+		if err = results.Err(); err != nil {
+			return errors.Wrap(err, "failed to plebian-bind eager loaded slice groups")
+		}
+
+		resultSlice = append(resultSlice, one)
+		localJoinCols = append(localJoinCols, localJoinCol)
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on progress")
+		return errors.Wrap(err, "failed to close results in eager load on groups")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for progress")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for groups")
 	}
 
-	if len(progressAfterSelectHooks) != 0 {
+	if len(groupAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
@@ -865,24 +1144,25 @@ func (userL) LoadProgresses(ctx context.Context, e boil.ContextExecutor, singula
 		}
 	}
 	if singular {
-		object.R.Progresses = resultSlice
+		object.R.Groups = resultSlice
 		for _, foreign := range resultSlice {
 			if foreign.R == nil {
-				foreign.R = &progressR{}
+				foreign.R = &groupR{}
 			}
-			foreign.R.User = object
+			foreign.R.Users = append(foreign.R.Users, object)
 		}
 		return nil
 	}
 
-	for _, foreign := range resultSlice {
+	for i, foreign := range resultSlice {
+		localJoinCol := localJoinCols[i]
 		for _, local := range slice {
-			if local.ID == foreign.UserID {
-				local.R.Progresses = append(local.R.Progresses, foreign)
+			if local.ID == localJoinCol {
+				local.R.Groups = append(local.R.Groups, foreign)
 				if foreign.R == nil {
-					foreign.R = &progressR{}
+					foreign.R = &groupR{}
 				}
-				foreign.R.User = local
+				foreign.R.Users = append(foreign.R.Users, local)
 				break
 			}
 		}
@@ -891,136 +1171,1527 @@ func (userL) LoadProgresses(ctx context.Context, e boil.ContextExecutor, singula
 	return nil
 }
 
-// SetRoot of the user to the related item.
-// Sets o.R.Root to related.
-// Adds o to related.R.RootUsers.
-func (o *User) SetRoot(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Node) error {
-	var err error
-	if insert {
-		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
-			return errors.Wrap(err, "failed to insert into foreign table")
+// LoadInfos allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadInfos(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
 		}
 	}
 
-	updateQuery := fmt.Sprintf(
-		"UPDATE \"users\" SET %s WHERE %s",
-		strmangle.SetParamNames("\"", "\"", 1, []string{"root_id"}),
-		strmangle.WhereClause("\"", "\"", 2, userPrimaryKeyColumns),
-	)
-	values := []interface{}{related.ID, o.ID}
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
 
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, updateQuery)
-		fmt.Fprintln(writer, values)
-	}
-	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
-		return errors.Wrap(err, "failed to update local table")
-	}
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
 
-	queries.Assign(&o.RootID, related.ID)
-	if o.R == nil {
-		o.R = &userR{
-			Root: related,
+			args = append(args, obj.ID)
 		}
-	} else {
-		o.R.Root = related
 	}
 
-	if related.R == nil {
-		related.R = &nodeR{
-			RootUsers: UserSlice{o},
-		}
-	} else {
-		related.R.RootUsers = append(related.R.RootUsers, o)
+	if len(args) == 0 {
+		return nil
 	}
 
-	return nil
-}
+	query := NewQuery(
+		qm.From(`infos`),
+		qm.WhereIn(`infos.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
 
-// RemoveRoot relationship.
-// Sets o.R.Root to nil.
-// Removes o from all passed in related items' relationships struct (Optional).
-func (o *User) RemoveRoot(ctx context.Context, exec boil.ContextExecutor, related *Node) error {
-	var err error
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load infos")
+	}
 
-	queries.SetScanner(&o.RootID, nil)
-	if _, err = o.Update(ctx, exec, boil.Whitelist("root_id")); err != nil {
-		return errors.Wrap(err, "failed to update local table")
+	var resultSlice []*Info
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice infos")
 	}
 
-	if o.R != nil {
-		o.R.Root = nil
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on infos")
 	}
-	if related == nil || related.R == nil {
-		return nil
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for infos")
 	}
 
-	for i, ri := range related.R.RootUsers {
-		if queries.Equal(o.RootID, ri.RootID) {
-			continue
+	if len(infoAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Infos = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &infoR{}
+			}
+			foreign.R.User = object
 		}
+		return nil
+	}
 
-		ln := len(related.R.RootUsers)
-		if ln > 1 && i < ln-1 {
-			related.R.RootUsers[i] = related.R.RootUsers[ln-1]
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.Infos = append(local.R.Infos, foreign)
+				if foreign.R == nil {
+					foreign.R = &infoR{}
+				}
+				foreign.R.User = local
+				break
+			}
 		}
-		related.R.RootUsers = related.R.RootUsers[:ln-1]
-		break
 	}
+
 	return nil
 }
 
-// AddInfos adds the given related objects to the existing relationships
-// of the user, optionally inserting them as new records.
-// Appends related to o.R.Infos.
-// Sets related.R.User appropriately.
-func (o *User) AddInfos(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Info) error {
-	var err error
-	for _, rel := range related {
-		if insert {
-			rel.UserID = o.ID
-			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
-				return errors.Wrap(err, "failed to insert into foreign table")
+// LoadJobs allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadJobs(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
 			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
 		} else {
-			updateQuery := fmt.Sprintf(
-				"UPDATE \"infos\" SET %s WHERE %s",
-				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
-				strmangle.WhereClause("\"", "\"", 2, infoPrimaryKeyColumns),
-			)
-			values := []interface{}{o.ID, rel.ID}
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
+	}
 
-			if boil.IsDebug(ctx) {
-				writer := boil.DebugWriterFrom(ctx)
-				fmt.Fprintln(writer, updateQuery)
-				fmt.Fprintln(writer, values)
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
 			}
-			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
-				return errors.Wrap(err, "failed to update foreign table")
+
+			for _, a := range args {
+				if queries.Equal(a, obj.ID) {
+					continue Outer
+				}
 			}
 
-			rel.UserID = o.ID
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`jobs`),
+		qm.WhereIn(`jobs.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load jobs")
+	}
+
+	var resultSlice []*Job
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice jobs")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on jobs")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for jobs")
+	}
+
+	if len(jobAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Jobs = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &jobR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.UserID) {
+				local.R.Jobs = append(local.R.Jobs, foreign)
+				if foreign.R == nil {
+					foreign.R = &jobR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadOwnerNodes allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadOwnerNodes(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.ID) {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`nodes`),
+		qm.WhereIn(`nodes.owner_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load nodes")
+	}
+
+	var resultSlice []*Node
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice nodes")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on nodes")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for nodes")
+	}
+
+	if len(nodeAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.OwnerNodes = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &nodeR{}
+			}
+			foreign.R.Owner = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.OwnerID) {
+				local.R.OwnerNodes = append(local.R.OwnerNodes, foreign)
+				if foreign.R == nil {
+					foreign.R = &nodeR{}
+				}
+				foreign.R.Owner = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadProgresses allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadProgresses(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`progress`),
+		qm.WhereIn(`progress.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load progress")
+	}
+
+	var resultSlice []*Progress
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice progress")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on progress")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for progress")
+	}
+
+	if len(progressAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Progresses = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &progressR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.Progresses = append(local.R.Progresses, foreign)
+				if foreign.R == nil {
+					foreign.R = &progressR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadShares allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadShares(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`shares`),
+		qm.WhereIn(`shares.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load shares")
+	}
+
+	var resultSlice []*Share
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice shares")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on shares")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for shares")
+	}
+
+	if len(shareAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Shares = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &shareR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.Shares = append(local.R.Shares, foreign)
+				if foreign.R == nil {
+					foreign.R = &shareR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadTrashes allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadTrashes(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`trash`),
+		qm.WhereIn(`trash.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load trash")
+	}
+
+	var resultSlice []*Trash
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice trash")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on trash")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for trash")
+	}
+
+	if len(trashAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Trashes = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &trashR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.Trashes = append(local.R.Trashes, foreign)
+				if foreign.R == nil {
+					foreign.R = &trashR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadUserKeys allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadUserKeys(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`user_keys`),
+		qm.WhereIn(`user_keys.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load user_keys")
+	}
+
+	var resultSlice []*UserKey
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice user_keys")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on user_keys")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for user_keys")
+	}
+
+	if len(userKeyAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.UserKeys = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &userKeyR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.UserKeys = append(local.R.UserKeys, foreign)
+				if foreign.R == nil {
+					foreign.R = &userKeyR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadVersions allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadVersions(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.ID) {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`versions`),
+		qm.WhereIn(`versions.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load versions")
+	}
+
+	var resultSlice []*Version
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice versions")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on versions")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for versions")
+	}
+
+	if len(versionAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Versions = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &versionR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.UserID) {
+				local.R.Versions = append(local.R.Versions, foreign)
+				if foreign.R == nil {
+					foreign.R = &versionR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetRoot of the user to the related item.
+// Sets o.R.Root to related.
+// Adds o to related.R.RootUsers.
+func (o *User) SetRoot(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Node) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"users\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"root_id"}),
+		strmangle.WhereClause("\"", "\"", 2, userPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.RootID, related.ID)
+	if o.R == nil {
+		o.R = &userR{
+			Root: related,
+		}
+	} else {
+		o.R.Root = related
+	}
+
+	if related.R == nil {
+		related.R = &nodeR{
+			RootUsers: UserSlice{o},
+		}
+	} else {
+		related.R.RootUsers = append(related.R.RootUsers, o)
+	}
+
+	return nil
+}
+
+// RemoveRoot relationship.
+// Sets o.R.Root to nil.
+// Removes o from all passed in related items' relationships struct.
+func (o *User) RemoveRoot(ctx context.Context, exec boil.ContextExecutor, related *Node) error {
+	var err error
+
+	queries.SetScanner(&o.RootID, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("root_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.Root = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.RootUsers {
+		if queries.Equal(o.RootID, ri.RootID) {
+			continue
+		}
+
+		ln := len(related.R.RootUsers)
+		if ln > 1 && i < ln-1 {
+			related.R.RootUsers[i] = related.R.RootUsers[ln-1]
+		}
+		related.R.RootUsers = related.R.RootUsers[:ln-1]
+		break
+	}
+	return nil
+}
+
+// SetTrash of the user to the related item.
+// Sets o.R.Trash to related.
+// Adds o to related.R.TrashUsers.
+func (o *User) SetTrash(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Node) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"users\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"trash_id"}),
+		strmangle.WhereClause("\"", "\"", 2, userPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.TrashID, related.ID)
+	if o.R == nil {
+		o.R = &userR{
+			Trash: related,
+		}
+	} else {
+		o.R.Trash = related
+	}
+
+	if related.R == nil {
+		related.R = &nodeR{
+			TrashUsers: UserSlice{o},
+		}
+	} else {
+		related.R.TrashUsers = append(related.R.TrashUsers, o)
+	}
+
+	return nil
+}
+
+// RemoveTrash relationship.
+// Sets o.R.Trash to nil.
+// Removes o from all passed in related items' relationships struct.
+func (o *User) RemoveTrash(ctx context.Context, exec boil.ContextExecutor, related *Node) error {
+	var err error
+
+	queries.SetScanner(&o.TrashID, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("trash_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.Trash = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.TrashUsers {
+		if queries.Equal(o.TrashID, ri.TrashID) {
+			continue
+		}
+
+		ln := len(related.R.TrashUsers)
+		if ln > 1 && i < ln-1 {
+			related.R.TrashUsers[i] = related.R.TrashUsers[ln-1]
+		}
+		related.R.TrashUsers = related.R.TrashUsers[:ln-1]
+		break
+	}
+	return nil
+}
+
+// AddGrants adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.Grants.
+// Sets related.R.User appropriately.
+func (o *User) AddGrants(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Grant) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.UserID, o.ID)
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"grants\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, grantPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.UserID, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			Grants: related,
+		}
+	} else {
+		o.R.Grants = append(o.R.Grants, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &grantR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// SetGrants removes all previously related items of the
+// user replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.User's Grants accordingly.
+// Replaces o.R.Grants with related.
+// Sets related.R.User's Grants accordingly.
+func (o *User) SetGrants(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Grant) error {
+	query := "update \"grants\" set \"user_id\" = null where \"user_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.Grants {
+			queries.SetScanner(&rel.UserID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.User = nil
+		}
+		o.R.Grants = nil
+	}
+
+	return o.AddGrants(ctx, exec, insert, related...)
+}
+
+// RemoveGrants relationships from objects passed in.
+// Removes related items from R.Grants (uses pointer comparison, removal does not keep order)
+// Sets related.R.User.
+func (o *User) RemoveGrants(ctx context.Context, exec boil.ContextExecutor, related ...*Grant) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.UserID, nil)
+		if rel.R != nil {
+			rel.R.User = nil
+		}
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("user_id")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.Grants {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.Grants)
+			if ln > 1 && i < ln-1 {
+				o.R.Grants[i] = o.R.Grants[ln-1]
+			}
+			o.R.Grants = o.R.Grants[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+// AddGroups adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.Groups.
+// Sets related.R.Users appropriately.
+func (o *User) AddGroups(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Group) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		}
+	}
+
+	for _, rel := range related {
+		query := "insert into \"group_members\" (\"user_id\", \"group_id\") values ($1, $2)"
+		values := []interface{}{o.ID, rel.ID}
+
+		if boil.IsDebug(ctx) {
+			writer := boil.DebugWriterFrom(ctx)
+			fmt.Fprintln(writer, query)
+			fmt.Fprintln(writer, values)
+		}
+		_, err = exec.ExecContext(ctx, query, values...)
+		if err != nil {
+			return errors.Wrap(err, "failed to insert into join table")
+		}
+	}
+	if o.R == nil {
+		o.R = &userR{
+			Groups: related,
+		}
+	} else {
+		o.R.Groups = append(o.R.Groups, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &groupR{
+				Users: UserSlice{o},
+			}
+		} else {
+			rel.R.Users = append(rel.R.Users, o)
+		}
+	}
+	return nil
+}
+
+// SetGroups removes all previously related items of the
+// user replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.Users's Groups accordingly.
+// Replaces o.R.Groups with related.
+// Sets related.R.Users's Groups accordingly.
+func (o *User) SetGroups(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Group) error {
+	query := "delete from \"group_members\" where \"user_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	removeGroupsFromUsersSlice(o, related)
+	if o.R != nil {
+		o.R.Groups = nil
+	}
+
+	return o.AddGroups(ctx, exec, insert, related...)
+}
+
+// RemoveGroups relationships from objects passed in.
+// Removes related items from R.Groups (uses pointer comparison, removal does not keep order)
+// Sets related.R.Users.
+func (o *User) RemoveGroups(ctx context.Context, exec boil.ContextExecutor, related ...*Group) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	query := fmt.Sprintf(
+		"delete from \"group_members\" where \"user_id\" = $1 and \"group_id\" in (%s)",
+		strmangle.Placeholders(dialect.UseIndexPlaceholders, len(related), 2, 1),
+	)
+	values := []interface{}{o.ID}
+	for _, rel := range related {
+		values = append(values, rel.ID)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err = exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+	removeGroupsFromUsersSlice(o, related)
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.Groups {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.Groups)
+			if ln > 1 && i < ln-1 {
+				o.R.Groups[i] = o.R.Groups[ln-1]
+			}
+			o.R.Groups = o.R.Groups[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+func removeGroupsFromUsersSlice(o *User, related []*Group) {
+	for _, rel := range related {
+		if rel.R == nil {
+			continue
+		}
+		for i, ri := range rel.R.Users {
+			if o.ID != ri.ID {
+				continue
+			}
+
+			ln := len(rel.R.Users)
+			if ln > 1 && i < ln-1 {
+				rel.R.Users[i] = rel.R.Users[ln-1]
+			}
+			rel.R.Users = rel.R.Users[:ln-1]
+			break
+		}
+	}
+}
+
+// AddInfos adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.Infos.
+// Sets related.R.User appropriately.
+func (o *User) AddInfos(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Info) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"infos\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, infoPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			Infos: related,
+		}
+	} else {
+		o.R.Infos = append(o.R.Infos, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &infoR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddJobs adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.Jobs.
+// Sets related.R.User appropriately.
+func (o *User) AddJobs(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Job) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.UserID, o.ID)
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"jobs\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, jobPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.UserID, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			Jobs: related,
+		}
+	} else {
+		o.R.Jobs = append(o.R.Jobs, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &jobR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// SetJobs removes all previously related items of the
+// user replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.User's Jobs accordingly.
+// Replaces o.R.Jobs with related.
+// Sets related.R.User's Jobs accordingly.
+func (o *User) SetJobs(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Job) error {
+	query := "update \"jobs\" set \"user_id\" = null where \"user_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.Jobs {
+			queries.SetScanner(&rel.UserID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.User = nil
+		}
+		o.R.Jobs = nil
+	}
+
+	return o.AddJobs(ctx, exec, insert, related...)
+}
+
+// RemoveJobs relationships from objects passed in.
+// Removes related items from R.Jobs (uses pointer comparison, removal does not keep order)
+// Sets related.R.User.
+func (o *User) RemoveJobs(ctx context.Context, exec boil.ContextExecutor, related ...*Job) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.UserID, nil)
+		if rel.R != nil {
+			rel.R.User = nil
+		}
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("user_id")); err != nil {
+			return err
 		}
 	}
-
 	if o.R == nil {
-		o.R = &userR{
-			Infos: related,
-		}
-	} else {
-		o.R.Infos = append(o.R.Infos, related...)
+		return nil
 	}
 
 	for _, rel := range related {
-		if rel.R == nil {
-			rel.R = &infoR{
-				User: o,
+		for i, ri := range o.R.Jobs {
+			if rel != ri {
+				continue
 			}
-		} else {
-			rel.R.User = o
+
+			ln := len(o.R.Jobs)
+			if ln > 1 && i < ln-1 {
+				o.R.Jobs[i] = o.R.Jobs[ln-1]
+			}
+			o.R.Jobs = o.R.Jobs[:ln-1]
+			break
 		}
 	}
+
 	return nil
 }
 
@@ -1105,9 +2776,9 @@ func (o *User) SetOwnerNodes(ctx context.Context, exec boil.ContextExecutor, ins
 
 			rel.R.Owner = nil
 		}
-
 		o.R.OwnerNodes = nil
 	}
+
 	return o.AddOwnerNodes(ctx, exec, insert, related...)
 }
 
@@ -1204,10 +2875,301 @@ func (o *User) AddProgresses(ctx context.Context, exec boil.ContextExecutor, ins
 	return nil
 }
 
+// AddShares adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.Shares.
+// Sets related.R.User appropriately.
+func (o *User) AddShares(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Share) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"shares\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, sharePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			Shares: related,
+		}
+	} else {
+		o.R.Shares = append(o.R.Shares, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &shareR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddTrashes adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.Trashes.
+// Sets related.R.User appropriately.
+func (o *User) AddTrashes(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Trash) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"trash\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, trashPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.NodeID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			Trashes: related,
+		}
+	} else {
+		o.R.Trashes = append(o.R.Trashes, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &trashR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddUserKeys adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.UserKeys.
+// Sets related.R.User appropriately.
+func (o *User) AddUserKeys(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*UserKey) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"user_keys\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, userKeyPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.UserID, rel.Version}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			UserKeys: related,
+		}
+	} else {
+		o.R.UserKeys = append(o.R.UserKeys, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &userKeyR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddVersions adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.Versions.
+// Sets related.R.User appropriately.
+func (o *User) AddVersions(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Version) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.UserID, o.ID)
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"versions\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, versionPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.UserID, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			Versions: related,
+		}
+	} else {
+		o.R.Versions = append(o.R.Versions, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &versionR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// SetVersions removes all previously related items of the
+// user replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.User's Versions accordingly.
+// Replaces o.R.Versions with related.
+// Sets related.R.User's Versions accordingly.
+func (o *User) SetVersions(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Version) error {
+	query := "update \"versions\" set \"user_id\" = null where \"user_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.Versions {
+			queries.SetScanner(&rel.UserID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.User = nil
+		}
+		o.R.Versions = nil
+	}
+
+	return o.AddVersions(ctx, exec, insert, related...)
+}
+
+// RemoveVersions relationships from objects passed in.
+// Removes related items from R.Versions (uses pointer comparison, removal does not keep order)
+// Sets related.R.User.
+func (o *User) RemoveVersions(ctx context.Context, exec boil.ContextExecutor, related ...*Version) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.UserID, nil)
+		if rel.R != nil {
+			rel.R.User = nil
+		}
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("user_id")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.Versions {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.Versions)
+			if ln > 1 && i < ln-1 {
+				o.R.Versions[i] = o.R.Versions[ln-1]
+			}
+			o.R.Versions = o.R.Versions[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
 // Users retrieves all the records using an executor.
 func Users(mods ...qm.QueryMod) userQuery {
 	mods = append(mods, qm.From("\"users\""))
-	return userQuery{NewQuery(mods...)}
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"users\".*"})
+	}
+
+	return userQuery{q}
 }
 
 // FindUser retrieves a single record by ID with an executor.
@@ -1227,7 +3189,7 @@ func FindUser(ctx context.Context, exec boil.ContextExecutor, iD int, selectCols
 
 	err := q.Bind(ctx, exec, userObj)
 	if err != nil {
-		if errors.Cause(err) == sql.ErrNoRows {
+		if errors.Is(err, sql.ErrNoRows) {
 			return nil, sql.ErrNoRows
 		}
 		return nil, errors.Wrap(err, "models: unable to select from users")
@@ -1501,6 +3463,7 @@ func (o *User) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnCo
 			userColumnsWithoutDefault,
 			nzDefaults,
 		)
+
 		update := updateColumns.UpdateColumnSet(
 			userAllColumns,
 			userPrimaryKeyColumns,
@@ -1543,7 +3506,7 @@ func (o *User) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnCo
 	}
 	if len(cache.retMapping) != 0 {
 		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
-		if err == sql.ErrNoRows {
+		if errors.Is(err, sql.ErrNoRows) {
 			err = nil // Postgres doesn't return anything when there's no update
 		}
 	} else {
@@ -1728,3 +3691,8 @@ func UserExists(ctx context.Context, exec boil.ContextExecutor, iD int) (bool, e
 
 	return exists, nil
 }
+
+// Exists checks if the User row exists.
+func (o *User) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return UserExists(ctx, exec, o.ID)
+}
@@ -0,0 +1,1598 @@
+// Code generated by SQLBoiler 4.14.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// Version is an object representing the database table.
+type Version struct {
+	ID         int         `boil:"id" json:"id" toml:"id" yaml:"id"`
+	NodeID     int         `boil:"node_id" json:"node_id" toml:"node_id" yaml:"node_id"`
+	Version    int         `boil:"version" json:"version" toml:"version" yaml:"version"`
+	MimeType   string      `boil:"mime_type" json:"mime_type" toml:"mime_type" yaml:"mime_type"`
+	Size       int64       `boil:"size" json:"size" toml:"size" yaml:"size"`
+	ModifiedOn time.Time   `boil:"modified_on" json:"modified_on" toml:"modified_on" yaml:"modified_on"`
+	CreatedOn  time.Time   `boil:"created_on" json:"created_on" toml:"created_on" yaml:"created_on"`
+	UserID     null.Int    `boil:"user_id" json:"user_id,omitempty" toml:"user_id" yaml:"user_id,omitempty"`
+	Blob       null.String `boil:"blob" json:"-" toml:"-" yaml:"-"`
+	Checksum   null.String `boil:"checksum" json:"checksum,omitempty" toml:"checksum" yaml:"checksum,omitempty"`
+	Encrypted  bool        `boil:"encrypted" json:"encrypted" toml:"encrypted" yaml:"encrypted"`
+
+	R *versionR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L versionL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var VersionColumns = struct {
+	ID         string
+	NodeID     string
+	Version    string
+	MimeType   string
+	Size       string
+	ModifiedOn string
+	CreatedOn  string
+	UserID     string
+	Blob       string
+	Checksum   string
+	Encrypted  string
+}{
+	ID:         "id",
+	NodeID:     "node_id",
+	Version:    "version",
+	MimeType:   "mime_type",
+	Size:       "size",
+	ModifiedOn: "modified_on",
+	CreatedOn:  "created_on",
+	UserID:     "user_id",
+	Blob:       "blob",
+	Checksum:   "checksum",
+	Encrypted:  "encrypted",
+}
+
+var VersionTableColumns = struct {
+	ID         string
+	NodeID     string
+	Version    string
+	MimeType   string
+	Size       string
+	ModifiedOn string
+	CreatedOn  string
+	UserID     string
+	Blob       string
+	Checksum   string
+	Encrypted  string
+}{
+	ID:         "versions.id",
+	NodeID:     "versions.node_id",
+	Version:    "versions.version",
+	MimeType:   "versions.mime_type",
+	Size:       "versions.size",
+	ModifiedOn: "versions.modified_on",
+	CreatedOn:  "versions.created_on",
+	UserID:     "versions.user_id",
+	Blob:       "versions.blob",
+	Checksum:   "versions.checksum",
+	Encrypted:  "versions.encrypted",
+}
+
+// Generated where
+
+var VersionWhere = struct {
+	ID         whereHelperint
+	NodeID     whereHelperint
+	Version    whereHelperint
+	MimeType   whereHelperstring
+	Size       whereHelperint64
+	ModifiedOn whereHelpertime_Time
+	CreatedOn  whereHelpertime_Time
+	UserID     whereHelpernull_Int
+	Blob       whereHelpernull_String
+	Checksum   whereHelpernull_String
+	Encrypted  whereHelperbool
+}{
+	ID:         whereHelperint{field: "\"versions\".\"id\""},
+	NodeID:     whereHelperint{field: "\"versions\".\"node_id\""},
+	Version:    whereHelperint{field: "\"versions\".\"version\""},
+	MimeType:   whereHelperstring{field: "\"versions\".\"mime_type\""},
+	Size:       whereHelperint64{field: "\"versions\".\"size\""},
+	ModifiedOn: whereHelpertime_Time{field: "\"versions\".\"modified_on\""},
+	CreatedOn:  whereHelpertime_Time{field: "\"versions\".\"created_on\""},
+	UserID:     whereHelpernull_Int{field: "\"versions\".\"user_id\""},
+	Blob:       whereHelpernull_String{field: "\"versions\".\"blob\""},
+	Checksum:   whereHelpernull_String{field: "\"versions\".\"checksum\""},
+	Encrypted:  whereHelperbool{field: "\"versions\".\"encrypted\""},
+}
+
+// VersionRels is where relationship names are stored.
+var VersionRels = struct {
+	Node        string
+	User        string
+	VersionBlob string
+}{
+	Node:        "Node",
+	User:        "User",
+	VersionBlob: "VersionBlob",
+}
+
+// versionR is where relationships are stored.
+type versionR struct {
+	Node        *Node `boil:"Node" json:"Node" toml:"Node" yaml:"Node"`
+	User        *User `boil:"User" json:"User" toml:"User" yaml:"User"`
+	VersionBlob *Blob `boil:"VersionBlob" json:"VersionBlob" toml:"VersionBlob" yaml:"VersionBlob"`
+}
+
+// NewStruct creates a new relationship struct
+func (*versionR) NewStruct() *versionR {
+	return &versionR{}
+}
+
+func (r *versionR) GetNode() *Node {
+	if r == nil {
+		return nil
+	}
+	return r.Node
+}
+
+func (r *versionR) GetUser() *User {
+	if r == nil {
+		return nil
+	}
+	return r.User
+}
+
+func (r *versionR) GetVersionBlob() *Blob {
+	if r == nil {
+		return nil
+	}
+	return r.VersionBlob
+}
+
+// versionL is where Load methods for each relationship are stored.
+type versionL struct{}
+
+var (
+	versionAllColumns            = []string{"id", "node_id", "version", "mime_type", "size", "modified_on", "created_on", "user_id", "blob", "checksum", "encrypted"}
+	versionColumnsWithoutDefault = []string{"node_id", "version", "mime_type", "size", "modified_on"}
+	versionColumnsWithDefault    = []string{"id", "created_on", "user_id", "blob", "checksum", "encrypted"}
+	versionPrimaryKeyColumns     = []string{"id"}
+	versionGeneratedColumns      = []string{}
+)
+
+type (
+	// VersionSlice is an alias for a slice of pointers to Version.
+	// This should almost always be used instead of []Version.
+	VersionSlice []*Version
+	// VersionHook is the signature for custom Version hook methods
+	VersionHook func(context.Context, boil.ContextExecutor, *Version) error
+
+	versionQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	versionType                 = reflect.TypeOf(&Version{})
+	versionMapping              = queries.MakeStructMapping(versionType)
+	versionPrimaryKeyMapping, _ = queries.BindMapping(versionType, versionMapping, versionPrimaryKeyColumns)
+	versionInsertCacheMut       sync.RWMutex
+	versionInsertCache          = make(map[string]insertCache)
+	versionUpdateCacheMut       sync.RWMutex
+	versionUpdateCache          = make(map[string]updateCache)
+	versionUpsertCacheMut       sync.RWMutex
+	versionUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var versionAfterSelectHooks []VersionHook
+
+var versionBeforeInsertHooks []VersionHook
+var versionAfterInsertHooks []VersionHook
+
+var versionBeforeUpdateHooks []VersionHook
+var versionAfterUpdateHooks []VersionHook
+
+var versionBeforeDeleteHooks []VersionHook
+var versionAfterDeleteHooks []VersionHook
+
+var versionBeforeUpsertHooks []VersionHook
+var versionAfterUpsertHooks []VersionHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *Version) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range versionAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *Version) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range versionBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *Version) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range versionAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *Version) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range versionBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *Version) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range versionAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *Version) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range versionBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *Version) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range versionAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *Version) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range versionBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *Version) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range versionAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddVersionHook registers your hook function for all future operations.
+func AddVersionHook(hookPoint boil.HookPoint, versionHook VersionHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		versionAfterSelectHooks = append(versionAfterSelectHooks, versionHook)
+	case boil.BeforeInsertHook:
+		versionBeforeInsertHooks = append(versionBeforeInsertHooks, versionHook)
+	case boil.AfterInsertHook:
+		versionAfterInsertHooks = append(versionAfterInsertHooks, versionHook)
+	case boil.BeforeUpdateHook:
+		versionBeforeUpdateHooks = append(versionBeforeUpdateHooks, versionHook)
+	case boil.AfterUpdateHook:
+		versionAfterUpdateHooks = append(versionAfterUpdateHooks, versionHook)
+	case boil.BeforeDeleteHook:
+		versionBeforeDeleteHooks = append(versionBeforeDeleteHooks, versionHook)
+	case boil.AfterDeleteHook:
+		versionAfterDeleteHooks = append(versionAfterDeleteHooks, versionHook)
+	case boil.BeforeUpsertHook:
+		versionBeforeUpsertHooks = append(versionBeforeUpsertHooks, versionHook)
+	case boil.AfterUpsertHook:
+		versionAfterUpsertHooks = append(versionAfterUpsertHooks, versionHook)
+	}
+}
+
+// One returns a single version record from the query.
+func (q versionQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Version, error) {
+	o := &Version{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for versions")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all Version records from the query.
+func (q versionQuery) All(ctx context.Context, exec boil.ContextExecutor) (VersionSlice, error) {
+	var o []*Version
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to Version slice")
+	}
+
+	if len(versionAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all Version records in the query.
+func (q versionQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count versions rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q versionQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if versions exists")
+	}
+
+	return count > 0, nil
+}
+
+// Node pointed to by the foreign key.
+func (o *Version) Node(mods ...qm.QueryMod) nodeQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.NodeID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Nodes(queryMods...)
+}
+
+// User pointed to by the foreign key.
+func (o *Version) User(mods ...qm.QueryMod) userQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.UserID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Users(queryMods...)
+}
+
+// VersionBlob pointed to by the foreign key.
+func (o *Version) VersionBlob(mods ...qm.QueryMod) blobQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"hash\" = ?", o.Blob),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Blobs(queryMods...)
+}
+
+// LoadNode allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (versionL) LoadNode(ctx context.Context, e boil.ContextExecutor, singular bool, maybeVersion interface{}, mods queries.Applicator) error {
+	var slice []*Version
+	var object *Version
+
+	if singular {
+		var ok bool
+		object, ok = maybeVersion.(*Version)
+		if !ok {
+			object = new(Version)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeVersion)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeVersion))
+			}
+		}
+	} else {
+		s, ok := maybeVersion.(*[]*Version)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeVersion)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeVersion))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &versionR{}
+		}
+		args = append(args, object.NodeID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &versionR{}
+			}
+
+			for _, a := range args {
+				if a == obj.NodeID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.NodeID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`nodes`),
+		qm.WhereIn(`nodes.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Node")
+	}
+
+	var resultSlice []*Node
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Node")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for nodes")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for nodes")
+	}
+
+	if len(nodeAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Node = foreign
+		if foreign.R == nil {
+			foreign.R = &nodeR{}
+		}
+		foreign.R.Versions = append(foreign.R.Versions, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.NodeID == foreign.ID {
+				local.R.Node = foreign
+				if foreign.R == nil {
+					foreign.R = &nodeR{}
+				}
+				foreign.R.Versions = append(foreign.R.Versions, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadUser allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (versionL) LoadUser(ctx context.Context, e boil.ContextExecutor, singular bool, maybeVersion interface{}, mods queries.Applicator) error {
+	var slice []*Version
+	var object *Version
+
+	if singular {
+		var ok bool
+		object, ok = maybeVersion.(*Version)
+		if !ok {
+			object = new(Version)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeVersion)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeVersion))
+			}
+		}
+	} else {
+		s, ok := maybeVersion.(*[]*Version)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeVersion)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeVersion))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &versionR{}
+		}
+		if !queries.IsNil(object.UserID) {
+			args = append(args, object.UserID)
+		}
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &versionR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.UserID) {
+					continue Outer
+				}
+			}
+
+			if !queries.IsNil(obj.UserID) {
+				args = append(args, obj.UserID)
+			}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`users`),
+		qm.WhereIn(`users.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for users")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
+	}
+
+	if len(userAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.User = foreign
+		if foreign.R == nil {
+			foreign.R = &userR{}
+		}
+		foreign.R.Versions = append(foreign.R.Versions, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.UserID, foreign.ID) {
+				local.R.User = foreign
+				if foreign.R == nil {
+					foreign.R = &userR{}
+				}
+				foreign.R.Versions = append(foreign.R.Versions, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadVersionBlob allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (versionL) LoadVersionBlob(ctx context.Context, e boil.ContextExecutor, singular bool, maybeVersion interface{}, mods queries.Applicator) error {
+	var slice []*Version
+	var object *Version
+
+	if singular {
+		var ok bool
+		object, ok = maybeVersion.(*Version)
+		if !ok {
+			object = new(Version)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeVersion)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeVersion))
+			}
+		}
+	} else {
+		s, ok := maybeVersion.(*[]*Version)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeVersion)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeVersion))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &versionR{}
+		}
+		if !queries.IsNil(object.Blob) {
+			args = append(args, object.Blob)
+		}
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &versionR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.Blob) {
+					continue Outer
+				}
+			}
+
+			if !queries.IsNil(obj.Blob) {
+				args = append(args, obj.Blob)
+			}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`blobs`),
+		qm.WhereIn(`blobs.hash in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Blob")
+	}
+
+	var resultSlice []*Blob
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Blob")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for blobs")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for blobs")
+	}
+
+	if len(blobAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.VersionBlob = foreign
+		if foreign.R == nil {
+			foreign.R = &blobR{}
+		}
+		foreign.R.Versions = append(foreign.R.Versions, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.Blob, foreign.Hash) {
+				local.R.VersionBlob = foreign
+				if foreign.R == nil {
+					foreign.R = &blobR{}
+				}
+				foreign.R.Versions = append(foreign.R.Versions, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetNode of the version to the related item.
+// Sets o.R.Node to related.
+// Adds o to related.R.Versions.
+func (o *Version) SetNode(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Node) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"versions\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"node_id"}),
+		strmangle.WhereClause("\"", "\"", 2, versionPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.NodeID = related.ID
+	if o.R == nil {
+		o.R = &versionR{
+			Node: related,
+		}
+	} else {
+		o.R.Node = related
+	}
+
+	if related.R == nil {
+		related.R = &nodeR{
+			Versions: VersionSlice{o},
+		}
+	} else {
+		related.R.Versions = append(related.R.Versions, o)
+	}
+
+	return nil
+}
+
+// SetUser of the version to the related item.
+// Sets o.R.User to related.
+// Adds o to related.R.Versions.
+func (o *Version) SetUser(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"versions\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+		strmangle.WhereClause("\"", "\"", 2, versionPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.UserID, related.ID)
+	if o.R == nil {
+		o.R = &versionR{
+			User: related,
+		}
+	} else {
+		o.R.User = related
+	}
+
+	if related.R == nil {
+		related.R = &userR{
+			Versions: VersionSlice{o},
+		}
+	} else {
+		related.R.Versions = append(related.R.Versions, o)
+	}
+
+	return nil
+}
+
+// RemoveUser relationship.
+// Sets o.R.User to nil.
+// Removes o from all passed in related items' relationships struct.
+func (o *Version) RemoveUser(ctx context.Context, exec boil.ContextExecutor, related *User) error {
+	var err error
+
+	queries.SetScanner(&o.UserID, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("user_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.User = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.Versions {
+		if queries.Equal(o.UserID, ri.UserID) {
+			continue
+		}
+
+		ln := len(related.R.Versions)
+		if ln > 1 && i < ln-1 {
+			related.R.Versions[i] = related.R.Versions[ln-1]
+		}
+		related.R.Versions = related.R.Versions[:ln-1]
+		break
+	}
+	return nil
+}
+
+// SetVersionBlob of the version to the related item.
+// Sets o.R.VersionBlob to related.
+// Adds o to related.R.Versions.
+func (o *Version) SetVersionBlob(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Blob) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"versions\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"blob"}),
+		strmangle.WhereClause("\"", "\"", 2, versionPrimaryKeyColumns),
+	)
+	values := []interface{}{related.Hash, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.Blob, related.Hash)
+	if o.R == nil {
+		o.R = &versionR{
+			VersionBlob: related,
+		}
+	} else {
+		o.R.VersionBlob = related
+	}
+
+	if related.R == nil {
+		related.R = &blobR{
+			Versions: VersionSlice{o},
+		}
+	} else {
+		related.R.Versions = append(related.R.Versions, o)
+	}
+
+	return nil
+}
+
+// RemoveVersionBlob relationship.
+// Sets o.R.VersionBlob to nil.
+// Removes o from all passed in related items' relationships struct.
+func (o *Version) RemoveVersionBlob(ctx context.Context, exec boil.ContextExecutor, related *Blob) error {
+	var err error
+
+	queries.SetScanner(&o.Blob, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("blob")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.VersionBlob = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.Versions {
+		if queries.Equal(o.Blob, ri.Blob) {
+			continue
+		}
+
+		ln := len(related.R.Versions)
+		if ln > 1 && i < ln-1 {
+			related.R.Versions[i] = related.R.Versions[ln-1]
+		}
+		related.R.Versions = related.R.Versions[:ln-1]
+		break
+	}
+	return nil
+}
+
+// Versions retrieves all the records using an executor.
+func Versions(mods ...qm.QueryMod) versionQuery {
+	mods = append(mods, qm.From("\"versions\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"versions\".*"})
+	}
+
+	return versionQuery{q}
+}
+
+// FindVersion retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindVersion(ctx context.Context, exec boil.ContextExecutor, iD int, selectCols ...string) (*Version, error) {
+	versionObj := &Version{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"versions\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, versionObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from versions")
+	}
+
+	if err = versionObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return versionObj, err
+	}
+
+	return versionObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *Version) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no versions provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(versionColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	versionInsertCacheMut.RLock()
+	cache, cached := versionInsertCache[key]
+	versionInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			versionAllColumns,
+			versionColumnsWithDefault,
+			versionColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(versionType, versionMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(versionType, versionMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"versions\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"versions\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into versions")
+	}
+
+	if !cached {
+		versionInsertCacheMut.Lock()
+		versionInsertCache[key] = cache
+		versionInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the Version.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *Version) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	versionUpdateCacheMut.RLock()
+	cache, cached := versionUpdateCache[key]
+	versionUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			versionAllColumns,
+			versionPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update versions, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"versions\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, versionPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(versionType, versionMapping, append(wl, versionPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update versions row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for versions")
+	}
+
+	if !cached {
+		versionUpdateCacheMut.Lock()
+		versionUpdateCache[key] = cache
+		versionUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q versionQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for versions")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for versions")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o VersionSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), versionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"versions\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, versionPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in version slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all version")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *Version) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no versions provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(versionColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	versionUpsertCacheMut.RLock()
+	cache, cached := versionUpsertCache[key]
+	versionUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			versionAllColumns,
+			versionColumnsWithDefault,
+			versionColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			versionAllColumns,
+			versionPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert versions, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(versionPrimaryKeyColumns))
+			copy(conflict, versionPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"versions\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(versionType, versionMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(versionType, versionMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert versions")
+	}
+
+	if !cached {
+		versionUpsertCacheMut.Lock()
+		versionUpsertCache[key] = cache
+		versionUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single Version record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *Version) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no Version provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), versionPrimaryKeyMapping)
+	sql := "DELETE FROM \"versions\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from versions")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for versions")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q versionQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no versionQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from versions")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for versions")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o VersionSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(versionBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), versionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"versions\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, versionPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from version slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for versions")
+	}
+
+	if len(versionAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *Version) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindVersion(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *VersionSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := VersionSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), versionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"versions\".* FROM \"versions\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, versionPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in VersionSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// VersionExists checks if the Version row exists.
+func VersionExists(ctx context.Context, exec boil.ContextExecutor, iD int) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"versions\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if versions exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the Version row exists.
+func (o *Version) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return VersionExists(ctx, exec, o.ID)
+}
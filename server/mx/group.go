@@ -0,0 +1,107 @@
+package mx
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/terotoi/koticloud/server/core"
+	"github.com/terotoi/koticloud/server/models"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// Group is a named set of users. Nodes can be shared with all members of a group at once.
+type Group struct {
+	models.Group `boil:",bind"`
+	Members      []string `boil:"-" json:"members"`
+}
+
+// GroupCreate creates a group.
+func GroupCreate(ctx context.Context, name string, tx boil.ContextExecutor) (*Group, error) {
+	if name == "" {
+		return nil, core.NewSystemError(http.StatusBadRequest, "", "invalid group name")
+	}
+
+	if _, err := GroupByName(ctx, name, tx); err == nil {
+		return nil, core.NewSystemError(http.StatusConflict, "", "group already exists")
+	}
+
+	g := Group{Group: models.Group{Name: name}, Members: []string{}}
+	err := queries.Raw("INSERT INTO groups (name) VALUES ($1) RETURNING id", name).
+		QueryRowContext(ctx, tx).Scan(&g.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Group %s created", name)
+	return &g, nil
+}
+
+// GroupDelete deletes a group. Grants given to the group are removed with it.
+func GroupDelete(ctx context.Context, g *Group, tx boil.ContextExecutor) error {
+	if _, err := queries.Raw("DELETE FROM groups WHERE id=$1", g.ID).ExecContext(ctx, tx); err != nil {
+		return err
+	}
+
+	log.Printf("Group %s deleted", g.Name)
+	return nil
+}
+
+// GroupByName returns a group by its name. Members are not loaded.
+func GroupByName(ctx context.Context, name string, tx boil.ContextExecutor) (*Group, error) {
+	var groups []*Group
+	if err := queries.Raw("SELECT * FROM groups WHERE name=$1", name).Bind(ctx, tx, &groups); err != nil {
+		return nil, err
+	}
+
+	if len(groups) == 0 {
+		return nil, core.NewSystemError(http.StatusNotFound, "", fmt.Sprintf("group %s not found", name))
+	}
+	return groups[0], nil
+}
+
+// Groups returns all groups with their members.
+func Groups(ctx context.Context, tx boil.ContextExecutor) ([]*Group, error) {
+	groups := []*Group{}
+	if err := queries.Raw("SELECT * FROM groups ORDER BY name").Bind(ctx, tx, &groups); err != nil {
+		return nil, err
+	}
+
+	for _, g := range groups {
+		users, err := models.Users(
+			qm.Where("id IN (SELECT user_id FROM group_members WHERE group_id=?)", g.ID),
+			qm.OrderBy("name")).All(ctx, tx)
+		if err != nil {
+			return nil, err
+		}
+
+		g.Members = []string{}
+		for _, u := range users {
+			g.Members = append(g.Members, u.Name)
+		}
+	}
+	return groups, nil
+}
+
+// GroupAddUser adds a user to a group.
+func GroupAddUser(ctx context.Context, g *Group, user *models.User, tx boil.ContextExecutor) error {
+	_, err := queries.Raw("INSERT INTO group_members (group_id, user_id) VALUES ($1, $2) "+
+		"ON CONFLICT DO NOTHING", g.ID, user.ID).ExecContext(ctx, tx)
+	if err == nil {
+		log.Printf("User %s added to group %s", user.Name, g.Name)
+	}
+	return err
+}
+
+// GroupRemoveUser removes a user from a group.
+func GroupRemoveUser(ctx context.Context, g *Group, user *models.User, tx boil.ContextExecutor) error {
+	_, err := queries.Raw("DELETE FROM group_members WHERE group_id=$1 AND user_id=$2",
+		g.ID, user.ID).ExecContext(ctx, tx)
+	if err == nil {
+		log.Printf("User %s removed from group %s", user.Name, g.Name)
+	}
+	return err
+}
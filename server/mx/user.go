@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 
+	"github.com/terotoi/koticloud/server/crypt"
 	"github.com/terotoi/koticloud/server/fs"
 	"github.com/terotoi/koticloud/server/models"
 	"github.com/volatiletech/null/v8"
@@ -15,6 +17,11 @@ import (
 
 // UserCreate creates an user object.
 func UserCreate(ctx context.Context, username, password string, admin bool, homeRoot string, tx boil.ContextExecutor) error {
+	// Names starting with a dot are reserved for system directories, such as the trash.
+	if !fs.IsValidName(username) || strings.HasPrefix(username, ".") {
+		return fmt.Errorf("invalid username: %s", username)
+	}
+
 	pwhash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
 	if err != nil {
 		return nil
@@ -42,6 +49,12 @@ func UserCreate(ctx context.Context, username, password string, admin bool, home
 		return err
 	}
 
+	if crypt.Enabled() {
+		if _, err := fs.CreateUserKey(ctx, user.ID, tx); err != nil {
+			return err
+		}
+	}
+
 	log.Printf("Creating root node for user %s", user.Name)
 	_, err = UserEnsureRootNode(ctx, user, homeRoot, tx)
 	if err != nil {
@@ -65,7 +78,7 @@ func UserEnsureRootNode(ctx context.Context, user *models.User, homeRoot string,
 		}
 
 		user.RootID = null.Int{Int: node.ID, Valid: true}
-		_, err = user.Update(ctx, tx, boil.Infer())
+		_, err = user.Update(ctx, tx, boil.Whitelist(models.UserColumns.RootID))
 	} else {
 		node, err = models.FindNode(ctx, tx, user.RootID.Int)
 	}
@@ -9,6 +9,7 @@ import (
 	"github.com/go-chi/jwtauth"
 	"github.com/terotoi/koticloud/server/api"
 	"github.com/terotoi/koticloud/server/core"
+	"github.com/terotoi/koticloud/server/dav"
 	"github.com/terotoi/koticloud/server/jobs"
 )
 
@@ -43,28 +44,72 @@ func setupRoutes(r *chi.Mux, cfg *core.Config, np *jobs.NodeProcessor, db *sql.D
 		r.Post("/node/id_for", api.Authorized(api.NodeIDForPath(auth, db), false, cfg, db))
 		r.Get("/node/ls/{nodeID:[0-9]+}", api.Authorized(api.NodeList(auth, db), false, cfg, db))
 		r.Post("/node/mkdir", api.Authorized(api.NodeMakeDir(cfg, db), false, cfg, db))
-		r.Post("/node/new", api.Authorized(api.NodeNew(cfg.UploadDir, cfg.HomeRoot, cfg.ThumbRoot, np.Channel, db),
+		r.Post("/node/new", api.Authorized(api.NodeNew(cfg.UploadDir, cfg.HomeRoot, cfg.ThumbRoot, cfg.BlobStore, np.Channel, db),
 			false, cfg, db))
-		r.Post("/node/update", api.Authorized(api.NodeUpdate(cfg.UploadDir, cfg.HomeRoot, cfg.ThumbRoot, np.Channel, db),
+		r.Post("/node/update", api.Authorized(api.NodeUpdate(cfg, np.Channel, db),
 			false, cfg, db))
 
+		r.Post("/upload/create", api.Authorized(api.UploadCreate(cfg.UploadDir, db), false, cfg, db))
+		r.Get("/upload/{sessionID:[0-9a-f]+}", api.Authorized(api.UploadStatus(cfg.UploadDir), false, cfg, db))
+		r.Put("/upload/{sessionID:[0-9a-f]+}/{offset:[0-9]+}",
+			api.Authorized(api.UploadChunk(cfg.UploadDir), false, cfg, db))
+		r.Post("/upload/{sessionID:[0-9a-f]+}/finalize",
+			api.Authorized(api.UploadFinalize(cfg.UploadDir, cfg.HomeRoot, cfg.BlobStore, np.Channel, db), false, cfg, db))
+
+		r.Get("/node/versions/{nodeID:[0-9]+}", api.Authorized(api.VersionList(db), false, cfg, db))
+		r.Get("/version/get/{versionID:[0-9]+}", api.Authorized(api.VersionGet(cfg.HomeRoot, db), false, cfg, db))
+		r.Get("/version/diff/{versionID:[0-9]+}", api.Authorized(api.VersionDiff(cfg.HomeRoot, db), false, cfg, db))
+		r.Post("/version/restore", api.Authorized(api.VersionRestore(cfg, np.Channel, db), false, cfg, db))
+
 		r.Get("/node/info/{nodeID:[0-9]+}", api.Authorized(api.NodeInfo(auth, db), false, cfg, db))
-		r.Post("/node/copy", api.Authorized(api.NodeCopy(cfg.HomeRoot, cfg.ThumbRoot, db), false, cfg, db))
+		r.Post("/node/copy", api.Authorized(api.NodeCopy(cfg.HomeRoot, cfg.ThumbRoot, cfg.BlobStore, db), false, cfg, db))
 		r.Post("/node/move", api.Authorized(api.NodeMove(cfg, db), false, cfg, db))
 		r.Post("/node/rename", api.Authorized(api.NodeRename(cfg, db), false, cfg, db))
 		r.Post("/node/delete", api.Authorized(api.NodeDelete(cfg.HomeRoot, cfg.ThumbRoot, db),
 			false, cfg, db))
-		r.Post("/node/search", api.Authorized(api.NodeSearch(db), false, cfg, db))
+		r.Get("/trash/list", api.Authorized(api.TrashList(db), false, cfg, db))
+		r.Post("/trash/restore", api.Authorized(api.TrashRestore(cfg.HomeRoot, db), false, cfg, db))
+		r.Post("/trash/empty", api.Authorized(api.TrashEmpty(cfg.HomeRoot, cfg.ThumbRoot, db), false, cfg, db))
+		r.Post("/share/create", api.Authorized(api.ShareCreate(db), false, cfg, db))
+		r.Get("/share/list", api.Authorized(api.ShareList(db), false, cfg, db))
+		r.Post("/share/revoke", api.Authorized(api.ShareRevoke(db), false, cfg, db))
+		r.Post("/grant/add", api.Authorized(api.GrantAdd(db), false, cfg, db))
+		r.Get("/grant/list/{nodeID:[0-9]+}", api.Authorized(api.GrantList(db), false, cfg, db))
+		r.Post("/grant/remove", api.Authorized(api.GrantRemove(db), false, cfg, db))
+		r.Get("/node/shared", api.Authorized(api.NodeSharedWithMe(db), false, cfg, db))
+		r.Post("/node/search", api.Authorized(api.NodeSearch(cfg, db), false, cfg, db))
 
 		r.Post("/user/settings", api.Authorized(api.QuerySettings(cfg, db), false, cfg, db))
 		r.Post("/user/create", api.Authorized(api.UserCreate(cfg, db), true, cfg, db))
 		r.Post("/user/setpassword", api.Authorized(api.SetPassword(db), false, cfg, db))
+		r.Post("/user/setquota", api.Authorized(api.SetQuota(db), true, cfg, db))
+		r.Get("/user/key", api.Authorized(api.KeyStatus(db), false, cfg, db))
+		r.Post("/user/key/unlock", api.Authorized(api.KeyUnlock(db), false, cfg, db))
+		r.Post("/user/key/lock", api.Authorized(api.KeyLock(), false, cfg, db))
+		r.Post("/user/key/passphrase", api.Authorized(api.KeySetPassphrase(db), false, cfg, db))
+		r.Get("/user/usage", api.Authorized(api.UsageList(db), true, cfg, db))
+		r.Post("/group/create", api.Authorized(api.GroupCreate(db), true, cfg, db))
+		r.Post("/group/delete", api.Authorized(api.GroupDelete(db), true, cfg, db))
+		r.Get("/group/list", api.Authorized(api.GroupList(db), true, cfg, db))
+		r.Post("/group/add_user", api.Authorized(api.GroupAddUser(db), true, cfg, db))
+		r.Post("/group/remove_user", api.Authorized(api.GroupRemoveUser(db), true, cfg, db))
 		r.Post("/admin/scan_deleted",
 			api.Authorized(api.ScanDeleted(np, cfg, db), true, cfg, db))
 		r.Post("/admin/scan_all",
 			api.Authorized(api.ScanAll(np, cfg, db), true, cfg, db))
 		r.Post("/admin/generate_thumbnails/{onlyMissing}",
 			api.Authorized(api.GenerateAllThumbnails(np, cfg.HomeRoot, db), true, cfg, db))
+		r.Post("/admin/index_text/{onlyMissing}",
+			api.Authorized(api.IndexAllText(np, cfg.HomeRoot, db), true, cfg, db))
+		r.Post("/admin/extract_info/{onlyMissing}",
+			api.Authorized(api.ExtractAllInfo(np, cfg.HomeRoot, db), true, cfg, db))
+		r.Post("/admin/scrub",
+			api.Authorized(api.ScrubAll(cfg.HomeRoot, db), true, cfg, db))
+		r.Get("/admin/jobs", api.Authorized(api.JobList(db), true, cfg, db))
+		r.Get("/admin/jobs/{jobID:[0-9]+}", api.Authorized(api.JobShow(db), true, cfg, db))
+		r.Post("/admin/jobs/{jobID:[0-9]+}/cancel", api.Authorized(api.JobCancel(db), true, cfg, db))
+		r.Get("/admin/process/failed", api.Authorized(api.ProcessFailed(db), true, cfg, db))
+		r.Post("/admin/process/requeue", api.Authorized(api.ProcessRequeue(np.Channel, db), true, cfg, db))
 
 		r.Get("/node/get/{nodeID:[0-9]+}",
 			api.AuthorizedNode(api.NodeGet(cfg.HomeRoot, db), false, cfg, db))
@@ -78,12 +123,29 @@ func setupRoutes(r *chi.Mux, cfg *core.Config, np *jobs.NodeProcessor, db *sql.D
 		r.Post("/cmd/run", api.Authorized(api.RunCommand(auth, cfg, db), false, cfg, db))
 	})
 
+	// WebDAV uses HTTP basic authentication instead of JWT.
+	for _, m := range dav.Methods {
+		chi.RegisterMethod(m)
+	}
+	davServer := dav.NewServer("/dav", cfg, np, db)
+	r.Handle("/dav", davServer)
+	r.Handle("/dav/*", davServer)
+
 	staticFiles := serveStaticFiles(cfg)
 
 	// Methods not requiring JWT authentication.
 	r.Group(func(r chi.Router) {
 		r.Post("/user/login", api.UserLogin(auth, cfg, db))
 
+		// Public share links
+		r.Get("/s/{slug}", api.Shared(api.SharedInfo(db), db))
+		r.Get("/s/{slug}/info/{nodeID:[0-9]+}", api.Shared(api.SharedInfo(db), db))
+		r.Get("/s/{slug}/ls/{nodeID:[0-9]+}", api.Shared(api.SharedList(db), db))
+		r.Get("/s/{slug}/get", api.Shared(api.SharedGet(cfg.HomeRoot, db), db))
+		r.Get("/s/{slug}/get/{nodeID:[0-9]+}", api.Shared(api.SharedGet(cfg.HomeRoot, db), db))
+		r.Get("/s/{slug}/thumb", api.Shared(api.SharedThumb(cfg, db), db))
+		r.Get("/s/{slug}/thumb/{nodeID:[0-9]+}", api.Shared(api.SharedThumb(cfg, db), db))
+
 		r.Get("/id/{nodeID:[0-9]+}", func(w http.ResponseWriter, r *http.Request) {
 			r.URL.Path = "/"
 			staticFiles(w, r)
@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"io"
+
+	"github.com/terotoi/koticloud/server/crypt"
+)
+
+// Reads the header of a file encrypted by Store. Whether a file is encrypted
+// is recorded by the caller, not guessed from its contents, so a file without
+// a valid header is corrupt.
+func readHeader(fh File) ([]byte, error) {
+	header := make([]byte, crypt.HeaderSize)
+	if _, err := io.ReadFull(fh, header); err == io.EOF || err == io.ErrUnexpectedEOF {
+		return nil, crypt.ErrCorrupt
+	} else if err != nil {
+		return nil, err
+	} else if !crypt.IsEncrypted(header) {
+		return nil, crypt.ErrCorrupt
+	}
+	return header, nil
+}
+
+// KeyOf returns the user ID and the version of the data key an encrypted
+// stored file is encrypted with.
+func KeyOf(path string) (userID, version int, err error) {
+	b, key, _ := resolve(path)
+	fh, err := b.Open(key)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer fh.Close()
+
+	header, err := readHeader(fh)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	userID, version = crypt.KeyOf(header)
+	return userID, version, nil
+}
+
+// Store copies the plaintext of srcPath to dstPath, encrypting it with key
+// unless it is nil. srcEncrypted tells if srcPath is encrypted. Either path
+// may be stored or on the local file system. dstPath is replaced atomically,
+// see WriteAtomic.
+func Store(srcPath string, srcEncrypted bool, dstPath string, key *crypt.Key) error {
+	src, err := Open(srcPath, srcEncrypted)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	return WriteAtomic(dstPath, func(w io.Writer) error {
+		if key == nil {
+			_, err := io.Copy(w, src)
+			return err
+		}
+
+		cw, err := crypt.NewWriter(w, key)
+		if err != nil {
+			return err
+		}
+
+		if _, err := io.Copy(cw, src); err != nil {
+			return err
+		}
+		return cw.Close()
+	})
+}
@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/terotoi/koticloud/server/crypt"
+)
+
+// Reads the plaintext of a stored file.
+func readStored(t *testing.T, path string, encrypted bool) ([]byte, error) {
+	fh, err := Open(path, encrypted)
+	if err != nil {
+		return nil, err
+	}
+	defer fh.Close()
+	return ioutil.ReadAll(fh)
+}
+
+func TestStoreEncrypted(t *testing.T) {
+	key, err := crypt.NewKey(7, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	crypt.Remember(key)
+	defer crypt.Forget(7)
+
+	dir := t.TempDir()
+	plain := bytes.Repeat([]byte("plaintext "), 10000)
+	if err := ioutil.WriteFile(dir+"/plain", plain, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Store(dir+"/plain", false, dir+"/sealed", key); err != nil {
+		t.Fatal(err)
+	}
+
+	if data, err := readStored(t, dir+"/sealed", true); err != nil || !bytes.Equal(data, plain) {
+		t.Errorf("read %d bytes, %v", len(data), err)
+	}
+
+	if info, err := Stat(dir+"/sealed", true); err != nil || info.Size != int64(len(plain)) {
+		t.Errorf("Stat = %v, %v", info, err)
+	}
+
+	if userID, version, err := KeyOf(dir + "/sealed"); err != nil || userID != 7 || version != 2 {
+		t.Errorf("KeyOf = %d, %d, %v", userID, version, err)
+	}
+
+	// Stored again as plaintext.
+	if err := Store(dir+"/sealed", true, dir+"/opened", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if data, err := readStored(t, dir+"/opened", false); err != nil || !bytes.Equal(data, plain) {
+		t.Errorf("read %d bytes, %v", len(data), err)
+	}
+}
+
+// A plaintext file is never taken for an encrypted one by its contents.
+func TestPlaintextWithHeader(t *testing.T) {
+	key, err := crypt.NewKey(8, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	if err := ioutil.WriteFile(dir+"/plain", []byte("hello"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Store(dir+"/plain", false, dir+"/sealed", key); err != nil {
+		t.Fatal(err)
+	}
+
+	// A plaintext file starting with the header of an encrypted file.
+	sealed, err := ioutil.ReadFile(dir + "/sealed")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(dir+"/lookalike", sealed, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if data, err := readStored(t, dir+"/lookalike", false); err != nil || !bytes.Equal(data, sealed) {
+		t.Errorf("read %q, %v", data, err)
+	}
+
+	if info, err := Stat(dir+"/lookalike", false); err != nil || info.Size != int64(len(sealed)) {
+		t.Errorf("Stat = %v, %v", info, err)
+	}
+
+	path, cleanup, err := Fetch(dir+"/lookalike", "", false)
+	if err != nil || path != dir+"/lookalike" {
+		t.Errorf("Fetch = %s, %v", path, err)
+	} else {
+		cleanup()
+	}
+
+	// A file recorded as encrypted must have a valid header.
+	if _, err := Open(dir+"/plain", true); err != crypt.ErrCorrupt {
+		t.Errorf("Open of a plaintext file as encrypted: %v", err)
+	}
+
+	if _, _, err := KeyOf(dir + "/plain"); err != crypt.ErrCorrupt {
+		t.Errorf("KeyOf of a plaintext file: %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Local stores files in a directory tree on the local file system.
+type Local struct {
+	root string
+}
+
+// NewLocal returns a backend storing files under the root directory.
+// With an empty root, keys are paths of the local file system.
+func NewLocal(root string) *Local {
+	return &Local{root: strings.TrimRight(root, "/")}
+}
+
+// LocalPath returns the path of a key on the local file system.
+func (l *Local) LocalPath(key string) string {
+	if l.root == "" {
+		return key
+	} else if key == "" {
+		return l.root
+	}
+	return l.root + "/" + key
+}
+
+func (l *Local) Open(key string) (File, error) {
+	return os.Open(l.LocalPath(key))
+}
+
+func (l *Local) Create(key string) (io.WriteCloser, error) {
+	return os.Create(l.LocalPath(key))
+}
+
+func (l *Local) Rename(oldKey, newKey string) error {
+	return os.Rename(l.LocalPath(oldKey), l.LocalPath(newKey))
+}
+
+func (l *Local) Remove(key string) error {
+	return os.Remove(l.LocalPath(key))
+}
+
+func (l *Local) RemoveAll(key string) error {
+	return os.RemoveAll(l.LocalPath(key))
+}
+
+func (l *Local) Stat(key string) (*FileInfo, error) {
+	st, err := os.Stat(l.LocalPath(key))
+	if err != nil {
+		return nil, err
+	}
+	return &FileInfo{Name: st.Name(), Size: st.Size(), ModTime: st.ModTime(), IsDir: st.IsDir()}, nil
+}
+
+func (l *Local) MkdirAll(key string) error {
+	return os.MkdirAll(l.LocalPath(key), 0770)
+}
+
+func (l *Local) Walk(key string, fn WalkFunc) error {
+	return filepath.WalkDir(l.LocalPath(key), func(path string, d fs.DirEntry, err error) error {
+		rel := path
+		if l.root != "" {
+			rel = strings.TrimLeft(strings.TrimPrefix(path, l.root), "/")
+		}
+
+		if err != nil {
+			return fn(rel, nil, err)
+		}
+
+		st, err := d.Info()
+		if err != nil {
+			return fn(rel, nil, err)
+		}
+		return fn(rel, &FileInfo{Name: d.Name(), Size: st.Size(), ModTime: st.ModTime(), IsDir: d.IsDir()}, nil)
+	})
+}
+
+// Link hard links a local file to a key. The modification time of the file
+// is set to the current time.
+func (l *Local) Link(localFile, key string) error {
+	path := l.LocalPath(key)
+	if err := os.Link(localFile, path); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	return os.Chtimes(path, now, now)
+}
@@ -0,0 +1,506 @@
+package storage
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Maximum number of bytes of an error response read for the error message.
+const maxS3ErrorSize = 4096
+
+// S3 stores files as objects in a bucket of an S3-compatible object store,
+// such as Amazon S3 or MinIO. Objects are addressed path-style. Directories
+// are empty objects with a trailing slash, and a directory also exists if any
+// object is under it. Files are written with a single request, which limits
+// their size to 5 GB.
+type S3 struct {
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	prefix    string // Prepended to all keys
+	client    http.Client
+}
+
+// NewS3 returns a backend storing files in a bucket, with keys prefixed by prefix.
+// endpoint is the URL of the server, such as https://s3.eu-north-1.amazonaws.com
+// or http://localhost:9000.
+func NewS3(endpoint, region, bucket, accessKey, secretKey, prefix string) (*S3, error) {
+	u, err := url.Parse(strings.TrimRight(endpoint, "/"))
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid S3 endpoint: %s", endpoint)
+	}
+
+	if bucket == "" {
+		return nil, fmt.Errorf("no S3 bucket given")
+	}
+
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+
+	return &S3{endpoint: u, region: region, bucket: bucket, accessKey: accessKey,
+		secretKey: secretKey, prefix: prefix}, nil
+}
+
+// Returns the object name of a key.
+func (s *S3) object(key string) string {
+	if key == "" {
+		return strings.TrimSuffix(s.prefix, "/")
+	}
+	return s.prefix + key
+}
+
+// Returns the object name of a directory key, with a trailing slash.
+func (s *S3) dirObject(key string) string {
+	if key == "" {
+		return s.prefix
+	}
+	return s.prefix + key + "/"
+}
+
+func notExist(op, key string) error {
+	return &os.PathError{Op: op, Path: key, Err: os.ErrNotExist}
+}
+
+// S3 error response.
+type s3Error struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// Sends a request for an object, or for the bucket if object is empty.
+// Responses other than 2xx are returned as errors, 404 as os.ErrNotExist.
+func (s *S3) request(method, object string, query url.Values, header http.Header,
+	body io.Reader, size int64) (*http.Response, error) {
+	p := "/" + s.bucket
+	if object != "" {
+		p += "/" + object
+	}
+
+	u := *s.endpoint
+	u.Path = p
+	u.RawPath = uriEncode(p, false)
+	u.RawQuery = canonicalQuery(query)
+
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	if body != nil {
+		req.ContentLength = size
+	}
+
+	s.sign(req, time.Now())
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return res, nil
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, notExist(strings.ToLower(method), object)
+	}
+
+	data, _ := ioutil.ReadAll(io.LimitReader(res.Body, maxS3ErrorSize))
+	var serr s3Error
+	if xml.Unmarshal(data, &serr) == nil && serr.Code != "" {
+		return nil, fmt.Errorf("s3 %s %s: %s: %s", method, object, serr.Code, serr.Message)
+	}
+	return nil, fmt.Errorf("s3 %s %s: %s", method, object, res.Status)
+}
+
+// Sends a request and discards the response body.
+func (s *S3) do(method, object string, header http.Header, body io.Reader, size int64) error {
+	res, err := s.request(method, object, nil, header, body, size)
+	if err != nil {
+		return err
+	}
+
+	// A copy may fail after the response status has been sent.
+	data, err := ioutil.ReadAll(io.LimitReader(res.Body, maxS3ErrorSize))
+	res.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	var serr s3Error
+	if xml.Unmarshal(data, &serr) == nil && serr.Code != "" {
+		return fmt.Errorf("s3 %s %s: %s: %s", method, object, serr.Code, serr.Message)
+	}
+	return nil
+}
+
+// Returns the size and modification time of an object.
+func (s *S3) head(object string) (*FileInfo, error) {
+	res, err := s.request(http.MethodHead, object, nil, nil, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	res.Body.Close()
+
+	info := FileInfo{Name: path.Base(object), Size: res.ContentLength}
+	info.ModTime, _ = http.ParseTime(res.Header.Get("Last-Modified"))
+	return &info, nil
+}
+
+// An object in a listing.
+type s3Object struct {
+	Key          string    `xml:"Key"`
+	Size         int64     `xml:"Size"`
+	LastModified time.Time `xml:"LastModified"`
+}
+
+// Result of ListObjectsV2.
+type s3ListResult struct {
+	Contents              []s3Object `xml:"Contents"`
+	IsTruncated           bool       `xml:"IsTruncated"`
+	NextContinuationToken string     `xml:"NextContinuationToken"`
+}
+
+// Calls fn for all objects with the prefix, in lexical order. At most max
+// objects are listed if max is positive.
+func (s *S3) list(prefix string, max int, fn func(obj *s3Object) error) error {
+	query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+	if max > 0 {
+		query.Set("max-keys", strconv.Itoa(max))
+	}
+
+	for count := 0; ; {
+		res, err := s.request(http.MethodGet, "", query, nil, nil, 0)
+		if err != nil {
+			return err
+		}
+
+		var result s3ListResult
+		err = xml.NewDecoder(res.Body).Decode(&result)
+		res.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		for i := range result.Contents {
+			if err := fn(&result.Contents[i]); err != nil {
+				return err
+			}
+
+			if count++; max > 0 && count >= max {
+				return nil
+			}
+		}
+
+		if !result.IsTruncated {
+			return nil
+		}
+		query.Set("continuation-token", result.NextContinuationToken)
+	}
+}
+
+// Returns true if there are objects under a directory, other than the directory itself.
+func (s *S3) hasChildren(dir string) (bool, error) {
+	found := false
+	err := s.list(dir, 2, func(obj *s3Object) error {
+		if obj.Key != dir {
+			found = true
+		}
+		return nil
+	})
+	return found, err
+}
+
+// Copies an object within the bucket.
+func (s *S3) copy(src, dst string) error {
+	header := http.Header{"X-Amz-Copy-Source": {uriEncode("/"+s.bucket+"/"+src, false)}}
+	return s.do(http.MethodPut, dst, header, nil, 0)
+}
+
+func (s *S3) Open(key string) (File, error) {
+	object := s.object(key)
+	info, err := s.head(object)
+	if err != nil {
+		return nil, err
+	}
+	return &s3File{s3: s, object: object, size: info.Size}, nil
+}
+
+func (s *S3) Create(key string) (io.WriteCloser, error) {
+	fh, err := ioutil.TempFile("", "koticloud-s3-*")
+	if err != nil {
+		return nil, err
+	}
+	return &s3Writer{File: fh, s3: s, object: s.object(key)}, nil
+}
+
+func (s *S3) Rename(oldKey, newKey string) error {
+	src, dst := s.object(oldKey), s.object(newKey)
+	if _, err := s.head(src); err == nil {
+		if err := s.copy(src, dst); err != nil {
+			return err
+		}
+		return s.do(http.MethodDelete, src, nil, nil, 0)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	// Objects under a directory are moved one by one.
+	srcDir, dstDir := s.dirObject(oldKey), s.dirObject(newKey)
+	var objects []string
+	if err := s.list(srcDir, 0, func(obj *s3Object) error {
+		objects = append(objects, obj.Key)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if len(objects) == 0 {
+		return notExist("rename", oldKey)
+	}
+
+	for _, obj := range objects {
+		if err := s.copy(obj, dstDir+strings.TrimPrefix(obj, srcDir)); err != nil {
+			return err
+		}
+	}
+
+	for _, obj := range objects {
+		if err := s.do(http.MethodDelete, obj, nil, nil, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *S3) Remove(key string) error {
+	info, err := s.Stat(key)
+	if err != nil {
+		return err
+	}
+
+	if !info.IsDir {
+		return s.do(http.MethodDelete, s.object(key), nil, nil, 0)
+	}
+
+	dir := s.dirObject(key)
+	if found, err := s.hasChildren(dir); err != nil {
+		return err
+	} else if found {
+		return &os.PathError{Op: "remove", Path: key, Err: fmt.Errorf("directory not empty")}
+	}
+	return s.do(http.MethodDelete, dir, nil, nil, 0)
+}
+
+func (s *S3) RemoveAll(key string) error {
+	var objects []string
+	if err := s.list(s.dirObject(key), 0, func(obj *s3Object) error {
+		objects = append(objects, obj.Key)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// Deleting a missing object is not an error.
+	objects = append(objects, s.object(key))
+	for _, obj := range objects {
+		if err := s.do(http.MethodDelete, obj, nil, nil, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *S3) Stat(key string) (*FileInfo, error) {
+	if key == "" {
+		return &FileInfo{Name: "/", IsDir: true}, nil
+	}
+
+	if info, err := s.head(s.object(key)); err == nil {
+		return info, nil
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	dir := s.dirObject(key)
+	if info, err := s.head(dir); err == nil {
+		return &FileInfo{Name: path.Base(key), ModTime: info.ModTime, IsDir: true}, nil
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if found, err := s.hasChildren(dir); err != nil {
+		return nil, err
+	} else if found {
+		return &FileInfo{Name: path.Base(key), IsDir: true}, nil
+	}
+	return nil, notExist("stat", key)
+}
+
+// MkdirAll creates a directory marker. Parent directories exist implicitly.
+func (s *S3) MkdirAll(key string) error {
+	if key == "" {
+		return nil
+	}
+	return s.do(http.MethodPut, s.dirObject(key), nil, strings.NewReader(""), 0)
+}
+
+func (s *S3) Walk(key string, fn WalkFunc) error {
+	info, err := s.Stat(key)
+	if err != nil {
+		return fn(key, nil, err)
+	}
+
+	if !info.IsDir {
+		return fn(key, info, nil)
+	}
+
+	// Directories are collected from the keys of the objects under them.
+	entries := map[string]*FileInfo{key: info}
+	dir := s.dirObject(key)
+	err = s.list(dir, 0, func(obj *s3Object) error {
+		rel := strings.TrimPrefix(obj.Key, s.prefix)
+		if strings.HasSuffix(rel, "/") {
+			rel = strings.TrimSuffix(rel, "/")
+			if e, ok := entries[rel]; ok {
+				e.ModTime = obj.LastModified
+			} else {
+				entries[rel] = &FileInfo{Name: path.Base(rel), ModTime: obj.LastModified, IsDir: true}
+			}
+		} else {
+			entries[rel] = &FileInfo{Name: path.Base(rel), Size: obj.Size, ModTime: obj.LastModified}
+		}
+
+		for p := path.Dir(rel); len(p) > len(key) && p != "."; p = path.Dir(p) {
+			if _, ok := entries[p]; !ok {
+				entries[p] = &FileInfo{Name: path.Base(p), IsDir: true}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fn(key, nil, err)
+	}
+
+	// Sorting puts directories before their contents.
+	keys := make([]string, 0, len(entries))
+	for k := range entries {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if err := fn(k, entries[k], nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// s3File reads an object with range requests, so that seeking does not
+// require downloading the whole object.
+type s3File struct {
+	s3     *S3
+	object string
+	size   int64
+	offset int64
+	body   io.ReadCloser
+}
+
+func (f *s3File) Read(p []byte) (int, error) {
+	if f.offset >= f.size {
+		return 0, io.EOF
+	}
+
+	if f.body == nil {
+		header := http.Header{"Range": {fmt.Sprintf("bytes=%d-", f.offset)}}
+		res, err := f.s3.request(http.MethodGet, f.object, nil, header, nil, 0)
+		if err != nil {
+			return 0, err
+		}
+		f.body = res.Body
+	}
+
+	n, err := f.body.Read(p)
+	f.offset += int64(n)
+	if err == io.EOF && f.offset < f.size {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+func (f *s3File) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += f.size
+	}
+
+	if offset < 0 {
+		return f.offset, fmt.Errorf("seek %s: negative offset", f.object)
+	}
+
+	if offset != f.offset && f.body != nil {
+		f.body.Close()
+		f.body = nil
+	}
+	f.offset = offset
+	return offset, nil
+}
+
+func (f *s3File) Close() error {
+	if f.body != nil {
+		return f.body.Close()
+	}
+	return nil
+}
+
+// s3Writer collects the contents of an object into a temporary file, and
+// uploads it when closed.
+type s3Writer struct {
+	*os.File
+	s3     *S3
+	object string
+}
+
+func (w *s3Writer) Close() error {
+	defer os.Remove(w.File.Name())
+	defer w.File.Close()
+
+	size, err := w.File.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.File.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	return w.s3.do(http.MethodPut, w.object, nil, w.File, size)
+}
@@ -0,0 +1,535 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testBucket    = "kc-test"
+	testAccessKey = "AKIDTEST"
+	testSecretKey = "secret/test+key"
+	testRegion    = "eu-north-1"
+)
+
+// fakeS3 is an in-memory S3 server for a single bucket. It verifies the
+// signatures of all requests, and lists at most pageSize objects at a time,
+// so that listings are paginated.
+type fakeS3 struct {
+	t        *testing.T
+	mu       sync.Mutex
+	objects  map[string][]byte
+	ranges   []string // Range headers of GET requests
+	pageSize int
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *httptest.Server) {
+	f := &fakeS3{t: t, objects: map[string][]byte{}, pageSize: 2}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	return f, srv
+}
+
+// Returns a backend using the fake server, with keys prefixed by prefix.
+func newTestS3(t *testing.T, srv *httptest.Server, secretKey, prefix string) *S3 {
+	s, err := NewS3(srv.URL, testRegion, testBucket, testAccessKey, secretKey, prefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// Returns the object names of the fake server, sorted.
+func (f *fakeS3) keys() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	keys := make([]string, 0, len(f.objects))
+	for k := range f.objects {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (f *fakeS3) error(w http.ResponseWriter, status int, code string) {
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "<Error><Code>%s</Code><Message>%s</Message></Error>", code, code)
+}
+
+// Checks the AWS Signature Version 4 of a request, computed independently
+// of S3.sign from the request as received.
+func (f *fakeS3) verify(r *http.Request) error {
+	auth := r.Header.Get("Authorization")
+	const algorithm = "AWS4-HMAC-SHA256 "
+	if !strings.HasPrefix(auth, algorithm) {
+		return fmt.Errorf("no signature: %q", auth)
+	}
+
+	fields := map[string]string{}
+	for _, field := range strings.Split(strings.TrimPrefix(auth, algorithm), ", ") {
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("invalid authorization field: %q", field)
+		}
+		fields[kv[0]] = kv[1]
+	}
+
+	date := r.Header.Get("X-Amz-Date")
+	if len(date) != len("20060102T150405Z") {
+		return fmt.Errorf("invalid date: %q", date)
+	}
+
+	scope := date[:8] + "/" + testRegion + "/s3/aws4_request"
+	if fields["Credential"] != testAccessKey+"/"+scope {
+		return fmt.Errorf("invalid credential: %q", fields["Credential"])
+	}
+
+	names := strings.Split(fields["SignedHeaders"], ";")
+	for _, required := range []string{"host", "x-amz-content-sha256", "x-amz-date"} {
+		if !contains(names, required) {
+			return fmt.Errorf("%s not signed", required)
+		}
+	}
+	if r.Header.Get("Range") != "" && !contains(names, "range") {
+		return fmt.Errorf("range not signed")
+	}
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		fmt.Fprintf(&canonicalHeaders, "%s:%s\n", name, strings.TrimSpace(value))
+	}
+
+	// Query parameters are sorted by their encoded names and values.
+	var params []string
+	for k, vs := range r.URL.Query() {
+		for _, v := range vs {
+			params = append(params, url.QueryEscape(k)+"="+strings.ReplaceAll(url.QueryEscape(v), "+", "%20"))
+		}
+	}
+	sort.Strings(params)
+
+	canonicalRequest := strings.Join([]string{r.Method, r.URL.EscapedPath(), strings.Join(params, "&"),
+		canonicalHeaders.String(), fields["SignedHeaders"], r.Header.Get("X-Amz-Content-Sha256")}, "\n")
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + date + "\n" + scope + "\n" + hex.EncodeToString(hash[:])
+
+	key := []byte("AWS4" + testSecretKey)
+	for _, part := range []string{date[:8], testRegion, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+
+	if signature := hex.EncodeToString(hmacSHA256(key, stringToSign)); fields["Signature"] != signature {
+		return fmt.Errorf("signature mismatch for %s %s", r.Method, r.URL)
+	}
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := f.verify(r); err != nil {
+		f.t.Log(err)
+		f.error(w, http.StatusForbidden, "SignatureDoesNotMatch")
+		return
+	}
+
+	bucket, object := strings.TrimPrefix(r.URL.Path, "/"), ""
+	if i := strings.Index(bucket, "/"); i >= 0 {
+		bucket, object = bucket[:i], bucket[i+1:]
+	}
+	if bucket != testBucket {
+		f.error(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet && object == "":
+		f.list(w, r)
+
+	case r.Method == http.MethodHead, r.Method == http.MethodGet:
+		data, ok := f.objects[object]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Last-Modified", time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC).Format(http.TimeFormat))
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		status := http.StatusOK
+		if rng := r.Header.Get("Range"); rng != "" {
+			f.ranges = append(f.ranges, rng)
+			start, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rng, "bytes="), "-"))
+			if err != nil || start > len(data) {
+				f.error(w, http.StatusRequestedRangeNotSatisfiable, "InvalidRange")
+				return
+			}
+			data = data[start:]
+			status = http.StatusPartialContent
+			w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		}
+		w.WriteHeader(status)
+		w.Write(data)
+
+	case r.Method == http.MethodPut && r.Header.Get("X-Amz-Copy-Source") != "":
+		src, err := url.PathUnescape(r.Header.Get("X-Amz-Copy-Source"))
+		if err != nil || !strings.HasPrefix(src, "/"+testBucket+"/") {
+			f.error(w, http.StatusBadRequest, "InvalidArgument")
+			return
+		}
+
+		data, ok := f.objects[strings.TrimPrefix(src, "/"+testBucket+"/")]
+		if !ok {
+			f.error(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		f.objects[object] = data
+		fmt.Fprint(w, "<CopyObjectResult></CopyObjectResult>")
+
+	case r.Method == http.MethodPut:
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			f.error(w, http.StatusBadRequest, "IncompleteBody")
+			return
+		}
+		f.objects[object] = data
+
+	case r.Method == http.MethodDelete:
+		delete(f.objects, object)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		f.error(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+// Lists objects with ListObjectsV2. Continuation tokens are the last key listed.
+func (f *fakeS3) list(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("list-type") != "2" {
+		f.error(w, http.StatusBadRequest, "InvalidArgument")
+		return
+	}
+
+	max := f.pageSize
+	if n, err := strconv.Atoi(q.Get("max-keys")); err == nil && n < max {
+		max = n
+	}
+
+	var keys []string
+	for k := range f.objects {
+		if strings.HasPrefix(k, q.Get("prefix")) && k > q.Get("continuation-token") {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var result s3ListResult
+	for _, k := range keys {
+		if len(result.Contents) == max {
+			result.IsTruncated = true
+			result.NextContinuationToken = result.Contents[max-1].Key
+			break
+		}
+		result.Contents = append(result.Contents, s3Object{Key: k, Size: int64(len(f.objects[k])),
+			LastModified: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)})
+	}
+
+	data, err := xml.Marshal(struct {
+		XMLName xml.Name `xml:"ListBucketResult"`
+		s3ListResult
+	}{s3ListResult: result})
+	if err != nil {
+		f.t.Error(err)
+	}
+	w.Write(data)
+}
+
+// Writes a file through a backend.
+func writeTestFile(t *testing.T, b Backend, key, content string) {
+	t.Helper()
+	w, err := b.Create(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.WriteString(w, content); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func assertKeys(t *testing.T, f *fakeS3, want ...string) {
+	t.Helper()
+	if got := f.keys(); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("objects %q, want %q", got, want)
+	}
+}
+
+func TestS3CreateOpen(t *testing.T) {
+	f, srv := newFakeS3(t)
+	s := newTestS3(t, srv, testSecretKey, "data")
+
+	writeTestFile(t, s, "dir/file name.txt", "0123456789abcdef")
+	assertKeys(t, f, "data/dir/file name.txt")
+
+	fh, err := s.Open("dir/file name.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fh.Close()
+
+	buf := make([]byte, 4)
+	if _, err := io.ReadFull(fh, buf); err != nil || string(buf) != "0123" {
+		t.Fatalf("read %q, %v", buf, err)
+	}
+
+	// Seeking elsewhere makes a new range request.
+	if off, err := fh.Seek(-6, io.SeekEnd); err != nil || off != 10 {
+		t.Fatalf("seek to %d, %v", off, err)
+	}
+
+	rest, err := ioutil.ReadAll(fh)
+	if err != nil || string(rest) != "abcdef" {
+		t.Fatalf("read %q, %v", rest, err)
+	}
+
+	if strings.Join(f.ranges, " ") != "bytes=0- bytes=10-" {
+		t.Errorf("range requests %q", f.ranges)
+	}
+
+	if _, err := s.Open("dir/missing"); !os.IsNotExist(err) {
+		t.Errorf("opening a missing file: %v", err)
+	}
+}
+
+func TestS3Stat(t *testing.T) {
+	f, srv := newFakeS3(t)
+	s := newTestS3(t, srv, testSecretKey, "")
+
+	writeTestFile(t, s, "a/b/c.txt", "abc")
+	if err := s.MkdirAll("empty"); err != nil {
+		t.Fatal(err)
+	}
+	assertKeys(t, f, "a/b/c.txt", "empty/")
+
+	for key, dir := range map[string]bool{"": true, "a": true, "a/b": true, "a/b/c.txt": false, "empty": true} {
+		info, err := s.Stat(key)
+		if err != nil {
+			t.Errorf("stat %q: %v", key, err)
+		} else if info.IsDir != dir {
+			t.Errorf("stat %q: IsDir %v", key, info.IsDir)
+		}
+	}
+
+	if info, err := s.Stat("a/b/c.txt"); err == nil && info.Size != 3 {
+		t.Errorf("size %d", info.Size)
+	}
+
+	if _, err := s.Stat("a/b/c"); !os.IsNotExist(err) {
+		t.Errorf("stat of a missing file: %v", err)
+	}
+}
+
+func TestS3RenameDir(t *testing.T) {
+	f, srv := newFakeS3(t)
+	s := newTestS3(t, srv, testSecretKey, "")
+
+	writeTestFile(t, s, "src/a.txt", "a")
+	writeTestFile(t, s, "src/sub/b.txt", "b")
+	writeTestFile(t, s, "src/sub/c.txt", "c")
+	writeTestFile(t, s, "srcfile", "not under src")
+	if err := s.MkdirAll("src/empty"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Rename("src", "dst/moved"); err != nil {
+		t.Fatal(err)
+	}
+	assertKeys(t, f, "dst/moved/a.txt", "dst/moved/empty/", "dst/moved/sub/b.txt", "dst/moved/sub/c.txt", "srcfile")
+
+	if err := s.Rename("srcfile", "dst/file"); err != nil {
+		t.Fatal(err)
+	}
+	assertKeys(t, f, "dst/file", "dst/moved/a.txt", "dst/moved/empty/", "dst/moved/sub/b.txt", "dst/moved/sub/c.txt")
+
+	if err := s.Rename("src", "other"); !os.IsNotExist(err) {
+		t.Errorf("renaming a missing directory: %v", err)
+	}
+}
+
+func TestS3Remove(t *testing.T) {
+	f, srv := newFakeS3(t)
+	s := newTestS3(t, srv, testSecretKey, "")
+
+	writeTestFile(t, s, "dir/a.txt", "a")
+	if err := s.MkdirAll("dir/empty"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Remove("dir"); err == nil {
+		t.Error("removed a directory which is not empty")
+	}
+
+	if err := s.Remove("dir/empty"); err != nil {
+		t.Error(err)
+	}
+
+	if err := s.Remove("dir/a.txt"); err != nil {
+		t.Error(err)
+	}
+	assertKeys(t, f)
+
+	if err := s.Remove("dir/a.txt"); !os.IsNotExist(err) {
+		t.Errorf("removing a missing file: %v", err)
+	}
+}
+
+func TestS3RemoveAll(t *testing.T) {
+	f, srv := newFakeS3(t)
+	s := newTestS3(t, srv, testSecretKey, "")
+
+	writeTestFile(t, s, "dir/a.txt", "a")
+	writeTestFile(t, s, "dir/sub/b.txt", "b")
+	writeTestFile(t, s, "dir/sub/c.txt", "c")
+	writeTestFile(t, s, "dir2", "kept")
+	if err := s.MkdirAll("dir"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.RemoveAll("dir"); err != nil {
+		t.Fatal(err)
+	}
+	assertKeys(t, f, "dir2")
+
+	if err := s.RemoveAll("missing"); err != nil {
+		t.Errorf("removing a missing directory: %v", err)
+	}
+}
+
+func TestS3Walk(t *testing.T) {
+	_, srv := newFakeS3(t)
+	s := newTestS3(t, srv, testSecretKey, "prefix/")
+
+	writeTestFile(t, s, "root/a.txt", "a")
+	writeTestFile(t, s, "root/sub/deep/b.txt", "bb")
+	writeTestFile(t, s, "rootfile", "not under root")
+	if err := s.MkdirAll("root/empty"); err != nil {
+		t.Fatal(err)
+	}
+
+	var visited []string
+	err := s.Walk("root", func(key string, info *FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		entry := key
+		if info.IsDir {
+			entry += "/"
+		} else {
+			entry += fmt.Sprintf("(%d)", info.Size)
+		}
+		visited = append(visited, entry)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "root/ root/a.txt(1) root/empty/ root/sub/ root/sub/deep/ root/sub/deep/b.txt(2)"
+	if got := strings.Join(visited, " "); got != want {
+		t.Errorf("visited %s, want %s", got, want)
+	}
+
+	err = s.Walk("missing", func(key string, info *FileInfo, err error) error { return err })
+	if !os.IsNotExist(err) {
+		t.Errorf("walking a missing directory: %v", err)
+	}
+}
+
+func TestS3BadSignature(t *testing.T) {
+	_, srv := newFakeS3(t)
+	s := newTestS3(t, srv, "wrong secret", "")
+
+	w, err := s.Create("file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.Copy(w, bytes.NewReader([]byte("data")))
+	if err := w.Close(); err == nil || !strings.Contains(err.Error(), "SignatureDoesNotMatch") {
+		t.Errorf("writing with a wrong secret key: %v", err)
+	}
+}
+
+func TestMount(t *testing.T) {
+	_, srv := newFakeS3(t)
+	s := newTestS3(t, srv, testSecretKey, "")
+
+	mountsMu.RLock()
+	saved := mounts
+	mountsMu.RUnlock()
+	t.Cleanup(func() {
+		mountsMu.Lock()
+		mounts = saved
+		mountsMu.Unlock()
+	})
+
+	Mount("/srv/mnt/", s)
+
+	if err := WriteAtomic("/srv/mnt/dir/file.txt", func(w io.Writer) error {
+		_, err := io.WriteString(w, "mounted")
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	fh, err := Open("/srv/mnt/dir/file.txt", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fh.Close()
+
+	if data, err := ioutil.ReadAll(fh); err != nil || string(data) != "mounted" {
+		t.Errorf("read %q, %v", data, err)
+	}
+
+	for path, want := range map[string]string{"/srv/mnt": "", "/srv/mnt/a/b": "a/b", "/srv/mntx": "/srv/mntx"} {
+		if _, key, _ := resolve(path); key != want {
+			t.Errorf("key of %s is %q, want %q", path, key, want)
+		}
+	}
+}
@@ -0,0 +1,336 @@
+// Package storage provides access to stored file contents through backends,
+// such as a directory on the local disk or an S3-compatible object store.
+//
+// Stored files are addressed by paths under a mounted root, such as the home
+// root or the thumbnail root of the configuration, so that the rest of the
+// server does not need to know which backend holds the files. Paths outside
+// all mounts refer to the local file system.
+//
+// Files are encrypted when they are written with Store and a key. Whether a
+// file is encrypted is recorded in the database, and given to the functions
+// reading it, which decrypt it transparently.
+package storage
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/terotoi/koticloud/server/crypt"
+)
+
+// FileInfo describes a stored file or directory.
+type FileInfo struct {
+	Name    string
+	Size    int64
+	ModTime time.Time
+	IsDir   bool
+}
+
+// File is a stored file opened for reading.
+type File interface {
+	io.Reader
+	io.Seeker
+	io.Closer
+}
+
+// WalkFunc is called by Walk for each file and directory, with its full path.
+// Directories are visited before their contents.
+type WalkFunc func(path string, info *FileInfo, err error) error
+
+// Backend stores files under slash-separated keys relative to its root.
+// Errors for missing files satisfy os.IsNotExist.
+type Backend interface {
+	// Open opens a file for reading.
+	Open(key string) (File, error)
+
+	// Create creates or truncates a file for writing. The contents are
+	// complete when the writer has been closed without an error.
+	Create(key string) (io.WriteCloser, error)
+
+	// Rename renames a file or a directory with everything under it.
+	Rename(oldKey, newKey string) error
+
+	// Remove removes a file or an empty directory.
+	Remove(key string) error
+
+	// RemoveAll removes a file or a directory with everything under it.
+	// Removing a missing file is not an error.
+	RemoveAll(key string) error
+
+	// Stat returns information about a file or a directory.
+	Stat(key string) (*FileInfo, error)
+
+	// MkdirAll creates a directory and all missing parents.
+	MkdirAll(key string) error
+
+	// Walk visits a directory and everything under it.
+	Walk(key string, fn WalkFunc) error
+}
+
+// localPather is implemented by backends which store files on the local file system.
+type localPather interface {
+	LocalPath(key string) string
+}
+
+// linker is implemented by backends which can hard link a local file.
+type linker interface {
+	Link(localFile, key string) error
+}
+
+type mount struct {
+	root    string
+	backend Backend
+}
+
+// The mounts, guarded by mountsMu.
+var (
+	mounts   []mount
+	mountsMu sync.RWMutex
+)
+
+// The local file system, for paths outside all mounts.
+var osBackend = NewLocal("")
+
+// Mount makes the files under a root path accessible through a backend.
+// Mounts are set up at startup, before any files are accessed.
+func Mount(root string, backend Backend) {
+	mountsMu.Lock()
+	defer mountsMu.Unlock()
+	mounts = append(mounts, mount{root: strings.TrimRight(root, "/"), backend: backend})
+}
+
+// Returns the backend holding a path and the key of the path in it.
+func resolve(path string) (Backend, string, string) {
+	mountsMu.RLock()
+	defer mountsMu.RUnlock()
+	for _, m := range mounts {
+		if path == m.root {
+			return m.backend, "", m.root
+		} else if strings.HasPrefix(path, m.root+"/") {
+			return m.backend, strings.TrimLeft(path[len(m.root):], "/"), m.root
+		}
+	}
+	return osBackend, path, ""
+}
+
+// Open opens a stored file for reading. The file is decrypted if encrypted
+// is true.
+func Open(path string, encrypted bool) (File, error) {
+	b, key, _ := resolve(path)
+	fh, err := b.Open(key)
+	if err != nil || !encrypted {
+		return fh, err
+	}
+
+	header, err := readHeader(fh)
+	if err != nil {
+		fh.Close()
+		return nil, err
+	}
+
+	r, err := crypt.NewReader(fh, header)
+	if err != nil {
+		fh.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// Create creates or truncates a stored file for writing. The contents are
+// stored as written.
+func Create(path string) (io.WriteCloser, error) {
+	b, key, _ := resolve(path)
+	return b.Create(key)
+}
+
+// Rename renames a stored file or directory. Files are copied if the paths
+// are on different backends.
+func Rename(oldPath, newPath string) error {
+	ob, oldKey, oldRoot := resolve(oldPath)
+	nb, newKey, newRoot := resolve(newPath)
+	if oldRoot == newRoot {
+		return ob.Rename(oldKey, newKey)
+	}
+
+	if err := CopyFile(oldPath, newPath); err != nil {
+		nb.Remove(newKey)
+		return err
+	}
+	return ob.Remove(oldKey)
+}
+
+// Remove removes a stored file or an empty directory.
+func Remove(path string) error {
+	b, key, _ := resolve(path)
+	return b.Remove(key)
+}
+
+// RemoveAll removes a stored file or a directory with everything under it.
+func RemoveAll(path string) error {
+	b, key, _ := resolve(path)
+	return b.RemoveAll(key)
+}
+
+// Stat returns information about a stored file or directory. If encrypted
+// is true, the size of the file is the size of its plaintext.
+func Stat(path string, encrypted bool) (*FileInfo, error) {
+	b, key, _ := resolve(path)
+	info, err := b.Stat(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if encrypted && !info.IsDir {
+		info.Size = crypt.PlainSize(info.Size)
+	}
+	return info, nil
+}
+
+// MkdirAll creates a directory and all missing parents.
+func MkdirAll(path string) error {
+	b, key, _ := resolve(path)
+	return b.MkdirAll(key)
+}
+
+// Walk visits a directory and everything under it. Paths given to fn are
+// full paths, which can be used with the other functions of this package.
+// Sizes are the sizes of the stored files, see crypt.PlainSize.
+func Walk(path string, fn WalkFunc) error {
+	b, key, root := resolve(path)
+	return b.Walk(key, func(p string, info *FileInfo, err error) error {
+		if root != "" && p != "" {
+			p = root + "/" + p
+		} else if root != "" {
+			p = root
+		}
+		return fn(p, info, err)
+	})
+}
+
+// CopyFile copies a file from srcPath to dstPath. Either path may be stored
+// or on the local file system. The contents are copied as stored, so that an
+// encrypted file stays encrypted with the same key. dstPath is replaced
+// atomically, see WriteAtomic.
+func CopyFile(srcPath, dstPath string) error {
+	b, key, _ := resolve(srcPath)
+	src, err := b.Open(key)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	return WriteAtomic(dstPath, func(w io.Writer) error {
+		_, err := io.Copy(w, src)
+		return err
+	})
+}
+
+// WriteAtomic creates or replaces a file with the contents written by write.
+// On the local file system, the contents are written into a temporary file
+// next to path, which is synced to disk and renamed over path, so that path
+// never holds partial contents. Objects in remote storage are replaced
+// atomically by the store itself.
+func WriteAtomic(path string, write func(w io.Writer) error) error {
+	b, _, _ := resolve(path)
+	if _, ok := b.(localPather); !ok {
+		dst, err := Create(path)
+		if err != nil {
+			return err
+		}
+
+		if err := write(dst); err != nil {
+			dst.Close()
+			return err
+		}
+		return dst.Close()
+	}
+
+	tmp := fmt.Sprintf("%s/.%s.tmp-%d", filepath.Dir(path), filepath.Base(path), time.Now().UnixNano())
+	dst, err := Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	// write must not be able to close the file before it has been synced.
+	err = write(struct{ io.Writer }{dst})
+	if s, ok := dst.(interface{ Sync() error }); ok && err == nil {
+		err = s.Sync()
+	}
+
+	if cerr := dst.Close(); err == nil {
+		err = cerr
+	}
+
+	if err == nil {
+		err = Rename(tmp, path)
+	}
+
+	if err != nil {
+		Remove(tmp)
+	}
+	return err
+}
+
+// Link hard links a local file to a stored path, so that the contents are
+// stored without copying them. Fails if the path is not on the same local file system.
+func Link(localFile, path string) error {
+	b, key, _ := resolve(path)
+	if l, ok := b.(linker); ok {
+		return l.Link(localFile, key)
+	}
+	return fmt.Errorf("cannot link %s: backend does not support links", path)
+}
+
+// IsLocal tells if a path is stored on the local file system, under the
+// same path.
+func IsLocal(path string) bool {
+	b, _, _ := resolve(path)
+	_, ok := b.(localPather)
+	return ok
+}
+
+// Fetch returns the path of a stored file on the local file system, for
+// external tools such as ImageMagick and ffmpeg. Files on remote backends
+// and encrypted files are copied into tempDir as plaintext, or into the
+// default directory for temporary files if tempDir is empty. The returned
+// function must be called to remove the copy after use. encrypted tells if
+// the file is encrypted.
+func Fetch(path, tempDir string, encrypted bool) (string, func(), error) {
+	b, key, _ := resolve(path)
+	if l, ok := b.(localPather); ok && !encrypted {
+		if _, err := b.Stat(key); err != nil {
+			return "", nil, err
+		}
+		return l.LocalPath(key), func() {}, nil
+	}
+
+	src, err := Open(path, encrypted)
+	if err != nil {
+		return "", nil, err
+	}
+	defer src.Close()
+
+	fh, err := ioutil.TempFile(tempDir, "fetch-*"+filepath.Ext(path))
+	if err != nil {
+		return "", nil, err
+	}
+
+	cleanup := func() { os.Remove(fh.Name()) }
+	if _, err := io.Copy(fh, src); err != nil {
+		fh.Close()
+		cleanup()
+		return "", nil, err
+	}
+
+	if err := fh.Close(); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return fh.Name(), cleanup, nil
+}
@@ -0,0 +1,344 @@
+package uploads
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/terotoi/koticloud/server/core"
+	"github.com/terotoi/koticloud/server/util"
+)
+
+const sessionDir = "sessions"
+
+// Serializes modifications to session metadata.
+var sessionMutex sync.Mutex
+
+// Range is a half-open byte range [Start, End) of received data.
+type Range struct {
+	Start int64
+	End   int64
+}
+
+// Session is a resumable upload. The data is staged in the upload directory
+// until all of it has been received and the session is finalized.
+type Session struct {
+	ID       string
+	UserID   int
+	ParentID int // ID of the target directory
+	Filename string
+	Size     int64
+	Received []Range
+	Created  time.Time
+	Updated  time.Time
+}
+
+// Complete returns true if all data has been received.
+func (s *Session) Complete() bool {
+	return s.Size == 0 ||
+		(len(s.Received) == 1 && s.Received[0].Start == 0 && s.Received[0].End == s.Size)
+}
+
+// ReceivedBytes returns the number of bytes received.
+func (s *Session) ReceivedBytes() int64 {
+	var n int64
+	for _, r := range s.Received {
+		n += r.End - r.Start
+	}
+	return n
+}
+
+// Adds a range to the received ranges, merging overlapping and adjacent ranges.
+func (s *Session) addRange(start, end int64) {
+	if end <= start {
+		return
+	}
+
+	ranges := append(s.Received, Range{start, end})
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].Start < ranges[j].Start
+	})
+
+	merged := []Range{ranges[0]}
+	for _, r := range ranges[1:] {
+		last := &merged[len(merged)-1]
+		if r.Start <= last.End {
+			if r.End > last.End {
+				last.End = r.End
+			}
+		} else {
+			merged = append(merged, r)
+		}
+	}
+	s.Received = merged
+}
+
+// Returns the directory for session files, creating it if needed.
+func sessionsPath(uploadDir string) (string, error) {
+	dir := filepath.Join(uploadDir, sessionDir)
+	if err := util.EnsureDirExists(dir); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+func metaPath(uploadDir, id string) string {
+	return filepath.Join(uploadDir, sessionDir, id+".json")
+}
+
+// DataPath returns the path of the staged data of a session.
+func DataPath(uploadDir, id string) string {
+	return filepath.Join(uploadDir, sessionDir, id+".part")
+}
+
+// Returns true if id looks like a session ID. Prevents path traversal
+// through session IDs given by clients.
+func validID(id string) bool {
+	if len(id) != 32 {
+		return false
+	}
+	_, err := hex.DecodeString(id)
+	return err == nil
+}
+
+func newID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func saveSession(uploadDir string, s *Session) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so that a crash does not leave
+	// a truncated metadata file.
+	path := metaPath(uploadDir, s.ID)
+	if err := ioutil.WriteFile(path+".tmp", data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+func loadSession(uploadDir, id string) (*Session, error) {
+	if !validID(id) {
+		return nil, core.NewSystemError(http.StatusBadRequest, "", "invalid upload session ID")
+	}
+
+	data, err := ioutil.ReadFile(metaPath(uploadDir, id))
+	if os.IsNotExist(err) {
+		return nil, core.NewSystemError(http.StatusNotFound, err.Error(), "upload session not found")
+	} else if err != nil {
+		return nil, err
+	}
+
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// listSessions returns all sessions in the upload directory.
+func listSessions(uploadDir string) ([]*Session, error) {
+	dir, err := sessionsPath(uploadDir)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []*Session
+	for _, e := range entries {
+		if !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+
+		s, err := loadSession(uploadDir, strings.TrimSuffix(e.Name(), ".json"))
+		if err != nil {
+			log.Printf("[upload] %s: %s", e.Name(), err)
+			continue
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, nil
+}
+
+// Create creates a new upload session. If the user already has an unfinished
+// session for the same file, that session is returned so that the upload
+// can be resumed.
+func Create(uploadDir string, userID, parentID int, filename string, size int64) (*Session, error) {
+	if size < 0 {
+		return nil, core.NewSystemError(http.StatusBadRequest, "", "invalid size")
+	}
+
+	sessionMutex.Lock()
+	defer sessionMutex.Unlock()
+
+	sessions, err := listSessions(uploadDir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range sessions {
+		if s.UserID == userID && s.ParentID == parentID && s.Filename == filename && s.Size == size {
+			log.Printf("[upload] resuming session %s for %s (%d/%d bytes)", s.ID, filename,
+				s.ReceivedBytes(), s.Size)
+			return s, nil
+		}
+	}
+
+	id, err := newID()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	s := &Session{
+		ID:       id,
+		UserID:   userID,
+		ParentID: parentID,
+		Filename: filename,
+		Size:     size,
+		Created:  now,
+		Updated:  now,
+	}
+
+	fh, err := os.OpenFile(DataPath(uploadDir, id), os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, err
+	}
+	fh.Close()
+
+	if err := saveSession(uploadDir, s); err != nil {
+		os.Remove(DataPath(uploadDir, id))
+		return nil, err
+	}
+
+	log.Printf("[upload] created session %s for %s (%d bytes)", s.ID, filename, size)
+	return s, nil
+}
+
+// Get returns a session owned by the given user.
+func Get(uploadDir, id string, userID int) (*Session, error) {
+	sessionMutex.Lock()
+	defer sessionMutex.Unlock()
+
+	s, err := loadSession(uploadDir, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.UserID != userID {
+		return nil, core.NewSystemError(http.StatusNotFound, "session owned by another user",
+			"upload session not found")
+	}
+	return s, nil
+}
+
+// WriteChunk writes data from r to the session at the given offset.
+// Data written before an error is recorded as received, so an interrupted
+// chunk only needs to be resent from where it was cut.
+func WriteChunk(uploadDir, id string, userID int, offset int64, r io.Reader) (*Session, error) {
+	s, err := Get(uploadDir, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if offset < 0 || offset > s.Size {
+		return nil, core.NewSystemError(http.StatusBadRequest, "", "invalid offset")
+	}
+
+	fh, err := os.OpenFile(DataPath(uploadDir, id), os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	defer fh.Close()
+
+	n, copyErr := io.Copy(io.NewOffsetWriter(fh, offset), io.LimitReader(r, s.Size-offset))
+	if copyErr == nil {
+		// Anything left over does not fit into the file.
+		if m, _ := r.Read(make([]byte, 1)); m > 0 {
+			copyErr = core.NewSystemError(http.StatusRequestEntityTooLarge, "",
+				"chunk exceeds the size of the upload")
+		}
+	}
+
+	sessionMutex.Lock()
+	defer sessionMutex.Unlock()
+
+	// Reload, another chunk may have been written concurrently.
+	s, err = loadSession(uploadDir, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.addRange(offset, offset+n)
+	s.Updated = time.Now()
+	if err := saveSession(uploadDir, s); err != nil {
+		return nil, err
+	}
+
+	if copyErr != nil {
+		return nil, copyErr
+	}
+	return s, nil
+}
+
+// Remove removes a session and its data.
+func Remove(uploadDir, id string) error {
+	sessionMutex.Lock()
+	defer sessionMutex.Unlock()
+
+	if !validID(id) {
+		return fmt.Errorf("invalid upload session ID: %s", id)
+	}
+
+	if err := os.Remove(DataPath(uploadDir, id)); err != nil && !os.IsNotExist(err) {
+		log.Printf("[upload] %s", err)
+	}
+	return os.Remove(metaPath(uploadDir, id))
+}
+
+// RemoveExpired removes sessions which have not received data in maxAge.
+// Returns the number of removed sessions.
+func RemoveExpired(uploadDir string, maxAge time.Duration) (int, error) {
+	sessionMutex.Lock()
+	sessions, err := listSessions(uploadDir)
+	sessionMutex.Unlock()
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, s := range sessions {
+		if time.Since(s.Updated) > maxAge {
+			log.Printf("[upload] removing expired session %s (%s, %d/%d bytes)", s.ID,
+				s.Filename, s.ReceivedBytes(), s.Size)
+			if err := Remove(uploadDir, s.ID); err != nil {
+				log.Printf("[upload] %s", err)
+			} else {
+				count++
+			}
+		}
+	}
+	return count, nil
+}
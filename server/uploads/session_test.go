@@ -0,0 +1,151 @@
+package uploads
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func TestAddRange(t *testing.T) {
+	for _, c := range []struct {
+		name     string
+		received []Range
+		start    int64
+		end      int64
+		want     []Range
+	}{
+		{"first", nil, 0, 10, []Range{{0, 10}}},
+		{"empty", []Range{{0, 10}}, 5, 5, []Range{{0, 10}}},
+		{"after a gap", []Range{{0, 10}}, 20, 30, []Range{{0, 10}, {20, 30}}},
+		{"before", []Range{{20, 30}}, 0, 10, []Range{{0, 10}, {20, 30}}},
+		{"adjacent", []Range{{0, 10}}, 10, 20, []Range{{0, 20}}},
+		{"overlapping", []Range{{0, 10}}, 5, 15, []Range{{0, 15}}},
+		{"contained", []Range{{0, 10}}, 2, 8, []Range{{0, 10}}},
+		{"filling a gap", []Range{{0, 10}, {20, 30}}, 10, 20, []Range{{0, 30}}},
+		{"covering several", []Range{{5, 10}, {20, 30}, {40, 50}}, 0, 45, []Range{{0, 50}}},
+	} {
+		s := &Session{Received: append([]Range(nil), c.received...)}
+		s.addRange(c.start, c.end)
+		if !reflect.DeepEqual(s.Received, c.want) {
+			t.Errorf("%s: got %v, want %v", c.name, s.Received, c.want)
+		}
+	}
+}
+
+// An interrupted chunk is recorded up to where it was cut, and the upload
+// is resumed from there by creating the session again.
+func TestResume(t *testing.T) {
+	dir := t.TempDir()
+	data := "hello, world"
+
+	s, err := Create(dir, 1, 2, "a.txt", int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cut := io.MultiReader(strings.NewReader(data[:5]), iotest.ErrReader(errors.New("connection reset")))
+	if _, err := WriteChunk(dir, s.ID, 1, 0, cut); err == nil {
+		t.Error("interrupted chunk succeeded")
+	}
+
+	resumed, err := Create(dir, 1, 2, "a.txt", int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	} else if resumed.ID != s.ID || resumed.ReceivedBytes() != 5 || resumed.Complete() {
+		t.Fatalf("resumed session %s with %d bytes", resumed.ID, resumed.ReceivedBytes())
+	}
+
+	// A different file or user gets a new session.
+	if other, err := Create(dir, 1, 2, "b.txt", int64(len(data))); err != nil || other.ID == s.ID {
+		t.Errorf("session of another file: %v", err)
+	} else if other, err := Create(dir, 3, 2, "a.txt", int64(len(data))); err != nil || other.ID == s.ID {
+		t.Errorf("session of another user: %v", err)
+	}
+
+	if _, err := WriteChunk(dir, s.ID, 3, 5, strings.NewReader(data[5:])); err == nil {
+		t.Error("wrote to the session of another user")
+	}
+
+	s, err = WriteChunk(dir, s.ID, 1, 5, strings.NewReader(data[5:]))
+	if err != nil {
+		t.Fatal(err)
+	} else if !s.Complete() {
+		t.Errorf("received %v of %d bytes", s.Received, s.Size)
+	}
+
+	if b, err := ioutil.ReadFile(DataPath(dir, s.ID)); err != nil || string(b) != data {
+		t.Errorf("staged %q, %v", b, err)
+	}
+}
+
+// Chunks out of order complete the upload once the gaps are filled, and
+// data beyond the size is refused.
+func TestWriteChunk(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Create(dir, 1, 2, "a.txt", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s, err = WriteChunk(dir, s.ID, 1, 6, strings.NewReader("6789")); err != nil || s.Complete() {
+		t.Fatalf("first chunk: %v", err)
+	}
+
+	if _, err := WriteChunk(dir, s.ID, 1, 3, strings.NewReader("3456789X")); err == nil {
+		t.Error("accepted a chunk past the size")
+	} else if _, err := WriteChunk(dir, s.ID, 1, 11, strings.NewReader("X")); err == nil {
+		t.Error("accepted an offset past the size")
+	}
+
+	if s, err = WriteChunk(dir, s.ID, 1, 0, strings.NewReader("012")); err != nil || !s.Complete() {
+		t.Fatalf("received %v: %v", s, err)
+	}
+
+	if b, err := ioutil.ReadFile(DataPath(dir, s.ID)); err != nil || string(b) != "0123456789" {
+		t.Errorf("staged %q, %v", b, err)
+	}
+}
+
+func TestSessionIDs(t *testing.T) {
+	dir := t.TempDir()
+	for _, id := range []string{"", "../../etc/passwd", strings.Repeat("g", 32)} {
+		if _, err := Get(dir, id, 1); err == nil {
+			t.Errorf("found session %q", id)
+		} else if err := Remove(dir, id); err == nil {
+			t.Errorf("removed session %q", id)
+		}
+	}
+}
+
+func TestRemoveExpired(t *testing.T) {
+	dir := t.TempDir()
+	old, err := Create(dir, 1, 2, "old.txt", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	old.Updated = time.Now().Add(-2 * time.Hour)
+	if err := saveSession(dir, old); err != nil {
+		t.Fatal(err)
+	}
+
+	recent, err := Create(dir, 1, 2, "recent.txt", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n, err := RemoveExpired(dir, time.Hour); err != nil || n != 1 {
+		t.Errorf("removed %d sessions: %v", n, err)
+	}
+
+	if _, err := Get(dir, old.ID, 1); err == nil {
+		t.Error("expired session remains")
+	} else if _, err := Get(dir, recent.ID, 1); err != nil {
+		t.Errorf("recent session: %v", err)
+	}
+}
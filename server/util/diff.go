@@ -0,0 +1,23 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+)
+
+// Diff returns an unified diff between two files, using the diff command.
+// The labels are used in place of the file names in the output.
+func Diff(aPath, aLabel, bPath, bLabel string) (string, error) {
+	cmd := exec.Command("diff", "-u", "--label", aLabel, "--label", bLabel, aPath, bPath)
+	out, err := cmd.Output()
+
+	// Exit status 1 means that the files differ.
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return string(out), nil
+	} else if err != nil {
+		return "", fmt.Errorf("diff: %s", err.Error())
+	}
+	return string(out), nil
+}
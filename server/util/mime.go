@@ -1,5 +1,7 @@
 package util
 
+import "strings"
+
 var imageFormats = []string{"image/jpeg", "image/png", "image/webp"}
 var videoFormats = []string{"video/mp4", "video/webm", "video/x-m4v", "image/gif"}
 var audioFormats = []string{"audio/aac", "audio/flac", "audio/mpeg", "audio/ogg", "audio/x-m4a"}
@@ -56,3 +58,54 @@ func TypesWithCustomThumbnails() []string {
 func HasCustomThumb(mimeType string) bool {
 	return IsMedia(mimeType) || IsPDF(mimeType)
 }
+
+// IsText returns true if the given mime type is a text type.
+func IsText(mimeType string) bool {
+	return strings.HasPrefix(mimeType, "text/") || mimeType == "application/json" ||
+		mimeType == "application/xml"
+}
+
+var officeFormats = []string{
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	"application/vnd.openxmlformats-officedocument.presentationml.presentation",
+	"application/vnd.oasis.opendocument.text",
+	"application/vnd.oasis.opendocument.spreadsheet",
+	"application/vnd.oasis.opendocument.presentation",
+}
+
+// IsOffice returns true if the given mime type is a supported office document type.
+func IsOffice(mimeType string) bool {
+	for _, f := range officeFormats {
+		if mimeType == f {
+			return true
+		}
+	}
+	return false
+}
+
+// HasText returns true if text can be extracted from files of the given type for searching.
+func HasText(mimeType string) bool {
+	return IsText(mimeType) || IsPDF(mimeType) || IsOffice(mimeType)
+}
+
+// DocumentTypes returns the mime types of PDF and office documents.
+func DocumentTypes() []string {
+	return append([]string{"application/pdf"}, officeFormats...)
+}
+
+// TypesWithMetadata returns the mime types from which metadata can be extracted.
+func TypesWithMetadata() []string {
+	formats := []string{"application/pdf"}
+	formats = append(formats, imageFormats...)
+	formats = append(formats, videoFormats...)
+	formats = append(formats, audioFormats...)
+	return formats
+}
+
+// TypesWithText returns the mime types, other than text/*, from which text can be extracted.
+func TypesWithText() []string {
+	formats := []string{"application/pdf", "application/json", "application/xml"}
+	formats = append(formats, officeFormats...)
+	return formats
+}
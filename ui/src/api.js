@@ -233,15 +233,16 @@ function makeDir(parentID, name, authToken, success, error) {
 /**
  * Searches for nodes in the database.
  * 
- * @param {string) text - the search text
+ * @param {string) text - the search query, such as 'type:image holiday'
  * @param {*} authToken 
- * @param {*} success 
+ * @param {*} success - function(nodes) called with the first page of results
  * @param {*} error 
  */
 function searchNodes(text, authToken, success, error) {
   fetchData('/node/search', 'post', 'json', {
-    Text: text
-  }, authToken, success, error)
+    Text: text,
+    Limit: 1000
+  }, authToken, (resp) => success(resp.Results), error)
 }
 
 /**
@@ -11,6 +11,7 @@ import Box from '@mui/material/Box'
 import Button from '@mui/material/Button'
 import Tooltip from '@mui/material/Tooltip'
 import ArrowUpward from '@mui/icons-material/ArrowUpward'
+import EventIcon from '@mui/icons-material/Event'
 import AddBoxIcon from '@mui/icons-material/AddBox'
 import CreateNewFolderIcon from '@mui/icons-material/CreateNewFolder'
 import CloudUploadIcon from '@mui/icons-material/CloudUpload'
@@ -18,6 +19,7 @@ import VisibilityIcon from '@mui/icons-material/Visibility'
 import VisibilityOffIcon from '@mui/icons-material/VisibilityOff'
 import ZoomInIcon from '@mui/icons-material/ZoomIn'
 import ZoomOutIcon from '@mui/icons-material/ZoomOut'
+import SortByAlphaIcon from '@mui/icons-material/SortByAlpha'
 
 import { openInputDialog } from '../dialogs/input'
 import { zooms, NodeGrid } from './grid'
@@ -62,7 +64,10 @@ export default function FileManagerView(props) {
 	const settingZoom = localStorage.getItem("zoom") ?
 		parseInt(localStorage.getItem("zoom")) : defaultZoom
 
+	const settingByDate = localStorage.getItem("sortByDate") === "true"
+
 	const [previews, setPreviews] = React.useState(settingPreviews)
+	const [byDate, setByDate] = React.useState(settingByDate)
 	const [zoom, setZoom] = React.useState(settingZoom)
 	const wm = props.wm
 
@@ -123,6 +128,11 @@ export default function FileManagerView(props) {
 		localStorage.setItem("previews", !previews ? "true" : "false")
 	}
 
+	function toggleSort() {
+		setByDate(!byDate)
+		localStorage.setItem("sortByDate", !byDate ? "true" : "false")
+	}
+
 	function changeZoom(dir) {
 		var z = zoom + dir
 		if (z < 0)
@@ -138,7 +148,7 @@ export default function FileManagerView(props) {
 	var nodes = props.node.children
 	if (props.clipboard !== null && props.clipboard.action == 'cut')
 		nodes = nodes.filter((n) => n !== props.clipboard.node)
-	nodes = sortNodes(nodes)
+	nodes = sortNodes(nodes, byDate)
 
 	let rs = null
 
@@ -195,6 +205,11 @@ export default function FileManagerView(props) {
 							{previews ? <VisibilityIcon /> : <VisibilityOffIcon />}
 						</Tooltip>
 					</Button>
+					<Button onClick={toggleSort}>
+						<Tooltip title={byDate ? "Sort by name" : "Sort photos by capture date"}>
+							{byDate ? <SortByAlphaIcon /> : <EventIcon />}
+						</Tooltip>
+					</Button>
 					<Button onClick={() => changeZoom(-1)}>
 						<Tooltip title="Smaller icons"><ZoomOutIcon /></Tooltip>
 					</Button>
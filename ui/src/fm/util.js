@@ -9,14 +9,22 @@
 
 /**
  * Sort nodes by name, with directories first in the list.
+ * If byDate is true, photos are sorted by their capture time
+ * and placed before other files.
  */
-export function sortNodes(ls) {
+export function sortNodes(ls, byDate) {
 	return [...ls].sort((a, b) => {
 		if (a.type === 'directory' && b.type !== 'directory')
 			return -1
 		if (b.type === 'directory' && a.type !== 'directory')
 			return 1
-		else
-			return a.name.localeCompare(b.name)
+		if (byDate && (a.taken_on || b.taken_on)) {
+			if (!b.taken_on)
+				return -1
+			if (!a.taken_on)
+				return 1
+			return new Date(a.taken_on) - new Date(b.taken_on)
+		}
+		return a.name.localeCompare(b.name)
 	})
 }
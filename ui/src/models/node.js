@@ -53,6 +53,11 @@ export default class Node {
 		this.length = node.length
 		this.progress = node.progress
 		this.volume = node.volume
+		this.taken_on = node.taken_on // Capture time of a photo, or null
+		this.checksum = node.checksum // SHA-256 hash of the contents
+		this.checksum_failed = node.checksum_failed // Contents do not match the checksum
+		this.proc_state = node.proc_state // Processing of thumbnails and metadata: pending, running, done, failed or null
+		this.proc_error = node.proc_error // Error of the latest processing attempt
 		this.path = ''
 
 		if (node.children) {